@@ -2,33 +2,91 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	grpcadapter "github.com/simaogato/wealthflow-backend/internal/adapter/grpc"
 	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
 	"github.com/simaogato/wealthflow-backend/internal/adapter/repository/postgres"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/bucket_manager"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/dashboard"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/expense"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/inflow"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/investment"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/scheduler"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 )
 
 const (
-	defaultAPIToken = "dev-token"
-	grpcPort        = ":8080"
+	defaultAPIToken        = "dev-token"
+	defaultGRPCListenAddr  = ":8080"
+	defaultShutdownTimeout = 15 * time.Second
+	defaultBuildValue      = "dev"
+
+	// scheduledProcessInterval is how often due scheduled transactions are activated
+	scheduledProcessInterval = time.Hour
+
+	// metricsPath is the HTTP path Prometheus metrics are served on (when METRICS_LISTEN_ADDR is set)
+	metricsPath = "/metrics"
+)
+
+// Build information, injected at compile time with -ldflags, e.g.
+//
+//	go build -ldflags "-X main.Version=1.2.0 -X main.Commit=$(git rev-parse --short HEAD) -X main.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/server
+//
+// Values that are not set report as "dev"
+var (
+	Version   string
+	Commit    string
+	BuildTime string
 )
 
 func main() {
+	build := buildInfo()
+	log.Printf("Starting WealthFlow server (version %s, commit %s, built %s)", build.Version, build.Commit, build.BuildTime)
+
+	// Validate the listen address up front so a typo fails before connecting to the database
+	listenAddr, err := grpcListenAddr()
+	if err != nil {
+		log.Fatalf("Invalid gRPC listen address: %v", err)
+	}
+	metricsAddr, err := metricsListenAddr()
+	if err != nil {
+		log.Fatalf("Invalid metrics listen address: %v", err)
+	}
+	drainTimeout, err := shutdownTimeout()
+	if err != nil {
+		log.Fatalf("Invalid shutdown timeout: %v", err)
+	}
+	slowQuery, err := slowQueryThreshold()
+	if err != nil {
+		log.Fatalf("Invalid slow query threshold: %v", err)
+	}
+	limits, err := listLimits()
+	if err != nil {
+		log.Fatalf("Invalid list limits: %v", err)
+	}
+	allowBlank, err := allowBlankDescriptions()
+	if err != nil {
+		log.Fatalf("Invalid description policy: %v", err)
+	}
+
 	// 1. Setup Database
 	dbConnStr := os.Getenv("DB_CONN_STR")
 	if dbConnStr == "" {
@@ -65,18 +123,26 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
+	postgres.SetSlowQueryThreshold(slowQuery)
 
 	// 2. Initialize Repositories (Postgres)
 	bucketRepo := postgres.NewBucketRepository(db)
 	transactionRepo := postgres.NewTransactionRepository(db)
 	splitRuleRepo := postgres.NewSplitRuleRepository(db)
 	marketValueRepo := postgres.NewMarketValueRepository(db)
+	transferTaskRepo := postgres.NewTransferTaskRepository(db)
+	holdingRepo := postgres.NewHoldingRepository(db)
+	unitOfWork := postgres.NewUnitOfWork(db)
 
 	// 3. Initialize Services (Use Cases)
-	inflowService := inflow.NewInflowService(bucketRepo, transactionRepo, splitRuleRepo)
+	inflowService := inflow.NewInflowService(bucketRepo, transactionRepo, splitRuleRepo, unitOfWork)
 	expenseService := expense.NewExpenseService(bucketRepo, transactionRepo)
-	investmentService := investment.NewInvestmentService(bucketRepo, marketValueRepo)
-	dashboardService := dashboard.NewDashboardService(bucketRepo, transactionRepo, marketValueRepo)
+	inflowService.AllowBlankDescription = allowBlank
+	expenseService.AllowBlankDescription = allowBlank
+	investmentService := investment.NewInvestmentService(bucketRepo, marketValueRepo, holdingRepo, unitOfWork)
+	dashboardService := dashboard.NewDashboardService(bucketRepo, transactionRepo, marketValueRepo, holdingRepo)
+	bucketService := bucket_manager.NewBucketService(bucketRepo, transactionRepo, transferTaskRepo, unitOfWork)
+	scheduledProcessor := scheduler.NewScheduledProcessor(transactionRepo)
 
 	// Initialize System Seeder and run it
 	systemSeeder := seeder.NewSystemSeeder(bucketRepo)
@@ -86,6 +152,16 @@ func main() {
 	}
 	log.Println("System buckets seeded successfully")
 
+	// Activate scheduled transactions that fell due while the server was down, then keep checking periodically
+	processScheduled(ctx, scheduledProcessor)
+	go func() {
+		ticker := time.NewTicker(scheduledProcessInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			processScheduled(ctx, scheduledProcessor)
+		}
+	}()
+
 	// 4. Start gRPC Server
 	// Get API token from environment or use default
 	apiToken := os.Getenv("API_TOKEN")
@@ -93,43 +169,312 @@ func main() {
 		apiToken = defaultAPIToken
 	}
 
-	// Create gRPC server with AuthInterceptor
-	grpcServer := grpclib.NewServer(
-		grpclib.UnaryInterceptor(grpcadapter.AuthInterceptor(apiToken)),
-	)
+	// Record request metrics first (so rejected calls are counted too), then authenticate
+	var unaryInterceptors []grpclib.UnaryServerInterceptor
+	var streamInterceptors []grpclib.StreamServerInterceptor
+	var metricsServer *http.Server
+	if metricsAddr != "" {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+		metrics, err := grpcadapter.NewMetrics(registry)
+		if err != nil {
+			log.Fatalf("Failed to register metrics: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, grpcadapter.MetricsInterceptor(metrics))
+		streamInterceptors = append(streamInterceptors, grpcadapter.MetricsStreamInterceptor(metrics))
+
+		metricsServer, err = startMetricsServer(metricsAddr, registry)
+		if err != nil {
+			log.Fatalf("Failed to start metrics server: %v", err)
+		}
+	} else {
+		log.Println("Metrics disabled (set METRICS_LISTEN_ADDR to serve Prometheus metrics)")
+	}
+	unaryInterceptors = append(unaryInterceptors, grpcadapter.AuthInterceptor(apiToken))
+	streamInterceptors = append(streamInterceptors, grpcadapter.AuthStreamInterceptor(apiToken))
+
+	// Create gRPC server with the interceptor chains (unary and streaming)
+	serverOpts := []grpclib.ServerOption{
+		grpclib.ChainUnaryInterceptor(unaryInterceptors...),
+		grpclib.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	// Enable TLS when a cert/key pair is configured; plaintext otherwise (local dev)
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		log.Fatalf("Failed to load TLS configuration: %v", err)
+	}
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpclib.Creds(credentials.NewTLS(tlsConfig)))
+		log.Println("TLS enabled for gRPC server")
+	} else {
+		log.Println("TLS disabled: gRPC server is using plaintext (set TLS_CERT_FILE and TLS_KEY_FILE to enable)")
+	}
+
+	grpcServer := grpclib.NewServer(serverOpts...)
 
 	// Register WealthFlowServiceServer
-	grpcAdapter := grpcadapter.NewServer(expenseService, inflowService, investmentService, dashboardService)
+	grpcAdapter := grpcadapter.NewServer(expenseService, inflowService, investmentService, dashboardService, bucketService, scheduledProcessor, systemSeeder, db, build, limits)
 	wealthflowv1.RegisterWealthFlowServiceServer(grpcServer, grpcAdapter)
 
 	reflection.Register(grpcServer)
 
-	// Listen on TCP port 8080
-	lis, err := net.Listen("tcp", grpcPort)
+	// Listen on the configured TCP address
+	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
-		log.Fatalf("Failed to listen on %s: %v", grpcPort, err)
+		log.Fatalf("Failed to listen on %s: %v", listenAddr, err)
 	}
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("gRPC server listening on %s", grpcPort)
+		log.Printf("gRPC server listening on %s", lis.Addr())
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("Failed to serve gRPC server: %v", err)
 		}
 	}()
 
 	// Graceful shutdown
-	waitForShutdown(grpcServer)
+	waitForShutdown(grpcServer, metricsServer, db, drainTimeout)
+}
+
+// startMetricsServer serves the registry's metrics over HTTP on addr at metricsPath
+// The listener is opened before returning, so a port already in use fails at startup
+func startMetricsServer(addr string, registry *prometheus.Registry) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		log.Printf("Metrics server listening on %s%s", lis.Addr(), metricsPath)
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve metrics: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// processScheduled activates the scheduled transactions due now, logging (not failing on) errors
+func processScheduled(ctx context.Context, processor *scheduler.ScheduledProcessor) {
+	activated, err := processor.ProcessScheduled(ctx, time.Now())
+	if len(activated) > 0 {
+		log.Printf("Activated %d scheduled transaction(s)", len(activated))
+	}
+	if err != nil {
+		log.Printf("Failed to process scheduled transactions: %v", err)
+	}
+}
+
+// buildInfo returns the compile-time build information, defaulting unset values to "dev"
+func buildInfo() grpcadapter.BuildInfo {
+	orDefault := func(value string) string {
+		if value == "" {
+			return defaultBuildValue
+		}
+		return value
+	}
+
+	return grpcadapter.BuildInfo{
+		Version:   orDefault(Version),
+		Commit:    orDefault(Commit),
+		BuildTime: orDefault(BuildTime),
+	}
+}
+
+// grpcListenAddr returns the gRPC bind address from GRPC_LISTEN_ADDR (default ":8080")
+// The address must be host:port (host may be empty to bind all interfaces) with a numeric port
+func grpcListenAddr() (string, error) {
+	addr := os.Getenv("GRPC_LISTEN_ADDR")
+	if addr == "" {
+		return defaultGRPCListenAddr, nil
+	}
+
+	if err := validateListenAddr("GRPC_LISTEN_ADDR", addr); err != nil {
+		return "", err
+	}
+
+	return addr, nil
+}
+
+// metricsListenAddr returns the bind address of the Prometheus metrics endpoint from METRICS_LISTEN_ADDR
+// Metrics are optional: an empty result (the default) means they are disabled
+func metricsListenAddr() (string, error) {
+	addr := os.Getenv("METRICS_LISTEN_ADDR")
+	if addr == "" {
+		return "", nil
+	}
+
+	if err := validateListenAddr("METRICS_LISTEN_ADDR", addr); err != nil {
+		return "", err
+	}
+
+	return addr, nil
+}
+
+// validateListenAddr ensures addr (read from the environment variable name) is host:port with a numeric port
+func validateListenAddr(name, addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%s %q must be in host:port form: %w", name, addr, err)
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil || portNum < 0 || portNum > 65535 {
+		return fmt.Errorf("%s %q has an invalid port %q", name, addr, port)
+	}
+
+	return nil
+}
+
+// shutdownTimeout returns how long in-flight requests may drain on shutdown, from SHUTDOWN_TIMEOUT (default 15s)
+// The value is a Go duration string (e.g. "30s", "1m") and must be positive
+func shutdownTimeout() (time.Duration, error) {
+	value := os.Getenv("SHUTDOWN_TIMEOUT")
+	if value == "" {
+		return defaultShutdownTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("SHUTDOWN_TIMEOUT %q must be a duration such as \"15s\": %w", value, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("SHUTDOWN_TIMEOUT %q must be positive", value)
+	}
+
+	return timeout, nil
+}
+
+// slowQueryThreshold returns how long a database statement may take before it is logged,
+// from SLOW_QUERY_THRESHOLD (default 200ms); "0" disables the slow-query log
+func slowQueryThreshold() (time.Duration, error) {
+	value := os.Getenv("SLOW_QUERY_THRESHOLD")
+	if value == "" {
+		return postgres.DefaultSlowQueryThreshold, nil
+	}
+
+	threshold, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("SLOW_QUERY_THRESHOLD %q must be a duration such as \"200ms\": %w", value, err)
+	}
+	if threshold < 0 {
+		return 0, fmt.Errorf("SLOW_QUERY_THRESHOLD %q must not be negative", value)
+	}
+
+	return threshold, nil
+}
+
+// listLimits returns the ListTransactions page size bounds from LIST_MAX_LIMIT (default 500)
+// and LIST_DEFAULT_LIMIT_ON_ZERO (default false: a zero limit is rejected)
+func listLimits() (grpcadapter.ListLimits, error) {
+	limits := grpcadapter.ListLimits{Max: grpcadapter.DefaultMaxListLimit}
+
+	if value := os.Getenv("LIST_MAX_LIMIT"); value != "" {
+		maxLimit, err := strconv.Atoi(value)
+		if err != nil || maxLimit <= 0 {
+			return limits, fmt.Errorf("LIST_MAX_LIMIT %q must be a positive integer", value)
+		}
+		limits.Max = maxLimit
+	}
+
+	if value := os.Getenv("LIST_DEFAULT_LIMIT_ON_ZERO"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return limits, fmt.Errorf("LIST_DEFAULT_LIMIT_ON_ZERO %q must be a boolean: %w", value, err)
+		}
+		limits.DefaultOnZero = enabled
+	}
+
+	return limits, nil
+}
+
+// allowBlankDescriptions returns whether inflows and expenses may have a blank description,
+// from ALLOW_BLANK_DESCRIPTIONS (default false: a description is required)
+func allowBlankDescriptions() (bool, error) {
+	value := os.Getenv("ALLOW_BLANK_DESCRIPTIONS")
+	if value == "" {
+		return false, nil
+	}
+
+	allow, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("ALLOW_BLANK_DESCRIPTIONS %q must be a boolean: %w", value, err)
+	}
+
+	return allow, nil
+}
+
+// loadTLSConfig loads the server certificate from TLS_CERT_FILE and TLS_KEY_FILE
+// Returns nil (plaintext) when neither is set; setting only one of them is an error
+func loadTLSConfig() (*tls.Config, error) {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	keyFile := os.Getenv("TLS_KEY_FILE")
+
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate key pair: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
 }
 
 // waitForShutdown waits for SIGTERM or SIGINT and gracefully shuts down the server
-func waitForShutdown(grpcServer *grpclib.Server) {
+// In-flight requests get up to drainTimeout to finish; after that the server is stopped forcibly
+// so a stuck client cannot hang the shutdown. The metrics server (nil if disabled) and the database
+// are closed once the gRPC server has stopped.
+func waitForShutdown(grpcServer *grpclib.Server, metricsServer *http.Server, db *postgres.DB, drainTimeout time.Duration) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 
 	sig := <-sigChan
-	log.Printf("Received signal: %v. Shutting down gracefully...", sig)
+	log.Printf("Received signal: %v. Shutting down gracefully (timeout %s)...", sig, drainTimeout)
 
-	grpcServer.GracefulStop()
-	log.Println("gRPC server stopped")
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Println("gRPC server stopped gracefully")
+	case <-time.After(drainTimeout):
+		log.Printf("Graceful shutdown timed out after %s, forcing stop", drainTimeout)
+		grpcServer.Stop()
+		<-stopped
+		log.Println("gRPC server stopped forcibly")
+	}
+
+	if metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			log.Printf("Failed to stop metrics server: %v", err)
+		} else {
+			log.Println("Metrics server stopped")
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		log.Printf("Failed to close database: %v", err)
+		return
+	}
+	log.Println("Database connection closed")
 }
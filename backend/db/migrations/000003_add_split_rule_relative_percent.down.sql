@@ -0,0 +1,5 @@
+-- WealthFlow Relative Percent Split Items Rollback
+-- Drops the relative_to_bucket_id column
+
+ALTER TABLE split_rule_items
+    DROP COLUMN IF EXISTS relative_to_bucket_id;
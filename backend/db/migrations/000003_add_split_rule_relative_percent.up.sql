@@ -0,0 +1,6 @@
+-- WealthFlow Relative Percent Split Items Migration
+-- Allows a PERCENT split item to be computed from what a sibling target bucket received
+
+-- NULL = percent of the remainder (after FIXED items); NOT NULL = percent of that bucket's allocation
+ALTER TABLE split_rule_items
+    ADD COLUMN relative_to_bucket_id UUID REFERENCES buckets(id);
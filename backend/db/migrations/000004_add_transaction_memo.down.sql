@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Memo Rollback
+-- Drops the memo column
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS memo;
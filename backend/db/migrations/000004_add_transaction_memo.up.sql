@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Memo Migration
+-- Adds an optional long-form memo (e.g. receipt details) separate from the short description
+
+ALTER TABLE transactions
+    ADD COLUMN memo TEXT; -- NULL when no memo was provided
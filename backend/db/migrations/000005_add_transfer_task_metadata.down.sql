@@ -0,0 +1,6 @@
+-- WealthFlow Transfer Task Metadata Rollback
+-- Drops the description and created_at columns
+
+ALTER TABLE transfer_tasks
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS description;
@@ -0,0 +1,6 @@
+-- WealthFlow Transfer Task Metadata Migration
+-- Adds a description (copied from the originating transaction) and a creation timestamp to transfer tasks
+
+ALTER TABLE transfer_tasks
+    ADD COLUMN description TEXT NOT NULL DEFAULT '', -- e.g. "Transfer to Investment"
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,5 @@
+-- WealthFlow Bucket Archiving Rollback
+-- Drops the is_archived column
+
+ALTER TABLE buckets
+    DROP COLUMN IF EXISTS is_archived;
@@ -0,0 +1,5 @@
+-- WealthFlow Bucket Archiving Migration
+-- Adds an archived flag so merged (or retired) buckets keep their history but stop being listed
+
+ALTER TABLE buckets
+    ADD COLUMN is_archived BOOLEAN NOT NULL DEFAULT FALSE;
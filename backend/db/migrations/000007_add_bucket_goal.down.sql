@@ -0,0 +1,5 @@
+-- WealthFlow Bucket Goal Rollback
+-- Drops the goal_amount column
+
+ALTER TABLE buckets
+    DROP COLUMN IF EXISTS goal_amount;
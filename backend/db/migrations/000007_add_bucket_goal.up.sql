@@ -0,0 +1,5 @@
+-- WealthFlow Bucket Goal Migration
+-- Adds an optional target amount (e.g. for savings buckets) to track progress against
+
+ALTER TABLE buckets
+    ADD COLUMN goal_amount DECIMAL; -- NULL when no goal is set
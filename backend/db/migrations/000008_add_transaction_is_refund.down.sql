@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Refund Flag Rollback
+-- Drops the is_refund column
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS is_refund;
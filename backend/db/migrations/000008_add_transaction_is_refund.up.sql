@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Refund Flag Migration
+-- Flags refunds (expenses logged in reverse) so listings can separate them from spending
+
+ALTER TABLE transactions
+    ADD COLUMN is_refund BOOLEAN NOT NULL DEFAULT FALSE;
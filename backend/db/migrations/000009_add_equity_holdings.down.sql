@@ -0,0 +1,7 @@
+-- WealthFlow Equity Holdings Rollback
+-- Drops the equity_holdings table and the unit_price column
+
+ALTER TABLE market_value_history
+    DROP COLUMN IF EXISTS unit_price;
+
+DROP TABLE IF EXISTS equity_holdings;
@@ -0,0 +1,13 @@
+-- WealthFlow Equity Holdings Migration
+-- Tracks the (fractional) quantity and weighted-average cost of equity buckets,
+-- and the per-unit price a market value was derived from
+
+CREATE TABLE equity_holdings (
+    bucket_id UUID PRIMARY KEY REFERENCES buckets(id),
+    quantity DECIMAL NOT NULL, -- Units held, may be fractional
+    avg_cost DECIMAL NOT NULL, -- Weighted-average cost per unit
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE market_value_history
+    ADD COLUMN unit_price DECIMAL; -- NULL unless recorded as a per-unit price
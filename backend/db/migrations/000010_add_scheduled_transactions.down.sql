@@ -0,0 +1,27 @@
+-- WealthFlow Scheduled Transactions Rollback
+-- Restores the unconditional balance trigger and drops the scheduling columns
+
+CREATE OR REPLACE FUNCTION update_bucket_balance()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- DEBIT increases balance (Asset increase)
+    IF NEW.type = 'DEBIT' THEN
+        UPDATE buckets 
+        SET current_balance = current_balance + NEW.amount 
+        WHERE id = NEW.bucket_id;
+    -- CREDIT decreases balance (Asset decrease)
+    ELSIF NEW.type = 'CREDIT' THEN
+        UPDATE buckets 
+        SET current_balance = current_balance - NEW.amount 
+        WHERE id = NEW.bucket_id;
+    END IF;
+    
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_transactions_scheduled_due;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS effective_date,
+    DROP COLUMN IF EXISTS scheduled;
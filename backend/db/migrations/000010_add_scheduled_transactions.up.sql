@@ -0,0 +1,34 @@
+-- WealthFlow Scheduled Transactions Migration
+-- Lets a one-off future transaction (e.g. an upcoming bill) be stored without affecting balances
+-- until it is activated on or after its effective date
+
+ALTER TABLE transactions
+    ADD COLUMN scheduled BOOLEAN NOT NULL DEFAULT FALSE, -- TRUE until activated
+    ADD COLUMN effective_date TIMESTAMP; -- NULL unless created as scheduled
+
+CREATE INDEX idx_transactions_scheduled_due ON transactions(effective_date) WHERE scheduled;
+
+-- Entries of a scheduled transaction are inserted without touching balances;
+-- activation applies them once the transaction is due
+CREATE OR REPLACE FUNCTION update_bucket_balance()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM transactions WHERE id = NEW.transaction_id AND scheduled) THEN
+        RETURN NEW;
+    END IF;
+
+    -- DEBIT increases balance (Asset increase)
+    IF NEW.type = 'DEBIT' THEN
+        UPDATE buckets 
+        SET current_balance = current_balance + NEW.amount 
+        WHERE id = NEW.bucket_id;
+    -- CREDIT decreases balance (Asset decrease)
+    ELSIF NEW.type = 'CREDIT' THEN
+        UPDATE buckets 
+        SET current_balance = current_balance - NEW.amount 
+        WHERE id = NEW.bucket_id;
+    END IF;
+    
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
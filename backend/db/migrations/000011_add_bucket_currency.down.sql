@@ -0,0 +1,5 @@
+-- WealthFlow Bucket Currency Rollback
+-- Drops the currency column
+
+ALTER TABLE buckets
+    DROP COLUMN IF EXISTS currency;
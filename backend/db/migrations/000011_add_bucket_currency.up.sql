@@ -0,0 +1,6 @@
+-- WealthFlow Bucket Currency Migration
+-- Stores the currency of each bucket's balance next to the decimal amount
+-- Existing buckets (and new ones that do not set a currency) are in EUR
+
+ALTER TABLE buckets
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'EUR'; -- ISO 4217 code
@@ -0,0 +1,5 @@
+-- WealthFlow Split Rule Destination Rollback
+-- Drops the destination_physical_bucket_id column
+
+ALTER TABLE split_rules
+    DROP COLUMN IF EXISTS destination_physical_bucket_id;
@@ -0,0 +1,6 @@
+-- WealthFlow Split Rule Destination Migration
+-- Lets a split rule name the physical bucket its inflows land in
+
+-- NULL = inferred from the parent of the first target bucket (rules created before this migration)
+ALTER TABLE split_rules
+    ADD COLUMN destination_physical_bucket_id UUID REFERENCES buckets(id);
@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Entry Sequence Rollback
+-- Drops the sequence column
+
+ALTER TABLE transaction_entries
+    DROP COLUMN IF EXISTS sequence;
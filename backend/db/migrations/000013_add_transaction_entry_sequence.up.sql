@@ -0,0 +1,6 @@
+-- WealthFlow Transaction Entry Sequence Migration
+-- Records the order entries were built in so they are displayed predictably (physical pair, then virtual)
+
+-- Entries created before this migration all get 0 and fall back to being ordered by ID
+ALTER TABLE transaction_entries
+    ADD COLUMN sequence INT NOT NULL DEFAULT 0;
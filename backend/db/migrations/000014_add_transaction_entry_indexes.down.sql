@@ -0,0 +1,5 @@
+-- WealthFlow Transaction Entry Indexes Rollback
+-- Drops the transaction_entries indexes
+
+DROP INDEX IF EXISTS idx_transaction_entries_transaction_id;
+DROP INDEX IF EXISTS idx_transaction_entries_bucket_id;
@@ -0,0 +1,6 @@
+-- WealthFlow Transaction Entry Indexes Migration
+-- Bucket-filtered transaction listing/counting joins transaction_entries on bucket_id, and entries are loaded
+-- by transaction_id; without indexes both scan the whole table as the ledger grows
+
+CREATE INDEX idx_transaction_entries_bucket_id ON transaction_entries(bucket_id);
+CREATE INDEX idx_transaction_entries_transaction_id ON transaction_entries(transaction_id);
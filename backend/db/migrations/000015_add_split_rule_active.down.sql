@@ -0,0 +1,5 @@
+-- WealthFlow Split Rule Active Rollback
+-- Drops the is_active column
+
+ALTER TABLE split_rules
+    DROP COLUMN IF EXISTS is_active;
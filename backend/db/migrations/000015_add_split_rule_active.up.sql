@@ -0,0 +1,6 @@
+-- WealthFlow Split Rule Active Migration
+-- Lets a split rule be disabled without deleting it (e.g. a bonus month allocated by hand)
+
+-- Existing rules stay active
+ALTER TABLE split_rules
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
@@ -7,12 +7,20 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
 )
 
+// authExemptMethods lists the RPCs that may be called without a token (readiness probes)
+var authExemptMethods = map[string]bool{
+	wealthflowv1.WealthFlowService_GetStatus_FullMethodName: true,
+}
+
 // AuthInterceptor returns a gRPC unary server interceptor that validates
 // the authorization token from request metadata.
 // If the token is missing or invalid, it returns status.Unauthenticated.
 // If valid, it calls the handler with the original context.
+// Methods in authExemptMethods skip the check.
 func AuthInterceptor(validToken string) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -20,20 +28,50 @@ func AuthInterceptor(validToken string) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		if authExemptMethods[info.FullMethod] {
+			return handler(ctx, req)
 		}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+		if err := authorize(ctx, validToken); err != nil {
+			return nil, err
 		}
 
-		if authHeaders[0] != validToken {
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor returns a gRPC stream server interceptor that applies
+// the same token validation as AuthInterceptor to streaming RPCs.
+func AuthStreamInterceptor(validToken string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := authorize(ss.Context(), validToken); err != nil {
+			return err
 		}
 
-		return handler(ctx, req)
+		return handler(srv, ss)
 	}
 }
+
+// authorize checks the authorization header in the incoming metadata against validToken
+func authorize(ctx context.Context, validToken string) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	if authHeaders[0] != validToken {
+		return status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	return nil
+}
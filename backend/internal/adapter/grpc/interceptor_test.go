@@ -9,6 +9,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
 )
 
 func TestAuthInterceptor(t *testing.T) {
@@ -90,3 +92,121 @@ func TestAuthInterceptor(t *testing.T) {
 		})
 	}
 }
+
+// mockServerStream is a minimal grpc.ServerStream carrying a fixed context
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestAuthStreamInterceptor(t *testing.T) {
+	validToken := "test-token-123"
+	interceptor := AuthStreamInterceptor(validToken)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		handlerCalled  bool
+		expectedCode   codes.Code
+		expectedErrMsg string
+	}{
+		{
+			name: "Valid Token",
+			ctx: metadata.NewIncomingContext(
+				context.Background(),
+				metadata.Pairs("authorization", validToken),
+			),
+			handlerCalled: true,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "Invalid Token",
+			ctx: metadata.NewIncomingContext(
+				context.Background(),
+				metadata.Pairs("authorization", "wrong-token"),
+			),
+			handlerCalled:  false,
+			expectedCode:   codes.Unauthenticated,
+			expectedErrMsg: "invalid token",
+		},
+		{
+			name:           "Missing Token",
+			ctx:            context.Background(),
+			handlerCalled:  false,
+			expectedCode:   codes.Unauthenticated,
+			expectedErrMsg: "missing metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerCalled := false
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				handlerCalled = true
+				return nil
+			}
+
+			info := &grpc.StreamServerInfo{
+				FullMethod:     "/test.Service/StreamMethod",
+				IsClientStream: true,
+			}
+
+			err := interceptor(nil, &mockServerStream{ctx: tt.ctx}, info, handler)
+
+			assert.Equal(t, tt.handlerCalled, handlerCalled, "handler called status mismatch")
+
+			if tt.expectedCode == codes.OK {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				st, ok := status.FromError(err)
+				assert.True(t, ok, "error should be a gRPC status")
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_ExemptMethod(t *testing.T) {
+	interceptor := AuthInterceptor("test-token-123")
+
+	handlerCalled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return "success", nil
+	}
+	info := &grpc.UnaryServerInfo{
+		FullMethod: wealthflowv1.WealthFlowService_GetStatus_FullMethodName,
+	}
+
+	// No metadata at all: the status RPC must still be reachable
+	resp, err := interceptor(context.Background(), "test-request", info, handler)
+
+	assert.NoError(t, err)
+	assert.True(t, handlerCalled)
+	assert.Equal(t, "success", resp)
+}
+
+func TestAuthInterceptor_VerifyLedgerIntegrityRequiresToken(t *testing.T) {
+	interceptor := AuthInterceptor("test-token-123")
+
+	handlerCalled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return "success", nil
+	}
+	info := &grpc.UnaryServerInfo{
+		FullMethod: wealthflowv1.WealthFlowService_VerifyLedgerIntegrity_FullMethodName,
+	}
+
+	// The integrity check scans the whole ledger, so it must not be exempt like the status RPC
+	_, err := interceptor(context.Background(), "test-request", info, handler)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, handlerCalled)
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics holds the Prometheus collectors the metrics interceptors record into
+// Both are labelled by full gRPC method name and status code (e.g. "/wealthflow.v1.WealthFlowService/LogExpense", "OK")
+type Metrics struct {
+	requests *prometheus.CounterVec   // Number of handled requests
+	latency  *prometheus.HistogramVec // Handling time in seconds
+}
+
+// NewMetrics creates the request metrics and registers them with registerer
+// Returns an error if they are already registered (e.g. NewMetrics called twice on the same registry)
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wealthflow_grpc_requests_total",
+			Help: "Number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wealthflow_grpc_request_duration_seconds",
+			Help:    "Time taken to handle gRPC requests, by method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.requests, m.latency} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// MetricsInterceptor returns a gRPC unary server interceptor that counts each request
+// and observes its latency, labelled by method and the status code of the returned error.
+// Chain it before AuthInterceptor so rejected (Unauthenticated) calls are recorded too.
+func MetricsInterceptor(metrics *Metrics) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		metrics.observe(info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// MetricsStreamInterceptor returns a gRPC stream server interceptor that records
+// streaming RPCs like MetricsInterceptor (the latency covers the whole stream).
+func MetricsStreamInterceptor(metrics *Metrics) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		metrics.observe(info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// observe records one handled request
+// Errors without a gRPC status (which grpc-go reports as Unknown) are labelled Unknown as well
+func (m *Metrics) observe(method string, err error, elapsed time.Duration) {
+	code := status.Code(err).String()
+	m.requests.WithLabelValues(method, code).Inc()
+	m.latency.WithLabelValues(method, code).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
+)
+
+func TestMetricsInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	require.NoError(t, err)
+	interceptor := MetricsInterceptor(metrics)
+
+	method := wealthflowv1.WealthFlowService_LogExpense_FullMethodName
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "success", nil
+	}
+	invalid := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad request")
+	}
+
+	resp, err := interceptor(context.Background(), "test-request", info, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, "success", resp)
+	_, err = interceptor(context.Background(), "test-request", info, ok)
+	assert.NoError(t, err)
+	_, err = interceptor(context.Background(), "test-request", info, invalid)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "InvalidArgument")))
+	// One latency series per (method, code) pair
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.latency))
+}
+
+func TestMetricsInterceptor_CountsRejectedByAuth(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	require.NoError(t, err)
+
+	// Chained as in main: metrics first, then auth
+	metricsInterceptor := MetricsInterceptor(metrics)
+	authInterceptor := AuthInterceptor("test-token-123")
+
+	handlerCalled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return "success", nil
+	}
+	method := wealthflowv1.WealthFlowService_GetNetWorth_FullMethodName
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	_, err = metricsInterceptor(context.Background(), "test-request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return authInterceptor(ctx, req, info, handler)
+	})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, handlerCalled)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "Unauthenticated")))
+}
+
+func TestMetricsStreamInterceptor(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	require.NoError(t, err)
+	interceptor := MetricsStreamInterceptor(metrics)
+
+	method := "/wealthflow.v1.WealthFlowService/StreamSomething"
+	info := &grpc.StreamServerInfo{FullMethod: method}
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.Internal, "boom")
+	}
+
+	err = interceptor(nil, &mockServerStream{ctx: context.Background()}, info, handler)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues(method, "Internal")))
+}
+
+func TestNewMetrics_AlreadyRegistered(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	_, err := NewMetrics(registry)
+	require.NoError(t, err)
+
+	_, err = NewMetrics(registry)
+	assert.Error(t, err)
+}
@@ -2,30 +2,107 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/allocator"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/bucket_manager"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/dashboard"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/expense"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/inflow"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/investment"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/scheduler"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 )
 
+// defaultBucketTransactionsLimit is the page size used by GetBucketTransactions when no limit is provided
+const defaultBucketTransactionsLimit = 10
+
+// defaultMarketValueHistoryLimit is the page size used by ListMarketValueHistory when no limit is provided
+const defaultMarketValueHistoryLimit = 100
+
+// defaultRecentMarketValuesLimit is the number of market values GetBucket returns when include_recent_market_values
+// is set without a limit
+const defaultRecentMarketValuesLimit = 30
+
+// currencyScale is the number of decimal places amounts are presented with (2 for EUR)
+// Buckets carry a currency, but every bucket is in domain.DefaultCurrency for now, so all use the EUR scale
+const currencyScale = 2
+
+// maxDecimalPlaces is the precision accepted for a decimal parsed from a request (finer than any currency or share quantity needs)
+const maxDecimalPlaces = 8
+
+// maxAmountMagnitude is the largest absolute value accepted for a decimal parsed from a request (1e15),
+// so absurd values cannot corrupt sums
+var maxAmountMagnitude = decimal.New(1, 15)
+
+// statusCheckTimeout bounds the dependency checks made by GetStatus so a hung database cannot block the probe
+const statusCheckTimeout = 2 * time.Second
+
+// Pinger checks connectivity to the database (satisfied by *postgres.DB)
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// BuildInfo identifies the running build (injected at compile time, see cmd/server)
+type BuildInfo struct {
+	Version   string
+	Commit    string
+	BuildTime string
+}
+
+// Page size defaults for ListTransactions
+const (
+	DefaultMaxListLimit = 500 // Upper bound applied when none is configured
+	DefaultListLimit    = 50  // Page size used for a zero limit when DefaultOnZero is enabled
+)
+
+// ListLimits bounds the page size clients may request from ListTransactions
+type ListLimits struct {
+	Max           int  // Larger requested limits are clamped to Max (0 disables the cap)
+	DefaultOnZero bool // If true, a zero limit returns DefaultListLimit rows instead of being rejected
+}
+
+// resolve returns the effective page size for a requested limit
+func (l ListLimits) resolve(limit int32) (int, error) {
+	effective := int(limit)
+	if effective == 0 && l.DefaultOnZero {
+		effective = DefaultListLimit
+	}
+	if effective <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "limit must be positive")
+	}
+	if l.Max > 0 && effective > l.Max {
+		effective = l.Max
+	}
+	return effective, nil
+}
+
 // Server implements the WealthFlowService gRPC server
 type Server struct {
 	wealthflowv1.UnimplementedWealthFlowServiceServer
 
-	ExpenseService    *expense.ExpenseService
-	InflowService     *inflow.InflowService
-	InvestmentService *investment.InvestmentService
-	DashboardService  *dashboard.DashboardService
+	ExpenseService     *expense.ExpenseService
+	InflowService      *inflow.InflowService
+	InvestmentService  *investment.InvestmentService
+	DashboardService   *dashboard.DashboardService
+	BucketService      *bucket_manager.BucketService
+	ScheduledProcessor *scheduler.ScheduledProcessor
+	SystemSeeder       *seeder.SystemSeeder
+	DB                 Pinger
+	BuildInfo          BuildInfo
+	ListLimits         ListLimits
 }
 
 // NewServer creates a new gRPC server instance
@@ -34,21 +111,67 @@ func NewServer(
 	inflowService *inflow.InflowService,
 	investmentService *investment.InvestmentService,
 	dashboardService *dashboard.DashboardService,
+	bucketService *bucket_manager.BucketService,
+	scheduledProcessor *scheduler.ScheduledProcessor,
+	systemSeeder *seeder.SystemSeeder,
+	db Pinger,
+	buildInfo BuildInfo,
+	listLimits ListLimits,
 ) *Server {
 	return &Server{
-		ExpenseService:    expenseService,
-		InflowService:     inflowService,
-		InvestmentService: investmentService,
-		DashboardService:  dashboardService,
+		ExpenseService:     expenseService,
+		InflowService:      inflowService,
+		InvestmentService:  investmentService,
+		DashboardService:   dashboardService,
+		BucketService:      bucketService,
+		ScheduledProcessor: scheduledProcessor,
+		SystemSeeder:       systemSeeder,
+		DB:                 db,
+		BuildInfo:          buildInfo,
+		ListLimits:         listLimits,
+	}
+}
+
+// GetStatus handles the GetStatus RPC
+// Failed checks are reported in the response rather than as an error, so callers always get the full picture
+func (s *Server) GetStatus(ctx context.Context, req *wealthflowv1.GetStatusRequest) (*wealthflowv1.GetStatusResponse, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, statusCheckTimeout)
+	defer cancel()
+
+	resp := &wealthflowv1.GetStatusResponse{
+		Version:   s.BuildInfo.Version,
+		Commit:    s.BuildInfo.Commit,
+		BuildTime: s.BuildInfo.BuildTime,
 	}
+
+	if err := s.DB.PingContext(checkCtx); err != nil {
+		resp.Status = "database unreachable"
+		return resp, nil
+	}
+	resp.DatabaseConnected = true
+
+	seeded, err := s.SystemSeeder.IsSeeded(checkCtx)
+	if err != nil {
+		resp.Status = "failed to check system buckets"
+		return resp, nil
+	}
+	resp.SystemBucketsSeeded = seeded
+	if !seeded {
+		resp.Status = "system buckets not seeded"
+		return resp, nil
+	}
+
+	resp.Ready = true
+	resp.Status = "ok"
+	return resp, nil
 }
 
 // RecordInflow handles the RecordInflow RPC
 func (s *Server) RecordInflow(ctx context.Context, req *wealthflowv1.RecordInflowRequest) (*wealthflowv1.RecordInflowResponse, error) {
 	// Parse amount from string to decimal
-	amount, err := decimal.NewFromString(req.Amount)
+	amount, err := parseAmount("amount", req.Amount)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid amount format: %v", err)
+		return nil, err
 	}
 
 	// Parse source bucket ID
@@ -57,14 +180,39 @@ func (s *Server) RecordInflow(ctx context.Context, req *wealthflowv1.RecordInflo
 		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
 	}
 
+	// Parse optional target virtual bucket ID
+	var targetVirtualBucketID *uuid.UUID
+	if req.TargetVirtualBucketId != "" {
+		targetID, err := uuid.Parse(req.TargetVirtualBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target_virtual_bucket_id format: %v", err)
+		}
+		targetVirtualBucketID = &targetID
+	}
+
+	// Parse optional parking physical bucket ID
+	var parkInPhysicalBucketID *uuid.UUID
+	if req.ParkInPhysicalBucketId != "" {
+		parkID, err := uuid.Parse(req.ParkInPhysicalBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid park_in_physical_bucket_id format: %v", err)
+		}
+		parkInPhysicalBucketID = &parkID
+	}
+
 	// Build input for usecase
 	// Note: Date is handled internally by the service (uses time.Now())
 	// If we need to support custom dates in the future, we'll need to modify the service
 	input := inflow.RecordInflowInput{
-		Amount:         amount,
-		Description:    req.Description,
-		SourceBucketID: sourceBucketID,
-		IsExternal:     req.IsExternal,
+		Amount:                 amount,
+		Description:            req.Description,
+		Memo:                   req.Memo,
+		SourceBucketID:         sourceBucketID,
+		IsExternal:             req.IsExternal,
+		AllocationMode:         protoAllocationModeToDomain(req.AllocationMode),
+		TargetVirtualBucketID:  targetVirtualBucketID,
+		DryRun:                 req.DryRun,
+		ParkInPhysicalBucketID: parkInPhysicalBucketID,
 	}
 
 	// Call usecase service
@@ -73,19 +221,258 @@ func (s *Server) RecordInflow(ctx context.Context, req *wealthflowv1.RecordInflo
 		return nil, mapError(err)
 	}
 
-	// Build response
-	return &wealthflowv1.RecordInflowResponse{
+	// Resolve the allocation breakdown from the created transaction
+	targets, err := s.InflowService.AllocationsFromTransaction(ctx, tx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	allocations := make([]*wealthflowv1.AllocationAmount, 0, len(targets))
+	for _, target := range targets {
+		allocations = append(allocations, &wealthflowv1.AllocationAmount{
+			BucketId: target.BucketID.String(),
+			Name:     target.Name,
+			Amount:   formatAmount(target.Amount),
+		})
+	}
+
+	// Build response (a dry run has no transaction to point to)
+	resp := &wealthflowv1.RecordInflowResponse{
+		CreatedAt:   timestamppb.New(tx.Date),
+		Allocations: allocations,
+		Parked:      inflow.IsParked(tx),
+	}
+	if !req.DryRun {
+		resp.TransactionId = tx.ID.String()
+	}
+	return resp, nil
+}
+
+// GetUnallocatedIncome handles the GetUnallocatedIncome RPC
+func (s *Server) GetUnallocatedIncome(ctx context.Context, req *wealthflowv1.GetUnallocatedIncomeRequest) (*wealthflowv1.GetUnallocatedIncomeResponse, error) {
+	// Call usecase service
+	income, err := s.InflowService.GetUnallocatedIncome(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	contributions := make([]*wealthflowv1.ParkedIncomeChange, 0, len(income.Contributions))
+	for _, contribution := range income.Contributions {
+		contributions = append(contributions, &wealthflowv1.ParkedIncomeChange{
+			Transaction: domainTransactionsToProto([]*domain.Transaction{contribution.Transaction})[0],
+			Amount:      formatAmount(contribution.Amount),
+		})
+	}
+
+	return &wealthflowv1.GetUnallocatedIncomeResponse{
+		BucketId:      income.Bucket.ID.String(),
+		Amount:        formatAmount(income.Amount),
+		Contributions: contributions,
+	}, nil
+}
+
+// AllocateUnallocatedIncome handles the AllocateUnallocatedIncome RPC
+func (s *Server) AllocateUnallocatedIncome(ctx context.Context, req *wealthflowv1.AllocateUnallocatedIncomeRequest) (*wealthflowv1.AllocateUnallocatedIncomeResponse, error) {
+	// Parse the allocation lines
+	lines := make([]inflow.ParkedIncomeAllocation, 0, len(req.Allocations))
+	for i, allocation := range req.Allocations {
+		bucketID, err := uuid.Parse(allocation.VirtualBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid virtual_bucket_id format for allocation %d: %v", i, err)
+		}
+		amount, err := parseAmount("amount", allocation.Amount)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, inflow.ParkedIncomeAllocation{VirtualBucketID: bucketID, Amount: amount})
+	}
+
+	// Call usecase service
+	tx, err := s.InflowService.AllocateUnallocatedIncome(ctx, lines, req.Description)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.AllocateUnallocatedIncomeResponse{
 		TransactionId: tx.ID.String(),
-		CreatedAt:     timestamppb.New(tx.Date),
+	}, nil
+}
+
+// GetInflowAllocation handles the GetInflowAllocation RPC
+func (s *Server) GetInflowAllocation(ctx context.Context, req *wealthflowv1.GetInflowAllocationRequest) (*wealthflowv1.GetInflowAllocationResponse, error) {
+	// Parse transaction ID
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction_id format: %v", err)
+	}
+
+	// Call usecase service
+	tx, targets, err := s.InflowService.GetInflowAllocation(ctx, transactionID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	allocations := make([]*wealthflowv1.AllocationAmount, 0, len(targets))
+	for _, target := range targets {
+		allocations = append(allocations, &wealthflowv1.AllocationAmount{
+			BucketId: target.BucketID.String(),
+			Name:     target.Name,
+			Amount:   formatAmount(target.Amount),
+		})
+	}
+
+	// Build response
+	return &wealthflowv1.GetInflowAllocationResponse{
+		Date:        timestamppb.New(tx.Date),
+		Description: tx.Description,
+		Allocations: allocations,
+	}, nil
+}
+
+// PreviewAllocation handles the PreviewAllocation RPC
+func (s *Server) PreviewAllocation(ctx context.Context, req *wealthflowv1.PreviewAllocationRequest) (*wealthflowv1.PreviewAllocationResponse, error) {
+	// Parse amount from string to decimal
+	amount, err := parseAmount("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse source bucket ID
+	sourceBucketID, err := uuid.Parse(req.SourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	preview, err := s.InflowService.PreviewAllocation(ctx, sourceBucketID, amount, protoAllocationModeToDomain(req.AllocationMode), req.Explain)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert allocation to proto (in split rule priority order)
+	allocations := make([]*wealthflowv1.AllocationAmount, 0, len(preview.Targets))
+	for _, target := range preview.Targets {
+		allocations = append(allocations, &wealthflowv1.AllocationAmount{
+			BucketId: target.BucketID.String(),
+			Name:     target.Name,
+			Amount:   formatAmount(target.Amount),
+		})
+	}
+
+	// Convert explanation steps to proto
+	steps := make([]*wealthflowv1.AllocationStep, 0, len(preview.Steps))
+	for _, step := range preview.Steps {
+		protoStep := &wealthflowv1.AllocationStep{
+			BucketId:         step.Item.TargetBucketID.String(),
+			Type:             domainSplitRuleItemTypeToProto(step.Type),
+			Value:            step.Item.Value.String(),
+			BaseAmount:       formatAmount(step.BaseAmount),
+			ComputedAmount:   formatAmount(step.ComputedAmount),
+			RunningRemainder: formatAmount(step.RunningRemainder),
+		}
+		if step.Item.RelativeToBucketID != nil {
+			protoStep.RelativeToBucketId = step.Item.RelativeToBucketID.String()
+		}
+		steps = append(steps, protoStep)
+	}
+
+	// Build response
+	return &wealthflowv1.PreviewAllocationResponse{
+		Allocations: allocations,
+		Steps:       steps,
+	}, nil
+}
+
+// CreateSplitRule handles the CreateSplitRule RPC
+func (s *Server) CreateSplitRule(ctx context.Context, req *wealthflowv1.CreateSplitRuleRequest) (*wealthflowv1.CreateSplitRuleResponse, error) {
+	// Parse source bucket ID
+	sourceBucketID, err := uuid.Parse(req.SourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+
+	// Parse destination physical bucket ID (optional)
+	var destinationID *uuid.UUID
+	if req.DestinationPhysicalBucketId != "" {
+		parsed, err := uuid.Parse(req.DestinationPhysicalBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid destination_physical_bucket_id format: %v", err)
+		}
+		destinationID = &parsed
+	}
+
+	// Convert proto items to domain items
+	items := make([]domain.SplitRuleItem, 0, len(req.Items))
+	for _, protoItem := range req.Items {
+		item, err := protoSplitRuleItemToDomain(protoItem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	// Call usecase service
+	rule, warnings, err := s.InflowService.CreateSplitRule(ctx, inflow.CreateSplitRuleInput{
+		Name:                        req.Name,
+		SourceBucketID:              sourceBucketID,
+		DestinationPhysicalBucketID: destinationID,
+		Items:                       items,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Build response
+	return &wealthflowv1.CreateSplitRuleResponse{
+		SplitRuleId: rule.ID.String(),
+		Warnings:    warnings,
+	}, nil
+}
+
+// ValidateSplitRule handles checking a split rule without persisting it
+// Invalid rules are reported in the response (not as an RPC error) so the client can show them inline
+func (s *Server) ValidateSplitRule(ctx context.Context, req *wealthflowv1.ValidateSplitRuleRequest) (*wealthflowv1.ValidateSplitRuleResponse, error) {
+	rule := domain.SplitRule{
+		Items: make([]domain.SplitRuleItem, 0, len(req.Items)),
+	}
+
+	// Parse optional source bucket ID
+	if req.SourceBucketId != "" {
+		sourceBucketID, err := uuid.Parse(req.SourceBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+		}
+		rule.SourceBucketID = sourceBucketID
+	}
+
+	// Convert proto items to domain items
+	for _, protoItem := range req.Items {
+		item, err := protoSplitRuleItemToDomain(protoItem)
+		if err != nil {
+			return nil, err
+		}
+		rule.Items = append(rule.Items, item)
+	}
+
+	if err := rule.Validate(); err != nil {
+		return &wealthflowv1.ValidateSplitRuleResponse{
+			Valid: false,
+			Error: err.Error(),
+		}, nil
+	}
+
+	return &wealthflowv1.ValidateSplitRuleResponse{
+		Valid:    true,
+		Warnings: rule.Lint(),
 	}, nil
 }
 
 // LogExpense handles the LogExpense RPC
 func (s *Server) LogExpense(ctx context.Context, req *wealthflowv1.LogExpenseRequest) (*wealthflowv1.LogExpenseResponse, error) {
 	// Parse amount from string to decimal
-	amount, err := decimal.NewFromString(req.Amount)
+	amount, err := parseAmount("amount", req.Amount)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid amount format: %v", err)
+		return nil, err
 	}
 
 	// Parse virtual bucket ID
@@ -114,9 +501,15 @@ func (s *Server) LogExpense(ctx context.Context, req *wealthflowv1.LogExpenseReq
 	input := expense.LogExpenseInput{
 		Amount:             amount,
 		Description:        req.Description,
+		Memo:               req.Memo,
 		VirtualBucketID:    virtualBucketID,
 		CategoryBucketID:   categoryBucketID,
 		PhysicalOverrideID: physicalOverrideID,
+		IsRefund:           req.IsRefund,
+	}
+	if req.EffectiveDate != nil {
+		effectiveDate := req.EffectiveDate.AsTime()
+		input.EffectiveDate = &effectiveDate
 	}
 
 	// Call usecase service
@@ -125,11 +518,11 @@ func (s *Server) LogExpense(ctx context.Context, req *wealthflowv1.LogExpenseReq
 		return nil, mapError(err)
 	}
 
-	// Determine which physical bucket was actually credited
+	// Determine which physical bucket was actually credited (debited for a refund)
 	// We need to find it from the transaction entries
 	var physicalBucketID string
 	for _, entry := range tx.Entries {
-		if entry.Layer == domain.LayerPhysical && entry.Type == domain.EntryTypeCredit {
+		if entry.Layer == domain.LayerPhysical && entry.BucketID != categoryBucketID {
 			physicalBucketID = entry.BucketID.String()
 			break
 		}
@@ -143,250 +536,1842 @@ func (s *Server) LogExpense(ctx context.Context, req *wealthflowv1.LogExpenseReq
 	}, nil
 }
 
-// UpdateInvestment handles the UpdateInvestment RPC
-func (s *Server) UpdateInvestment(ctx context.Context, req *wealthflowv1.UpdateInvestmentRequest) (*wealthflowv1.UpdateInvestmentResponse, error) {
-	// Parse bucket ID
-	bucketID, err := uuid.Parse(req.BucketId)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+// ProcessScheduledTransactions handles the ProcessScheduledTransactions RPC
+func (s *Server) ProcessScheduledTransactions(ctx context.Context, req *wealthflowv1.ProcessScheduledTransactionsRequest) (*wealthflowv1.ProcessScheduledTransactionsResponse, error) {
+	asOf := time.Now()
+	if req.AsOf != nil {
+		asOf = req.AsOf.AsTime()
 	}
 
-	// Parse market value from string to decimal
-	marketValue, err := decimal.NewFromString(req.MarketValue)
+	activated, err := s.ScheduledProcessor.ProcessScheduled(ctx, asOf)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid market_value format: %v", err)
+		return nil, mapError(err)
 	}
 
-	// Call usecase service
-	entry, err := s.InvestmentService.UpdateMarketValue(ctx, bucketID, marketValue)
-	if err != nil {
-		return nil, mapError(err)
+	ids := make([]string, 0, len(activated))
+	for _, id := range activated {
+		ids = append(ids, id.String())
 	}
 
-	// Build response with the created entry
-	return &wealthflowv1.UpdateInvestmentResponse{
-		EntryId:   entry.ID.String(),
-		CreatedAt: timestamppb.New(entry.Date),
+	return &wealthflowv1.ProcessScheduledTransactionsResponse{
+		ActivatedTransactionIds: ids,
 	}, nil
 }
 
-// ListBuckets handles the ListBuckets RPC
-func (s *Server) ListBuckets(ctx context.Context, req *wealthflowv1.ListBucketsRequest) (*wealthflowv1.ListBucketsResponse, error) {
-	// Parse bucket type filter (optional)
-	// If bucket_type is UNSPECIFIED (0), treat it as no filter
-	var typeFilter domain.BucketType
-	if req.BucketType != wealthflowv1.BucketType_BUCKET_TYPE_UNSPECIFIED {
-		typeFilter = protoBucketTypeToDomain(req.BucketType)
-	}
-
-	// Get buckets from repository
-	buckets, err := s.DashboardService.BucketRepo.List(ctx, typeFilter)
+// LogSplitExpense handles the LogSplitExpense RPC
+func (s *Server) LogSplitExpense(ctx context.Context, req *wealthflowv1.LogSplitExpenseRequest) (*wealthflowv1.LogSplitExpenseResponse, error) {
+	// Parse total amount from string to decimal
+	amount, err := parseAmount("amount", req.Amount)
 	if err != nil {
-		return nil, mapError(err)
-	}
-
-	// Convert domain buckets to proto buckets
-	protoBuckets := make([]*wealthflowv1.Bucket, 0, len(buckets))
-	for _, bucket := range buckets {
-		protoBuckets = append(protoBuckets, domainBucketToProto(bucket))
+		return nil, err
 	}
 
-	return &wealthflowv1.ListBucketsResponse{
-		Buckets: protoBuckets,
-	}, nil
-}
-
-// ListTransactions handles the ListTransactions RPC
-func (s *Server) ListTransactions(ctx context.Context, req *wealthflowv1.ListTransactionsRequest) (*wealthflowv1.ListTransactionsResponse, error) {
-	// Validate limit (must be positive)
-	if req.Limit <= 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "limit must be positive")
+	// Parse virtual bucket ID
+	virtualBucketID, err := uuid.Parse(req.VirtualBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid virtual_bucket_id format: %v", err)
 	}
 
-	// Validate offset (must be non-negative)
-	if req.Offset < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "offset must be non-negative")
+	// Parse optional physical override ID
+	var physicalOverrideID *uuid.UUID
+	if req.PhysicalBucketOverrideId != "" {
+		overrideID, err := uuid.Parse(req.PhysicalBucketOverrideId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid physical_bucket_override_id format: %v", err)
+		}
+		physicalOverrideID = &overrideID
 	}
 
-	// Parse optional bucket ID filter
-	var bucketID *uuid.UUID
-	if req.BucketId != "" {
-		parsedID, err := uuid.Parse(req.BucketId)
+	// Parse lines
+	lines := make([]expense.ExpenseLine, 0, len(req.Lines))
+	categoryIDs := make(map[uuid.UUID]bool, len(req.Lines))
+	for i, protoLine := range req.Lines {
+		categoryID, err := uuid.Parse(protoLine.CategoryBucketId)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid category_bucket_id format in line %d: %v", i, err)
 		}
-		bucketID = &parsedID
+		lineAmount, err := parseAmount("line amount", protoLine.Amount)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, expense.ExpenseLine{CategoryBucketID: categoryID, Amount: lineAmount})
+		categoryIDs[categoryID] = true
 	}
 
-	// Get total count for accurate pagination
-	totalCount, err := s.DashboardService.TransactionRepo.Count(ctx, bucketID)
+	// Call usecase service
+	tx, err := s.ExpenseService.LogSplitExpense(ctx, expense.LogSplitExpenseInput{
+		Amount:             amount,
+		Description:        req.Description,
+		Memo:               req.Memo,
+		VirtualBucketID:    virtualBucketID,
+		PhysicalOverrideID: physicalOverrideID,
+		Lines:              lines,
+	})
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	// Get transactions from repository
-	transactions, err := s.DashboardService.TransactionRepo.List(ctx, int(req.Limit), int(req.Offset), bucketID)
+	// The physical credit is the only physical entry not on a category
+	var physicalBucketID string
+	for _, entry := range tx.Entries {
+		if entry.Layer == domain.LayerPhysical && !categoryIDs[entry.BucketID] {
+			physicalBucketID = entry.BucketID.String()
+			break
+		}
+	}
+
+	return &wealthflowv1.LogSplitExpenseResponse{
+		TransactionId:    tx.ID.String(),
+		CreatedAt:        timestamppb.New(tx.Date),
+		PhysicalBucketId: physicalBucketID,
+	}, nil
+}
+
+// UpdateInvestment handles the UpdateInvestment RPC
+func (s *Server) UpdateInvestment(ctx context.Context, req *wealthflowv1.UpdateInvestmentRequest) (*wealthflowv1.UpdateInvestmentResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
 	if err != nil {
-		return nil, mapError(err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
 	}
 
-	// Collect all unique bucket IDs from the transactions
-	bucketIDSet := make(map[uuid.UUID]bool)
-	for _, tx := range transactions {
-		for _, entry := range tx.Entries {
-			bucketIDSet[entry.BucketID] = true
+	// A per-unit price is recorded against the bucket's quantity instead of a total market value
+	var entry *domain.MarketValueHistory
+	if req.UnitPrice != "" {
+		if req.MarketValue != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "market_value and unit_price are mutually exclusive")
+		}
+		unitPrice, err := parseAmount("unit_price", req.UnitPrice)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	// Fetch bucket names for all unique bucket IDs
-	// Always initialize the map (even if empty) to ensure it's never nil
-	bucketNames := make(map[string]string)
-	if len(bucketIDSet) > 0 {
-		bucketIDList := make([]uuid.UUID, 0, len(bucketIDSet))
-		for id := range bucketIDSet {
-			bucketIDList = append(bucketIDList, id)
+		entry, err = s.InvestmentService.UpdateMarketPrice(ctx, bucketID, unitPrice)
+		if err != nil {
+			return nil, mapError(err)
+		}
+	} else {
+		// Parse market value from string to decimal
+		marketValue, err := parseAmount("market_value", req.MarketValue)
+		if err != nil {
+			return nil, err
 		}
 
-		// Fetch each bucket to get its name
-		for _, id := range bucketIDList {
-			bucket, err := s.DashboardService.BucketRepo.GetByID(ctx, id)
-			if err != nil {
-				// If bucket not found, skip it (shouldn't happen, but handle gracefully)
-				continue
-			}
-			bucketNames[id.String()] = bucket.Name
+		// Call usecase service
+		entry, err = s.InvestmentService.UpdateMarketValue(ctx, bucketID, marketValue)
+		if err != nil {
+			return nil, mapError(err)
 		}
 	}
 
-	// Convert domain transactions to proto transactions
-	protoTransactions := make([]*wealthflowv1.Transaction, 0, len(transactions))
-	for _, tx := range transactions {
-		// Calculate transaction amount from entries
-		// For simplicity, we'll sum all credit amounts in the physical layer
-		var amount decimal.Decimal
+	// Build response with the created entry
+	return &wealthflowv1.UpdateInvestmentResponse{
+		EntryId:   entry.ID.String(),
+		CreatedAt: timestamppb.New(entry.Date),
+	}, nil
+}
+
+// GetInvestmentProfit handles fetching a bucket's current profit/loss including the percentage return
+func (s *Server) GetInvestmentProfit(ctx context.Context, req *wealthflowv1.GetInvestmentProfitRequest) (*wealthflowv1.GetInvestmentProfitResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	detail, err := s.InvestmentService.CalculateProfitDetail(ctx, bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	resp := &wealthflowv1.GetInvestmentProfitResponse{
+		BookValue:      formatAmount(detail.BookValue),
+		MarketValue:    formatAmount(detail.MarketValue),
+		AbsoluteProfit: formatAmount(detail.AbsoluteProfit),
+	}
+	// Leave percent_profit empty when it is undefined (zero book value)
+	if detail.PercentProfit != nil {
+		resp.PercentProfit = detail.PercentProfit.String()
+	}
+
+	return resp, nil
+}
+
+// ListMarketValueHistory handles the ListMarketValueHistory RPC
+func (s *Server) ListMarketValueHistory(ctx context.Context, req *wealthflowv1.ListMarketValueHistoryRequest) (*wealthflowv1.ListMarketValueHistoryResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Apply default page size
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultMarketValueHistoryLimit
+	}
+
+	// Call usecase service
+	entries, totalCount, err := s.InvestmentService.ListMarketValueHistory(ctx, bucketID, limit, int(req.Offset), req.Ascending)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoEntries := make([]*wealthflowv1.MarketValueEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, &wealthflowv1.MarketValueEntry{
+			Id:          entry.ID.String(),
+			Date:        timestamppb.New(entry.Date),
+			MarketValue: formatAmount(entry.MarketValue),
+		})
+	}
+
+	return &wealthflowv1.ListMarketValueHistoryResponse{
+		Entries:    protoEntries,
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+// ListBuckets handles the ListBuckets RPC
+func (s *Server) ListBuckets(ctx context.Context, req *wealthflowv1.ListBucketsRequest) (*wealthflowv1.ListBucketsResponse, error) {
+	// Parse bucket type filter (optional)
+	// If bucket_type is UNSPECIFIED (0), treat it as no filter
+	var typeFilter domain.BucketType
+	if req.BucketType != wealthflowv1.BucketType_BUCKET_TYPE_UNSPECIFIED {
+		typeFilter = protoBucketTypeToDomain(req.BucketType)
+	}
+
+	// Get buckets from repository
+	buckets, err := s.DashboardService.BucketRepo.List(ctx, typeFilter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Fetch the latest market values of the listed equity buckets in one query, if requested
+	var marketValues map[uuid.UUID]decimal.Decimal
+	if req.IncludeMarketValue {
+		marketValues, err = s.DashboardService.LatestMarketValues(ctx, buckets)
+		if err != nil {
+			return nil, mapError(err)
+		}
+	}
+
+	// Convert domain buckets to proto buckets
+	protoBuckets := make([]*wealthflowv1.Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		protoBucket := domainBucketToProto(bucket)
+		if marketValue, ok := marketValues[bucket.ID]; ok {
+			protoBucket.MarketValue = formatAmount(marketValue)
+		}
+		protoBuckets = append(protoBuckets, protoBucket)
+	}
+
+	return &wealthflowv1.ListBucketsResponse{
+		Buckets: protoBuckets,
+	}, nil
+}
+
+// GetBucketsSummary handles the GetBucketsSummary RPC
+func (s *Server) GetBucketsSummary(ctx context.Context, req *wealthflowv1.GetBucketsSummaryRequest) (*wealthflowv1.GetBucketsSummaryResponse, error) {
+	// Call dashboard service
+	summary, err := s.DashboardService.GetBucketsSummary(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Key counts by the domain type string (e.g. "PHYSICAL")
+	countsByType := make(map[string]int32, len(summary.CountsByType))
+	for bucketType, count := range summary.CountsByType {
+		countsByType[string(bucketType)] = int32(count)
+	}
+
+	return &wealthflowv1.GetBucketsSummaryResponse{
+		CountsByType:   countsByType,
+		TotalLiquidity: formatAmount(summary.TotalLiquidity),
+	}, nil
+}
+
+// GetProfitHistory handles the GetProfitHistory RPC
+func (s *Server) GetProfitHistory(ctx context.Context, req *wealthflowv1.GetProfitHistoryRequest) (*wealthflowv1.GetProfitHistoryResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Optional date range: defaults to all history up to now
+	var start time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	// Call usecase service
+	points, err := s.DashboardService.GetProfitHistory(ctx, bucketID, start, end)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoPoints := make([]*wealthflowv1.ProfitPoint, 0, len(points))
+	for _, point := range points {
+		protoPoints = append(protoPoints, &wealthflowv1.ProfitPoint{
+			Date:        timestamppb.New(point.Date),
+			BookValue:   formatAmount(point.BookValue),
+			MarketValue: formatAmount(point.MarketValue),
+			Profit:      formatAmount(point.Profit),
+		})
+	}
+
+	return &wealthflowv1.GetProfitHistoryResponse{
+		Points: protoPoints,
+	}, nil
+}
+
+// GetCategorySpendingTrend handles the GetCategorySpendingTrend RPC
+func (s *Server) GetCategorySpendingTrend(ctx context.Context, req *wealthflowv1.GetCategorySpendingTrendRequest) (*wealthflowv1.GetCategorySpendingTrendResponse, error) {
+	// Parse category bucket ID
+	categoryBucketID, err := uuid.Parse(req.CategoryBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category_bucket_id format: %v", err)
+	}
+
+	// Optional date range: defaults to all history up to now
+	var start time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	// Optional granularity: defaults to month over month
+	granularity := domain.TrendGranularityMonth
+	if req.Granularity != "" {
+		granularity = domain.TrendGranularity(req.Granularity)
+	}
+
+	// Call usecase service
+	totals, err := s.DashboardService.GetCategoryTrend(ctx, categoryBucketID, start, end, granularity)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoPoints := make([]*wealthflowv1.SpendingPeriod, 0, len(totals))
+	for _, total := range totals {
+		protoPoints = append(protoPoints, &wealthflowv1.SpendingPeriod{
+			Period: timestamppb.New(total.Period),
+			Total:  formatAmount(total.Total),
+		})
+	}
+
+	return &wealthflowv1.GetCategorySpendingTrendResponse{
+		Points: protoPoints,
+	}, nil
+}
+
+// GetTransactionsByDateHistogram handles the GetTransactionsByDateHistogram RPC
+func (s *Server) GetTransactionsByDateHistogram(ctx context.Context, req *wealthflowv1.GetTransactionsByDateHistogramRequest) (*wealthflowv1.GetTransactionsByDateHistogramResponse, error) {
+	// Optional date range: defaults to all history up to now
+	var start time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	// Parse optional bucket filter
+	var bucketID *uuid.UUID
+	if req.BucketId != "" {
+		parsedID, err := uuid.Parse(req.BucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+		}
+		bucketID = &parsedID
+	}
+
+	// Call usecase service
+	counts, err := s.DashboardService.GetTransactionHistogram(ctx, start, end, bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoDays := make([]*wealthflowv1.TransactionDayCount, 0, len(counts))
+	for _, count := range counts {
+		protoDays = append(protoDays, &wealthflowv1.TransactionDayCount{
+			Date:  timestamppb.New(count.Date),
+			Count: int32(count.Count),
+		})
+	}
+
+	return &wealthflowv1.GetTransactionsByDateHistogramResponse{
+		Days: protoDays,
+	}, nil
+}
+
+// GetExpenseBreakdownByPhysicalBucket handles the GetExpenseBreakdownByPhysicalBucket RPC
+func (s *Server) GetExpenseBreakdownByPhysicalBucket(ctx context.Context, req *wealthflowv1.GetExpenseBreakdownByPhysicalBucketRequest) (*wealthflowv1.GetExpenseBreakdownByPhysicalBucketResponse, error) {
+	// Optional date range: defaults to all history up to now
+	var start time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	// Call usecase service
+	breakdown, err := s.DashboardService.GetExpenseBreakdownByPhysicalBucket(ctx, start, end)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoBuckets := make([]*wealthflowv1.PhysicalBucketSpending, 0, len(breakdown))
+	for _, spending := range breakdown {
+		protoBuckets = append(protoBuckets, &wealthflowv1.PhysicalBucketSpending{
+			PhysicalBucketId: spending.Bucket.ID.String(),
+			Name:             spending.Bucket.Name,
+			TotalSpent:       formatAmount(spending.TotalSpent),
+		})
+	}
+
+	return &wealthflowv1.GetExpenseBreakdownByPhysicalBucketResponse{
+		Buckets: protoBuckets,
+	}, nil
+}
+
+// GetInflowAllocationShares handles the GetInflowAllocationShares RPC
+func (s *Server) GetInflowAllocationShares(ctx context.Context, req *wealthflowv1.GetInflowAllocationSharesRequest) (*wealthflowv1.GetInflowAllocationSharesResponse, error) {
+	// Optional date range: defaults to all history up to now
+	var start time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	// Call usecase service
+	shares, err := s.DashboardService.GetInflowAllocationShares(ctx, start, end)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	protoShares := make([]*wealthflowv1.AllocationShare, 0, len(shares))
+	for _, share := range shares {
+		protoShares = append(protoShares, &wealthflowv1.AllocationShare{
+			Bucket:       domainBucketToProto(share.Bucket),
+			Total:        formatAmount(share.Total),
+			SharePercent: share.SharePercent.StringFixed(2),
+		})
+	}
+
+	return &wealthflowv1.GetInflowAllocationSharesResponse{
+		Shares: protoShares,
+	}, nil
+}
+
+// VerifyLedgerIntegrity handles the VerifyLedgerIntegrity RPC
+func (s *Server) VerifyLedgerIntegrity(ctx context.Context, req *wealthflowv1.VerifyLedgerIntegrityRequest) (*wealthflowv1.VerifyLedgerIntegrityResponse, error) {
+	report, err := s.DashboardService.VerifyLedgerIntegrity(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoViolations := make([]*wealthflowv1.LedgerViolation, 0, len(report.Violations))
+	for _, violation := range report.Violations {
+		protoViolations = append(protoViolations, &wealthflowv1.LedgerViolation{
+			TransactionId: violation.TransactionID.String(),
+			Reason:        violation.Reason,
+		})
+	}
+
+	return &wealthflowv1.VerifyLedgerIntegrityResponse{
+		TransactionsChecked: int32(report.TransactionsChecked),
+		Violations:          protoViolations,
+	}, nil
+}
+
+// ListBucketsByParent handles the ListBucketsByParent RPC
+func (s *Server) ListBucketsByParent(ctx context.Context, req *wealthflowv1.ListBucketsByParentRequest) (*wealthflowv1.ListBucketsByParentResponse, error) {
+	// Parse parent ID
+	parentID, err := uuid.Parse(req.ParentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent_id format: %v", err)
+	}
+
+	// Call dashboard service
+	buckets, err := s.DashboardService.ListBucketsByParent(ctx, parentID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert domain buckets to proto buckets
+	protoBuckets := make([]*wealthflowv1.Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		protoBuckets = append(protoBuckets, domainBucketToProto(bucket))
+	}
+
+	return &wealthflowv1.ListBucketsByParentResponse{
+		Buckets: protoBuckets,
+	}, nil
+}
+
+// ListTransactions handles the ListTransactions RPC
+func (s *Server) ListTransactions(ctx context.Context, req *wealthflowv1.ListTransactionsRequest) (*wealthflowv1.ListTransactionsResponse, error) {
+	// Resolve limit (clamped to the configured maximum, see ListLimits)
+	limit, err := s.ListLimits.resolve(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate offset (must be non-negative)
+	if req.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "offset must be non-negative")
+	}
+
+	// Parse optional bucket ID filter
+	var bucketID *uuid.UUID
+	if req.BucketId != "" {
+		parsedID, err := uuid.Parse(req.BucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+		}
+		bucketID = &parsedID
+	}
+
+	// Parse optional multi-bucket filter
+	var bucketIDs []uuid.UUID
+	for _, rawID := range req.BucketIds {
+		parsedID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_ids format: %v", err)
+		}
+		bucketIDs = append(bucketIDs, parsedID)
+	}
+
+	if bucketID != nil {
+		bucketIDs = append(bucketIDs, *bucketID)
+	}
+
+	// Parse optional kind filter
+	kind := domain.TransactionKind(req.Kind)
+	if !kind.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid kind %q: must be REFUND or EXTERNAL_INFLOW", req.Kind)
+	}
+
+	// All filters compose: transactions must match every filter that is set
+	filter := domain.TransactionFilter{
+		BucketIDs: bucketIDs,
+		Kind:      kind,
+	}
+
+	// Get total count for accurate pagination
+	totalCount, err := s.DashboardService.TransactionRepo.CountFiltered(ctx, filter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Get transactions from repository
+	transactions, err := s.DashboardService.TransactionRepo.ListFiltered(ctx, limit, int(req.Offset), filter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoTransactions := domainTransactionsToProto(transactions)
+	if req.IncludeEntries {
+		for i, tx := range transactions {
+			protoTransactions[i].Entries = domainTransactionEntriesToProto(tx.Entries)
+		}
+	}
+
+	return &wealthflowv1.ListTransactionsResponse{
+		Transactions: protoTransactions,
+		TotalCount:   int32(totalCount),
+		BucketNames:  s.resolveBucketNames(ctx, transactions),
+	}, nil
+}
+
+// GetBucketTransactions handles the GetBucketTransactions RPC
+// Thin wrapper over the bucket-filtered ListTransactions path with a small default limit
+func (s *Server) GetBucketTransactions(ctx context.Context, req *wealthflowv1.GetBucketTransactionsRequest) (*wealthflowv1.GetBucketTransactionsResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Validate limit (optional, must be non-negative)
+	if req.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be non-negative")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultBucketTransactionsLimit
+	}
+
+	// Verify the bucket exists (NotFound otherwise)
+	if _, err := s.DashboardService.BucketRepo.GetByID(ctx, bucketID); err != nil {
+		return nil, mapError(err)
+	}
+
+	totalCount, err := s.DashboardService.TransactionRepo.Count(ctx, &bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Repository returns transactions ordered by date DESC (newest first)
+	transactions, err := s.DashboardService.TransactionRepo.List(ctx, limit, 0, &bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetBucketTransactionsResponse{
+		Transactions: domainTransactionsToProto(transactions),
+		TotalCount:   int32(totalCount),
+		BucketNames:  s.resolveBucketNames(ctx, transactions),
+	}, nil
+}
+
+// resolveBucketNames builds a bucket_id -> bucket_name map for all buckets involved in the transactions
+// Always returns an initialized map (even if empty) to ensure it's never nil
+func (s *Server) resolveBucketNames(ctx context.Context, transactions []*domain.Transaction) map[string]string {
+	// Collect all unique bucket IDs from the transactions
+	bucketIDSet := make(map[uuid.UUID]bool)
+	for _, tx := range transactions {
+		for _, entry := range tx.Entries {
+			bucketIDSet[entry.BucketID] = true
+		}
+	}
+
+	// Fetch each bucket to get its name
+	bucketNames := make(map[string]string)
+	for id := range bucketIDSet {
+		bucket, err := s.DashboardService.BucketRepo.GetByID(ctx, id)
+		if err != nil {
+			// If bucket not found, skip it (shouldn't happen, but handle gracefully)
+			continue
+		}
+		bucketNames[id.String()] = bucket.Name
+	}
+
+	return bucketNames
+}
+
+// domainTransactionsToProto converts domain transactions to proto transaction summaries
+func domainTransactionsToProto(transactions []*domain.Transaction) []*wealthflowv1.Transaction {
+	protoTransactions := make([]*wealthflowv1.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		// Calculate transaction amount from entries
+		// For simplicity, we'll sum all credit amounts in the physical layer
+		var amount decimal.Decimal
 		for _, entry := range tx.Entries {
 			if entry.Layer == domain.LayerPhysical && entry.Type == domain.EntryTypeCredit {
 				amount = amount.Add(entry.Amount)
 			}
 		}
 
-		// Determine if this is an internal transfer
-		// It's internal if it's not an external inflow
-		isInternalTransfer := !tx.IsExternalInflow
+		// Determine if this is an internal transfer
+		// It's internal if it's not an external inflow
+		isInternalTransfer := !tx.IsExternalInflow
+
+		protoTransactions = append(protoTransactions, &wealthflowv1.Transaction{
+			Id:                 tx.ID.String(),
+			Description:        tx.Description,
+			Amount:             formatAmount(amount),
+			Date:               timestamppb.New(tx.Date),
+			IsExternal:         tx.IsExternalInflow,
+			IsInternalTransfer: isInternalTransfer,
+			IsRefund:           tx.IsRefund,
+			Scheduled:          tx.Scheduled,
+		})
+	}
+
+	return protoTransactions
+}
+
+// GetTransactionCount handles the GetTransactionCount RPC
+// Lets a client show a total without fetching a page of transactions
+func (s *Server) GetTransactionCount(ctx context.Context, req *wealthflowv1.GetTransactionCountRequest) (*wealthflowv1.GetTransactionCountResponse, error) {
+	// Parse optional bucket ID filter
+	var bucketID *uuid.UUID
+	if req.BucketId != "" {
+		parsedID, err := uuid.Parse(req.BucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+		}
+		bucketID = &parsedID
+	}
+
+	count, err := s.DashboardService.TransactionRepo.Count(ctx, bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetTransactionCountResponse{
+		Count: int64(count),
+	}, nil
+}
+
+// GetTransaction handles the GetTransaction RPC
+// Returns the full transaction (entries and memo), unlike the summarized ListTransactions
+func (s *Server) GetTransaction(ctx context.Context, req *wealthflowv1.GetTransactionRequest) (*wealthflowv1.GetTransactionResponse, error) {
+	// Parse transaction ID
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction_id format: %v", err)
+	}
+
+	// Get transaction from repository
+	tx, err := s.DashboardService.TransactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetTransactionResponse{
+		Transaction: domainTransactionsToProto([]*domain.Transaction{tx})[0],
+		Memo:        tx.Memo,
+		Entries:     domainTransactionEntriesToProto(tx.Entries),
+		BucketNames: s.resolveBucketNames(ctx, []*domain.Transaction{tx}),
+	}, nil
+}
+
+// DeleteTransaction handles the DeleteTransaction RPC
+// Removes the transaction entirely and reverts its effect on bucket balances
+func (s *Server) DeleteTransaction(ctx context.Context, req *wealthflowv1.DeleteTransactionRequest) (*wealthflowv1.DeleteTransactionResponse, error) {
+	// Parse transaction ID
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction_id format: %v", err)
+	}
+
+	// Delete from repository
+	if err := s.DashboardService.TransactionRepo.Delete(ctx, transactionID); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.DeleteTransactionResponse{}, nil
+}
+
+// ListTransferTasks lists transfer tasks (pending only unless include_completed is set)
+func (s *Server) ListTransferTasks(ctx context.Context, req *wealthflowv1.ListTransferTasksRequest) (*wealthflowv1.ListTransferTasksResponse, error) {
+	tasks, err := s.BucketService.TransferTaskRepo.List(ctx, req.IncludeCompleted)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoTasks := make([]*wealthflowv1.TransferTask, 0, len(tasks))
+	for _, task := range tasks {
+		protoTasks = append(protoTasks, domainTransferTaskToProto(task))
+	}
+
+	return &wealthflowv1.ListTransferTasksResponse{
+		Tasks: protoTasks,
+	}, nil
+}
+
+// ListOverdueTransferTasks handles listing pending transfer tasks older than a number of days
+func (s *Server) ListOverdueTransferTasks(ctx context.Context, req *wealthflowv1.ListOverdueTransferTasksRequest) (*wealthflowv1.ListOverdueTransferTasksResponse, error) {
+	tasks, err := s.BucketService.ListOverdueTransferTasks(ctx, int(req.Days))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoTasks := make([]*wealthflowv1.TransferTask, 0, len(tasks))
+	for _, task := range tasks {
+		protoTasks = append(protoTasks, domainTransferTaskToProto(task))
+	}
+
+	return &wealthflowv1.ListOverdueTransferTasksResponse{
+		Tasks: protoTasks,
+	}, nil
+}
+
+// CompleteTransferTask handles the CompleteTransferTask RPC
+func (s *Server) CompleteTransferTask(ctx context.Context, req *wealthflowv1.CompleteTransferTaskRequest) (*wealthflowv1.CompleteTransferTaskResponse, error) {
+	// Parse task ID
+	taskID, err := uuid.Parse(req.TaskId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid task_id format: %v", err)
+	}
+
+	// Call usecase service
+	task, err := s.BucketService.CompleteTransferTask(ctx, taskID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.CompleteTransferTaskResponse{
+		Task: domainTransferTaskToProto(*task),
+	}, nil
+}
+
+// domainTransferTaskToProto converts a domain TransferTask to its proto representation
+func domainTransferTaskToProto(task domain.TransferTask) *wealthflowv1.TransferTask {
+	protoTask := &wealthflowv1.TransferTask{
+		Id:                   task.ID.String(),
+		RelatedTransactionId: task.RelatedTransactionID.String(),
+		FromPhysicalBucketId: task.FromPhysicalBucketID.String(),
+		ToPhysicalBucketId:   task.ToPhysicalBucketID.String(),
+		Amount:               formatAmount(task.Amount),
+		IsCompleted:          task.IsCompleted,
+		Description:          task.Description,
+		CreatedAt:            timestamppb.New(task.CreatedAt),
+	}
+	if task.CompletedTransactionID != nil {
+		protoTask.CompletedTransactionId = task.CompletedTransactionID.String()
+	}
+	return protoTask
+}
+
+// domainTransactionEntriesToProto converts domain TransactionEntries to proto TransactionEntry messages
+func domainTransactionEntriesToProto(entries []domain.TransactionEntry) []*wealthflowv1.TransactionEntry {
+	protoEntries := make([]*wealthflowv1.TransactionEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntries = append(protoEntries, domainTransactionEntryToProto(entry))
+	}
+	return protoEntries
+}
+
+// domainTransactionEntryToProto converts a domain TransactionEntry to a proto TransactionEntry message
+func domainTransactionEntryToProto(entry domain.TransactionEntry) *wealthflowv1.TransactionEntry {
+	return &wealthflowv1.TransactionEntry{
+		Id:       entry.ID.String(),
+		BucketId: entry.BucketID.String(),
+		Amount:   formatAmount(entry.Amount),
+		Type:     string(entry.Type),
+		Layer:    string(entry.Layer),
+	}
+}
+
+// GetNetWorth handles the GetNetWorth RPC
+func (s *Server) GetNetWorth(ctx context.Context, req *wealthflowv1.GetNetWorthRequest) (*wealthflowv1.GetNetWorthResponse, error) {
+	// Call dashboard service
+	calculatedAt := time.Now()
+	result, err := s.DashboardService.GetNetWorth(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Build response
+	return &wealthflowv1.GetNetWorthResponse{
+		TotalNetWorth:   formatAmount(result.Total),
+		Liquidity:       formatAmount(result.Liquidity),
+		Equity:          formatAmount(result.Equity),
+		EquityBookValue: formatAmount(result.EquityBookValue),
+		EquityProfit:    formatAmount(result.EquityProfit),
+		CalculatedAt:    timestamppb.New(calculatedAt),
+	}, nil
+}
+
+// GetLiquidity handles the GetLiquidity RPC
+func (s *Server) GetLiquidity(ctx context.Context, req *wealthflowv1.GetLiquidityRequest) (*wealthflowv1.GetLiquidityResponse, error) {
+	liquidity, err := s.DashboardService.GetLiquidity(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Same formatting as GetNetWorthResponse.liquidity
+	return &wealthflowv1.GetLiquidityResponse{
+		Liquidity: formatAmount(liquidity),
+	}, nil
+}
+
+// GetBucket handles the GetBucket RPC
+func (s *Server) GetBucket(ctx context.Context, req *wealthflowv1.GetBucketRequest) (*wealthflowv1.GetBucketResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Get bucket from repository
+	bucket, err := s.DashboardService.BucketRepo.GetByID(ctx, bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert domain bucket to proto bucket
+	protoBucket := domainBucketToProto(bucket)
+
+	// Load the parent's name so clients can show e.g. "Free Cash (in Main Bank)" without a second call
+	parentName := ""
+	if bucket.ParentPhysicalBucketID != nil {
+		parent, err := s.DashboardService.BucketRepo.GetByID(ctx, *bucket.ParentPhysicalBucketID)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		parentName = parent.Name
+	}
+
+	resp := &wealthflowv1.GetBucketResponse{
+		Bucket:     protoBucket,
+		ParentName: parentName,
+	}
+
+	// Optionally load the recent market values (e.g. for a sparkline)
+	if req.IncludeRecentMarketValues {
+		limit := int(req.RecentMarketValuesLimit)
+		if limit == 0 {
+			limit = defaultRecentMarketValuesLimit
+		}
+		entries, err := s.InvestmentService.RecentMarketValues(ctx, bucket, limit)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		for _, entry := range entries {
+			resp.RecentMarketValues = append(resp.RecentMarketValues, &wealthflowv1.MarketValueEntry{
+				Id:          entry.ID.String(),
+				Date:        timestamppb.New(entry.Date),
+				MarketValue: formatAmount(entry.MarketValue),
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// RecalculateBalances handles repairing bucket balance drift
+// Like every RPC it requires the API token (AuthInterceptor)
+func (s *Server) RecalculateBalances(ctx context.Context, req *wealthflowv1.RecalculateBalancesRequest) (*wealthflowv1.RecalculateBalancesResponse, error) {
+	result, err := s.DashboardService.RecalculateAllBalances(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.RecalculateBalancesResponse{
+		BucketsCorrected: int32(result.BucketsCorrected),
+		TotalDrift:       formatAmount(result.TotalDrift),
+	}, nil
+}
+
+// ListBucketGoals handles the ListBucketGoals RPC
+func (s *Server) ListBucketGoals(ctx context.Context, req *wealthflowv1.ListBucketGoalsRequest) (*wealthflowv1.ListBucketGoalsResponse, error) {
+	goals, err := s.DashboardService.ListBucketGoals(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoGoals := make([]*wealthflowv1.BucketGoal, 0, len(goals))
+	for _, goal := range goals {
+		protoGoals = append(protoGoals, &wealthflowv1.BucketGoal{
+			Bucket:   domainBucketToProto(goal.Bucket),
+			Progress: goal.Progress.String(),
+			Met:      goal.Met,
+		})
+	}
+
+	return &wealthflowv1.ListBucketGoalsResponse{
+		Goals: protoGoals,
+	}, nil
+}
+
+// GetBucketTree handles the GetBucketTree RPC
+func (s *Server) GetBucketTree(ctx context.Context, req *wealthflowv1.GetBucketTreeRequest) (*wealthflowv1.GetBucketTreeResponse, error) {
+	nodes, err := s.DashboardService.GetBucketTree(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoNodes := make([]*wealthflowv1.BucketTreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		children := make([]*wealthflowv1.Bucket, 0, len(node.Children))
+		for _, child := range node.Children {
+			children = append(children, domainBucketToProto(child))
+		}
+
+		protoNodes = append(protoNodes, &wealthflowv1.BucketTreeNode{
+			Bucket:           domainBucketToProto(node.Physical),
+			Children:         children,
+			VirtualBalance:   formatAmount(node.VirtualBalance),
+			UnbucketedAmount: formatAmount(node.Unbucketed),
+		})
+	}
+
+	return &wealthflowv1.GetBucketTreeResponse{
+		Nodes: protoNodes,
+	}, nil
+}
+
+// GetUnbucketedAmount handles the GetUnbucketedAmount RPC
+func (s *Server) GetUnbucketedAmount(ctx context.Context, req *wealthflowv1.GetUnbucketedAmountRequest) (*wealthflowv1.GetUnbucketedAmountResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	result, err := s.DashboardService.GetUnbucketedAmount(ctx, bucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetUnbucketedAmountResponse{
+		PhysicalBalance:  formatAmount(result.PhysicalBalance),
+		VirtualBalance:   formatAmount(result.VirtualBalance),
+		UnbucketedAmount: formatAmount(result.Unbucketed),
+		HasDrift:         result.HasDrift,
+	}, nil
+}
+
+// ImportTransactions handles the ImportTransactions client-streaming RPC
+// Each row is routed through the same path as its unary counterpart (RecordInflow / LogExpense).
+// Row failures are collected into the summary instead of aborting the stream.
+func (s *Server) ImportTransactions(stream wealthflowv1.WealthFlowService_ImportTransactionsServer) error {
+	ctx := stream.Context()
+
+	summary := &wealthflowv1.ImportTransactionsResponse{
+		Errors:         []*wealthflowv1.ImportRowError{},
+		TransactionIds: []string{},
+	}
+
+	var row int32
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(summary)
+		}
+		if err != nil {
+			return err
+		}
+		row++
+
+		txID, err := s.importRow(ctx, req)
+		if err != nil {
+			summary.FailureCount++
+			summary.Errors = append(summary.Errors, &wealthflowv1.ImportRowError{
+				Row:   row,
+				Error: status.Convert(err).Message(),
+			})
+			continue
+		}
+
+		summary.SuccessCount++
+		summary.TransactionIds = append(summary.TransactionIds, txID)
+	}
+}
+
+// importRow processes a single import row and returns the created transaction ID
+// Rows are recorded at server time, so a row setting a date is rejected rather than silently re-dated;
+// a dry-run inflow is rejected too, since it would not create the transaction the summary reports
+func (s *Server) importRow(ctx context.Context, req *wealthflowv1.ImportTransactionsRequest) (string, error) {
+	switch row := req.Row.(type) {
+	case *wealthflowv1.ImportTransactionsRequest_Inflow:
+		if row.Inflow.Date != nil {
+			return "", status.Errorf(codes.InvalidArgument, "date is not supported for imported rows")
+		}
+		if row.Inflow.DryRun {
+			return "", status.Errorf(codes.InvalidArgument, "dry_run is not supported for imported rows")
+		}
+		resp, err := s.RecordInflow(ctx, row.Inflow)
+		if err != nil {
+			return "", err
+		}
+		return resp.TransactionId, nil
+	case *wealthflowv1.ImportTransactionsRequest_Expense:
+		if row.Expense.Date != nil {
+			return "", status.Errorf(codes.InvalidArgument, "date is not supported for imported rows")
+		}
+		resp, err := s.LogExpense(ctx, row.Expense)
+		if err != nil {
+			return "", err
+		}
+		return resp.TransactionId, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "row must be an inflow or an expense")
+	}
+}
+
+// domainBucketTypeToProto converts a domain BucketType to a proto BucketType enum
+func domainBucketTypeToProto(domainType domain.BucketType) wealthflowv1.BucketType {
+	switch domainType {
+	case domain.BucketTypePhysical:
+		return wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL
+	case domain.BucketTypeVirtual:
+		return wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL
+	case domain.BucketTypeIncome:
+		return wealthflowv1.BucketType_BUCKET_TYPE_INCOME
+	case domain.BucketTypeExpense:
+		return wealthflowv1.BucketType_BUCKET_TYPE_EXPENSE
+	case domain.BucketTypeEquity:
+		return wealthflowv1.BucketType_BUCKET_TYPE_EQUITY
+	default:
+		return wealthflowv1.BucketType_BUCKET_TYPE_UNSPECIFIED
+	}
+}
+
+// protoBucketTypeToDomain converts a proto BucketType enum to a domain BucketType
+func protoBucketTypeToDomain(protoType wealthflowv1.BucketType) domain.BucketType {
+	switch protoType {
+	case wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL:
+		return domain.BucketTypePhysical
+	case wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL:
+		return domain.BucketTypeVirtual
+	case wealthflowv1.BucketType_BUCKET_TYPE_INCOME:
+		return domain.BucketTypeIncome
+	case wealthflowv1.BucketType_BUCKET_TYPE_EXPENSE:
+		return domain.BucketTypeExpense
+	case wealthflowv1.BucketType_BUCKET_TYPE_EQUITY:
+		return domain.BucketTypeEquity
+	default:
+		return ""
+	}
+}
+
+// domainSplitRuleItemTypeToProto converts a domain SplitRuleItemType to a proto SplitRuleItemType enum
+func domainSplitRuleItemTypeToProto(domainType domain.SplitRuleItemType) wealthflowv1.SplitRuleItemType {
+	switch domainType {
+	case domain.SplitRuleItemTypeFixed:
+		return wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_FIXED
+	case domain.SplitRuleItemTypePercent:
+		return wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_PERCENT
+	case domain.SplitRuleItemTypeRemainder:
+		return wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER
+	default:
+		return wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_UNSPECIFIED
+	}
+}
+
+// protoSplitRuleItemTypeToDomain converts a proto SplitRuleItemType enum to a domain SplitRuleItemType
+func protoSplitRuleItemTypeToDomain(protoType wealthflowv1.SplitRuleItemType) domain.SplitRuleItemType {
+	switch protoType {
+	case wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_FIXED:
+		return domain.SplitRuleItemTypeFixed
+	case wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_PERCENT:
+		return domain.SplitRuleItemTypePercent
+	case wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER:
+		return domain.SplitRuleItemTypeRemainder
+	default:
+		return ""
+	}
+}
+
+// domainSplitRuleItemToProto converts a domain SplitRuleItem to a proto SplitRuleItem message
+func domainSplitRuleItemToProto(item domain.SplitRuleItem) *wealthflowv1.SplitRuleItem {
+	protoItem := &wealthflowv1.SplitRuleItem{
+		Id:             item.ID.String(),
+		TargetBucketId: item.TargetBucketID.String(),
+		Type:           domainSplitRuleItemTypeToProto(item.Type),
+		Value:          item.Value.String(),
+		Priority:       int32(item.Priority),
+	}
+
+	if item.RelativeToBucketID != nil {
+		protoItem.RelativeToBucketId = item.RelativeToBucketID.String()
+	}
+
+	return protoItem
+}
+
+// protoAllocationModeToDomain converts a proto AllocationMode enum to an allocator AllocationMode
+// UNSPECIFIED maps to STRICT
+func protoAllocationModeToDomain(protoMode wealthflowv1.AllocationMode) allocator.AllocationMode {
+	switch protoMode {
+	case wealthflowv1.AllocationMode_ALLOCATION_MODE_SCALE_FIXED:
+		return allocator.AllocationModeScaleFixed
+	default:
+		return allocator.AllocationModeStrict
+	}
+}
+
+// protoSplitRuleItemToDomain converts a proto SplitRuleItem to a domain SplitRuleItem
+// Returns an InvalidArgument status error if a field cannot be parsed
+func protoSplitRuleItemToDomain(protoItem *wealthflowv1.SplitRuleItem) (domain.SplitRuleItem, error) {
+	targetBucketID, err := uuid.Parse(protoItem.TargetBucketId)
+	if err != nil {
+		return domain.SplitRuleItem{}, status.Errorf(codes.InvalidArgument, "invalid target_bucket_id format: %v", err)
+	}
+
+	// Value is ignored for REMAINDER items, so it may be empty
+	value := decimal.Zero
+	if protoItem.Value != "" {
+		value, err = parseAmount("value", protoItem.Value)
+		if err != nil {
+			return domain.SplitRuleItem{}, err
+		}
+	}
+
+	item := domain.SplitRuleItem{
+		TargetBucketID: targetBucketID,
+		Type:           protoSplitRuleItemTypeToDomain(protoItem.Type),
+		Value:          value,
+		Priority:       int(protoItem.Priority),
+	}
+
+	if protoItem.RelativeToBucketId != "" {
+		relativeToBucketID, err := uuid.Parse(protoItem.RelativeToBucketId)
+		if err != nil {
+			return domain.SplitRuleItem{}, status.Errorf(codes.InvalidArgument, "invalid relative_to_bucket_id format: %v", err)
+		}
+		item.RelativeToBucketID = &relativeToBucketID
+	}
+
+	return item, nil
+}
+
+// parseAmount parses a decimal request field, rejecting values with more than maxDecimalPlaces
+// decimal places or a magnitude above maxAmountMagnitude
+// Returns an InvalidArgument status error naming the field
+func parseAmount(field, value string) (decimal.Decimal, error) {
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "invalid %s format: %v", field, err)
+	}
+	// Trailing zeros are harmless; only reject precision that would be lost
+	if !amount.Equal(amount.Truncate(maxDecimalPlaces)) {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "%s must have at most %d decimal places", field, maxDecimalPlaces)
+	}
+	if amount.Abs().GreaterThan(maxAmountMagnitude) {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "%s must not exceed %s in magnitude", field, maxAmountMagnitude)
+	}
+	return amount, nil
+}
+
+// formatAmount formats an amount to the currency scale for proto responses (e.g. "650" -> "650.00")
+// The database keeps full precision; only the presentation is rounded
+func formatAmount(amount decimal.Decimal) string {
+	return amount.StringFixed(currencyScale)
+}
+
+// domainBucketToProto converts a domain Bucket to a proto Bucket message
+func domainBucketToProto(bucket *domain.Bucket) *wealthflowv1.Bucket {
+	protoBucket := &wealthflowv1.Bucket{
+		Id:             bucket.ID.String(),
+		Name:           bucket.Name,
+		Type:           domainBucketTypeToProto(bucket.BucketType),
+		CurrentBalance: formatAmount(bucket.CurrentBalance),
+		IsArchived:     bucket.IsArchived,
+	}
+
+	// Set parent_id if it exists
+	if bucket.ParentPhysicalBucketID != nil {
+		protoBucket.ParentId = bucket.ParentPhysicalBucketID.String()
+	}
+
+	// Set goal and progress if a goal exists
+	if bucket.GoalAmount != nil {
+		protoBucket.GoalAmount = formatAmount(*bucket.GoalAmount)
+	}
+	if progress := bucket.GoalProgress(); progress != nil {
+		protoBucket.GoalProgress = progress.String()
+	}
+
+	return protoBucket
+}
+
+// GetSplitRule handles the GetSplitRule RPC
+func (s *Server) GetSplitRule(ctx context.Context, req *wealthflowv1.GetSplitRuleRequest) (*wealthflowv1.GetSplitRuleResponse, error) {
+	// Parse source bucket ID
+	sourceBucketID, err := uuid.Parse(req.SourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	rule, err := s.InflowService.GetSplitRule(ctx, sourceBucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert items to proto
+	items := make([]*wealthflowv1.SplitRuleItem, 0, len(rule.Items))
+	for _, item := range rule.Items {
+		items = append(items, domainSplitRuleItemToProto(item))
+	}
+
+	summary := rule.Summarize()
+	resp := &wealthflowv1.GetSplitRuleResponse{
+		SplitRuleId:            rule.ID.String(),
+		Name:                   rule.Name,
+		SourceBucketId:         rule.SourceBucketID.String(),
+		Items:                  items,
+		TotalFixed:             formatAmount(summary.FixedTotal),
+		TotalPercent:           summary.PercentTotal.String(),
+		RemainderPercent:       summary.RemainderPercent.String(),
+		HasMeaningfulRemainder: summary.HasMeaningfulRemainder,
+		Active:                 rule.Active,
+	}
+	if rule.DestinationPhysicalBucketID != nil {
+		resp.DestinationPhysicalBucketId = rule.DestinationPhysicalBucketID.String()
+	}
+
+	return resp, nil
+}
+
+// EnableSplitRule handles re-enabling a disabled split rule
+func (s *Server) EnableSplitRule(ctx context.Context, req *wealthflowv1.EnableSplitRuleRequest) (*wealthflowv1.EnableSplitRuleResponse, error) {
+	rule, err := s.setSplitRuleActive(ctx, req.SourceBucketId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wealthflowv1.EnableSplitRuleResponse{
+		SplitRuleId: rule.ID.String(),
+		Active:      rule.Active,
+	}, nil
+}
+
+// DisableSplitRule handles disabling a split rule without deleting it
+func (s *Server) DisableSplitRule(ctx context.Context, req *wealthflowv1.DisableSplitRuleRequest) (*wealthflowv1.DisableSplitRuleResponse, error) {
+	rule, err := s.setSplitRuleActive(ctx, req.SourceBucketId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wealthflowv1.DisableSplitRuleResponse{
+		SplitRuleId: rule.ID.String(),
+		Active:      rule.Active,
+	}, nil
+}
+
+// setSplitRuleActive parses the source bucket ID shared by EnableSplitRule and DisableSplitRule and updates the rule
+func (s *Server) setSplitRuleActive(ctx context.Context, sourceBucketIDStr string, active bool) (*domain.SplitRule, error) {
+	// Parse source bucket ID
+	sourceBucketID, err := uuid.Parse(sourceBucketIDStr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	rule, err := s.InflowService.SetSplitRuleActive(ctx, sourceBucketID, active)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return rule, nil
+}
+
+// CloneSplitRule handles copying a split rule to another income bucket
+func (s *Server) CloneSplitRule(ctx context.Context, req *wealthflowv1.CloneSplitRuleRequest) (*wealthflowv1.CloneSplitRuleResponse, error) {
+	// Parse bucket IDs
+	fromID, err := uuid.Parse(req.FromSourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid from_source_bucket_id format: %v", err)
+	}
+	toID, err := uuid.Parse(req.ToSourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid to_source_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	rule, err := s.InflowService.CloneSplitRule(ctx, inflow.CloneSplitRuleInput{
+		FromSourceBucketID: fromID,
+		ToSourceBucketID:   toID,
+		Name:               req.Name,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert items to proto
+	items := make([]*wealthflowv1.SplitRuleItem, 0, len(rule.Items))
+	for _, item := range rule.Items {
+		items = append(items, domainSplitRuleItemToProto(item))
+	}
+
+	resp := &wealthflowv1.CloneSplitRuleResponse{
+		SplitRuleId:    rule.ID.String(),
+		Name:           rule.Name,
+		SourceBucketId: rule.SourceBucketID.String(),
+		Items:          items,
+	}
+	if rule.DestinationPhysicalBucketID != nil {
+		resp.DestinationPhysicalBucketId = rule.DestinationPhysicalBucketID.String()
+	}
+
+	return resp, nil
+}
+
+// ListIncomeSources handles the ListIncomeSources RPC
+func (s *Server) ListIncomeSources(ctx context.Context, req *wealthflowv1.ListIncomeSourcesRequest) (*wealthflowv1.ListIncomeSourcesResponse, error) {
+	sources, err := s.InflowService.ListIncomeSources(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	protoSources := make([]*wealthflowv1.IncomeSource, 0, len(sources))
+	for _, source := range sources {
+		protoSources = append(protoSources, &wealthflowv1.IncomeSource{
+			Bucket:    domainBucketToProto(source.Bucket),
+			HasRule:   source.HasRule,
+			ItemCount: int32(source.ItemCount),
+		})
+	}
+
+	return &wealthflowv1.ListIncomeSourcesResponse{
+		Sources: protoSources,
+	}, nil
+}
+
+// ReparentVirtualBucket handles the ReparentVirtualBucket RPC
+func (s *Server) ReparentVirtualBucket(ctx context.Context, req *wealthflowv1.ReparentVirtualBucketRequest) (*wealthflowv1.ReparentVirtualBucketResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Parse new parent ID
+	newParentID, err := uuid.Parse(req.NewParentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid new_parent_id format: %v", err)
+	}
 
-		protoTx := &wealthflowv1.Transaction{
-			Id:                 tx.ID.String(),
-			Description:        tx.Description,
-			Amount:             amount.String(),
-			Date:               timestamppb.New(tx.Date),
-			IsExternal:         tx.IsExternalInflow,
-			IsInternalTransfer: isInternalTransfer,
+	// Call usecase service
+	result, err := s.BucketService.ReparentVirtualBucket(ctx, bucketID, newParentID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Build response
+	resp := &wealthflowv1.ReparentVirtualBucketResponse{
+		Bucket: domainBucketToProto(result.Bucket),
+	}
+	if result.Transaction != nil {
+		resp.TransactionId = result.Transaction.ID.String()
+	}
+	if result.TransferTask != nil {
+		resp.TransferTaskId = result.TransferTask.ID.String()
+	}
+
+	return resp, nil
+}
+
+// MergeCategoryBuckets handles merging a redundant expense category into another
+func (s *Server) MergeCategoryBuckets(ctx context.Context, req *wealthflowv1.MergeCategoryBucketsRequest) (*wealthflowv1.MergeCategoryBucketsResponse, error) {
+	// Parse source bucket ID
+	sourceID, err := uuid.Parse(req.SourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+
+	// Parse target bucket ID
+	targetID, err := uuid.Parse(req.TargetBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	repointed, err := s.BucketService.MergeCategoryBuckets(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.MergeCategoryBucketsResponse{
+		RepointedEntries: int32(repointed),
+	}, nil
+}
+
+// RecategorizeTransactions handles moving past expenses from one category to another
+func (s *Server) RecategorizeTransactions(ctx context.Context, req *wealthflowv1.RecategorizeTransactionsRequest) (*wealthflowv1.RecategorizeTransactionsResponse, error) {
+	// Parse source category ID
+	sourceID, err := uuid.Parse(req.SourceCategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_category_id format: %v", err)
+	}
+
+	// Parse target category ID
+	targetID, err := uuid.Parse(req.TargetCategoryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid target_category_id format: %v", err)
+	}
+
+	// Call usecase service
+	updated, err := s.ExpenseService.RecategorizeTransactions(ctx, sourceID, targetID, req.DescriptionFilter)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.RecategorizeTransactionsResponse{
+		UpdatedEntries: int32(updated),
+	}, nil
+}
+
+// GetStatement handles the GetStatement RPC
+func (s *Server) GetStatement(ctx context.Context, req *wealthflowv1.GetStatementRequest) (*wealthflowv1.GetStatementResponse, error) {
+	// Both ends of the period are required (a statement has no sensible default range)
+	if req.StartDate == nil || req.EndDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "start_date and end_date are required")
+	}
+
+	// Call usecase service
+	statement, err := s.DashboardService.GetStatement(ctx, req.StartDate.AsTime(), req.EndDate.AsTime())
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	transactions := make([]*wealthflowv1.StatementTransaction, 0, len(statement.Transactions))
+	for _, item := range statement.Transactions {
+		transactions = append(transactions, &wealthflowv1.StatementTransaction{
+			Transaction:     domainTransactionsToProto([]*domain.Transaction{item.Transaction})[0],
+			LiquidityChange: formatAmount(item.LiquidityChange),
+		})
+	}
+	marketValueChanges := make([]*wealthflowv1.StatementMarketValueChange, 0, len(statement.MarketValueChanges))
+	for _, change := range statement.MarketValueChanges {
+		marketValueChanges = append(marketValueChanges, &wealthflowv1.StatementMarketValueChange{
+			BucketId:            change.Bucket.ID.String(),
+			BucketName:          change.Bucket.Name,
+			Date:                timestamppb.New(change.Date),
+			PreviousMarketValue: formatAmount(change.PreviousValue),
+			MarketValue:         formatAmount(change.MarketValue),
+			Change:              formatAmount(change.Change),
+		})
+	}
+
+	return &wealthflowv1.GetStatementResponse{
+		OpeningNetWorth:    formatAmount(statement.OpeningNetWorth),
+		ClosingNetWorth:    formatAmount(statement.ClosingNetWorth),
+		Transactions:       transactions,
+		MarketValueChanges: marketValueChanges,
+	}, nil
+}
+
+// GetTransactionsBetweenBuckets handles the GetTransactionsBetweenBuckets RPC
+func (s *Server) GetTransactionsBetweenBuckets(ctx context.Context, req *wealthflowv1.GetTransactionsBetweenBucketsRequest) (*wealthflowv1.GetTransactionsBetweenBucketsResponse, error) {
+	// Parse bucket IDs
+	bucketA, err := uuid.Parse(req.BucketAId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_a_id format: %v", err)
+	}
+	bucketB, err := uuid.Parse(req.BucketBId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_b_id format: %v", err)
+	}
+
+	// Call usecase service
+	transactions, err := s.DashboardService.GetTransactionsBetweenBuckets(ctx, bucketA, bucketB)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetTransactionsBetweenBucketsResponse{
+		Transactions: domainTransactionsToProto(transactions),
+	}, nil
+}
+
+// GetFundingBucket handles the GetFundingBucket RPC
+func (s *Server) GetFundingBucket(ctx context.Context, req *wealthflowv1.GetFundingBucketRequest) (*wealthflowv1.GetFundingBucketResponse, error) {
+	// Parse virtual bucket ID
+	virtualBucketID, err := uuid.Parse(req.VirtualBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid virtual_bucket_id format: %v", err)
+	}
+
+	// Call usecase service
+	bucket, err := s.DashboardService.GetFundingBucket(ctx, virtualBucketID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.GetFundingBucketResponse{
+		BucketId: bucket.ID.String(),
+		Name:     bucket.Name,
+		Type:     domainBucketTypeToProto(bucket.BucketType),
+	}, nil
+}
+
+// GetUpcomingObligations handles the GetUpcomingObligations RPC
+func (s *Server) GetUpcomingObligations(ctx context.Context, req *wealthflowv1.GetUpcomingObligationsRequest) (*wealthflowv1.GetUpcomingObligationsResponse, error) {
+	// Call usecase service
+	forecast, err := s.DashboardService.GetUpcoming(ctx, int(req.HorizonDays))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Convert to proto
+	items := make([]*wealthflowv1.UpcomingObligation, 0, len(forecast.Items))
+	for _, item := range forecast.Items {
+		items = append(items, &wealthflowv1.UpcomingObligation{
+			Transaction:      domainTransactionsToProto([]*domain.Transaction{item.Transaction})[0],
+			LiquidityChange:  formatAmount(item.LiquidityChange),
+			ProjectedBalance: formatAmount(item.ProjectedBalance),
+		})
+	}
+
+	return &wealthflowv1.GetUpcomingObligationsResponse{
+		CurrentLiquidity:   formatAmount(forecast.CurrentLiquidity),
+		Items:              items,
+		ProjectedLiquidity: formatAmount(forecast.ProjectedLiquidity),
+		Until:              timestamppb.New(forecast.Until),
+	}, nil
+}
+
+// CreateBuckets handles the CreateBuckets RPC
+func (s *Server) CreateBuckets(ctx context.Context, req *wealthflowv1.CreateBucketsRequest) (*wealthflowv1.CreateBucketsResponse, error) {
+	// Convert the batch to usecase inputs
+	inputs := make([]bucket_manager.NewBucketInput, 0, len(req.Buckets))
+	for i, b := range req.Buckets {
+		input := bucket_manager.NewBucketInput{
+			Key:        b.Key,
+			Name:       b.Name,
+			BucketType: protoBucketTypeToDomain(b.Type),
+			ParentKey:  b.ParentKey,
+		}
+		if b.ParentId != "" {
+			parentID, err := uuid.Parse(b.ParentId)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid parent_id format for bucket %d: %v", i, err)
+			}
+			input.ParentID = &parentID
 		}
+		inputs = append(inputs, input)
+	}
 
-		protoTransactions = append(protoTransactions, protoTx)
+	// Call usecase service
+	buckets, err := s.BucketService.CreateBuckets(ctx, inputs)
+	if err != nil {
+		return nil, mapError(err)
 	}
 
-	return &wealthflowv1.ListTransactionsResponse{
-		Transactions: protoTransactions,
-		TotalCount:   int32(totalCount),
-		BucketNames:  bucketNames,
+	protoBuckets := make([]*wealthflowv1.Bucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		protoBuckets = append(protoBuckets, domainBucketToProto(bucket))
+	}
+
+	return &wealthflowv1.CreateBucketsResponse{
+		Buckets: protoBuckets,
 	}, nil
 }
 
-// GetNetWorth handles the GetNetWorth RPC
-func (s *Server) GetNetWorth(ctx context.Context, req *wealthflowv1.GetNetWorthRequest) (*wealthflowv1.GetNetWorthResponse, error) {
-	// Call dashboard service
-	result, err := s.DashboardService.GetNetWorth(ctx)
+// SetBucketGoal handles setting or clearing a bucket's goal amount
+func (s *Server) SetBucketGoal(ctx context.Context, req *wealthflowv1.SetBucketGoalRequest) (*wealthflowv1.SetBucketGoalResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Parse goal amount (empty clears the goal)
+	var goal *decimal.Decimal
+	if req.GoalAmount != "" {
+		parsedGoal, err := parseAmount("goal_amount", req.GoalAmount)
+		if err != nil {
+			return nil, err
+		}
+		goal = &parsedGoal
+	}
+
+	// Call usecase service
+	bucket, err := s.BucketService.SetBucketGoal(ctx, bucketID, goal)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	// Build response
-	return &wealthflowv1.GetNetWorthResponse{
-		TotalNetWorth: result.Total.String(),
-		Liquidity:     result.Liquidity.String(),
-		Equity:        result.Equity.String(),
+	return &wealthflowv1.SetBucketGoalResponse{
+		Bucket: domainBucketToProto(bucket),
 	}, nil
 }
 
-// GetBucket handles the GetBucket RPC
-func (s *Server) GetBucket(ctx context.Context, req *wealthflowv1.GetBucketRequest) (*wealthflowv1.GetBucketResponse, error) {
+// SetOpeningBalance handles the SetOpeningBalance RPC
+func (s *Server) SetOpeningBalance(ctx context.Context, req *wealthflowv1.SetOpeningBalanceRequest) (*wealthflowv1.SetOpeningBalanceResponse, error) {
 	// Parse bucket ID
 	bucketID, err := uuid.Parse(req.BucketId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
 	}
 
-	// Get bucket from repository
-	bucket, err := s.DashboardService.BucketRepo.GetByID(ctx, bucketID)
+	// Parse amount from string to decimal
+	amount, err := parseAmount("amount", req.Amount)
 	if err != nil {
-		// Check if it's a "not found" error
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Errorf(codes.NotFound, "bucket not found: %v", err)
+		return nil, err
+	}
+
+	// Parse optional virtual bucket ID
+	var virtualBucketID *uuid.UUID
+	if req.VirtualBucketId != "" {
+		id, err := uuid.Parse(req.VirtualBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid virtual_bucket_id format: %v", err)
 		}
-		return nil, mapError(err)
+		virtualBucketID = &id
 	}
 
-	// Convert domain bucket to proto bucket
-	protoBucket := domainBucketToProto(bucket)
+	// Call usecase service
+	tx, err := s.BucketService.SetOpeningBalance(ctx, bucket_manager.SetOpeningBalanceInput{
+		BucketID:        bucketID,
+		Amount:          amount,
+		VirtualBucketID: virtualBucketID,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
 
 	// Build response
-	return &wealthflowv1.GetBucketResponse{
-		Bucket: protoBucket,
+	return &wealthflowv1.SetOpeningBalanceResponse{
+		TransactionId: tx.ID.String(),
 	}, nil
 }
 
-// domainBucketTypeToProto converts a domain BucketType to a proto BucketType enum
-func domainBucketTypeToProto(domainType domain.BucketType) wealthflowv1.BucketType {
-	switch domainType {
-	case domain.BucketTypePhysical:
-		return wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL
-	case domain.BucketTypeVirtual:
-		return wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL
-	case domain.BucketTypeIncome:
-		return wealthflowv1.BucketType_BUCKET_TYPE_INCOME
-	case domain.BucketTypeExpense:
-		return wealthflowv1.BucketType_BUCKET_TYPE_EXPENSE
-	case domain.BucketTypeEquity:
-		return wealthflowv1.BucketType_BUCKET_TYPE_EQUITY
-	default:
-		return wealthflowv1.BucketType_BUCKET_TYPE_UNSPECIFIED
+// MoveBetweenVirtualBuckets handles the MoveBetweenVirtualBuckets RPC
+func (s *Server) MoveBetweenVirtualBuckets(ctx context.Context, req *wealthflowv1.MoveBetweenVirtualBucketsRequest) (*wealthflowv1.MoveBetweenVirtualBucketsResponse, error) {
+	// Parse bucket IDs
+	sourceBucketID, err := uuid.Parse(req.SourceBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid source_bucket_id format: %v", err)
+	}
+	destinationBucketID, err := uuid.Parse(req.DestinationBucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid destination_bucket_id format: %v", err)
+	}
+
+	// Parse amount from string to decimal
+	amount, err := parseAmount("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call usecase service
+	tx, err := s.BucketService.MoveBetweenVirtualBuckets(ctx, bucket_manager.MoveBetweenVirtualBucketsInput{
+		SourceBucketID:         sourceBucketID,
+		DestinationBucketID:    destinationBucketID,
+		Amount:                 amount,
+		Description:            req.Description,
+		RequireSufficientFunds: req.RequireSufficientFunds,
+	})
+	if err != nil {
+		return nil, mapError(err)
 	}
+
+	// Build response
+	return &wealthflowv1.MoveBetweenVirtualBucketsResponse{
+		TransactionId: tx.ID.String(),
+	}, nil
 }
 
-// protoBucketTypeToDomain converts a proto BucketType enum to a domain BucketType
-func protoBucketTypeToDomain(protoType wealthflowv1.BucketType) domain.BucketType {
-	switch protoType {
-	case wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL:
-		return domain.BucketTypePhysical
-	case wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL:
-		return domain.BucketTypeVirtual
-	case wealthflowv1.BucketType_BUCKET_TYPE_INCOME:
-		return domain.BucketTypeIncome
-	case wealthflowv1.BucketType_BUCKET_TYPE_EXPENSE:
-		return domain.BucketTypeExpense
-	case wealthflowv1.BucketType_BUCKET_TYPE_EQUITY:
-		return domain.BucketTypeEquity
-	default:
-		return ""
+// AddEquityPurchase records a purchase of units of an equity bucket
+func (s *Server) AddEquityPurchase(ctx context.Context, req *wealthflowv1.AddEquityPurchaseRequest) (*wealthflowv1.AddEquityPurchaseResponse, error) {
+	// Parse bucket ID
+	bucketID, err := uuid.Parse(req.BucketId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bucket_id format: %v", err)
+	}
+
+	// Parse quantity and unit price from strings to decimals
+	quantity, err := parseAmount("quantity", req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	unitPrice, err := parseAmount("unit_price", req.UnitPrice)
+	if err != nil {
+		return nil, err
 	}
+
+	// Parse optional source virtual bucket ID
+	var sourceID *uuid.UUID
+	if req.SourceVirtualBucketId != "" {
+		id, err := uuid.Parse(req.SourceVirtualBucketId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid source_virtual_bucket_id format: %v", err)
+		}
+		sourceID = &id
+	}
+
+	// Call usecase service
+	result, err := s.InvestmentService.AddEquityPurchase(ctx, investment.AddEquityPurchaseInput{
+		BucketID:              bucketID,
+		Quantity:              quantity,
+		UnitPrice:             unitPrice,
+		SourceVirtualBucketID: sourceID,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &wealthflowv1.AddEquityPurchaseResponse{
+		Quantity:      result.Holding.Quantity.String(),
+		AvgCost:       result.Holding.AvgCost.String(),
+		TransactionId: result.Transaction.ID.String(),
+	}, nil
 }
 
-// domainBucketToProto converts a domain Bucket to a proto Bucket message
-func domainBucketToProto(bucket *domain.Bucket) *wealthflowv1.Bucket {
-	protoBucket := &wealthflowv1.Bucket{
-		Id:             bucket.ID.String(),
-		Name:           bucket.Name,
-		Type:           domainBucketTypeToProto(bucket.BucketType),
-		CurrentBalance: bucket.CurrentBalance.String(),
+// CreateEquityBucket creates an equity bucket with its initial book value and optional market value
+func (s *Server) CreateEquityBucket(ctx context.Context, req *wealthflowv1.CreateEquityBucketRequest) (*wealthflowv1.CreateEquityBucketResponse, error) {
+	// Parse book value from string to decimal
+	bookValue, err := parseAmount("book_value", req.BookValue)
+	if err != nil {
+		return nil, err
 	}
 
-	// Set parent_id if it exists
-	if bucket.ParentPhysicalBucketID != nil {
-		protoBucket.ParentId = bucket.ParentPhysicalBucketID.String()
+	// Parse optional market value
+	var marketValue *decimal.Decimal
+	if req.MarketValue != "" {
+		value, err := parseAmount("market_value", req.MarketValue)
+		if err != nil {
+			return nil, err
+		}
+		marketValue = &value
 	}
 
-	return protoBucket
+	// Create the bucket with its initial book value and market value
+	result, err := s.BucketService.CreateEquityBucket(ctx, bucket_manager.CreateEquityBucketInput{
+		Name:        req.Name,
+		BookValue:   bookValue,
+		MarketValue: marketValue,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	bucket := result.Bucket
+
+	resp := &wealthflowv1.CreateEquityBucketResponse{
+		TransactionId: result.Transaction.ID.String(),
+	}
+	if result.MarketValue != nil {
+		resp.MarketValueEntryId = result.MarketValue.ID.String()
+	}
+
+	// First profit snapshot
+	detail, err := s.InvestmentService.CalculateProfitDetail(ctx, bucket.ID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	resp.Bucket = domainBucketToProto(bucket)
+	resp.BookValue = formatAmount(detail.BookValue)
+	resp.MarketValue = formatAmount(detail.MarketValue)
+	resp.AbsoluteProfit = formatAmount(detail.AbsoluteProfit)
+	if detail.PercentProfit != nil {
+		resp.PercentProfit = detail.PercentProfit.String()
+	}
+
+	return resp, nil
+}
+
+// notFoundResources pairs each typed not-found error with the resource type reported to clients
+var notFoundResources = []struct {
+	err          error
+	resourceType string
+}{
+	{domain.ErrBucketNotFound, "bucket"},
+	{domain.ErrSplitRuleNotFound, "split_rule"},
+	{domain.ErrTransactionNotFound, "transaction"},
+	{domain.ErrTransferTaskNotFound, "transfer_task"},
+}
+
+// notFoundStatus builds a NotFound status carrying an errdetails.ResourceInfo with the missing resource type
+func notFoundStatus(resourceType, message string) error {
+	st := status.New(codes.NotFound, message)
+	if withDetails, err := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		Description:  message,
+	}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
 }
 
 // mapError converts domain errors to gRPC status errors
@@ -395,16 +2380,28 @@ func mapError(err error) error {
 		return nil
 	}
 
-	errorMsg := err.Error()
+	// Map typed not-found errors to NotFound, naming the missing resource type in a ResourceInfo detail
+	for _, notFound := range notFoundResources {
+		if errors.Is(err, notFound.err) {
+			return notFoundStatus(notFound.resourceType, err.Error())
+		}
+	}
 
-	// Map common validation errors to InvalidArgument
-	if strings.Contains(errorMsg, "must be positive") ||
-		strings.Contains(errorMsg, "invalid") ||
-		strings.Contains(errorMsg, "must reference") ||
-		strings.Contains(errorMsg, "must have") {
-		return status.Errorf(codes.InvalidArgument, "%s", errorMsg)
+	// Map state conflicts to FailedPrecondition
+	if errors.Is(err, domain.ErrTransactionHasCompletedTransfer) || errors.Is(err, domain.ErrTransferTaskAlreadyCompleted) ||
+		errors.Is(err, domain.ErrInsufficientFunds) || errors.Is(err, domain.ErrCurrencyMismatch) ||
+		errors.Is(err, domain.ErrSplitRuleHasNoItems) || errors.Is(err, domain.ErrSplitRuleInactive) {
+		return status.Errorf(codes.FailedPrecondition, "%s", err.Error())
 	}
 
+	// Map typed validation errors to InvalidArgument
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	}
+
+	errorMsg := err.Error()
+
 	// Map "not found" errors to NotFound
 	if strings.Contains(errorMsg, "not found") {
 		return status.Errorf(codes.NotFound, "%s", errorMsg)
@@ -0,0 +1,418 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Wrapped ErrBucketNotFound maps to NotFound",
+			err:          fmt.Errorf("%w: %s", domain.ErrBucketNotFound, uuid.New()),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Doubly wrapped ErrBucketNotFound maps to NotFound",
+			err:          fmt.Errorf("failed to load target: %w", fmt.Errorf("%w: %s", domain.ErrBucketNotFound, uuid.New())),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Unbalanced transaction maps to InvalidArgument",
+			err:          (&domain.Transaction{Entries: []domain.TransactionEntry{{BucketID: uuid.New(), Amount: decimal.NewFromInt(10), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical}}}).Validate(),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Invalid split rule maps to InvalidArgument",
+			err:          (&domain.SplitRule{}).Validate(),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Untyped error with a validation keyword maps to Internal",
+			err:          errors.New("expense amount must be positive"),
+			expectedCode: codes.Internal,
+		},
+		{
+			name:         "Postgres invalid input syntax maps to Internal",
+			err:          errors.New(`pq: invalid input syntax for type uuid: "abc"`),
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "Bucket role error maps to InvalidArgument",
+			err: (&domain.Bucket{
+				ID:         uuid.New(),
+				Name:       "XTB Portfolio",
+				BucketType: domain.BucketTypeEquity,
+			}).ValidateRole(domain.BucketRoleExpenseCategory),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "ValidationError without keyword maps to InvalidArgument",
+			err:          domain.NewValidationError("items list cannot be empty"),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Wrapped ValidationError maps to InvalidArgument",
+			err:          fmt.Errorf("failed to allocate: %w", domain.NewValidationError("no REMAINDER item found")),
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Wrapped ErrTransactionHasCompletedTransfer maps to FailedPrecondition",
+			err:          fmt.Errorf("%w: %s", domain.ErrTransactionHasCompletedTransfer, uuid.New()),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Wrapped ErrTransferTaskNotFound maps to NotFound",
+			err:          fmt.Errorf("%w: %s", domain.ErrTransferTaskNotFound, uuid.New()),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Wrapped ErrSplitRuleNotFound maps to NotFound",
+			err:          fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, uuid.New()),
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Wrapped ErrInsufficientFunds maps to FailedPrecondition",
+			err:          fmt.Errorf("%w: Free Cash holds 10, cannot move 20", domain.ErrInsufficientFunds),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Wrapped ErrTransferTaskAlreadyCompleted maps to FailedPrecondition",
+			err:          fmt.Errorf("%w: %s", domain.ErrTransferTaskAlreadyCompleted, uuid.New()),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Wrapped ErrCurrencyMismatch maps to FailedPrecondition",
+			err:          fmt.Errorf("failed to sum net worth: %w: EUR and USD", domain.ErrCurrencyMismatch),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Wrapped ErrSplitRuleHasNoItems maps to FailedPrecondition",
+			err:          fmt.Errorf("%w: rule 123 of source bucket ID 456", domain.ErrSplitRuleHasNoItems),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Wrapped ErrSplitRuleInactive maps to FailedPrecondition",
+			err:          fmt.Errorf("%w: rule 123", domain.ErrSplitRuleInactive),
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Unknown error maps to Internal",
+			err:          errors.New("connection reset by peer"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mapError(tt.err)
+			st, ok := status.FromError(err)
+			assert.True(t, ok, "error should be a gRPC status")
+			assert.Equal(t, tt.expectedCode, st.Code())
+			assert.Equal(t, tt.err.Error(), st.Message())
+		})
+	}
+
+	assert.NoError(t, mapError(nil))
+}
+
+func TestMapError_NotFoundResourceType(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		resourceType string
+	}{
+		{"Missing bucket", fmt.Errorf("%w: %s", domain.ErrBucketNotFound, uuid.New()), "bucket"},
+		{"Missing split rule", fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, uuid.New()), "split_rule"},
+		{"Missing transaction", fmt.Errorf("%w: %s", domain.ErrTransactionNotFound, uuid.New()), "transaction"},
+		{"Missing transfer task", fmt.Errorf("%w: %s", domain.ErrTransferTaskNotFound, uuid.New()), "transfer_task"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(mapError(tt.err))
+			require.True(t, ok, "error should be a gRPC status")
+			assert.Equal(t, codes.NotFound, st.Code())
+
+			details := st.Details()
+			require.Len(t, details, 1)
+			info, ok := details[0].(*errdetails.ResourceInfo)
+			require.True(t, ok, "detail should be a ResourceInfo")
+			assert.Equal(t, tt.resourceType, info.ResourceType)
+		})
+	}
+}
+
+func TestValidateSplitRule(t *testing.T) {
+	groceries := uuid.New().String()
+	savings := uuid.New().String()
+	unallocated := uuid.New().String()
+
+	tests := []struct {
+		name             string
+		items            []*wealthflowv1.SplitRuleItem
+		expectedValid    bool
+		expectedError    string
+		expectedWarnings int
+	}{
+		{
+			name: "Valid rule",
+			items: []*wealthflowv1.SplitRuleItem{
+				{TargetBucketId: groceries, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_FIXED, Value: "300", Priority: 1},
+				{TargetBucketId: savings, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_PERCENT, Value: "20", Priority: 2},
+				{TargetBucketId: unallocated, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER, Priority: 3},
+			},
+			expectedValid: true,
+		},
+		{
+			name: "Valid rule with percent-sum warning",
+			items: []*wealthflowv1.SplitRuleItem{
+				{TargetBucketId: savings, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_PERCENT, Value: "100", Priority: 1},
+				{TargetBucketId: unallocated, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER, Priority: 2},
+			},
+			expectedValid:    true,
+			expectedWarnings: 1,
+		},
+		{
+			name: "Missing REMAINDER",
+			items: []*wealthflowv1.SplitRuleItem{
+				{TargetBucketId: groceries, Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_FIXED, Value: "300", Priority: 1},
+			},
+			expectedError: "split rule must have exactly one REMAINDER item",
+		},
+		{
+			name:          "No items",
+			expectedError: "split rule must have at least one item",
+		},
+	}
+
+	server := &Server{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.ValidateSplitRule(context.Background(), &wealthflowv1.ValidateSplitRuleRequest{Items: tt.items})
+			assert.NoError(t, err, "validation failures are reported in the response")
+			assert.Equal(t, tt.expectedValid, resp.Valid)
+			assert.Equal(t, tt.expectedError, resp.Error)
+			assert.Len(t, resp.Warnings, tt.expectedWarnings)
+		})
+	}
+
+	// Malformed input is still an RPC error
+	_, err := server.ValidateSplitRule(context.Background(), &wealthflowv1.ValidateSplitRuleRequest{
+		Items: []*wealthflowv1.SplitRuleItem{{TargetBucketId: "not-a-uuid"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{amount: "650", expected: "650.00"},
+		{amount: "1050.5", expected: "1050.50"},
+		{amount: "0", expected: "0.00"},
+		{amount: "-12.3", expected: "-12.30"},
+		{amount: "33.333333", expected: "33.33"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatAmount(decimal.RequireFromString(tt.amount)))
+		})
+	}
+}
+
+func TestDomainBucketToProto_FormatsBalance(t *testing.T) {
+	bucket := &domain.Bucket{
+		ID:             uuid.New(),
+		Name:           "Main Bank",
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.NewFromInt(650),
+	}
+
+	assert.Equal(t, "650.00", domainBucketToProto(bucket).CurrentBalance)
+}
+
+// fakePinger is a Pinger returning a fixed error
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) PingContext(ctx context.Context) error {
+	return p.err
+}
+
+func TestGetStatus_DatabaseUnreachable(t *testing.T) {
+	server := &Server{
+		DB:        fakePinger{err: errors.New("connection refused")},
+		BuildInfo: BuildInfo{Version: "1.2.0", Commit: "abc1234", BuildTime: "2025-01-01T00:00:00Z"},
+	}
+
+	resp, err := server.GetStatus(context.Background(), &wealthflowv1.GetStatusRequest{})
+
+	// Failed checks are reported in the response, not as an RPC error
+	assert.NoError(t, err)
+	assert.False(t, resp.Ready)
+	assert.False(t, resp.DatabaseConnected)
+	assert.False(t, resp.SystemBucketsSeeded)
+	assert.Equal(t, "database unreachable", resp.Status)
+	// Build info is reported even when checks fail
+	assert.Equal(t, "1.2.0", resp.Version)
+	assert.Equal(t, "abc1234", resp.Commit)
+	assert.Equal(t, "2025-01-01T00:00:00Z", resp.BuildTime)
+}
+
+func TestListLimits_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   ListLimits
+		limit    int32
+		expected int
+		wantErr  bool
+	}{
+		{name: "within max", limits: ListLimits{Max: 500}, limit: 20, expected: 20},
+		{name: "over max is clamped", limits: ListLimits{Max: 500}, limit: 1000000, expected: 500},
+		{name: "zero is rejected by default", limits: ListLimits{Max: 500}, limit: 0, wantErr: true},
+		{name: "zero uses default when enabled", limits: ListLimits{Max: 500, DefaultOnZero: true}, limit: 0, expected: DefaultListLimit},
+		{name: "default is clamped to max", limits: ListLimits{Max: 10, DefaultOnZero: true}, limit: 0, expected: 10},
+		{name: "negative is rejected", limits: ListLimits{Max: 500, DefaultOnZero: true}, limit: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := tt.limits.resolve(tt.limit)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+func TestListTransactions_ZeroLimitRejected(t *testing.T) {
+	server := &Server{ListLimits: ListLimits{Max: DefaultMaxListLimit}}
+
+	_, err := server.ListTransactions(context.Background(), &wealthflowv1.ListTransactionsRequest{Limit: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetStatement_RequiresPeriod(t *testing.T) {
+	server := &Server{}
+
+	_, err := server.GetStatement(context.Background(), &wealthflowv1.GetStatementRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "plain amount", value: "650.50", expected: "650.5"},
+		{name: "eight decimal places", value: "0.12345678", expected: "0.12345678"},
+		{name: "trailing zeros beyond eight places", value: "1.5000000000", expected: "1.5"},
+		{name: "negative amount", value: "-25", expected: "-25"},
+		{name: "largest magnitude", value: "1000000000000000", expected: "1000000000000000"},
+		{name: "over-long decimal", value: "0.123456789", wantErr: true},
+		{name: "over-large value", value: "1000000000000000.01", wantErr: true},
+		{name: "over-large negative value", value: "-99999999999999999999", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := parseAmount("amount", tt.value)
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Contains(t, err.Error(), "amount")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, amount.String())
+		})
+	}
+}
+
+func TestLogExpense_RejectsOverLongAmount(t *testing.T) {
+	server := &Server{}
+
+	_, err := server.LogExpense(context.Background(), &wealthflowv1.LogExpenseRequest{Amount: "12.123456789"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "at most 8 decimal places")
+}
+
+func TestRecordInflow_RejectsOverLargeAmount(t *testing.T) {
+	server := &Server{}
+
+	_, err := server.RecordInflow(context.Background(), &wealthflowv1.RecordInflowRequest{Amount: "1e30"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "must not exceed")
+}
+
+func TestImportRow_RejectsDateAndDryRun(t *testing.T) {
+	server := &Server{}
+
+	tests := []struct {
+		name   string
+		row    *wealthflowv1.ImportTransactionsRequest
+		errMsg string
+	}{
+		{"dated inflow", &wealthflowv1.ImportTransactionsRequest{Row: &wealthflowv1.ImportTransactionsRequest_Inflow{
+			Inflow: &wealthflowv1.RecordInflowRequest{Amount: "10", Date: timestamppb.Now()},
+		}}, "date is not supported"},
+		{"dry-run inflow", &wealthflowv1.ImportTransactionsRequest{Row: &wealthflowv1.ImportTransactionsRequest_Inflow{
+			Inflow: &wealthflowv1.RecordInflowRequest{Amount: "10", DryRun: true},
+		}}, "dry_run is not supported"},
+		{"dated expense", &wealthflowv1.ImportTransactionsRequest{Row: &wealthflowv1.ImportTransactionsRequest_Expense{
+			Expense: &wealthflowv1.LogExpenseRequest{Amount: "10", Date: timestamppb.Now()},
+		}}, "date is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txID, err := server.importRow(context.Background(), tt.row)
+
+			assert.Empty(t, txID)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestLogSplitExpense_RejectsInvalidLine(t *testing.T) {
+	server := &Server{}
+
+	_, err := server.LogSplitExpense(context.Background(), &wealthflowv1.LogSplitExpenseRequest{
+		Amount:          "80.00",
+		VirtualBucketId: uuid.New().String(),
+		Lines: []*wealthflowv1.ExpenseLine{
+			{CategoryBucketId: uuid.New().String(), Amount: "62.40"},
+			{CategoryBucketId: "not-a-uuid", Amount: "17.60"},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "line 1")
+}
@@ -81,6 +81,112 @@ func (BucketType) EnumDescriptor() ([]byte, []int) {
 	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{0}
 }
 
+// SplitRuleItemType represents how a split rule item's amount is computed
+type SplitRuleItemType int32
+
+const (
+	SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_UNSPECIFIED SplitRuleItemType = 0
+	SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_FIXED       SplitRuleItemType = 1
+	SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_PERCENT     SplitRuleItemType = 2
+	SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER   SplitRuleItemType = 3
+)
+
+// Enum value maps for SplitRuleItemType.
+var (
+	SplitRuleItemType_name = map[int32]string{
+		0: "SPLIT_RULE_ITEM_TYPE_UNSPECIFIED",
+		1: "SPLIT_RULE_ITEM_TYPE_FIXED",
+		2: "SPLIT_RULE_ITEM_TYPE_PERCENT",
+		3: "SPLIT_RULE_ITEM_TYPE_REMAINDER",
+	}
+	SplitRuleItemType_value = map[string]int32{
+		"SPLIT_RULE_ITEM_TYPE_UNSPECIFIED": 0,
+		"SPLIT_RULE_ITEM_TYPE_FIXED":       1,
+		"SPLIT_RULE_ITEM_TYPE_PERCENT":     2,
+		"SPLIT_RULE_ITEM_TYPE_REMAINDER":   3,
+	}
+)
+
+func (x SplitRuleItemType) Enum() *SplitRuleItemType {
+	p := new(SplitRuleItemType)
+	*p = x
+	return p
+}
+
+func (x SplitRuleItemType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SplitRuleItemType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wealthflow_v1_service_proto_enumTypes[1].Descriptor()
+}
+
+func (SplitRuleItemType) Type() protoreflect.EnumType {
+	return &file_wealthflow_v1_service_proto_enumTypes[1]
+}
+
+func (x SplitRuleItemType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SplitRuleItemType.Descriptor instead.
+func (SplitRuleItemType) EnumDescriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+// AllocationMode controls how FIXED split rule items exceeding the inflow amount are handled
+type AllocationMode int32
+
+const (
+	// Same as STRICT
+	AllocationMode_ALLOCATION_MODE_UNSPECIFIED AllocationMode = 0
+	// Fail the allocation
+	AllocationMode_ALLOCATION_MODE_STRICT AllocationMode = 1
+	// Scale FIXED items down proportionally to fit, leaving nothing for PERCENT/REMAINDER items
+	AllocationMode_ALLOCATION_MODE_SCALE_FIXED AllocationMode = 2
+)
+
+// Enum value maps for AllocationMode.
+var (
+	AllocationMode_name = map[int32]string{
+		0: "ALLOCATION_MODE_UNSPECIFIED",
+		1: "ALLOCATION_MODE_STRICT",
+		2: "ALLOCATION_MODE_SCALE_FIXED",
+	}
+	AllocationMode_value = map[string]int32{
+		"ALLOCATION_MODE_UNSPECIFIED": 0,
+		"ALLOCATION_MODE_STRICT":      1,
+		"ALLOCATION_MODE_SCALE_FIXED": 2,
+	}
+)
+
+func (x AllocationMode) Enum() *AllocationMode {
+	p := new(AllocationMode)
+	*p = x
+	return p
+}
+
+func (x AllocationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllocationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_wealthflow_v1_service_proto_enumTypes[2].Descriptor()
+}
+
+func (AllocationMode) Type() protoreflect.EnumType {
+	return &file_wealthflow_v1_service_proto_enumTypes[2]
+}
+
+func (x AllocationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AllocationMode.Descriptor instead.
+func (AllocationMode) EnumDescriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
 // RecordInflowRequest represents an income/inflow transaction
 type RecordInflowRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -93,9 +199,24 @@ type RecordInflowRequest struct {
 	// If true, this is an external inflow that triggers Split Rule Engine
 	IsExternal bool `protobuf:"varint,4,opt,name=is_external,json=isExternal,proto3" json:"is_external,omitempty"`
 	// Optional: Transaction date (defaults to server time if not provided)
-	Date          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Date *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	// Optional: Long-form memo (e.g. receipt details), returned by GetTransaction only
+	Memo string `protobuf:"bytes,6,opt,name=memo,proto3" json:"memo,omitempty"`
+	// Optional: How FIXED split rule items exceeding the amount are handled (defaults to STRICT)
+	AllocationMode AllocationMode `protobuf:"varint,7,opt,name=allocation_mode,json=allocationMode,proto3,enum=wealthflow.v1.AllocationMode" json:"allocation_mode,omitempty"`
+	// Optional: Virtual bucket ID (UUID as string) receiving the full amount without a split rule
+	// (e.g. a gift or reimbursement). Mutually exclusive with is_external and allocation_mode
+	TargetVirtualBucketId string `protobuf:"bytes,8,opt,name=target_virtual_bucket_id,json=targetVirtualBucketId,proto3" json:"target_virtual_bucket_id,omitempty"`
+	// Optional: If true, run every validation and the allocation and return the would-be breakdown
+	// without recording the transaction
+	DryRun bool `protobuf:"varint,9,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Optional: Physical bucket ID (UUID as string) the money arrived in. When set, income that neither a split rule
+	// nor target_virtual_bucket_id applies to is parked in System Extra Income for later allocation instead of failing.
+	// There is no default landing bucket: clients relying on this fallback must send the field with every inflow,
+	// since the server cannot tell which bank received the money otherwise and the inflow fails as without it
+	ParkInPhysicalBucketId string `protobuf:"bytes,10,opt,name=park_in_physical_bucket_id,json=parkInPhysicalBucketId,proto3" json:"park_in_physical_bucket_id,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *RecordInflowRequest) Reset() {
@@ -163,13 +284,52 @@ func (x *RecordInflowRequest) GetDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RecordInflowRequest) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *RecordInflowRequest) GetAllocationMode() AllocationMode {
+	if x != nil {
+		return x.AllocationMode
+	}
+	return AllocationMode_ALLOCATION_MODE_UNSPECIFIED
+}
+
+func (x *RecordInflowRequest) GetTargetVirtualBucketId() string {
+	if x != nil {
+		return x.TargetVirtualBucketId
+	}
+	return ""
+}
+
+func (x *RecordInflowRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RecordInflowRequest) GetParkInPhysicalBucketId() string {
+	if x != nil {
+		return x.ParkInPhysicalBucketId
+	}
+	return ""
+}
+
 // RecordInflowResponse returns the created transaction details
 type RecordInflowResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Transaction ID (UUID as string)
+	// Transaction ID (UUID as string) - empty for a dry run
 	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	// Timestamp when the transaction was created
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Where the money went: one entry per virtual bucket credited by the split (in split rule order)
+	Allocations []*AllocationAmount `protobuf:"bytes,3,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	// True if the income was parked in System Extra Income and still needs to be allocated
+	Parked        bool `protobuf:"varint,4,opt,name=parked,proto3" json:"parked,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -218,6 +378,20 @@ func (x *RecordInflowResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RecordInflowResponse) GetAllocations() []*AllocationAmount {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *RecordInflowResponse) GetParked() bool {
+	if x != nil {
+		return x.Parked
+	}
+	return false
+}
+
 // LogExpenseRequest represents an expense transaction
 type LogExpenseRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -233,7 +407,15 @@ type LogExpenseRequest struct {
 	// If not provided, uses the parent physical bucket of virtual_bucket_id
 	PhysicalBucketOverrideId string `protobuf:"bytes,5,opt,name=physical_bucket_override_id,json=physicalBucketOverrideId,proto3" json:"physical_bucket_override_id,omitempty"`
 	// Optional: Transaction date (defaults to server time if not provided)
-	Date          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	Date *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	// Optional: Long-form memo (e.g. receipt details), returned by GetTransaction only
+	Memo string `protobuf:"bytes,7,opt,name=memo,proto3" json:"memo,omitempty"`
+	// Optional: Record a refund (e.g. a returned item) - money flows back from the category
+	// into the virtual and physical buckets. The amount must still be positive
+	IsRefund bool `protobuf:"varint,8,opt,name=is_refund,json=isRefund,proto3" json:"is_refund,omitempty"`
+	// Optional: Schedule the expense for a future date (e.g. an upcoming bill). It is stored but not counted
+	// in balances or net worth until ProcessScheduledTransactions activates it on or after this date
+	EffectiveDate *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -310,6 +492,27 @@ func (x *LogExpenseRequest) GetDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *LogExpenseRequest) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *LogExpenseRequest) GetIsRefund() bool {
+	if x != nil {
+		return x.IsRefund
+	}
+	return false
+}
+
+func (x *LogExpenseRequest) GetEffectiveDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveDate
+	}
+	return nil
+}
+
 // LogExpenseResponse returns the created transaction details
 type LogExpenseResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -317,7 +520,7 @@ type LogExpenseResponse struct {
 	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
 	// Timestamp when the transaction was created
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// Physical bucket ID that was actually credited (useful when override was used)
+	// Physical bucket ID that was actually credited, or debited for a refund (useful when override was used)
 	PhysicalBucketId string `protobuf:"bytes,3,opt,name=physical_bucket_id,json=physicalBucketId,proto3" json:"physical_bucket_id,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
@@ -382,7 +585,10 @@ type UpdateInvestmentRequest struct {
 	// Market value as a decimal string (e.g., "650.00") to preserve precision
 	MarketValue string `protobuf:"bytes,2,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
 	// Optional: Date of the market value (defaults to server time if not provided)
-	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Date *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// Optional: Per-unit price as a decimal string, instead of market_value, for buckets with purchases
+	// recorded via AddEquityPurchase (the market value becomes quantity * unit_price)
+	UnitPrice     string `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -438,6 +644,13 @@ func (x *UpdateInvestmentRequest) GetDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpdateInvestmentRequest) GetUnitPrice() string {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return ""
+}
+
 // UpdateInvestmentResponse confirms the market value update
 type UpdateInvestmentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -497,9 +710,12 @@ func (x *UpdateInvestmentResponse) GetCreatedAt() *timestamppb.Timestamp {
 type ListBucketsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional: Filter by bucket type
-	BucketType    BucketType `protobuf:"varint,1,opt,name=bucket_type,json=bucketType,proto3,enum=wealthflow.v1.BucketType" json:"bucket_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	BucketType BucketType `protobuf:"varint,1,opt,name=bucket_type,json=bucketType,proto3,enum=wealthflow.v1.BucketType" json:"bucket_type,omitempty"`
+	// Optional: Populate market_value on EQUITY buckets with their current market value
+	// (quantity * latest unit price for buckets with purchases, as GetInvestmentProfit values them)
+	IncludeMarketValue bool `protobuf:"varint,2,opt,name=include_market_value,json=includeMarketValue,proto3" json:"include_market_value,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ListBucketsRequest) Reset() {
@@ -539,6 +755,13 @@ func (x *ListBucketsRequest) GetBucketType() BucketType {
 	return BucketType_BUCKET_TYPE_UNSPECIFIED
 }
 
+func (x *ListBucketsRequest) GetIncludeMarketValue() bool {
+	if x != nil {
+		return x.IncludeMarketValue
+	}
+	return false
+}
+
 // ListBucketsResponse returns a list of buckets
 type ListBucketsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -597,7 +820,16 @@ type Bucket struct {
 	// Current balance as a decimal string (e.g., "1000.50") to preserve precision
 	CurrentBalance string `protobuf:"bytes,4,opt,name=current_balance,json=currentBalance,proto3" json:"current_balance,omitempty"`
 	// Optional: Parent physical bucket ID (UUID as string) - only set for VIRTUAL buckets
-	ParentId      string `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ParentId string `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Whether the bucket has been archived (e.g. merged into another category)
+	IsArchived bool `protobuf:"varint,6,opt,name=is_archived,json=isArchived,proto3" json:"is_archived,omitempty"`
+	// Optional: Goal amount as a decimal string - empty if no goal is set
+	GoalAmount string `protobuf:"bytes,7,opt,name=goal_amount,json=goalAmount,proto3" json:"goal_amount,omitempty"`
+	// Optional: Percentage of the goal reached (current_balance / goal_amount * 100) - empty if no goal is set
+	GoalProgress string `protobuf:"bytes,8,opt,name=goal_progress,json=goalProgress,proto3" json:"goal_progress,omitempty"`
+	// Optional: Latest market value as a decimal string - only set for EQUITY buckets with market value history,
+	// and only when requested (e.g. ListBuckets with include_market_value)
+	MarketValue   string `protobuf:"bytes,9,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -667,15 +899,52 @@ func (x *Bucket) GetParentId() string {
 	return ""
 }
 
+func (x *Bucket) GetIsArchived() bool {
+	if x != nil {
+		return x.IsArchived
+	}
+	return false
+}
+
+func (x *Bucket) GetGoalAmount() string {
+	if x != nil {
+		return x.GoalAmount
+	}
+	return ""
+}
+
+func (x *Bucket) GetGoalProgress() string {
+	if x != nil {
+		return x.GoalProgress
+	}
+	return ""
+}
+
+func (x *Bucket) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
 // ListTransactionsRequest represents a request to list transactions
 type ListTransactionsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Maximum number of transactions to return
+	// Maximum number of transactions to return - values above the server maximum (default 500) are clamped;
+	// 0 is rejected unless the server is configured to apply a default page size instead
 	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	// Number of transactions to skip (for pagination)
 	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	// Optional: Filter by bucket ID (UUID as string) - returns transactions involving this bucket
-	BucketId      string `protobuf:"bytes,3,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	BucketId string `protobuf:"bytes,3,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Optional: Filter by several bucket IDs (UUIDs as strings) - returns transactions involving any of them
+	// (e.g. a physical bucket and its virtual children). Combined with bucket_id if both are set.
+	BucketIds []string `protobuf:"bytes,4,rep,name=bucket_ids,json=bucketIds,proto3" json:"bucket_ids,omitempty"`
+	// If true, each transaction includes its entries; omitted otherwise to keep the payload small
+	IncludeEntries bool `protobuf:"varint,5,opt,name=include_entries,json=includeEntries,proto3" json:"include_entries,omitempty"`
+	// Optional: Only return one kind of transaction - "REFUND" or "EXTERNAL_INFLOW" (empty returns all).
+	// Combined with the bucket filters if both are set.
+	Kind          string `protobuf:"bytes,6,opt,name=kind,proto3" json:"kind,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -731,6 +1000,27 @@ func (x *ListTransactionsRequest) GetBucketId() string {
 	return ""
 }
 
+func (x *ListTransactionsRequest) GetBucketIds() []string {
+	if x != nil {
+		return x.BucketIds
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetIncludeEntries() bool {
+	if x != nil {
+		return x.IncludeEntries
+	}
+	return false
+}
+
+func (x *ListTransactionsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
 // ListTransactionsResponse returns a list of transactions
 type ListTransactionsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -810,8 +1100,14 @@ type Transaction struct {
 	IsExternal bool `protobuf:"varint,5,opt,name=is_external,json=isExternal,proto3" json:"is_external,omitempty"`
 	// Whether this is an internal transfer transaction
 	IsInternalTransfer bool `protobuf:"varint,6,opt,name=is_internal_transfer,json=isInternalTransfer,proto3" json:"is_internal_transfer,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// All entries of the transaction (both layers) - only populated when requested (e.g. ListTransactions include_entries)
+	Entries []*TransactionEntry `protobuf:"bytes,7,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Whether this is a refund logged through LogExpense
+	IsRefund bool `protobuf:"varint,8,opt,name=is_refund,json=isRefund,proto3" json:"is_refund,omitempty"`
+	// Whether this is a scheduled transaction not yet counted in balances (date is its effective date)
+	Scheduled     bool `protobuf:"varint,9,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Transaction) Reset() {
@@ -886,6 +1182,27 @@ func (x *Transaction) GetIsInternalTransfer() bool {
 	return false
 }
 
+func (x *Transaction) GetEntries() []*TransactionEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Transaction) GetIsRefund() bool {
+	if x != nil {
+		return x.IsRefund
+	}
+	return false
+}
+
+func (x *Transaction) GetScheduled() bool {
+	if x != nil {
+		return x.Scheduled
+	}
+	return false
+}
+
 // GetNetWorthRequest represents a request to get net worth
 type GetNetWorthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -931,7 +1248,14 @@ type GetNetWorthResponse struct {
 	// Liquidity (sum of PHYSICAL bucket balances) as a decimal string
 	Liquidity string `protobuf:"bytes,2,opt,name=liquidity,proto3" json:"liquidity,omitempty"`
 	// Equity (sum of EQUITY bucket market values) as a decimal string
-	Equity        string `protobuf:"bytes,3,opt,name=equity,proto3" json:"equity,omitempty"`
+	Equity string `protobuf:"bytes,3,opt,name=equity,proto3" json:"equity,omitempty"`
+	// Equity book value (sum of balances of the EQUITY buckets that have a market value) as a decimal string
+	// Equity book value (sum of EQUITY bucket balances) as a decimal string
+	EquityBookValue string `protobuf:"bytes,4,opt,name=equity_book_value,json=equityBookValue,proto3" json:"equity_book_value,omitempty"`
+	// Equity profit (market value - book value across EQUITY buckets) as a decimal string
+	EquityProfit string `protobuf:"bytes,5,opt,name=equity_profit,json=equityProfit,proto3" json:"equity_profit,omitempty"`
+	// When the figures were calculated (server time), so cached values are unambiguous
+	CalculatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -987,13 +1311,38 @@ func (x *GetNetWorthResponse) GetEquity() string {
 	return ""
 }
 
+func (x *GetNetWorthResponse) GetEquityBookValue() string {
+	if x != nil {
+		return x.EquityBookValue
+	}
+	return ""
+}
+
+func (x *GetNetWorthResponse) GetEquityProfit() string {
+	if x != nil {
+		return x.EquityProfit
+	}
+	return ""
+}
+
+func (x *GetNetWorthResponse) GetCalculatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CalculatedAt
+	}
+	return nil
+}
+
 // GetBucketRequest represents a request to get a bucket by ID
 type GetBucketRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Bucket ID (UUID as string)
-	BucketId      string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Optional: If true, return the bucket's most recent market values (equity buckets only; ignored otherwise)
+	IncludeRecentMarketValues bool `protobuf:"varint,2,opt,name=include_recent_market_values,json=includeRecentMarketValues,proto3" json:"include_recent_market_values,omitempty"`
+	// Optional: Number of recent market values to return (defaults to 30)
+	RecentMarketValuesLimit int32 `protobuf:"varint,3,opt,name=recent_market_values_limit,json=recentMarketValuesLimit,proto3" json:"recent_market_values_limit,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *GetBucketRequest) Reset() {
@@ -1033,13 +1382,31 @@ func (x *GetBucketRequest) GetBucketId() string {
 	return ""
 }
 
+func (x *GetBucketRequest) GetIncludeRecentMarketValues() bool {
+	if x != nil {
+		return x.IncludeRecentMarketValues
+	}
+	return false
+}
+
+func (x *GetBucketRequest) GetRecentMarketValuesLimit() int32 {
+	if x != nil {
+		return x.RecentMarketValuesLimit
+	}
+	return 0
+}
+
 // GetBucketResponse returns a single bucket
 type GetBucketResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The requested bucket
-	Bucket        *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Name of the parent physical bucket (only for virtual buckets, empty otherwise)
+	ParentName string `protobuf:"bytes,2,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`
+	// Most recent market values, oldest first (only if include_recent_market_values was set for an equity bucket)
+	RecentMarketValues []*MarketValueEntry `protobuf:"bytes,3,rep,name=recent_market_values,json=recentMarketValues,proto3" json:"recent_market_values,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *GetBucketResponse) Reset() {
@@ -1079,65 +1446,7268 @@ func (x *GetBucketResponse) GetBucket() *Bucket {
 	return nil
 }
 
-var File_wealthflow_v1_service_proto protoreflect.FileDescriptor
+func (x *GetBucketResponse) GetParentName() string {
+	if x != nil {
+		return x.ParentName
+	}
+	return ""
+}
 
-const file_wealthflow_v1_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1bwealthflow/v1/service.proto\x12\rwealthflow.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x01\n" +
-	"\x13RecordInflowRequest\x12\x16\n" +
-	"\x06amount\x18\x01 \x01(\tR\x06amount\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12(\n" +
-	"\x10source_bucket_id\x18\x03 \x01(\tR\x0esourceBucketId\x12\x1f\n" +
-	"\vis_external\x18\x04 \x01(\bR\n" +
-	"isExternal\x12.\n" +
-	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"x\n" +
-	"\x14RecordInflowResponse\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x129\n" +
-	"\n" +
-	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x96\x02\n" +
-	"\x11LogExpenseRequest\x12\x16\n" +
-	"\x06amount\x18\x01 \x01(\tR\x06amount\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12*\n" +
-	"\x11virtual_bucket_id\x18\x03 \x01(\tR\x0fvirtualBucketId\x12,\n" +
-	"\x12category_bucket_id\x18\x04 \x01(\tR\x10categoryBucketId\x12=\n" +
-	"\x1bphysical_bucket_override_id\x18\x05 \x01(\tR\x18physicalBucketOverrideId\x12.\n" +
-	"\x04date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"\xa4\x01\n" +
-	"\x12LogExpenseResponse\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x129\n" +
-	"\n" +
-	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
-	"\x12physical_bucket_id\x18\x03 \x01(\tR\x10physicalBucketId\"\x89\x01\n" +
-	"\x17UpdateInvestmentRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12!\n" +
-	"\fmarket_value\x18\x02 \x01(\tR\vmarketValue\x12.\n" +
-	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"p\n" +
-	"\x18UpdateInvestmentResponse\x12\x19\n" +
-	"\bentry_id\x18\x01 \x01(\tR\aentryId\x129\n" +
-	"\n" +
-	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"P\n" +
-	"\x12ListBucketsRequest\x12:\n" +
-	"\vbucket_type\x18\x01 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\n" +
-	"bucketType\"F\n" +
-	"\x13ListBucketsResponse\x12/\n" +
-	"\abuckets\x18\x01 \x03(\v2\x15.wealthflow.v1.BucketR\abuckets\"\xa1\x01\n" +
-	"\x06Bucket\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\x04type\x12'\n" +
-	"\x0fcurrent_balance\x18\x04 \x01(\tR\x0ecurrentBalance\x12\x1b\n" +
-	"\tparent_id\x18\x05 \x01(\tR\bparentId\"d\n" +
-	"\x17ListTransactionsRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1b\n" +
-	"\tbucket_id\x18\x03 \x01(\tR\bbucketId\"\x98\x02\n" +
-	"\x18ListTransactionsResponse\x12>\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x1a.wealthflow.v1.TransactionR\ftransactions\x12\x1f\n" +
+func (x *GetBucketResponse) GetRecentMarketValues() []*MarketValueEntry {
+	if x != nil {
+		return x.RecentMarketValues
+	}
+	return nil
+}
+
+// ImportTransactionsRequest represents a single row of a bulk import
+type ImportTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The row to import - either an inflow or an expense
+	//
+	// Types that are valid to be assigned to Row:
+	//
+	//	*ImportTransactionsRequest_Inflow
+	//	*ImportTransactionsRequest_Expense
+	Row           isImportTransactionsRequest_Row `protobuf_oneof:"row"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTransactionsRequest) Reset() {
+	*x = ImportTransactionsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTransactionsRequest) ProtoMessage() {}
+
+func (x *ImportTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportTransactionsRequest) GetRow() isImportTransactionsRequest_Row {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+func (x *ImportTransactionsRequest) GetInflow() *RecordInflowRequest {
+	if x != nil {
+		if x, ok := x.Row.(*ImportTransactionsRequest_Inflow); ok {
+			return x.Inflow
+		}
+	}
+	return nil
+}
+
+func (x *ImportTransactionsRequest) GetExpense() *LogExpenseRequest {
+	if x != nil {
+		if x, ok := x.Row.(*ImportTransactionsRequest_Expense); ok {
+			return x.Expense
+		}
+	}
+	return nil
+}
+
+type isImportTransactionsRequest_Row interface {
+	isImportTransactionsRequest_Row()
+}
+
+type ImportTransactionsRequest_Inflow struct {
+	Inflow *RecordInflowRequest `protobuf:"bytes,1,opt,name=inflow,proto3,oneof"`
+}
+
+type ImportTransactionsRequest_Expense struct {
+	Expense *LogExpenseRequest `protobuf:"bytes,2,opt,name=expense,proto3,oneof"`
+}
+
+func (*ImportTransactionsRequest_Inflow) isImportTransactionsRequest_Row() {}
+
+func (*ImportTransactionsRequest_Expense) isImportTransactionsRequest_Row() {}
+
+// ImportTransactionsResponse summarizes the result of a bulk import
+type ImportTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of rows imported successfully
+	SuccessCount int32 `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	// Number of rows that failed
+	FailureCount int32 `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
+	// Per-row errors for the rows that failed
+	Errors []*ImportRowError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Transaction IDs (UUID as string) created for the successful rows, in stream order
+	TransactionIds []string `protobuf:"bytes,4,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportTransactionsResponse) Reset() {
+	*x = ImportTransactionsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTransactionsResponse) ProtoMessage() {}
+
+func (x *ImportTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ImportTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ImportTransactionsResponse) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+func (x *ImportTransactionsResponse) GetFailureCount() int32 {
+	if x != nil {
+		return x.FailureCount
+	}
+	return 0
+}
+
+func (x *ImportTransactionsResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportTransactionsResponse) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+// ImportRowError describes why a single import row failed
+type ImportRowError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Row number in the stream (1-based)
+	Row int32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Error message for this row
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportRowError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetBucketTreeRequest represents a request to get the bucket hierarchy
+type GetBucketTreeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketTreeRequest) Reset() {
+	*x = GetBucketTreeRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketTreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketTreeRequest) ProtoMessage() {}
+
+func (x *GetBucketTreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketTreeRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketTreeRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+// GetBucketTreeResponse returns the physical buckets with their virtual children
+type GetBucketTreeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One node per physical bucket, ordered by name
+	Nodes         []*BucketTreeNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketTreeResponse) Reset() {
+	*x = GetBucketTreeResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketTreeResponse) ProtoMessage() {}
+
+func (x *GetBucketTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketTreeResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketTreeResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBucketTreeResponse) GetNodes() []*BucketTreeNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+// BucketTreeNode represents a physical bucket and the virtual buckets it holds
+type BucketTreeNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The physical bucket
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Virtual buckets whose parent is this physical bucket, ordered by name
+	Children []*Bucket `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	// Sum of the children's balances as a decimal string
+	VirtualBalance string `protobuf:"bytes,3,opt,name=virtual_balance,json=virtualBalance,proto3" json:"virtual_balance,omitempty"`
+	// Physical balance minus the sum of the children's balances as a decimal string
+	// Non-zero means money landed physically but was not virtually assigned (or vice versa)
+	UnbucketedAmount string `protobuf:"bytes,4,opt,name=unbucketed_amount,json=unbucketedAmount,proto3" json:"unbucketed_amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BucketTreeNode) Reset() {
+	*x = BucketTreeNode{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketTreeNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketTreeNode) ProtoMessage() {}
+
+func (x *BucketTreeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketTreeNode.ProtoReflect.Descriptor instead.
+func (*BucketTreeNode) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BucketTreeNode) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *BucketTreeNode) GetChildren() []*Bucket {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *BucketTreeNode) GetVirtualBalance() string {
+	if x != nil {
+		return x.VirtualBalance
+	}
+	return ""
+}
+
+func (x *BucketTreeNode) GetUnbucketedAmount() string {
+	if x != nil {
+		return x.UnbucketedAmount
+	}
+	return ""
+}
+
+// GetUnbucketedAmountRequest represents a request to check a physical bucket's allocation consistency
+type GetUnbucketedAmountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Physical bucket ID (UUID as string)
+	BucketId      string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnbucketedAmountRequest) Reset() {
+	*x = GetUnbucketedAmountRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnbucketedAmountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnbucketedAmountRequest) ProtoMessage() {}
+
+func (x *GetUnbucketedAmountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnbucketedAmountRequest.ProtoReflect.Descriptor instead.
+func (*GetUnbucketedAmountRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUnbucketedAmountRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+// GetUnbucketedAmountResponse returns the allocation consistency figures for a physical bucket
+type GetUnbucketedAmountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Physical bucket balance as a decimal string
+	PhysicalBalance string `protobuf:"bytes,1,opt,name=physical_balance,json=physicalBalance,proto3" json:"physical_balance,omitempty"`
+	// Sum of the virtual children's balances as a decimal string
+	VirtualBalance string `protobuf:"bytes,2,opt,name=virtual_balance,json=virtualBalance,proto3" json:"virtual_balance,omitempty"`
+	// Physical balance minus the sum of the virtual children's balances as a decimal string
+	UnbucketedAmount string `protobuf:"bytes,3,opt,name=unbucketed_amount,json=unbucketedAmount,proto3" json:"unbucketed_amount,omitempty"`
+	// True when unbucketed_amount is non-zero (allocation drift)
+	HasDrift      bool `protobuf:"varint,4,opt,name=has_drift,json=hasDrift,proto3" json:"has_drift,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnbucketedAmountResponse) Reset() {
+	*x = GetUnbucketedAmountResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnbucketedAmountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnbucketedAmountResponse) ProtoMessage() {}
+
+func (x *GetUnbucketedAmountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnbucketedAmountResponse.ProtoReflect.Descriptor instead.
+func (*GetUnbucketedAmountResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUnbucketedAmountResponse) GetPhysicalBalance() string {
+	if x != nil {
+		return x.PhysicalBalance
+	}
+	return ""
+}
+
+func (x *GetUnbucketedAmountResponse) GetVirtualBalance() string {
+	if x != nil {
+		return x.VirtualBalance
+	}
+	return ""
+}
+
+func (x *GetUnbucketedAmountResponse) GetUnbucketedAmount() string {
+	if x != nil {
+		return x.UnbucketedAmount
+	}
+	return ""
+}
+
+func (x *GetUnbucketedAmountResponse) GetHasDrift() bool {
+	if x != nil {
+		return x.HasDrift
+	}
+	return false
+}
+
+// GetBucketTransactionsRequest represents a request for a bucket's recent activity
+type GetBucketTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Optional: Maximum number of transactions to return (defaults to 10 if not provided)
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketTransactionsRequest) Reset() {
+	*x = GetBucketTransactionsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketTransactionsRequest) ProtoMessage() {}
+
+func (x *GetBucketTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBucketTransactionsRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetBucketTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// GetBucketTransactionsResponse returns a bucket's recent transactions
+type GetBucketTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of transactions, newest first
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Total count of transactions involving this bucket
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Map of bucket_id -> bucket_name for all buckets involved in the returned transactions
+	BucketNames   map[string]string `protobuf:"bytes,3,rep,name=bucket_names,json=bucketNames,proto3" json:"bucket_names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketTransactionsResponse) Reset() {
+	*x = GetBucketTransactionsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketTransactionsResponse) ProtoMessage() {}
+
+func (x *GetBucketTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBucketTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetBucketTransactionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetBucketTransactionsResponse) GetBucketNames() map[string]string {
+	if x != nil {
+		return x.BucketNames
+	}
+	return nil
+}
+
+// PreviewAllocationRequest represents a request to preview a split rule allocation
+type PreviewAllocationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source bucket ID (UUID as string) - the bucket whose split rule is applied
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Amount as a decimal string (e.g., "1000.00") to preserve precision
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// If true, include the step-by-step derivation in the response
+	Explain bool `protobuf:"varint,3,opt,name=explain,proto3" json:"explain,omitempty"`
+	// Optional: How FIXED split rule items exceeding the amount are handled (defaults to STRICT)
+	AllocationMode AllocationMode `protobuf:"varint,4,opt,name=allocation_mode,json=allocationMode,proto3,enum=wealthflow.v1.AllocationMode" json:"allocation_mode,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PreviewAllocationRequest) Reset() {
+	*x = PreviewAllocationRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAllocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAllocationRequest) ProtoMessage() {}
+
+func (x *PreviewAllocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAllocationRequest.ProtoReflect.Descriptor instead.
+func (*PreviewAllocationRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PreviewAllocationRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *PreviewAllocationRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *PreviewAllocationRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+func (x *PreviewAllocationRequest) GetAllocationMode() AllocationMode {
+	if x != nil {
+		return x.AllocationMode
+	}
+	return AllocationMode_ALLOCATION_MODE_UNSPECIFIED
+}
+
+// PreviewAllocationResponse returns the computed allocation
+type PreviewAllocationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One entry per split rule item, in priority order
+	Allocations []*AllocationAmount `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	// Ordered derivation steps (only populated when explain is true)
+	Steps         []*AllocationStep `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewAllocationResponse) Reset() {
+	*x = PreviewAllocationResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewAllocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewAllocationResponse) ProtoMessage() {}
+
+func (x *PreviewAllocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewAllocationResponse.ProtoReflect.Descriptor instead.
+func (*PreviewAllocationResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PreviewAllocationResponse) GetAllocations() []*AllocationAmount {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *PreviewAllocationResponse) GetSteps() []*AllocationStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+// AllocationAmount represents the amount allocated to a single target bucket
+type AllocationAmount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Target bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Allocated amount as a decimal string
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Target bucket name
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationAmount) Reset() {
+	*x = AllocationAmount{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationAmount) ProtoMessage() {}
+
+func (x *AllocationAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationAmount.ProtoReflect.Descriptor instead.
+func (*AllocationAmount) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AllocationAmount) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *AllocationAmount) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *AllocationAmount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// AllocationStep describes how a single split rule item's amount was derived
+type AllocationStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Target bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Item type
+	Type SplitRuleItemType `protobuf:"varint,2,opt,name=type,proto3,enum=wealthflow.v1.SplitRuleItemType" json:"type,omitempty"`
+	// Item value as a decimal string (amount for FIXED, percentage for PERCENT)
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Amount the value was applied to as a decimal string
+	BaseAmount string `protobuf:"bytes,4,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`
+	// Amount allocated to the target bucket as a decimal string
+	ComputedAmount string `protobuf:"bytes,5,opt,name=computed_amount,json=computedAmount,proto3" json:"computed_amount,omitempty"`
+	// Amount left to allocate after this step as a decimal string
+	RunningRemainder string `protobuf:"bytes,6,opt,name=running_remainder,json=runningRemainder,proto3" json:"running_remainder,omitempty"`
+	// Optional: Bucket ID (UUID as string) a relative PERCENT item is based on
+	RelativeToBucketId string `protobuf:"bytes,7,opt,name=relative_to_bucket_id,json=relativeToBucketId,proto3" json:"relative_to_bucket_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AllocationStep) Reset() {
+	*x = AllocationStep{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationStep) ProtoMessage() {}
+
+func (x *AllocationStep) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationStep.ProtoReflect.Descriptor instead.
+func (*AllocationStep) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AllocationStep) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *AllocationStep) GetType() SplitRuleItemType {
+	if x != nil {
+		return x.Type
+	}
+	return SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_UNSPECIFIED
+}
+
+func (x *AllocationStep) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AllocationStep) GetBaseAmount() string {
+	if x != nil {
+		return x.BaseAmount
+	}
+	return ""
+}
+
+func (x *AllocationStep) GetComputedAmount() string {
+	if x != nil {
+		return x.ComputedAmount
+	}
+	return ""
+}
+
+func (x *AllocationStep) GetRunningRemainder() string {
+	if x != nil {
+		return x.RunningRemainder
+	}
+	return ""
+}
+
+func (x *AllocationStep) GetRelativeToBucketId() string {
+	if x != nil {
+		return x.RelativeToBucketId
+	}
+	return ""
+}
+
+// SplitRuleItem represents a single item of a split rule
+type SplitRuleItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Item ID (UUID as string) - ignored on create
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Target bucket ID (UUID as string) - must be a virtual bucket
+	TargetBucketId string `protobuf:"bytes,2,opt,name=target_bucket_id,json=targetBucketId,proto3" json:"target_bucket_id,omitempty"`
+	// How the amount is computed
+	Type SplitRuleItemType `protobuf:"varint,3,opt,name=type,proto3,enum=wealthflow.v1.SplitRuleItemType" json:"type,omitempty"`
+	// Value as a decimal string - amount for FIXED, percentage (0-100) for PERCENT, ignored for REMAINDER
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Lower number = executed first
+	Priority int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Optional: Bucket ID (UUID as string) a PERCENT item is relative to (instead of the remainder)
+	RelativeToBucketId string `protobuf:"bytes,6,opt,name=relative_to_bucket_id,json=relativeToBucketId,proto3" json:"relative_to_bucket_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SplitRuleItem) Reset() {
+	*x = SplitRuleItem{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitRuleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitRuleItem) ProtoMessage() {}
+
+func (x *SplitRuleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitRuleItem.ProtoReflect.Descriptor instead.
+func (*SplitRuleItem) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SplitRuleItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SplitRuleItem) GetTargetBucketId() string {
+	if x != nil {
+		return x.TargetBucketId
+	}
+	return ""
+}
+
+func (x *SplitRuleItem) GetType() SplitRuleItemType {
+	if x != nil {
+		return x.Type
+	}
+	return SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_UNSPECIFIED
+}
+
+func (x *SplitRuleItem) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SplitRuleItem) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SplitRuleItem) GetRelativeToBucketId() string {
+	if x != nil {
+		return x.RelativeToBucketId
+	}
+	return ""
+}
+
+// CreateSplitRuleRequest represents a request to create a split rule
+type CreateSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the split rule
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Source bucket ID (UUID as string) - must be an income bucket
+	SourceBucketId string `protobuf:"bytes,2,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Items of the split rule (exactly one must be REMAINDER)
+	Items []*SplitRuleItem `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	// Optional: Physical bucket ID (UUID as string) the inflows land in - every target must be one of its
+	// virtual buckets. If empty, it is inferred from the parent of the first target bucket.
+	DestinationPhysicalBucketId string `protobuf:"bytes,4,opt,name=destination_physical_bucket_id,json=destinationPhysicalBucketId,proto3" json:"destination_physical_bucket_id,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *CreateSplitRuleRequest) Reset() {
+	*x = CreateSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSplitRuleRequest) ProtoMessage() {}
+
+func (x *CreateSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateSplitRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSplitRuleRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *CreateSplitRuleRequest) GetItems() []*SplitRuleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateSplitRuleRequest) GetDestinationPhysicalBucketId() string {
+	if x != nil {
+		return x.DestinationPhysicalBucketId
+	}
+	return ""
+}
+
+// CreateSplitRuleResponse returns the created split rule ID and any advisory warnings
+type CreateSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Split rule ID (UUID as string)
+	SplitRuleId string `protobuf:"bytes,1,opt,name=split_rule_id,json=splitRuleId,proto3" json:"split_rule_id,omitempty"`
+	// Non-fatal warnings about likely mistakes (e.g. a PERCENT item of 0)
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSplitRuleResponse) Reset() {
+	*x = CreateSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSplitRuleResponse) ProtoMessage() {}
+
+func (x *CreateSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateSplitRuleResponse) GetSplitRuleId() string {
+	if x != nil {
+		return x.SplitRuleId
+	}
+	return ""
+}
+
+func (x *CreateSplitRuleResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// ReparentVirtualBucketRequest represents a request to move a virtual bucket to a new physical parent
+type ReparentVirtualBucketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Virtual bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// New parent physical bucket ID (UUID as string)
+	NewParentId   string `protobuf:"bytes,2,opt,name=new_parent_id,json=newParentId,proto3" json:"new_parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReparentVirtualBucketRequest) Reset() {
+	*x = ReparentVirtualBucketRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReparentVirtualBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReparentVirtualBucketRequest) ProtoMessage() {}
+
+func (x *ReparentVirtualBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReparentVirtualBucketRequest.ProtoReflect.Descriptor instead.
+func (*ReparentVirtualBucketRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReparentVirtualBucketRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ReparentVirtualBucketRequest) GetNewParentId() string {
+	if x != nil {
+		return x.NewParentId
+	}
+	return ""
+}
+
+// ReparentVirtualBucketResponse returns the updated bucket and the generated records
+type ReparentVirtualBucketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The updated virtual bucket
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Balancing transaction ID (UUID as string) - empty if the bucket had no balance
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Transfer task ID (UUID as string) - empty if the bucket had no balance
+	TransferTaskId string `protobuf:"bytes,3,opt,name=transfer_task_id,json=transferTaskId,proto3" json:"transfer_task_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReparentVirtualBucketResponse) Reset() {
+	*x = ReparentVirtualBucketResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReparentVirtualBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReparentVirtualBucketResponse) ProtoMessage() {}
+
+func (x *ReparentVirtualBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReparentVirtualBucketResponse.ProtoReflect.Descriptor instead.
+func (*ReparentVirtualBucketResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReparentVirtualBucketResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *ReparentVirtualBucketResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReparentVirtualBucketResponse) GetTransferTaskId() string {
+	if x != nil {
+		return x.TransferTaskId
+	}
+	return ""
+}
+
+// ListBucketsByParentRequest represents a request to list a physical bucket's children
+type ListBucketsByParentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Parent physical bucket ID (UUID as string)
+	ParentId      string `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketsByParentRequest) Reset() {
+	*x = ListBucketsByParentRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketsByParentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsByParentRequest) ProtoMessage() {}
+
+func (x *ListBucketsByParentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsByParentRequest.ProtoReflect.Descriptor instead.
+func (*ListBucketsByParentRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListBucketsByParentRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+// ListBucketsByParentResponse returns the child buckets
+type ListBucketsByParentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Child buckets ordered by name
+	Buckets       []*Bucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketsByParentResponse) Reset() {
+	*x = ListBucketsByParentResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketsByParentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsByParentResponse) ProtoMessage() {}
+
+func (x *ListBucketsByParentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsByParentResponse.ProtoReflect.Descriptor instead.
+func (*ListBucketsByParentResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListBucketsByParentResponse) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// GetTransactionRequest represents a request to get a transaction by ID
+type GetTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetTransactionResponse returns the full transaction
+type GetTransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction summary
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Long-form memo (empty if not set)
+	Memo string `protobuf:"bytes,2,opt,name=memo,proto3" json:"memo,omitempty"`
+	// All entries of the transaction (both layers)
+	Entries []*TransactionEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Map of bucket_id (UUID as string) -> bucket_name for all buckets in the entries
+	BucketNames   map[string]string `protobuf:"bytes,4,rep,name=bucket_names,json=bucketNames,proto3" json:"bucket_names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponse) ProtoMessage() {}
+
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *GetTransactionResponse) GetEntries() []*TransactionEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetBucketNames() map[string]string {
+	if x != nil {
+		return x.BucketNames
+	}
+	return nil
+}
+
+// TransactionEntry represents a single double-entry line of a transaction
+type TransactionEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entry ID (UUID as string)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,2,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Amount as a decimal string (absolute value, always positive)
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Entry type: "DEBIT" or "CREDIT"
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// Accounting layer: "PHYSICAL" or "VIRTUAL"
+	Layer         string `protobuf:"bytes,5,opt,name=layer,proto3" json:"layer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionEntry) Reset() {
+	*x = TransactionEntry{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionEntry) ProtoMessage() {}
+
+func (x *TransactionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionEntry.ProtoReflect.Descriptor instead.
+func (*TransactionEntry) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TransactionEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransactionEntry) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *TransactionEntry) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TransactionEntry) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TransactionEntry) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
+}
+
+// SetOpeningBalanceRequest represents a request to set a bucket's starting balance
+type SetOpeningBalanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Physical bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Opening balance as a decimal string (negative for e.g. credit card debt)
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Optional: Virtual child bucket ID (UUID as string) that receives the same balance
+	VirtualBucketId string `protobuf:"bytes,3,opt,name=virtual_bucket_id,json=virtualBucketId,proto3" json:"virtual_bucket_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetOpeningBalanceRequest) Reset() {
+	*x = SetOpeningBalanceRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOpeningBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOpeningBalanceRequest) ProtoMessage() {}
+
+func (x *SetOpeningBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOpeningBalanceRequest.ProtoReflect.Descriptor instead.
+func (*SetOpeningBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetOpeningBalanceRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *SetOpeningBalanceRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *SetOpeningBalanceRequest) GetVirtualBucketId() string {
+	if x != nil {
+		return x.VirtualBucketId
+	}
+	return ""
+}
+
+// SetOpeningBalanceResponse returns the created transaction
+type SetOpeningBalanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Opening balance transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOpeningBalanceResponse) Reset() {
+	*x = SetOpeningBalanceResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOpeningBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOpeningBalanceResponse) ProtoMessage() {}
+
+func (x *SetOpeningBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOpeningBalanceResponse.ProtoReflect.Descriptor instead.
+func (*SetOpeningBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetOpeningBalanceResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetProfitHistoryRequest represents a request for an equity bucket's profit over time
+type GetProfitHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Equity bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Optional: Start of the date range (inclusive, defaults to all history)
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Optional: End of the date range (inclusive, defaults to server time)
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfitHistoryRequest) Reset() {
+	*x = GetProfitHistoryRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfitHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfitHistoryRequest) ProtoMessage() {}
+
+func (x *GetProfitHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfitHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetProfitHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetProfitHistoryRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetProfitHistoryRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetProfitHistoryRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// GetProfitHistoryResponse returns one point per market value date
+type GetProfitHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Points ordered by date ascending
+	Points        []*ProfitPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfitHistoryResponse) Reset() {
+	*x = GetProfitHistoryResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfitHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfitHistoryResponse) ProtoMessage() {}
+
+func (x *GetProfitHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfitHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetProfitHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetProfitHistoryResponse) GetPoints() []*ProfitPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// ProfitPoint represents the unrealized profit at a market value date
+type ProfitPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market value date
+	Date *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Book value as of this date as a decimal string
+	BookValue string `protobuf:"bytes,2,opt,name=book_value,json=bookValue,proto3" json:"book_value,omitempty"`
+	// Market value as a decimal string
+	MarketValue string `protobuf:"bytes,3,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	// Profit (market_value - book_value) as a decimal string, negative for a loss
+	Profit        string `protobuf:"bytes,4,opt,name=profit,proto3" json:"profit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfitPoint) Reset() {
+	*x = ProfitPoint{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfitPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfitPoint) ProtoMessage() {}
+
+func (x *ProfitPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfitPoint.ProtoReflect.Descriptor instead.
+func (*ProfitPoint) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ProfitPoint) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ProfitPoint) GetBookValue() string {
+	if x != nil {
+		return x.BookValue
+	}
+	return ""
+}
+
+func (x *ProfitPoint) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+func (x *ProfitPoint) GetProfit() string {
+	if x != nil {
+		return x.Profit
+	}
+	return ""
+}
+
+// ListMarketValueHistoryRequest represents a request to list a bucket's market value history
+type ListMarketValueHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Maximum number of entries to return (defaults to 100 if not provided)
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Number of entries to skip (for pagination)
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// If true, entries are ordered oldest-first (e.g. for charting); newest-first otherwise
+	Ascending     bool `protobuf:"varint,4,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMarketValueHistoryRequest) Reset() {
+	*x = ListMarketValueHistoryRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMarketValueHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketValueHistoryRequest) ProtoMessage() {}
+
+func (x *ListMarketValueHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketValueHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListMarketValueHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListMarketValueHistoryRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *ListMarketValueHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMarketValueHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListMarketValueHistoryRequest) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+// ListMarketValueHistoryResponse returns a page of market value history
+type ListMarketValueHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Market value entries
+	Entries []*MarketValueEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Total count of entries for the bucket (for pagination)
+	TotalCount    int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMarketValueHistoryResponse) Reset() {
+	*x = ListMarketValueHistoryResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMarketValueHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketValueHistoryResponse) ProtoMessage() {}
+
+func (x *ListMarketValueHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketValueHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListMarketValueHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListMarketValueHistoryResponse) GetEntries() []*MarketValueEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListMarketValueHistoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// MarketValueEntry represents a single market value history point
+type MarketValueEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entry ID (UUID as string)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Date of the market value
+	Date *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	// Market value as a decimal string
+	MarketValue   string `protobuf:"bytes,3,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketValueEntry) Reset() {
+	*x = MarketValueEntry{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketValueEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketValueEntry) ProtoMessage() {}
+
+func (x *MarketValueEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketValueEntry.ProtoReflect.Descriptor instead.
+func (*MarketValueEntry) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MarketValueEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MarketValueEntry) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *MarketValueEntry) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+// DeleteTransactionRequest represents a request to delete a transaction
+type DeleteTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionRequest) Reset() {
+	*x = DeleteTransactionRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionRequest) ProtoMessage() {}
+
+func (x *DeleteTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// DeleteTransactionResponse confirms the deletion
+type DeleteTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionResponse) Reset() {
+	*x = DeleteTransactionResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionResponse) ProtoMessage() {}
+
+func (x *DeleteTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{49}
+}
+
+// GetTransactionCountRequest represents a request to count transactions
+type GetTransactionCountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Filter by bucket ID (UUID as string) - counts transactions involving this bucket
+	BucketId      string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionCountRequest) Reset() {
+	*x = GetTransactionCountRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionCountRequest) ProtoMessage() {}
+
+func (x *GetTransactionCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionCountRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionCountRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetTransactionCountRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+// GetTransactionCountResponse returns the number of matching transactions
+type GetTransactionCountResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of transactions
+	Count         int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionCountResponse) Reset() {
+	*x = GetTransactionCountResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionCountResponse) ProtoMessage() {}
+
+func (x *GetTransactionCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionCountResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionCountResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetTransactionCountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// GetBucketsSummaryRequest represents a request for aggregate bucket information
+type GetBucketsSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketsSummaryRequest) Reset() {
+	*x = GetBucketsSummaryRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketsSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketsSummaryRequest) ProtoMessage() {}
+
+func (x *GetBucketsSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketsSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketsSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{52}
+}
+
+// GetBucketsSummaryResponse returns aggregate bucket information
+type GetBucketsSummaryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Map of bucket type (e.g. "PHYSICAL", "VIRTUAL") -> number of buckets of that type
+	CountsByType map[string]int32 `protobuf:"bytes,1,rep,name=counts_by_type,json=countsByType,proto3" json:"counts_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Sum of all PHYSICAL bucket balances as a decimal string
+	TotalLiquidity string `protobuf:"bytes,2,opt,name=total_liquidity,json=totalLiquidity,proto3" json:"total_liquidity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetBucketsSummaryResponse) Reset() {
+	*x = GetBucketsSummaryResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketsSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketsSummaryResponse) ProtoMessage() {}
+
+func (x *GetBucketsSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketsSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketsSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetBucketsSummaryResponse) GetCountsByType() map[string]int32 {
+	if x != nil {
+		return x.CountsByType
+	}
+	return nil
+}
+
+func (x *GetBucketsSummaryResponse) GetTotalLiquidity() string {
+	if x != nil {
+		return x.TotalLiquidity
+	}
+	return ""
+}
+
+// GetSplitRuleRequest represents a request to get the split rule of an income bucket
+type GetSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source bucket ID (UUID as string) - the income bucket the rule applies to
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSplitRuleRequest) Reset() {
+	*x = GetSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSplitRuleRequest) ProtoMessage() {}
+
+func (x *GetSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*GetSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetSplitRuleRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+// GetSplitRuleResponse returns the split rule
+type GetSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Split rule ID (UUID as string)
+	SplitRuleId string `protobuf:"bytes,1,opt,name=split_rule_id,json=splitRuleId,proto3" json:"split_rule_id,omitempty"`
+	// Name of the split rule
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Source bucket ID (UUID as string)
+	SourceBucketId string `protobuf:"bytes,3,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Items of the split rule in priority order
+	Items []*SplitRuleItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// Sum of all FIXED item values as a decimal string - smaller inflows cannot be allocated
+	TotalFixed string `protobuf:"bytes,5,opt,name=total_fixed,json=totalFixed,proto3" json:"total_fixed,omitempty"`
+	// Physical bucket ID (UUID as string) the inflows land in - empty if the rule infers it from its first target
+	DestinationPhysicalBucketId string `protobuf:"bytes,6,opt,name=destination_physical_bucket_id,json=destinationPhysicalBucketId,proto3" json:"destination_physical_bucket_id,omitempty"`
+	// Sum of PERCENT-of-remainder item values as a decimal string (relative items excluded)
+	TotalPercent string `protobuf:"bytes,7,opt,name=total_percent,json=totalPercent,proto3" json:"total_percent,omitempty"`
+	// Share (0-100) of the amount left after FIXED items that reaches the REMAINDER item, as a decimal string
+	RemainderPercent string `protobuf:"bytes,8,opt,name=remainder_percent,json=remainderPercent,proto3" json:"remainder_percent,omitempty"`
+	// True if the REMAINDER item receives a meaningful share rather than only leftovers
+	HasMeaningfulRemainder bool `protobuf:"varint,9,opt,name=has_meaningful_remainder,json=hasMeaningfulRemainder,proto3" json:"has_meaningful_remainder,omitempty"`
+	// False if the rule is disabled: external inflows of the source bucket are then handled as if it had no rule
+	Active        bool `protobuf:"varint,10,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSplitRuleResponse) Reset() {
+	*x = GetSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSplitRuleResponse) ProtoMessage() {}
+
+func (x *GetSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*GetSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetSplitRuleResponse) GetSplitRuleId() string {
+	if x != nil {
+		return x.SplitRuleId
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetItems() []*SplitRuleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetSplitRuleResponse) GetTotalFixed() string {
+	if x != nil {
+		return x.TotalFixed
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetDestinationPhysicalBucketId() string {
+	if x != nil {
+		return x.DestinationPhysicalBucketId
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetTotalPercent() string {
+	if x != nil {
+		return x.TotalPercent
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetRemainderPercent() string {
+	if x != nil {
+		return x.RemainderPercent
+	}
+	return ""
+}
+
+func (x *GetSplitRuleResponse) GetHasMeaningfulRemainder() bool {
+	if x != nil {
+		return x.HasMeaningfulRemainder
+	}
+	return false
+}
+
+func (x *GetSplitRuleResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// ListTransferTasksRequest represents a request to list transfer tasks
+type ListTransferTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// If true, completed tasks are included; only pending tasks are returned otherwise
+	IncludeCompleted bool `protobuf:"varint,1,opt,name=include_completed,json=includeCompleted,proto3" json:"include_completed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListTransferTasksRequest) Reset() {
+	*x = ListTransferTasksRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransferTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferTasksRequest) ProtoMessage() {}
+
+func (x *ListTransferTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTransferTasksRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListTransferTasksRequest) GetIncludeCompleted() bool {
+	if x != nil {
+		return x.IncludeCompleted
+	}
+	return false
+}
+
+// ListTransferTasksResponse returns the transfer tasks, newest first
+type ListTransferTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TransferTask        `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransferTasksResponse) Reset() {
+	*x = ListTransferTasksResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransferTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferTasksResponse) ProtoMessage() {}
+
+func (x *ListTransferTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTransferTasksResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListTransferTasksResponse) GetTasks() []*TransferTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// TransferTask represents a pending (or completed) move of money between physical buckets
+type TransferTask struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID (UUID as string)
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Transaction that caused this task (UUID as string)
+	RelatedTransactionId string `protobuf:"bytes,2,opt,name=related_transaction_id,json=relatedTransactionId,proto3" json:"related_transaction_id,omitempty"`
+	// Transaction that resolved this task (UUID as string) - empty until completed
+	CompletedTransactionId string `protobuf:"bytes,3,opt,name=completed_transaction_id,json=completedTransactionId,proto3" json:"completed_transaction_id,omitempty"`
+	// Source physical bucket ID (UUID as string)
+	FromPhysicalBucketId string `protobuf:"bytes,4,opt,name=from_physical_bucket_id,json=fromPhysicalBucketId,proto3" json:"from_physical_bucket_id,omitempty"`
+	// Destination physical bucket ID (UUID as string)
+	ToPhysicalBucketId string `protobuf:"bytes,5,opt,name=to_physical_bucket_id,json=toPhysicalBucketId,proto3" json:"to_physical_bucket_id,omitempty"`
+	// Amount to move as a decimal string
+	Amount string `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Whether the move has been done
+	IsCompleted bool `protobuf:"varint,7,opt,name=is_completed,json=isCompleted,proto3" json:"is_completed,omitempty"`
+	// Description copied from the originating transaction (e.g. "Transfer to Investment")
+	Description string `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	// When the task was generated
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferTask) Reset() {
+	*x = TransferTask{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferTask) ProtoMessage() {}
+
+func (x *TransferTask) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferTask.ProtoReflect.Descriptor instead.
+func (*TransferTask) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TransferTask) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferTask) GetRelatedTransactionId() string {
+	if x != nil {
+		return x.RelatedTransactionId
+	}
+	return ""
+}
+
+func (x *TransferTask) GetCompletedTransactionId() string {
+	if x != nil {
+		return x.CompletedTransactionId
+	}
+	return ""
+}
+
+func (x *TransferTask) GetFromPhysicalBucketId() string {
+	if x != nil {
+		return x.FromPhysicalBucketId
+	}
+	return ""
+}
+
+func (x *TransferTask) GetToPhysicalBucketId() string {
+	if x != nil {
+		return x.ToPhysicalBucketId
+	}
+	return ""
+}
+
+func (x *TransferTask) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TransferTask) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *TransferTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TransferTask) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// MergeCategoryBucketsRequest represents a request to merge one expense category into another
+type MergeCategoryBucketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Expense bucket to merge and archive (UUID as string)
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Expense bucket that receives the entries (UUID as string)
+	TargetBucketId string `protobuf:"bytes,2,opt,name=target_bucket_id,json=targetBucketId,proto3" json:"target_bucket_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeCategoryBucketsRequest) Reset() {
+	*x = MergeCategoryBucketsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoryBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoryBucketsRequest) ProtoMessage() {}
+
+func (x *MergeCategoryBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoryBucketsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCategoryBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MergeCategoryBucketsRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *MergeCategoryBucketsRequest) GetTargetBucketId() string {
+	if x != nil {
+		return x.TargetBucketId
+	}
+	return ""
+}
+
+// MergeCategoryBucketsResponse returns the outcome of the merge
+type MergeCategoryBucketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of transaction entries re-pointed to the target bucket
+	RepointedEntries int32 `protobuf:"varint,1,opt,name=repointed_entries,json=repointedEntries,proto3" json:"repointed_entries,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MergeCategoryBucketsResponse) Reset() {
+	*x = MergeCategoryBucketsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoryBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoryBucketsResponse) ProtoMessage() {}
+
+func (x *MergeCategoryBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoryBucketsResponse.ProtoReflect.Descriptor instead.
+func (*MergeCategoryBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MergeCategoryBucketsResponse) GetRepointedEntries() int32 {
+	if x != nil {
+		return x.RepointedEntries
+	}
+	return 0
+}
+
+// GetInvestmentProfitRequest represents a request for a bucket's current profit/loss
+type GetInvestmentProfitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bucket ID (UUID as string)
+	BucketId      string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvestmentProfitRequest) Reset() {
+	*x = GetInvestmentProfitRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvestmentProfitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvestmentProfitRequest) ProtoMessage() {}
+
+func (x *GetInvestmentProfitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvestmentProfitRequest.ProtoReflect.Descriptor instead.
+func (*GetInvestmentProfitRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetInvestmentProfitRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+// GetInvestmentProfitResponse returns the profit/loss and the values it is based on
+type GetInvestmentProfitResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Book value (invested cash) as a decimal string
+	BookValue string `protobuf:"bytes,1,opt,name=book_value,json=bookValue,proto3" json:"book_value,omitempty"`
+	// Latest market value as a decimal string (equals book_value if no history exists)
+	MarketValue string `protobuf:"bytes,2,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	// Profit (market_value - book_value) as a decimal string, negative for a loss
+	AbsoluteProfit string `protobuf:"bytes,3,opt,name=absolute_profit,json=absoluteProfit,proto3" json:"absolute_profit,omitempty"`
+	// Percentage return (absolute_profit / book_value * 100) as a decimal string - empty if book_value is 0
+	PercentProfit string `protobuf:"bytes,4,opt,name=percent_profit,json=percentProfit,proto3" json:"percent_profit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvestmentProfitResponse) Reset() {
+	*x = GetInvestmentProfitResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvestmentProfitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvestmentProfitResponse) ProtoMessage() {}
+
+func (x *GetInvestmentProfitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvestmentProfitResponse.ProtoReflect.Descriptor instead.
+func (*GetInvestmentProfitResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetInvestmentProfitResponse) GetBookValue() string {
+	if x != nil {
+		return x.BookValue
+	}
+	return ""
+}
+
+func (x *GetInvestmentProfitResponse) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+func (x *GetInvestmentProfitResponse) GetAbsoluteProfit() string {
+	if x != nil {
+		return x.AbsoluteProfit
+	}
+	return ""
+}
+
+func (x *GetInvestmentProfitResponse) GetPercentProfit() string {
+	if x != nil {
+		return x.PercentProfit
+	}
+	return ""
+}
+
+// ValidateSplitRuleRequest represents a split rule to check without persisting it
+type ValidateSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Source bucket ID (UUID as string)
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Items of the split rule (exactly one must be REMAINDER)
+	Items         []*SplitRuleItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateSplitRuleRequest) Reset() {
+	*x = ValidateSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateSplitRuleRequest) ProtoMessage() {}
+
+func (x *ValidateSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*ValidateSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ValidateSplitRuleRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *ValidateSplitRuleRequest) GetItems() []*SplitRuleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ValidateSplitRuleResponse returns the validation result
+type ValidateSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the rule could be saved as is
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// The validation error - empty if valid
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Non-fatal warnings about likely mistakes (e.g. PERCENT items adding up to 100%)
+	Warnings      []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateSplitRuleResponse) Reset() {
+	*x = ValidateSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateSplitRuleResponse) ProtoMessage() {}
+
+func (x *ValidateSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*ValidateSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ValidateSplitRuleResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateSplitRuleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateSplitRuleResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// RecalculateBalancesRequest represents a request to repair bucket balance drift
+type RecalculateBalancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecalculateBalancesRequest) Reset() {
+	*x = RecalculateBalancesRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecalculateBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateBalancesRequest) ProtoMessage() {}
+
+func (x *RecalculateBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateBalancesRequest.ProtoReflect.Descriptor instead.
+func (*RecalculateBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{65}
+}
+
+// RecalculateBalancesResponse returns what was corrected
+type RecalculateBalancesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of buckets whose stored balance was corrected
+	BucketsCorrected int32 `protobuf:"varint,1,opt,name=buckets_corrected,json=bucketsCorrected,proto3" json:"buckets_corrected,omitempty"`
+	// Sum of the absolute corrections as a decimal string
+	TotalDrift    string `protobuf:"bytes,2,opt,name=total_drift,json=totalDrift,proto3" json:"total_drift,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecalculateBalancesResponse) Reset() {
+	*x = RecalculateBalancesResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecalculateBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateBalancesResponse) ProtoMessage() {}
+
+func (x *RecalculateBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateBalancesResponse.ProtoReflect.Descriptor instead.
+func (*RecalculateBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RecalculateBalancesResponse) GetBucketsCorrected() int32 {
+	if x != nil {
+		return x.BucketsCorrected
+	}
+	return 0
+}
+
+func (x *RecalculateBalancesResponse) GetTotalDrift() string {
+	if x != nil {
+		return x.TotalDrift
+	}
+	return ""
+}
+
+// SetBucketGoalRequest represents a request to set a bucket's goal
+type SetBucketGoalRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Goal amount as a decimal string - must be positive; empty clears the goal
+	GoalAmount    string `protobuf:"bytes,2,opt,name=goal_amount,json=goalAmount,proto3" json:"goal_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBucketGoalRequest) Reset() {
+	*x = SetBucketGoalRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBucketGoalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBucketGoalRequest) ProtoMessage() {}
+
+func (x *SetBucketGoalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBucketGoalRequest.ProtoReflect.Descriptor instead.
+func (*SetBucketGoalRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetBucketGoalRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *SetBucketGoalRequest) GetGoalAmount() string {
+	if x != nil {
+		return x.GoalAmount
+	}
+	return ""
+}
+
+// SetBucketGoalResponse returns the updated bucket (including its progress)
+type SetBucketGoalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *Bucket                `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBucketGoalResponse) Reset() {
+	*x = SetBucketGoalResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBucketGoalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBucketGoalResponse) ProtoMessage() {}
+
+func (x *SetBucketGoalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBucketGoalResponse.ProtoReflect.Descriptor instead.
+func (*SetBucketGoalResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetBucketGoalResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+// ListBucketGoalsRequest represents a request to list bucket goals
+type ListBucketGoalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketGoalsRequest) Reset() {
+	*x = ListBucketGoalsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketGoalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketGoalsRequest) ProtoMessage() {}
+
+func (x *ListBucketGoalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketGoalsRequest.ProtoReflect.Descriptor instead.
+func (*ListBucketGoalsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{69}
+}
+
+// BucketGoal represents a virtual bucket's progress towards its goal
+type BucketGoal struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The bucket (includes goal_amount and goal_progress)
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Percentage of the goal reached as a decimal string (e.g. "80")
+	Progress string `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Whether the balance has reached or exceeded the goal
+	Met           bool `protobuf:"varint,3,opt,name=met,proto3" json:"met,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BucketGoal) Reset() {
+	*x = BucketGoal{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BucketGoal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketGoal) ProtoMessage() {}
+
+func (x *BucketGoal) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketGoal.ProtoReflect.Descriptor instead.
+func (*BucketGoal) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *BucketGoal) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *BucketGoal) GetProgress() string {
+	if x != nil {
+		return x.Progress
+	}
+	return ""
+}
+
+func (x *BucketGoal) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+// ListBucketGoalsResponse contains the bucket goals, sorted by progress descending
+type ListBucketGoalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Goals         []*BucketGoal          `protobuf:"bytes,1,rep,name=goals,proto3" json:"goals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketGoalsResponse) Reset() {
+	*x = ListBucketGoalsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketGoalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketGoalsResponse) ProtoMessage() {}
+
+func (x *ListBucketGoalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketGoalsResponse.ProtoReflect.Descriptor instead.
+func (*ListBucketGoalsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListBucketGoalsResponse) GetGoals() []*BucketGoal {
+	if x != nil {
+		return x.Goals
+	}
+	return nil
+}
+
+// GetStatusRequest represents a request for the server's readiness status
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{72}
+}
+
+// GetStatusResponse describes whether the server is ready to handle requests
+type GetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when every check below passed
+	Ready bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	// Whether the database answered a ping
+	DatabaseConnected bool `protobuf:"varint,2,opt,name=database_connected,json=databaseConnected,proto3" json:"database_connected,omitempty"`
+	// Whether all system buckets (fixed UUIDs) exist
+	SystemBucketsSeeded bool `protobuf:"varint,3,opt,name=system_buckets_seeded,json=systemBucketsSeeded,proto3" json:"system_buckets_seeded,omitempty"`
+	// Human-readable summary (e.g. "ok", "database unreachable")
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// Build version ("dev" when not injected at compile time)
+	Version string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	// Git commit the server was built from ("dev" when not injected)
+	Commit string `protobuf:"bytes,6,opt,name=commit,proto3" json:"commit,omitempty"`
+	// Build timestamp ("dev" when not injected)
+	BuildTime     string `protobuf:"bytes,7,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetStatusResponse) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetDatabaseConnected() bool {
+	if x != nil {
+		return x.DatabaseConnected
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetSystemBucketsSeeded() bool {
+	if x != nil {
+		return x.SystemBucketsSeeded
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+// CompleteTransferTaskRequest represents a request to complete a transfer task
+type CompleteTransferTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transfer task ID (UUID as string)
+	TaskId        string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTransferTaskRequest) Reset() {
+	*x = CompleteTransferTaskRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTransferTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTransferTaskRequest) ProtoMessage() {}
+
+func (x *CompleteTransferTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTransferTaskRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTransferTaskRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CompleteTransferTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// CompleteTransferTaskResponse returns the completed task (with completed_transaction_id set)
+type CompleteTransferTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TransferTask          `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTransferTaskResponse) Reset() {
+	*x = CompleteTransferTaskResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTransferTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTransferTaskResponse) ProtoMessage() {}
+
+func (x *CompleteTransferTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTransferTaskResponse.ProtoReflect.Descriptor instead.
+func (*CompleteTransferTaskResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CompleteTransferTaskResponse) GetTask() *TransferTask {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// GetCategorySpendingTrendRequest represents a request for a category's spending per period
+type GetCategorySpendingTrendRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Expense category bucket ID (UUID as string)
+	CategoryBucketId string `protobuf:"bytes,1,opt,name=category_bucket_id,json=categoryBucketId,proto3" json:"category_bucket_id,omitempty"`
+	// Optional: Start of the date range (inclusive, defaults to all history)
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Optional: End of the date range (inclusive, defaults to server time)
+	EndDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Optional: Period length - "day", "week", "month" or "year" (defaults to "month")
+	Granularity   string `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategorySpendingTrendRequest) Reset() {
+	*x = GetCategorySpendingTrendRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategorySpendingTrendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategorySpendingTrendRequest) ProtoMessage() {}
+
+func (x *GetCategorySpendingTrendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategorySpendingTrendRequest.ProtoReflect.Descriptor instead.
+func (*GetCategorySpendingTrendRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetCategorySpendingTrendRequest) GetCategoryBucketId() string {
+	if x != nil {
+		return x.CategoryBucketId
+	}
+	return ""
+}
+
+func (x *GetCategorySpendingTrendRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetCategorySpendingTrendRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetCategorySpendingTrendRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+// GetCategorySpendingTrendResponse returns one point per period with spending
+type GetCategorySpendingTrendResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Points ordered by period ascending; periods without spending are omitted
+	Points        []*SpendingPeriod `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategorySpendingTrendResponse) Reset() {
+	*x = GetCategorySpendingTrendResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategorySpendingTrendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategorySpendingTrendResponse) ProtoMessage() {}
+
+func (x *GetCategorySpendingTrendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategorySpendingTrendResponse.ProtoReflect.Descriptor instead.
+func (*GetCategorySpendingTrendResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetCategorySpendingTrendResponse) GetPoints() []*SpendingPeriod {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// SpendingPeriod represents the spending within a single period
+type SpendingPeriod struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the period
+	Period *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	// Total spent (net of refunds) as a decimal string
+	Total         string `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpendingPeriod) Reset() {
+	*x = SpendingPeriod{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingPeriod) ProtoMessage() {}
+
+func (x *SpendingPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingPeriod.ProtoReflect.Descriptor instead.
+func (*SpendingPeriod) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *SpendingPeriod) GetPeriod() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *SpendingPeriod) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+// CreateEquityBucketRequest represents a request to create an equity bucket with an initial book value
+type CreateEquityBucketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the bucket
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Initial book value (what was paid) as a decimal string - must be positive
+	BookValue string `protobuf:"bytes,2,opt,name=book_value,json=bookValue,proto3" json:"book_value,omitempty"`
+	// Optional: Initial market value as a decimal string (no market value entry is recorded if empty)
+	MarketValue   string `protobuf:"bytes,3,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEquityBucketRequest) Reset() {
+	*x = CreateEquityBucketRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEquityBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEquityBucketRequest) ProtoMessage() {}
+
+func (x *CreateEquityBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEquityBucketRequest.ProtoReflect.Descriptor instead.
+func (*CreateEquityBucketRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CreateEquityBucketRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketRequest) GetBookValue() string {
+	if x != nil {
+		return x.BookValue
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketRequest) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+// CreateEquityBucketResponse returns the created bucket and its first profit snapshot
+type CreateEquityBucketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The created equity bucket
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Initial book value transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Initial market value entry ID (UUID as string) - empty if no market value was given
+	MarketValueEntryId string `protobuf:"bytes,3,opt,name=market_value_entry_id,json=marketValueEntryId,proto3" json:"market_value_entry_id,omitempty"`
+	// Book value as a decimal string
+	BookValue string `protobuf:"bytes,4,opt,name=book_value,json=bookValue,proto3" json:"book_value,omitempty"`
+	// Market value as a decimal string (equals book_value if no market value was given)
+	MarketValue string `protobuf:"bytes,5,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	// Profit (market_value - book_value) as a decimal string, 0 if the market value equals the book value
+	AbsoluteProfit string `protobuf:"bytes,6,opt,name=absolute_profit,json=absoluteProfit,proto3" json:"absolute_profit,omitempty"`
+	// Percentage return (absolute_profit / book_value * 100) as a decimal string
+	PercentProfit string `protobuf:"bytes,7,opt,name=percent_profit,json=percentProfit,proto3" json:"percent_profit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEquityBucketResponse) Reset() {
+	*x = CreateEquityBucketResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEquityBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEquityBucketResponse) ProtoMessage() {}
+
+func (x *CreateEquityBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEquityBucketResponse.ProtoReflect.Descriptor instead.
+func (*CreateEquityBucketResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CreateEquityBucketResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *CreateEquityBucketResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketResponse) GetMarketValueEntryId() string {
+	if x != nil {
+		return x.MarketValueEntryId
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketResponse) GetBookValue() string {
+	if x != nil {
+		return x.BookValue
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketResponse) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketResponse) GetAbsoluteProfit() string {
+	if x != nil {
+		return x.AbsoluteProfit
+	}
+	return ""
+}
+
+func (x *CreateEquityBucketResponse) GetPercentProfit() string {
+	if x != nil {
+		return x.PercentProfit
+	}
+	return ""
+}
+
+// AddEquityPurchaseRequest represents a purchase of units of an equity bucket
+type AddEquityPurchaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Equity bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Units bought as a decimal string, may be fractional (e.g., "0.25")
+	Quantity string `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Price paid per unit as a decimal string
+	UnitPrice string `protobuf:"bytes,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	// Optional: Virtual bucket the purchase is paid from (UUID as string); its parent physical bucket pays too
+	// Required unless this is the bucket's first purchase (an initial holding)
+	SourceVirtualBucketId string `protobuf:"bytes,4,opt,name=source_virtual_bucket_id,json=sourceVirtualBucketId,proto3" json:"source_virtual_bucket_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AddEquityPurchaseRequest) Reset() {
+	*x = AddEquityPurchaseRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEquityPurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEquityPurchaseRequest) ProtoMessage() {}
+
+func (x *AddEquityPurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEquityPurchaseRequest.ProtoReflect.Descriptor instead.
+func (*AddEquityPurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *AddEquityPurchaseRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *AddEquityPurchaseRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AddEquityPurchaseRequest) GetUnitPrice() string {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return ""
+}
+
+func (x *AddEquityPurchaseRequest) GetSourceVirtualBucketId() string {
+	if x != nil {
+		return x.SourceVirtualBucketId
+	}
+	return ""
+}
+
+// AddEquityPurchaseResponse returns the updated holding
+type AddEquityPurchaseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Units held after the purchase as a decimal string
+	Quantity string `protobuf:"bytes,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Weighted-average cost per unit after the purchase as a decimal string
+	AvgCost string `protobuf:"bytes,2,opt,name=avg_cost,json=avgCost,proto3" json:"avg_cost,omitempty"`
+	// Book value transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddEquityPurchaseResponse) Reset() {
+	*x = AddEquityPurchaseResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddEquityPurchaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddEquityPurchaseResponse) ProtoMessage() {}
+
+func (x *AddEquityPurchaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddEquityPurchaseResponse.ProtoReflect.Descriptor instead.
+func (*AddEquityPurchaseResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *AddEquityPurchaseResponse) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AddEquityPurchaseResponse) GetAvgCost() string {
+	if x != nil {
+		return x.AvgCost
+	}
+	return ""
+}
+
+func (x *AddEquityPurchaseResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetTransactionsByDateHistogramRequest represents a request for transaction counts per day
+type GetTransactionsByDateHistogramRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Start of the date range (inclusive, defaults to all history)
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Optional: End of the date range (inclusive, defaults to server time)
+	EndDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Optional: Only count transactions involving this bucket (UUID as string)
+	BucketId      string `protobuf:"bytes,3,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsByDateHistogramRequest) Reset() {
+	*x = GetTransactionsByDateHistogramRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsByDateHistogramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsByDateHistogramRequest) ProtoMessage() {}
+
+func (x *GetTransactionsByDateHistogramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsByDateHistogramRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionsByDateHistogramRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetTransactionsByDateHistogramRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetTransactionsByDateHistogramRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetTransactionsByDateHistogramRequest) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+// GetTransactionsByDateHistogramResponse returns one entry per day with transactions
+type GetTransactionsByDateHistogramResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Days ordered ascending; days without transactions are omitted
+	Days          []*TransactionDayCount `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsByDateHistogramResponse) Reset() {
+	*x = GetTransactionsByDateHistogramResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsByDateHistogramResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsByDateHistogramResponse) ProtoMessage() {}
+
+func (x *GetTransactionsByDateHistogramResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsByDateHistogramResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionsByDateHistogramResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetTransactionsByDateHistogramResponse) GetDays() []*TransactionDayCount {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// TransactionDayCount represents the number of transactions on a single day
+type TransactionDayCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the day
+	Date *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Number of transactions dated that day
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionDayCount) Reset() {
+	*x = TransactionDayCount{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionDayCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionDayCount) ProtoMessage() {}
+
+func (x *TransactionDayCount) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionDayCount.ProtoReflect.Descriptor instead.
+func (*TransactionDayCount) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *TransactionDayCount) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *TransactionDayCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ListOverdueTransferTasksRequest represents a request for stale pending transfer tasks
+type ListOverdueTransferTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Age threshold in days - only tasks created more than this many days ago are returned (must be non-negative)
+	Days          int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverdueTransferTasksRequest) Reset() {
+	*x = ListOverdueTransferTasksRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverdueTransferTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverdueTransferTasksRequest) ProtoMessage() {}
+
+func (x *ListOverdueTransferTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverdueTransferTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListOverdueTransferTasksRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListOverdueTransferTasksRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+// ListOverdueTransferTasksResponse returns the overdue transfer tasks, oldest first
+type ListOverdueTransferTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TransferTask        `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverdueTransferTasksResponse) Reset() {
+	*x = ListOverdueTransferTasksResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverdueTransferTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverdueTransferTasksResponse) ProtoMessage() {}
+
+func (x *ListOverdueTransferTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverdueTransferTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListOverdueTransferTasksResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListOverdueTransferTasksResponse) GetTasks() []*TransferTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// GetExpenseBreakdownByPhysicalBucketRequest represents a request for spending per physical bucket
+type GetExpenseBreakdownByPhysicalBucketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Start of the date range (inclusive, defaults to all history)
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Optional: End of the date range (inclusive, defaults to server time)
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketRequest) Reset() {
+	*x = GetExpenseBreakdownByPhysicalBucketRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpenseBreakdownByPhysicalBucketRequest) ProtoMessage() {}
+
+func (x *GetExpenseBreakdownByPhysicalBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpenseBreakdownByPhysicalBucketRequest.ProtoReflect.Descriptor instead.
+func (*GetExpenseBreakdownByPhysicalBucketRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// GetExpenseBreakdownByPhysicalBucketResponse returns the spending of each physical bucket
+type GetExpenseBreakdownByPhysicalBucketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by total_spent descending; physical buckets without spending are omitted
+	Buckets       []*PhysicalBucketSpending `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketResponse) Reset() {
+	*x = GetExpenseBreakdownByPhysicalBucketResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpenseBreakdownByPhysicalBucketResponse) ProtoMessage() {}
+
+func (x *GetExpenseBreakdownByPhysicalBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpenseBreakdownByPhysicalBucketResponse.ProtoReflect.Descriptor instead.
+func (*GetExpenseBreakdownByPhysicalBucketResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GetExpenseBreakdownByPhysicalBucketResponse) GetBuckets() []*PhysicalBucketSpending {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// PhysicalBucketSpending represents the money that left a single physical bucket
+type PhysicalBucketSpending struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Physical bucket ID (UUID as string)
+	PhysicalBucketId string `protobuf:"bytes,1,opt,name=physical_bucket_id,json=physicalBucketId,proto3" json:"physical_bucket_id,omitempty"`
+	// Physical bucket name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Sum of the bucket's PHYSICAL-layer CREDIT entries as a decimal string
+	TotalSpent    string `protobuf:"bytes,3,opt,name=total_spent,json=totalSpent,proto3" json:"total_spent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhysicalBucketSpending) Reset() {
+	*x = PhysicalBucketSpending{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhysicalBucketSpending) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhysicalBucketSpending) ProtoMessage() {}
+
+func (x *PhysicalBucketSpending) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhysicalBucketSpending.ProtoReflect.Descriptor instead.
+func (*PhysicalBucketSpending) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *PhysicalBucketSpending) GetPhysicalBucketId() string {
+	if x != nil {
+		return x.PhysicalBucketId
+	}
+	return ""
+}
+
+func (x *PhysicalBucketSpending) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PhysicalBucketSpending) GetTotalSpent() string {
+	if x != nil {
+		return x.TotalSpent
+	}
+	return ""
+}
+
+// GetInflowAllocationSharesRequest represents a request for the effective allocation of external inflows
+type GetInflowAllocationSharesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Start of the date range (inclusive, defaults to all history)
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Optional: End of the date range (inclusive, defaults to server time)
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInflowAllocationSharesRequest) Reset() {
+	*x = GetInflowAllocationSharesRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInflowAllocationSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInflowAllocationSharesRequest) ProtoMessage() {}
+
+func (x *GetInflowAllocationSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInflowAllocationSharesRequest.ProtoReflect.Descriptor instead.
+func (*GetInflowAllocationSharesRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetInflowAllocationSharesRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetInflowAllocationSharesRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// GetInflowAllocationSharesResponse returns the share of external inflows received by each virtual bucket
+type GetInflowAllocationSharesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by total descending; buckets that received nothing are omitted
+	Shares        []*AllocationShare `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInflowAllocationSharesResponse) Reset() {
+	*x = GetInflowAllocationSharesResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInflowAllocationSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInflowAllocationSharesResponse) ProtoMessage() {}
+
+func (x *GetInflowAllocationSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInflowAllocationSharesResponse.ProtoReflect.Descriptor instead.
+func (*GetInflowAllocationSharesResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetInflowAllocationSharesResponse) GetShares() []*AllocationShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+// AllocationShare represents the part of the external inflows received by a single virtual bucket
+type AllocationShare struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The virtual bucket
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Sum received from external inflows as a decimal string
+	Total string `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Percentage of all allocated inflow money as a decimal string (e.g. "33.33")
+	SharePercent  string `protobuf:"bytes,3,opt,name=share_percent,json=sharePercent,proto3" json:"share_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationShare) Reset() {
+	*x = AllocationShare{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationShare) ProtoMessage() {}
+
+func (x *AllocationShare) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationShare.ProtoReflect.Descriptor instead.
+func (*AllocationShare) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *AllocationShare) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *AllocationShare) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *AllocationShare) GetSharePercent() string {
+	if x != nil {
+		return x.SharePercent
+	}
+	return ""
+}
+
+// VerifyLedgerIntegrityRequest represents a request to validate all stored transactions
+type VerifyLedgerIntegrityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLedgerIntegrityRequest) Reset() {
+	*x = VerifyLedgerIntegrityRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLedgerIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLedgerIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyLedgerIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLedgerIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLedgerIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{94}
+}
+
+// VerifyLedgerIntegrityResponse returns the outcome of the ledger integrity check
+type VerifyLedgerIntegrityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of transactions validated
+	TransactionsChecked int32 `protobuf:"varint,1,opt,name=transactions_checked,json=transactionsChecked,proto3" json:"transactions_checked,omitempty"`
+	// Transactions failing validation (empty when the ledger is consistent)
+	Violations    []*LedgerViolation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLedgerIntegrityResponse) Reset() {
+	*x = VerifyLedgerIntegrityResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLedgerIntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLedgerIntegrityResponse) ProtoMessage() {}
+
+func (x *VerifyLedgerIntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLedgerIntegrityResponse.ProtoReflect.Descriptor instead.
+func (*VerifyLedgerIntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *VerifyLedgerIntegrityResponse) GetTransactionsChecked() int32 {
+	if x != nil {
+		return x.TransactionsChecked
+	}
+	return 0
+}
+
+func (x *VerifyLedgerIntegrityResponse) GetViolations() []*LedgerViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// LedgerViolation represents a stored transaction that fails validation
+type LedgerViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Why the transaction is invalid (e.g. "sum of debits must equal sum of credits for PHYSICAL layer")
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerViolation) Reset() {
+	*x = LedgerViolation{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerViolation) ProtoMessage() {}
+
+func (x *LedgerViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerViolation.ProtoReflect.Descriptor instead.
+func (*LedgerViolation) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *LedgerViolation) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LedgerViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// LogSplitExpenseRequest represents an expense split across several categories
+type LogSplitExpenseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total amount as a decimal string (e.g., "80.00"); must equal the sum of the line amounts
+	Amount string `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Description of the expense
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Virtual bucket ID (UUID as string) - the virtual bucket paying for the whole expense
+	VirtualBucketId string `protobuf:"bytes,3,opt,name=virtual_bucket_id,json=virtualBucketId,proto3" json:"virtual_bucket_id,omitempty"`
+	// Optional: Override the physical bucket to credit
+	// If not provided, uses the parent physical bucket of virtual_bucket_id
+	PhysicalBucketOverrideId string `protobuf:"bytes,4,opt,name=physical_bucket_override_id,json=physicalBucketOverrideId,proto3" json:"physical_bucket_override_id,omitempty"`
+	// Optional: Long-form memo (e.g. receipt details), returned by GetTransaction only
+	Memo string `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	// Per-category amounts (at least one; each category at most once)
+	Lines         []*ExpenseLine `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogSplitExpenseRequest) Reset() {
+	*x = LogSplitExpenseRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogSplitExpenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSplitExpenseRequest) ProtoMessage() {}
+
+func (x *LogSplitExpenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSplitExpenseRequest.ProtoReflect.Descriptor instead.
+func (*LogSplitExpenseRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *LogSplitExpenseRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseRequest) GetVirtualBucketId() string {
+	if x != nil {
+		return x.VirtualBucketId
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseRequest) GetPhysicalBucketOverrideId() string {
+	if x != nil {
+		return x.PhysicalBucketOverrideId
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseRequest) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseRequest) GetLines() []*ExpenseLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// ExpenseLine represents the part of a split expense booked against one category
+type ExpenseLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category bucket ID (UUID as string) - must be an EXPENSE type bucket
+	CategoryBucketId string `protobuf:"bytes,1,opt,name=category_bucket_id,json=categoryBucketId,proto3" json:"category_bucket_id,omitempty"`
+	// Amount as a decimal string (e.g., "62.40")
+	Amount        string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpenseLine) Reset() {
+	*x = ExpenseLine{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseLine) ProtoMessage() {}
+
+func (x *ExpenseLine) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseLine.ProtoReflect.Descriptor instead.
+func (*ExpenseLine) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ExpenseLine) GetCategoryBucketId() string {
+	if x != nil {
+		return x.CategoryBucketId
+	}
+	return ""
+}
+
+func (x *ExpenseLine) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// LogSplitExpenseResponse returns the created transaction details
+type LogSplitExpenseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Timestamp when the transaction was created
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Physical bucket ID that was actually credited (useful when override was used)
+	PhysicalBucketId string `protobuf:"bytes,3,opt,name=physical_bucket_id,json=physicalBucketId,proto3" json:"physical_bucket_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LogSplitExpenseResponse) Reset() {
+	*x = LogSplitExpenseResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogSplitExpenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSplitExpenseResponse) ProtoMessage() {}
+
+func (x *LogSplitExpenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSplitExpenseResponse.ProtoReflect.Descriptor instead.
+func (*LogSplitExpenseResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *LogSplitExpenseResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LogSplitExpenseResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LogSplitExpenseResponse) GetPhysicalBucketId() string {
+	if x != nil {
+		return x.PhysicalBucketId
+	}
+	return ""
+}
+
+// GetLiquidityRequest represents a request for total liquidity
+type GetLiquidityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiquidityRequest) Reset() {
+	*x = GetLiquidityRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiquidityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiquidityRequest) ProtoMessage() {}
+
+func (x *GetLiquidityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiquidityRequest.ProtoReflect.Descriptor instead.
+func (*GetLiquidityRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{100}
+}
+
+// GetLiquidityResponse returns the total liquidity
+type GetLiquidityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sum of all physical bucket balances as a decimal string (same as GetNetWorthResponse.liquidity)
+	Liquidity     string `protobuf:"bytes,1,opt,name=liquidity,proto3" json:"liquidity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiquidityResponse) Reset() {
+	*x = GetLiquidityResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiquidityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiquidityResponse) ProtoMessage() {}
+
+func (x *GetLiquidityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiquidityResponse.ProtoReflect.Descriptor instead.
+func (*GetLiquidityResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *GetLiquidityResponse) GetLiquidity() string {
+	if x != nil {
+		return x.Liquidity
+	}
+	return ""
+}
+
+// ProcessScheduledTransactionsRequest represents a request to activate due scheduled transactions
+type ProcessScheduledTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: Activate transactions with an effective date at or before this time (defaults to server time)
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessScheduledTransactionsRequest) Reset() {
+	*x = ProcessScheduledTransactionsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessScheduledTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessScheduledTransactionsRequest) ProtoMessage() {}
+
+func (x *ProcessScheduledTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessScheduledTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ProcessScheduledTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ProcessScheduledTransactionsRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+// ProcessScheduledTransactionsResponse returns the activated transactions
+type ProcessScheduledTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs (UUIDs as strings) of the activated transactions, oldest effective date first
+	ActivatedTransactionIds []string `protobuf:"bytes,1,rep,name=activated_transaction_ids,json=activatedTransactionIds,proto3" json:"activated_transaction_ids,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *ProcessScheduledTransactionsResponse) Reset() {
+	*x = ProcessScheduledTransactionsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessScheduledTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessScheduledTransactionsResponse) ProtoMessage() {}
+
+func (x *ProcessScheduledTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessScheduledTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ProcessScheduledTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ProcessScheduledTransactionsResponse) GetActivatedTransactionIds() []string {
+	if x != nil {
+		return x.ActivatedTransactionIds
+	}
+	return nil
+}
+
+// ListIncomeSourcesRequest represents a request to list the income sources
+type ListIncomeSourcesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomeSourcesRequest) Reset() {
+	*x = ListIncomeSourcesRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomeSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomeSourcesRequest) ProtoMessage() {}
+
+func (x *ListIncomeSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomeSourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomeSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{104}
+}
+
+// IncomeSource represents an income bucket with a summary of its split rule
+type IncomeSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The income bucket
+	Bucket *Bucket `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Whether the bucket has a split rule
+	HasRule bool `protobuf:"varint,2,opt,name=has_rule,json=hasRule,proto3" json:"has_rule,omitempty"`
+	// Number of items in the split rule (0 without a rule)
+	ItemCount     int32 `protobuf:"varint,3,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomeSource) Reset() {
+	*x = IncomeSource{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomeSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomeSource) ProtoMessage() {}
+
+func (x *IncomeSource) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomeSource.ProtoReflect.Descriptor instead.
+func (*IncomeSource) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *IncomeSource) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *IncomeSource) GetHasRule() bool {
+	if x != nil {
+		return x.HasRule
+	}
+	return false
+}
+
+func (x *IncomeSource) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+// ListIncomeSourcesResponse returns the income sources
+type ListIncomeSourcesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Income buckets in the same order as ListBuckets
+	Sources       []*IncomeSource `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomeSourcesResponse) Reset() {
+	*x = ListIncomeSourcesResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomeSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomeSourcesResponse) ProtoMessage() {}
+
+func (x *ListIncomeSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomeSourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomeSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ListIncomeSourcesResponse) GetSources() []*IncomeSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// MoveBetweenVirtualBucketsRequest represents a request to move money between two virtual buckets
+type MoveBetweenVirtualBucketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source virtual bucket ID (UUID as string)
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Destination virtual bucket ID (UUID as string), held in the same physical bucket as the source
+	DestinationBucketId string `protobuf:"bytes,2,opt,name=destination_bucket_id,json=destinationBucketId,proto3" json:"destination_bucket_id,omitempty"`
+	// Amount to move as a decimal string (must be positive)
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Optional: Description (defaults to "Move from <source> to <destination>")
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Optional: Reject the move with FAILED_PRECONDITION if the source balance is below the amount
+	RequireSufficientFunds bool `protobuf:"varint,5,opt,name=require_sufficient_funds,json=requireSufficientFunds,proto3" json:"require_sufficient_funds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) Reset() {
+	*x = MoveBetweenVirtualBucketsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveBetweenVirtualBucketsRequest) ProtoMessage() {}
+
+func (x *MoveBetweenVirtualBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveBetweenVirtualBucketsRequest.ProtoReflect.Descriptor instead.
+func (*MoveBetweenVirtualBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) GetDestinationBucketId() string {
+	if x != nil {
+		return x.DestinationBucketId
+	}
+	return ""
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MoveBetweenVirtualBucketsRequest) GetRequireSufficientFunds() bool {
+	if x != nil {
+		return x.RequireSufficientFunds
+	}
+	return false
+}
+
+// MoveBetweenVirtualBucketsResponse returns the created transaction
+type MoveBetweenVirtualBucketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Move transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveBetweenVirtualBucketsResponse) Reset() {
+	*x = MoveBetweenVirtualBucketsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveBetweenVirtualBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveBetweenVirtualBucketsResponse) ProtoMessage() {}
+
+func (x *MoveBetweenVirtualBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveBetweenVirtualBucketsResponse.ProtoReflect.Descriptor instead.
+func (*MoveBetweenVirtualBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *MoveBetweenVirtualBucketsResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetInflowAllocationRequest represents a request for the allocation of a past inflow
+type GetInflowAllocationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External inflow transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInflowAllocationRequest) Reset() {
+	*x = GetInflowAllocationRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInflowAllocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInflowAllocationRequest) ProtoMessage() {}
+
+func (x *GetInflowAllocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInflowAllocationRequest.ProtoReflect.Descriptor instead.
+func (*GetInflowAllocationRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *GetInflowAllocationRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetInflowAllocationResponse returns the allocation applied to the inflow
+type GetInflowAllocationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Date of the inflow
+	Date *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Description of the inflow
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Amount allocated per target bucket, in the order the entries were recorded
+	Allocations   []*AllocationAmount `protobuf:"bytes,3,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInflowAllocationResponse) Reset() {
+	*x = GetInflowAllocationResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInflowAllocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInflowAllocationResponse) ProtoMessage() {}
+
+func (x *GetInflowAllocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInflowAllocationResponse.ProtoReflect.Descriptor instead.
+func (*GetInflowAllocationResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GetInflowAllocationResponse) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetInflowAllocationResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GetInflowAllocationResponse) GetAllocations() []*AllocationAmount {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+// RecategorizeTransactionsRequest represents a request to move past expenses to another category
+type RecategorizeTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Expense bucket the expenses are currently booked against (UUID as string)
+	SourceCategoryId string `protobuf:"bytes,1,opt,name=source_category_id,json=sourceCategoryId,proto3" json:"source_category_id,omitempty"`
+	// Expense bucket to book them against instead (UUID as string)
+	TargetCategoryId string `protobuf:"bytes,2,opt,name=target_category_id,json=targetCategoryId,proto3" json:"target_category_id,omitempty"`
+	// Optional: Only recategorize transactions whose description contains this text (case-insensitive)
+	DescriptionFilter string `protobuf:"bytes,3,opt,name=description_filter,json=descriptionFilter,proto3" json:"description_filter,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RecategorizeTransactionsRequest) Reset() {
+	*x = RecategorizeTransactionsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecategorizeTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecategorizeTransactionsRequest) ProtoMessage() {}
+
+func (x *RecategorizeTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecategorizeTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*RecategorizeTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *RecategorizeTransactionsRequest) GetSourceCategoryId() string {
+	if x != nil {
+		return x.SourceCategoryId
+	}
+	return ""
+}
+
+func (x *RecategorizeTransactionsRequest) GetTargetCategoryId() string {
+	if x != nil {
+		return x.TargetCategoryId
+	}
+	return ""
+}
+
+func (x *RecategorizeTransactionsRequest) GetDescriptionFilter() string {
+	if x != nil {
+		return x.DescriptionFilter
+	}
+	return ""
+}
+
+// RecategorizeTransactionsResponse returns the outcome of the recategorization
+type RecategorizeTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of transaction entries re-pointed to the target category
+	UpdatedEntries int32 `protobuf:"varint,1,opt,name=updated_entries,json=updatedEntries,proto3" json:"updated_entries,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecategorizeTransactionsResponse) Reset() {
+	*x = RecategorizeTransactionsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecategorizeTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecategorizeTransactionsResponse) ProtoMessage() {}
+
+func (x *RecategorizeTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecategorizeTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*RecategorizeTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *RecategorizeTransactionsResponse) GetUpdatedEntries() int32 {
+	if x != nil {
+		return x.UpdatedEntries
+	}
+	return 0
+}
+
+// GetStatementRequest represents a request for a net worth statement over [start_date, end_date)
+type GetStatementRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the period (inclusive) - the opening net worth covers everything dated before it
+	StartDate *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// End of the period (exclusive, e.g. the first day of the next month) - must be after start_date
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementRequest) Reset() {
+	*x = GetStatementRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementRequest) ProtoMessage() {}
+
+func (x *GetStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetStatementRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *GetStatementRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetStatementRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+// GetStatementResponse returns how net worth moved over the period
+// opening_net_worth + every liquidity_change + every market value change = closing_net_worth
+type GetStatementResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Net worth at start_date as a decimal string
+	OpeningNetWorth string `protobuf:"bytes,1,opt,name=opening_net_worth,json=openingNetWorth,proto3" json:"opening_net_worth,omitempty"`
+	// Net worth at end_date as a decimal string
+	ClosingNetWorth string `protobuf:"bytes,2,opt,name=closing_net_worth,json=closingNetWorth,proto3" json:"closing_net_worth,omitempty"`
+	// Transactions that changed liquidity, ordered by date ascending
+	Transactions []*StatementTransaction `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Market values recorded for equity buckets, ordered by date ascending
+	MarketValueChanges []*StatementMarketValueChange `protobuf:"bytes,4,rep,name=market_value_changes,json=marketValueChanges,proto3" json:"market_value_changes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetStatementResponse) Reset() {
+	*x = GetStatementResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementResponse) ProtoMessage() {}
+
+func (x *GetStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementResponse.ProtoReflect.Descriptor instead.
+func (*GetStatementResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *GetStatementResponse) GetOpeningNetWorth() string {
+	if x != nil {
+		return x.OpeningNetWorth
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetClosingNetWorth() string {
+	if x != nil {
+		return x.ClosingNetWorth
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetTransactions() []*StatementTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetStatementResponse) GetMarketValueChanges() []*StatementMarketValueChange {
+	if x != nil {
+		return x.MarketValueChanges
+	}
+	return nil
+}
+
+// StatementTransaction represents a transaction in a statement with its effect on net worth
+type StatementTransaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction summary
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Net change to physical bucket balances as a decimal string, negative for money leaving
+	LiquidityChange string `protobuf:"bytes,2,opt,name=liquidity_change,json=liquidityChange,proto3" json:"liquidity_change,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StatementTransaction) Reset() {
+	*x = StatementTransaction{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementTransaction) ProtoMessage() {}
+
+func (x *StatementTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementTransaction.ProtoReflect.Descriptor instead.
+func (*StatementTransaction) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *StatementTransaction) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *StatementTransaction) GetLiquidityChange() string {
+	if x != nil {
+		return x.LiquidityChange
+	}
+	return ""
+}
+
+// StatementMarketValueChange represents a market value recorded for an equity bucket in a statement
+type StatementMarketValueChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Equity bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Equity bucket name
+	BucketName string `protobuf:"bytes,2,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// Market value date
+	Date *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	// The bucket's previous market value as a decimal string ("0" if it had none)
+	PreviousMarketValue string `protobuf:"bytes,4,opt,name=previous_market_value,json=previousMarketValue,proto3" json:"previous_market_value,omitempty"`
+	// Recorded market value as a decimal string
+	MarketValue string `protobuf:"bytes,5,opt,name=market_value,json=marketValue,proto3" json:"market_value,omitempty"`
+	// market_value - previous_market_value as a decimal string, negative for a loss
+	Change        string `protobuf:"bytes,6,opt,name=change,proto3" json:"change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementMarketValueChange) Reset() {
+	*x = StatementMarketValueChange{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementMarketValueChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementMarketValueChange) ProtoMessage() {}
+
+func (x *StatementMarketValueChange) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementMarketValueChange.ProtoReflect.Descriptor instead.
+func (*StatementMarketValueChange) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *StatementMarketValueChange) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *StatementMarketValueChange) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *StatementMarketValueChange) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *StatementMarketValueChange) GetPreviousMarketValue() string {
+	if x != nil {
+		return x.PreviousMarketValue
+	}
+	return ""
+}
+
+func (x *StatementMarketValueChange) GetMarketValue() string {
+	if x != nil {
+		return x.MarketValue
+	}
+	return ""
+}
+
+func (x *StatementMarketValueChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+// CloneSplitRuleRequest represents a request to copy a split rule to another income bucket
+type CloneSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source bucket ID (UUID as string) - the income bucket whose split rule is copied
+	FromSourceBucketId string `protobuf:"bytes,1,opt,name=from_source_bucket_id,json=fromSourceBucketId,proto3" json:"from_source_bucket_id,omitempty"`
+	// Source bucket ID (UUID as string) - the income bucket receiving the copy; must not have a split rule
+	ToSourceBucketId string `protobuf:"bytes,2,opt,name=to_source_bucket_id,json=toSourceBucketId,proto3" json:"to_source_bucket_id,omitempty"`
+	// Optional: Name of the copy. If empty, the copied rule's name is kept
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneSplitRuleRequest) Reset() {
+	*x = CloneSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneSplitRuleRequest) ProtoMessage() {}
+
+func (x *CloneSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*CloneSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *CloneSplitRuleRequest) GetFromSourceBucketId() string {
+	if x != nil {
+		return x.FromSourceBucketId
+	}
+	return ""
+}
+
+func (x *CloneSplitRuleRequest) GetToSourceBucketId() string {
+	if x != nil {
+		return x.ToSourceBucketId
+	}
+	return ""
+}
+
+func (x *CloneSplitRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// CloneSplitRuleResponse returns the new split rule
+type CloneSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Split rule ID (UUID as string) of the copy
+	SplitRuleId string `protobuf:"bytes,1,opt,name=split_rule_id,json=splitRuleId,proto3" json:"split_rule_id,omitempty"`
+	// Name of the copy
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Source bucket ID (UUID as string) the copy applies to
+	SourceBucketId string `protobuf:"bytes,3,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	// Items of the copy in priority order (with fresh IDs)
+	Items []*SplitRuleItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// Physical bucket ID (UUID as string) the inflows land in - empty if the rule infers it from its first target
+	DestinationPhysicalBucketId string `protobuf:"bytes,5,opt,name=destination_physical_bucket_id,json=destinationPhysicalBucketId,proto3" json:"destination_physical_bucket_id,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *CloneSplitRuleResponse) Reset() {
+	*x = CloneSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneSplitRuleResponse) ProtoMessage() {}
+
+func (x *CloneSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*CloneSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *CloneSplitRuleResponse) GetSplitRuleId() string {
+	if x != nil {
+		return x.SplitRuleId
+	}
+	return ""
+}
+
+func (x *CloneSplitRuleResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CloneSplitRuleResponse) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+func (x *CloneSplitRuleResponse) GetItems() []*SplitRuleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CloneSplitRuleResponse) GetDestinationPhysicalBucketId() string {
+	if x != nil {
+		return x.DestinationPhysicalBucketId
+	}
+	return ""
+}
+
+// GetUpcomingObligationsRequest represents a request for a cash-flow forecast
+type GetUpcomingObligationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of days to look ahead (must be positive)
+	HorizonDays   int32 `protobuf:"varint,1,opt,name=horizon_days,json=horizonDays,proto3" json:"horizon_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUpcomingObligationsRequest) Reset() {
+	*x = GetUpcomingObligationsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUpcomingObligationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUpcomingObligationsRequest) ProtoMessage() {}
+
+func (x *GetUpcomingObligationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUpcomingObligationsRequest.ProtoReflect.Descriptor instead.
+func (*GetUpcomingObligationsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *GetUpcomingObligationsRequest) GetHorizonDays() int32 {
+	if x != nil {
+		return x.HorizonDays
+	}
+	return 0
+}
+
+// GetUpcomingObligationsResponse returns the forecast
+// current_liquidity + every liquidity_change = projected_liquidity
+type GetUpcomingObligationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sum of all physical bucket balances now as a decimal string
+	CurrentLiquidity string `protobuf:"bytes,1,opt,name=current_liquidity,json=currentLiquidity,proto3" json:"current_liquidity,omitempty"`
+	// Pending scheduled transactions up to the horizon (including overdue ones not activated yet),
+	// ordered by effective date ascending
+	Items []*UpcomingObligation `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Liquidity once every item has happened as a decimal string
+	ProjectedLiquidity string `protobuf:"bytes,3,opt,name=projected_liquidity,json=projectedLiquidity,proto3" json:"projected_liquidity,omitempty"`
+	// End of the forecast (now + horizon_days)
+	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUpcomingObligationsResponse) Reset() {
+	*x = GetUpcomingObligationsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUpcomingObligationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUpcomingObligationsResponse) ProtoMessage() {}
+
+func (x *GetUpcomingObligationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUpcomingObligationsResponse.ProtoReflect.Descriptor instead.
+func (*GetUpcomingObligationsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetUpcomingObligationsResponse) GetCurrentLiquidity() string {
+	if x != nil {
+		return x.CurrentLiquidity
+	}
+	return ""
+}
+
+func (x *GetUpcomingObligationsResponse) GetItems() []*UpcomingObligation {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetUpcomingObligationsResponse) GetProjectedLiquidity() string {
+	if x != nil {
+		return x.ProjectedLiquidity
+	}
+	return ""
+}
+
+func (x *GetUpcomingObligationsResponse) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+// UpcomingObligation represents a pending scheduled transaction in a forecast
+type UpcomingObligation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction summary (date is its effective date)
+	Transaction *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Net change it will make to physical bucket balances as a decimal string, negative for money leaving
+	LiquidityChange string `protobuf:"bytes,2,opt,name=liquidity_change,json=liquidityChange,proto3" json:"liquidity_change,omitempty"`
+	// Liquidity once this item and every earlier one has happened as a decimal string
+	ProjectedBalance string `protobuf:"bytes,3,opt,name=projected_balance,json=projectedBalance,proto3" json:"projected_balance,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpcomingObligation) Reset() {
+	*x = UpcomingObligation{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpcomingObligation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpcomingObligation) ProtoMessage() {}
+
+func (x *UpcomingObligation) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpcomingObligation.ProtoReflect.Descriptor instead.
+func (*UpcomingObligation) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *UpcomingObligation) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *UpcomingObligation) GetLiquidityChange() string {
+	if x != nil {
+		return x.LiquidityChange
+	}
+	return ""
+}
+
+func (x *UpcomingObligation) GetProjectedBalance() string {
+	if x != nil {
+		return x.ProjectedBalance
+	}
+	return ""
+}
+
+// CreateBucketsRequest represents a request to create several buckets at once
+// The whole batch is validated before anything is written; nothing is created if any bucket is invalid
+type CreateBucketsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*NewBucket           `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketsRequest) Reset() {
+	*x = CreateBucketsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketsRequest) ProtoMessage() {}
+
+func (x *CreateBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketsRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *CreateBucketsRequest) GetBuckets() []*NewBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// NewBucket describes one bucket of a CreateBuckets batch
+type NewBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional client-supplied key other buckets of the batch use as parent_key (unique within the batch)
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Name of the bucket
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Bucket type: PHYSICAL, VIRTUAL, INCOME or EXPENSE (use CreateEquityBucket for equity buckets)
+	Type BucketType `protobuf:"varint,3,opt,name=type,proto3,enum=wealthflow.v1.BucketType" json:"type,omitempty"`
+	// Virtual buckets only: key of a physical bucket in the same batch
+	ParentKey string `protobuf:"bytes,4,opt,name=parent_key,json=parentKey,proto3" json:"parent_key,omitempty"`
+	// Virtual buckets only: existing physical bucket ID (UUID as string), instead of parent_key
+	ParentId      string `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewBucket) Reset() {
+	*x = NewBucket{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewBucket) ProtoMessage() {}
+
+func (x *NewBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewBucket.ProtoReflect.Descriptor instead.
+func (*NewBucket) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *NewBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *NewBucket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NewBucket) GetType() BucketType {
+	if x != nil {
+		return x.Type
+	}
+	return BucketType_BUCKET_TYPE_UNSPECIFIED
+}
+
+func (x *NewBucket) GetParentKey() string {
+	if x != nil {
+		return x.ParentKey
+	}
+	return ""
+}
+
+func (x *NewBucket) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+// CreateBucketsResponse returns the created buckets in request order with their server-assigned IDs
+type CreateBucketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*Bucket              `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketsResponse) Reset() {
+	*x = CreateBucketsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketsResponse) ProtoMessage() {}
+
+func (x *CreateBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketsResponse.ProtoReflect.Descriptor instead.
+func (*CreateBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *CreateBucketsResponse) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// GetUnallocatedIncomeRequest represents a request for the parked income
+type GetUnallocatedIncomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnallocatedIncomeRequest) Reset() {
+	*x = GetUnallocatedIncomeRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnallocatedIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnallocatedIncomeRequest) ProtoMessage() {}
+
+func (x *GetUnallocatedIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnallocatedIncomeRequest.ProtoReflect.Descriptor instead.
+func (*GetUnallocatedIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{125}
+}
+
+// GetUnallocatedIncomeResponse returns the parked income awaiting allocation
+type GetUnallocatedIncomeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// System Extra Income bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Parked income not allocated yet as a decimal string
+	// (computed from the contributions; the bucket's current_balance also reflects opening balances)
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Parked inflows and allocations out of the parked income, ordered by date ascending
+	Contributions []*ParkedIncomeChange `protobuf:"bytes,3,rep,name=contributions,proto3" json:"contributions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnallocatedIncomeResponse) Reset() {
+	*x = GetUnallocatedIncomeResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnallocatedIncomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnallocatedIncomeResponse) ProtoMessage() {}
+
+func (x *GetUnallocatedIncomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnallocatedIncomeResponse.ProtoReflect.Descriptor instead.
+func (*GetUnallocatedIncomeResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *GetUnallocatedIncomeResponse) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetUnallocatedIncomeResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *GetUnallocatedIncomeResponse) GetContributions() []*ParkedIncomeChange {
+	if x != nil {
+		return x.Contributions
+	}
+	return nil
+}
+
+// ParkedIncomeChange represents a transaction that changed the parked income
+type ParkedIncomeChange struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Transaction *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Change as a decimal string: positive for a parked inflow, negative for an allocation
+	Amount        string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParkedIncomeChange) Reset() {
+	*x = ParkedIncomeChange{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParkedIncomeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParkedIncomeChange) ProtoMessage() {}
+
+func (x *ParkedIncomeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParkedIncomeChange.ProtoReflect.Descriptor instead.
+func (*ParkedIncomeChange) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ParkedIncomeChange) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *ParkedIncomeChange) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// AllocateUnallocatedIncomeRequest represents a request to distribute parked income
+type AllocateUnallocatedIncomeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One line per receiving virtual bucket (each bucket at most once)
+	Allocations []*ParkedIncomeAllocation `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	// Optional: Description of the transaction (defaults to "Allocate parked income")
+	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocateUnallocatedIncomeRequest) Reset() {
+	*x = AllocateUnallocatedIncomeRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateUnallocatedIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateUnallocatedIncomeRequest) ProtoMessage() {}
+
+func (x *AllocateUnallocatedIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateUnallocatedIncomeRequest.ProtoReflect.Descriptor instead.
+func (*AllocateUnallocatedIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *AllocateUnallocatedIncomeRequest) GetAllocations() []*ParkedIncomeAllocation {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+func (x *AllocateUnallocatedIncomeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ParkedIncomeAllocation represents the amount of parked income a virtual bucket receives
+type ParkedIncomeAllocation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Virtual bucket ID (UUID as string)
+	VirtualBucketId string `protobuf:"bytes,1,opt,name=virtual_bucket_id,json=virtualBucketId,proto3" json:"virtual_bucket_id,omitempty"`
+	// Amount as a decimal string - must be positive
+	Amount        string `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParkedIncomeAllocation) Reset() {
+	*x = ParkedIncomeAllocation{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParkedIncomeAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParkedIncomeAllocation) ProtoMessage() {}
+
+func (x *ParkedIncomeAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParkedIncomeAllocation.ProtoReflect.Descriptor instead.
+func (*ParkedIncomeAllocation) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *ParkedIncomeAllocation) GetVirtualBucketId() string {
+	if x != nil {
+		return x.VirtualBucketId
+	}
+	return ""
+}
+
+func (x *ParkedIncomeAllocation) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// AllocateUnallocatedIncomeResponse returns the allocation transaction
+type AllocateUnallocatedIncomeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Allocation transaction ID (UUID as string)
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocateUnallocatedIncomeResponse) Reset() {
+	*x = AllocateUnallocatedIncomeResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateUnallocatedIncomeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateUnallocatedIncomeResponse) ProtoMessage() {}
+
+func (x *AllocateUnallocatedIncomeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateUnallocatedIncomeResponse.ProtoReflect.Descriptor instead.
+func (*AllocateUnallocatedIncomeResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *AllocateUnallocatedIncomeResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// GetTransactionsBetweenBucketsRequest represents a request for the transactions between two physical buckets
+type GetTransactionsBetweenBucketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First physical bucket ID (UUID as string)
+	BucketAId string `protobuf:"bytes,1,opt,name=bucket_a_id,json=bucketAId,proto3" json:"bucket_a_id,omitempty"`
+	// Second physical bucket ID (UUID as string) - must differ from bucket_a_id
+	BucketBId     string `protobuf:"bytes,2,opt,name=bucket_b_id,json=bucketBId,proto3" json:"bucket_b_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsBetweenBucketsRequest) Reset() {
+	*x = GetTransactionsBetweenBucketsRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsBetweenBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsBetweenBucketsRequest) ProtoMessage() {}
+
+func (x *GetTransactionsBetweenBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsBetweenBucketsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionsBetweenBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *GetTransactionsBetweenBucketsRequest) GetBucketAId() string {
+	if x != nil {
+		return x.BucketAId
+	}
+	return ""
+}
+
+func (x *GetTransactionsBetweenBucketsRequest) GetBucketBId() string {
+	if x != nil {
+		return x.BucketBId
+	}
+	return ""
+}
+
+// GetTransactionsBetweenBucketsResponse returns the transactions with entries for both buckets
+type GetTransactionsBetweenBucketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transactions ordered by date descending (scheduled transactions not activated yet are excluded)
+	Transactions  []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsBetweenBucketsResponse) Reset() {
+	*x = GetTransactionsBetweenBucketsResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsBetweenBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsBetweenBucketsResponse) ProtoMessage() {}
+
+func (x *GetTransactionsBetweenBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsBetweenBucketsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionsBetweenBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *GetTransactionsBetweenBucketsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// GetFundingBucketRequest represents a request for the physical bucket backing a virtual bucket
+type GetFundingBucketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Virtual bucket ID (UUID as string)
+	VirtualBucketId string `protobuf:"bytes,1,opt,name=virtual_bucket_id,json=virtualBucketId,proto3" json:"virtual_bucket_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetFundingBucketRequest) Reset() {
+	*x = GetFundingBucketRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFundingBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFundingBucketRequest) ProtoMessage() {}
+
+func (x *GetFundingBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFundingBucketRequest.ProtoReflect.Descriptor instead.
+func (*GetFundingBucketRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *GetFundingBucketRequest) GetVirtualBucketId() string {
+	if x != nil {
+		return x.VirtualBucketId
+	}
+	return ""
+}
+
+// GetFundingBucketResponse returns the parent physical bucket of the virtual bucket
+type GetFundingBucketResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Physical bucket ID (UUID as string)
+	BucketId string `protobuf:"bytes,1,opt,name=bucket_id,json=bucketId,proto3" json:"bucket_id,omitempty"`
+	// Physical bucket name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Bucket type (always BUCKET_TYPE_PHYSICAL)
+	Type          BucketType `protobuf:"varint,3,opt,name=type,proto3,enum=wealthflow.v1.BucketType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFundingBucketResponse) Reset() {
+	*x = GetFundingBucketResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFundingBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFundingBucketResponse) ProtoMessage() {}
+
+func (x *GetFundingBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFundingBucketResponse.ProtoReflect.Descriptor instead.
+func (*GetFundingBucketResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *GetFundingBucketResponse) GetBucketId() string {
+	if x != nil {
+		return x.BucketId
+	}
+	return ""
+}
+
+func (x *GetFundingBucketResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetFundingBucketResponse) GetType() BucketType {
+	if x != nil {
+		return x.Type
+	}
+	return BucketType_BUCKET_TYPE_UNSPECIFIED
+}
+
+// EnableSplitRuleRequest represents a request to re-enable a split rule
+type EnableSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source bucket ID (UUID as string) - the income bucket the rule applies to
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnableSplitRuleRequest) Reset() {
+	*x = EnableSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableSplitRuleRequest) ProtoMessage() {}
+
+func (x *EnableSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*EnableSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *EnableSplitRuleRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+// EnableSplitRuleResponse returns the updated split rule state
+type EnableSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Split rule ID (UUID as string)
+	SplitRuleId string `protobuf:"bytes,1,opt,name=split_rule_id,json=splitRuleId,proto3" json:"split_rule_id,omitempty"`
+	// Whether the rule is active (always true)
+	Active        bool `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableSplitRuleResponse) Reset() {
+	*x = EnableSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableSplitRuleResponse) ProtoMessage() {}
+
+func (x *EnableSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*EnableSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *EnableSplitRuleResponse) GetSplitRuleId() string {
+	if x != nil {
+		return x.SplitRuleId
+	}
+	return ""
+}
+
+func (x *EnableSplitRuleResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// DisableSplitRuleRequest represents a request to disable a split rule without deleting it
+type DisableSplitRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source bucket ID (UUID as string) - the income bucket the rule applies to
+	SourceBucketId string `protobuf:"bytes,1,opt,name=source_bucket_id,json=sourceBucketId,proto3" json:"source_bucket_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DisableSplitRuleRequest) Reset() {
+	*x = DisableSplitRuleRequest{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableSplitRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableSplitRuleRequest) ProtoMessage() {}
+
+func (x *DisableSplitRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableSplitRuleRequest.ProtoReflect.Descriptor instead.
+func (*DisableSplitRuleRequest) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *DisableSplitRuleRequest) GetSourceBucketId() string {
+	if x != nil {
+		return x.SourceBucketId
+	}
+	return ""
+}
+
+// DisableSplitRuleResponse returns the updated split rule state
+type DisableSplitRuleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Split rule ID (UUID as string)
+	SplitRuleId string `protobuf:"bytes,1,opt,name=split_rule_id,json=splitRuleId,proto3" json:"split_rule_id,omitempty"`
+	// Whether the rule is active (always false)
+	Active        bool `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableSplitRuleResponse) Reset() {
+	*x = DisableSplitRuleResponse{}
+	mi := &file_wealthflow_v1_service_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableSplitRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableSplitRuleResponse) ProtoMessage() {}
+
+func (x *DisableSplitRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wealthflow_v1_service_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableSplitRuleResponse.ProtoReflect.Descriptor instead.
+func (*DisableSplitRuleResponse) Descriptor() ([]byte, []int) {
+	return file_wealthflow_v1_service_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *DisableSplitRuleResponse) GetSplitRuleId() string {
+	if x != nil {
+		return x.SplitRuleId
+	}
+	return ""
+}
+
+func (x *DisableSplitRuleResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+var File_wealthflow_v1_service_proto protoreflect.FileDescriptor
+
+const file_wealthflow_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bwealthflow/v1/service.proto\x12\rwealthflow.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x03\n" +
+	"\x13RecordInflowRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12(\n" +
+	"\x10source_bucket_id\x18\x03 \x01(\tR\x0esourceBucketId\x12\x1f\n" +
+	"\vis_external\x18\x04 \x01(\bR\n" +
+	"isExternal\x12.\n" +
+	"\x04date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x12\n" +
+	"\x04memo\x18\x06 \x01(\tR\x04memo\x12F\n" +
+	"\x0fallocation_mode\x18\a \x01(\x0e2\x1d.wealthflow.v1.AllocationModeR\x0eallocationMode\x127\n" +
+	"\x18target_virtual_bucket_id\x18\b \x01(\tR\x15targetVirtualBucketId\x12\x17\n" +
+	"\adry_run\x18\t \x01(\bR\x06dryRun\x12:\n" +
+	"\x1apark_in_physical_bucket_id\x18\n" +
+	" \x01(\tR\x16parkInPhysicalBucketId\"\xd3\x01\n" +
+	"\x14RecordInflowResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12A\n" +
+	"\vallocations\x18\x03 \x03(\v2\x1f.wealthflow.v1.AllocationAmountR\vallocations\x12\x16\n" +
+	"\x06parked\x18\x04 \x01(\bR\x06parked\"\x8a\x03\n" +
+	"\x11LogExpenseRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12*\n" +
+	"\x11virtual_bucket_id\x18\x03 \x01(\tR\x0fvirtualBucketId\x12,\n" +
+	"\x12category_bucket_id\x18\x04 \x01(\tR\x10categoryBucketId\x12=\n" +
+	"\x1bphysical_bucket_override_id\x18\x05 \x01(\tR\x18physicalBucketOverrideId\x12.\n" +
+	"\x04date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x12\n" +
+	"\x04memo\x18\a \x01(\tR\x04memo\x12\x1b\n" +
+	"\tis_refund\x18\b \x01(\bR\bisRefund\x12A\n" +
+	"\x0eeffective_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveDate\"\xa4\x01\n" +
+	"\x12LogExpenseResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
+	"\x12physical_bucket_id\x18\x03 \x01(\tR\x10physicalBucketId\"\xa8\x01\n" +
+	"\x17UpdateInvestmentRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12!\n" +
+	"\fmarket_value\x18\x02 \x01(\tR\vmarketValue\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\tR\tunitPrice\"p\n" +
+	"\x18UpdateInvestmentResponse\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x82\x01\n" +
+	"\x12ListBucketsRequest\x12:\n" +
+	"\vbucket_type\x18\x01 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\n" +
+	"bucketType\x120\n" +
+	"\x14include_market_value\x18\x02 \x01(\bR\x12includeMarketValue\"F\n" +
+	"\x13ListBucketsResponse\x12/\n" +
+	"\abuckets\x18\x01 \x03(\v2\x15.wealthflow.v1.BucketR\abuckets\"\xab\x02\n" +
+	"\x06Bucket\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\x04type\x12'\n" +
+	"\x0fcurrent_balance\x18\x04 \x01(\tR\x0ecurrentBalance\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x1f\n" +
+	"\vis_archived\x18\x06 \x01(\bR\n" +
+	"isArchived\x12\x1f\n" +
+	"\vgoal_amount\x18\a \x01(\tR\n" +
+	"goalAmount\x12#\n" +
+	"\rgoal_progress\x18\b \x01(\tR\fgoalProgress\x12!\n" +
+	"\fmarket_value\x18\t \x01(\tR\vmarketValue\"\xc0\x01\n" +
+	"\x17ListTransactionsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tbucket_id\x18\x03 \x01(\tR\bbucketId\x12\x1d\n" +
+	"\n" +
+	"bucket_ids\x18\x04 \x03(\tR\tbucketIds\x12'\n" +
+	"\x0finclude_entries\x18\x05 \x01(\bR\x0eincludeEntries\x12\x12\n" +
+	"\x04kind\x18\x06 \x01(\tR\x04kind\"\x98\x02\n" +
+	"\x18ListTransactionsResponse\x12>\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1a.wealthflow.v1.TransactionR\ftransactions\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12[\n" +
 	"\fbucket_names\x18\x03 \x03(\v28.wealthflow.v1.ListTransactionsResponse.BucketNamesEntryR\vbucketNames\x1a>\n" +
 	"\x10BucketNamesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xda\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd0\x02\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
@@ -1145,16 +8715,475 @@ const file_wealthflow_v1_service_proto_rawDesc = "" +
 	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1f\n" +
 	"\vis_external\x18\x05 \x01(\bR\n" +
 	"isExternal\x120\n" +
-	"\x14is_internal_transfer\x18\x06 \x01(\bR\x12isInternalTransfer\"\x14\n" +
-	"\x12GetNetWorthRequest\"s\n" +
+	"\x14is_internal_transfer\x18\x06 \x01(\bR\x12isInternalTransfer\x129\n" +
+	"\aentries\x18\a \x03(\v2\x1f.wealthflow.v1.TransactionEntryR\aentries\x12\x1b\n" +
+	"\tis_refund\x18\b \x01(\bR\bisRefund\x12\x1c\n" +
+	"\tscheduled\x18\t \x01(\bR\tscheduled\"\x14\n" +
+	"\x12GetNetWorthRequest\"\x85\x02\n" +
 	"\x13GetNetWorthResponse\x12&\n" +
 	"\x0ftotal_net_worth\x18\x01 \x01(\tR\rtotalNetWorth\x12\x1c\n" +
 	"\tliquidity\x18\x02 \x01(\tR\tliquidity\x12\x16\n" +
-	"\x06equity\x18\x03 \x01(\tR\x06equity\"/\n" +
+	"\x06equity\x18\x03 \x01(\tR\x06equity\x12*\n" +
+	"\x11equity_book_value\x18\x04 \x01(\tR\x0fequityBookValue\x12#\n" +
+	"\requity_profit\x18\x05 \x01(\tR\fequityProfit\x12?\n" +
+	"\rcalculated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\"\xad\x01\n" +
 	"\x10GetBucketRequest\x12\x1b\n" +
-	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"B\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12?\n" +
+	"\x1cinclude_recent_market_values\x18\x02 \x01(\bR\x19includeRecentMarketValues\x12;\n" +
+	"\x1arecent_market_values_limit\x18\x03 \x01(\x05R\x17recentMarketValuesLimit\"\xb6\x01\n" +
 	"\x11GetBucketResponse\x12-\n" +
-	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket*\xa5\x01\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12\x1f\n" +
+	"\vparent_name\x18\x02 \x01(\tR\n" +
+	"parentName\x12Q\n" +
+	"\x14recent_market_values\x18\x03 \x03(\v2\x1f.wealthflow.v1.MarketValueEntryR\x12recentMarketValues\"\x9e\x01\n" +
+	"\x19ImportTransactionsRequest\x12<\n" +
+	"\x06inflow\x18\x01 \x01(\v2\".wealthflow.v1.RecordInflowRequestH\x00R\x06inflow\x12<\n" +
+	"\aexpense\x18\x02 \x01(\v2 .wealthflow.v1.LogExpenseRequestH\x00R\aexpenseB\x05\n" +
+	"\x03row\"\xc6\x01\n" +
+	"\x1aImportTransactionsResponse\x12#\n" +
+	"\rsuccess_count\x18\x01 \x01(\x05R\fsuccessCount\x12#\n" +
+	"\rfailure_count\x18\x02 \x01(\x05R\ffailureCount\x125\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1d.wealthflow.v1.ImportRowErrorR\x06errors\x12'\n" +
+	"\x0ftransaction_ids\x18\x04 \x03(\tR\x0etransactionIds\"8\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x16\n" +
+	"\x14GetBucketTreeRequest\"L\n" +
+	"\x15GetBucketTreeResponse\x123\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x1d.wealthflow.v1.BucketTreeNodeR\x05nodes\"\xc8\x01\n" +
+	"\x0eBucketTreeNode\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x121\n" +
+	"\bchildren\x18\x02 \x03(\v2\x15.wealthflow.v1.BucketR\bchildren\x12'\n" +
+	"\x0fvirtual_balance\x18\x03 \x01(\tR\x0evirtualBalance\x12+\n" +
+	"\x11unbucketed_amount\x18\x04 \x01(\tR\x10unbucketedAmount\"9\n" +
+	"\x1aGetUnbucketedAmountRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"\xbb\x01\n" +
+	"\x1bGetUnbucketedAmountResponse\x12)\n" +
+	"\x10physical_balance\x18\x01 \x01(\tR\x0fphysicalBalance\x12'\n" +
+	"\x0fvirtual_balance\x18\x02 \x01(\tR\x0evirtualBalance\x12+\n" +
+	"\x11unbucketed_amount\x18\x03 \x01(\tR\x10unbucketedAmount\x12\x1b\n" +
+	"\thas_drift\x18\x04 \x01(\bR\bhasDrift\"Q\n" +
+	"\x1cGetBucketTransactionsRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa2\x02\n" +
+	"\x1dGetBucketTransactionsResponse\x12>\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1a.wealthflow.v1.TransactionR\ftransactions\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12`\n" +
+	"\fbucket_names\x18\x03 \x03(\v2=.wealthflow.v1.GetBucketTransactionsResponse.BucketNamesEntryR\vbucketNames\x1a>\n" +
+	"\x10BucketNamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbe\x01\n" +
+	"\x18PreviewAllocationRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x18\n" +
+	"\aexplain\x18\x03 \x01(\bR\aexplain\x12F\n" +
+	"\x0fallocation_mode\x18\x04 \x01(\x0e2\x1d.wealthflow.v1.AllocationModeR\x0eallocationMode\"\x93\x01\n" +
+	"\x19PreviewAllocationResponse\x12A\n" +
+	"\vallocations\x18\x01 \x03(\v2\x1f.wealthflow.v1.AllocationAmountR\vallocations\x123\n" +
+	"\x05steps\x18\x02 \x03(\v2\x1d.wealthflow.v1.AllocationStepR\x05steps\"[\n" +
+	"\x10AllocationAmount\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\xa3\x02\n" +
+	"\x0eAllocationStep\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x124\n" +
+	"\x04type\x18\x02 \x01(\x0e2 .wealthflow.v1.SplitRuleItemTypeR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1f\n" +
+	"\vbase_amount\x18\x04 \x01(\tR\n" +
+	"baseAmount\x12'\n" +
+	"\x0fcomputed_amount\x18\x05 \x01(\tR\x0ecomputedAmount\x12+\n" +
+	"\x11running_remainder\x18\x06 \x01(\tR\x10runningRemainder\x121\n" +
+	"\x15relative_to_bucket_id\x18\a \x01(\tR\x12relativeToBucketId\"\xe4\x01\n" +
+	"\rSplitRuleItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10target_bucket_id\x18\x02 \x01(\tR\x0etargetBucketId\x124\n" +
+	"\x04type\x18\x03 \x01(\x0e2 .wealthflow.v1.SplitRuleItemTypeR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x121\n" +
+	"\x15relative_to_bucket_id\x18\x06 \x01(\tR\x12relativeToBucketId\"\xcf\x01\n" +
+	"\x16CreateSplitRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\x10source_bucket_id\x18\x02 \x01(\tR\x0esourceBucketId\x122\n" +
+	"\x05items\x18\x03 \x03(\v2\x1c.wealthflow.v1.SplitRuleItemR\x05items\x12C\n" +
+	"\x1edestination_physical_bucket_id\x18\x04 \x01(\tR\x1bdestinationPhysicalBucketId\"Y\n" +
+	"\x17CreateSplitRuleResponse\x12\"\n" +
+	"\rsplit_rule_id\x18\x01 \x01(\tR\vsplitRuleId\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"_\n" +
+	"\x1cReparentVirtualBucketRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\"\n" +
+	"\rnew_parent_id\x18\x02 \x01(\tR\vnewParentId\"\x9f\x01\n" +
+	"\x1dReparentVirtualBucketResponse\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12(\n" +
+	"\x10transfer_task_id\x18\x03 \x01(\tR\x0etransferTaskId\"9\n" +
+	"\x1aListBucketsByParentRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\tR\bparentId\"N\n" +
+	"\x1bListBucketsByParentResponse\x12/\n" +
+	"\abuckets\x18\x01 \x03(\v2\x15.wealthflow.v1.BucketR\abuckets\">\n" +
+	"\x15GetTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xc0\x02\n" +
+	"\x16GetTransactionResponse\x12<\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1a.wealthflow.v1.TransactionR\vtransaction\x12\x12\n" +
+	"\x04memo\x18\x02 \x01(\tR\x04memo\x129\n" +
+	"\aentries\x18\x03 \x03(\v2\x1f.wealthflow.v1.TransactionEntryR\aentries\x12Y\n" +
+	"\fbucket_names\x18\x04 \x03(\v26.wealthflow.v1.GetTransactionResponse.BucketNamesEntryR\vbucketNames\x1a>\n" +
+	"\x10BucketNamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x01\n" +
+	"\x10TransactionEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tbucket_id\x18\x02 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05layer\x18\x05 \x01(\tR\x05layer\"{\n" +
+	"\x18SetOpeningBalanceRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12*\n" +
+	"\x11virtual_bucket_id\x18\x03 \x01(\tR\x0fvirtualBucketId\"B\n" +
+	"\x19SetOpeningBalanceResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xa8\x01\n" +
+	"\x17GetProfitHistoryRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"N\n" +
+	"\x18GetProfitHistoryResponse\x122\n" +
+	"\x06points\x18\x01 \x03(\v2\x1a.wealthflow.v1.ProfitPointR\x06points\"\x97\x01\n" +
+	"\vProfitPoint\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x1d\n" +
+	"\n" +
+	"book_value\x18\x02 \x01(\tR\tbookValue\x12!\n" +
+	"\fmarket_value\x18\x03 \x01(\tR\vmarketValue\x12\x16\n" +
+	"\x06profit\x18\x04 \x01(\tR\x06profit\"\x88\x01\n" +
+	"\x1dListMarketValueHistoryRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1c\n" +
+	"\tascending\x18\x04 \x01(\bR\tascending\"|\n" +
+	"\x1eListMarketValueHistoryResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.wealthflow.v1.MarketValueEntryR\aentries\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"u\n" +
+	"\x10MarketValueEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12!\n" +
+	"\fmarket_value\x18\x03 \x01(\tR\vmarketValue\"A\n" +
+	"\x18DeleteTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\x1b\n" +
+	"\x19DeleteTransactionResponse\"9\n" +
+	"\x1aGetTransactionCountRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"3\n" +
+	"\x1bGetTransactionCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"\x1a\n" +
+	"\x18GetBucketsSummaryRequest\"\xe7\x01\n" +
+	"\x19GetBucketsSummaryResponse\x12`\n" +
+	"\x0ecounts_by_type\x18\x01 \x03(\v2:.wealthflow.v1.GetBucketsSummaryResponse.CountsByTypeEntryR\fcountsByType\x12'\n" +
+	"\x0ftotal_liquidity\x18\x02 \x01(\tR\x0etotalLiquidity\x1a?\n" +
+	"\x11CountsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"?\n" +
+	"\x13GetSplitRuleRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\"\xb6\x03\n" +
+	"\x14GetSplitRuleResponse\x12\"\n" +
+	"\rsplit_rule_id\x18\x01 \x01(\tR\vsplitRuleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x10source_bucket_id\x18\x03 \x01(\tR\x0esourceBucketId\x122\n" +
+	"\x05items\x18\x04 \x03(\v2\x1c.wealthflow.v1.SplitRuleItemR\x05items\x12\x1f\n" +
+	"\vtotal_fixed\x18\x05 \x01(\tR\n" +
+	"totalFixed\x12C\n" +
+	"\x1edestination_physical_bucket_id\x18\x06 \x01(\tR\x1bdestinationPhysicalBucketId\x12#\n" +
+	"\rtotal_percent\x18\a \x01(\tR\ftotalPercent\x12+\n" +
+	"\x11remainder_percent\x18\b \x01(\tR\x10remainderPercent\x128\n" +
+	"\x18has_meaningful_remainder\x18\t \x01(\bR\x16hasMeaningfulRemainder\x12\x16\n" +
+	"\x06active\x18\n" +
+	" \x01(\bR\x06active\"G\n" +
+	"\x18ListTransferTasksRequest\x12+\n" +
+	"\x11include_completed\x18\x01 \x01(\bR\x10includeCompleted\"N\n" +
+	"\x19ListTransferTasksResponse\x121\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x1b.wealthflow.v1.TransferTaskR\x05tasks\"\x90\x03\n" +
+	"\fTransferTask\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\x16related_transaction_id\x18\x02 \x01(\tR\x14relatedTransactionId\x128\n" +
+	"\x18completed_transaction_id\x18\x03 \x01(\tR\x16completedTransactionId\x125\n" +
+	"\x17from_physical_bucket_id\x18\x04 \x01(\tR\x14fromPhysicalBucketId\x121\n" +
+	"\x15to_physical_bucket_id\x18\x05 \x01(\tR\x12toPhysicalBucketId\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12!\n" +
+	"\fis_completed\x18\a \x01(\bR\visCompleted\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"q\n" +
+	"\x1bMergeCategoryBucketsRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x12(\n" +
+	"\x10target_bucket_id\x18\x02 \x01(\tR\x0etargetBucketId\"K\n" +
+	"\x1cMergeCategoryBucketsResponse\x12+\n" +
+	"\x11repointed_entries\x18\x01 \x01(\x05R\x10repointedEntries\"9\n" +
+	"\x1aGetInvestmentProfitRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\"\xaf\x01\n" +
+	"\x1bGetInvestmentProfitResponse\x12\x1d\n" +
+	"\n" +
+	"book_value\x18\x01 \x01(\tR\tbookValue\x12!\n" +
+	"\fmarket_value\x18\x02 \x01(\tR\vmarketValue\x12'\n" +
+	"\x0fabsolute_profit\x18\x03 \x01(\tR\x0eabsoluteProfit\x12%\n" +
+	"\x0epercent_profit\x18\x04 \x01(\tR\rpercentProfit\"x\n" +
+	"\x18ValidateSplitRuleRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x122\n" +
+	"\x05items\x18\x02 \x03(\v2\x1c.wealthflow.v1.SplitRuleItemR\x05items\"c\n" +
+	"\x19ValidateSplitRuleResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"\x1c\n" +
+	"\x1aRecalculateBalancesRequest\"k\n" +
+	"\x1bRecalculateBalancesResponse\x12+\n" +
+	"\x11buckets_corrected\x18\x01 \x01(\x05R\x10bucketsCorrected\x12\x1f\n" +
+	"\vtotal_drift\x18\x02 \x01(\tR\n" +
+	"totalDrift\"T\n" +
+	"\x14SetBucketGoalRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1f\n" +
+	"\vgoal_amount\x18\x02 \x01(\tR\n" +
+	"goalAmount\"F\n" +
+	"\x15SetBucketGoalResponse\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\"\x18\n" +
+	"\x16ListBucketGoalsRequest\"i\n" +
+	"\n" +
+	"BucketGoal\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12\x1a\n" +
+	"\bprogress\x18\x02 \x01(\tR\bprogress\x12\x10\n" +
+	"\x03met\x18\x03 \x01(\bR\x03met\"J\n" +
+	"\x17ListBucketGoalsResponse\x12/\n" +
+	"\x05goals\x18\x01 \x03(\v2\x19.wealthflow.v1.BucketGoalR\x05goals\"\x12\n" +
+	"\x10GetStatusRequest\"\xf5\x01\n" +
+	"\x11GetStatusResponse\x12\x14\n" +
+	"\x05ready\x18\x01 \x01(\bR\x05ready\x12-\n" +
+	"\x12database_connected\x18\x02 \x01(\bR\x11databaseConnected\x122\n" +
+	"\x15system_buckets_seeded\x18\x03 \x01(\bR\x13systemBucketsSeeded\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x06 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\a \x01(\tR\tbuildTime\"6\n" +
+	"\x1bCompleteTransferTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"O\n" +
+	"\x1cCompleteTransferTaskResponse\x12/\n" +
+	"\x04task\x18\x01 \x01(\v2\x1b.wealthflow.v1.TransferTaskR\x04task\"\xe3\x01\n" +
+	"\x1fGetCategorySpendingTrendRequest\x12,\n" +
+	"\x12category_bucket_id\x18\x01 \x01(\tR\x10categoryBucketId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12 \n" +
+	"\vgranularity\x18\x04 \x01(\tR\vgranularity\"Y\n" +
+	" GetCategorySpendingTrendResponse\x125\n" +
+	"\x06points\x18\x01 \x03(\v2\x1d.wealthflow.v1.SpendingPeriodR\x06points\"Z\n" +
+	"\x0eSpendingPeriod\x122\n" +
+	"\x06period\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x06period\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\tR\x05total\"q\n" +
+	"\x19CreateEquityBucketRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"book_value\x18\x02 \x01(\tR\tbookValue\x12!\n" +
+	"\fmarket_value\x18\x03 \x01(\tR\vmarketValue\"\xb7\x02\n" +
+	"\x1aCreateEquityBucketResponse\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x121\n" +
+	"\x15market_value_entry_id\x18\x03 \x01(\tR\x12marketValueEntryId\x12\x1d\n" +
+	"\n" +
+	"book_value\x18\x04 \x01(\tR\tbookValue\x12!\n" +
+	"\fmarket_value\x18\x05 \x01(\tR\vmarketValue\x12'\n" +
+	"\x0fabsolute_profit\x18\x06 \x01(\tR\x0eabsoluteProfit\x12%\n" +
+	"\x0epercent_profit\x18\a \x01(\tR\rpercentProfit\"\xab\x01\n" +
+	"\x18AddEquityPurchaseRequest\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\tR\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\tR\tunitPrice\x127\n" +
+	"\x18source_virtual_bucket_id\x18\x04 \x01(\tR\x15sourceVirtualBucketId\"y\n" +
+	"\x19AddEquityPurchaseResponse\x12\x1a\n" +
+	"\bquantity\x18\x01 \x01(\tR\bquantity\x12\x19\n" +
+	"\bavg_cost\x18\x02 \x01(\tR\aavgCost\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"\xb6\x01\n" +
+	"%GetTransactionsByDateHistogramRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x1b\n" +
+	"\tbucket_id\x18\x03 \x01(\tR\bbucketId\"`\n" +
+	"&GetTransactionsByDateHistogramResponse\x126\n" +
+	"\x04days\x18\x01 \x03(\v2\".wealthflow.v1.TransactionDayCountR\x04days\"[\n" +
+	"\x13TransactionDayCount\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"5\n" +
+	"\x1fListOverdueTransferTasksRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"U\n" +
+	" ListOverdueTransferTasksResponse\x121\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x1b.wealthflow.v1.TransferTaskR\x05tasks\"\x9e\x01\n" +
+	"*GetExpenseBreakdownByPhysicalBucketRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"n\n" +
+	"+GetExpenseBreakdownByPhysicalBucketResponse\x12?\n" +
+	"\abuckets\x18\x01 \x03(\v2%.wealthflow.v1.PhysicalBucketSpendingR\abuckets\"{\n" +
+	"\x16PhysicalBucketSpending\x12,\n" +
+	"\x12physical_bucket_id\x18\x01 \x01(\tR\x10physicalBucketId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vtotal_spent\x18\x03 \x01(\tR\n" +
+	"totalSpent\"\x94\x01\n" +
+	" GetInflowAllocationSharesRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"[\n" +
+	"!GetInflowAllocationSharesResponse\x126\n" +
+	"\x06shares\x18\x01 \x03(\v2\x1e.wealthflow.v1.AllocationShareR\x06shares\"{\n" +
+	"\x0fAllocationShare\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\tR\x05total\x12#\n" +
+	"\rshare_percent\x18\x03 \x01(\tR\fsharePercent\"\x1e\n" +
+	"\x1cVerifyLedgerIntegrityRequest\"\x92\x01\n" +
+	"\x1dVerifyLedgerIntegrityResponse\x121\n" +
+	"\x14transactions_checked\x18\x01 \x01(\x05R\x13transactionsChecked\x12>\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x1e.wealthflow.v1.LedgerViolationR\n" +
+	"violations\"P\n" +
+	"\x0fLedgerViolation\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x83\x02\n" +
+	"\x16LogSplitExpenseRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12*\n" +
+	"\x11virtual_bucket_id\x18\x03 \x01(\tR\x0fvirtualBucketId\x12=\n" +
+	"\x1bphysical_bucket_override_id\x18\x04 \x01(\tR\x18physicalBucketOverrideId\x12\x12\n" +
+	"\x04memo\x18\x05 \x01(\tR\x04memo\x120\n" +
+	"\x05lines\x18\x06 \x03(\v2\x1a.wealthflow.v1.ExpenseLineR\x05lines\"S\n" +
+	"\vExpenseLine\x12,\n" +
+	"\x12category_bucket_id\x18\x01 \x01(\tR\x10categoryBucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"\xa9\x01\n" +
+	"\x17LogSplitExpenseResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12,\n" +
+	"\x12physical_bucket_id\x18\x03 \x01(\tR\x10physicalBucketId\"\x15\n" +
+	"\x13GetLiquidityRequest\"4\n" +
+	"\x14GetLiquidityResponse\x12\x1c\n" +
+	"\tliquidity\x18\x01 \x01(\tR\tliquidity\"V\n" +
+	"#ProcessScheduledTransactionsRequest\x12/\n" +
+	"\x05as_of\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\"b\n" +
+	"$ProcessScheduledTransactionsResponse\x12:\n" +
+	"\x19activated_transaction_ids\x18\x01 \x03(\tR\x17activatedTransactionIds\"\x1a\n" +
+	"\x18ListIncomeSourcesRequest\"w\n" +
+	"\fIncomeSource\x12-\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x15.wealthflow.v1.BucketR\x06bucket\x12\x19\n" +
+	"\bhas_rule\x18\x02 \x01(\bR\ahasRule\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x03 \x01(\x05R\titemCount\"R\n" +
+	"\x19ListIncomeSourcesResponse\x125\n" +
+	"\asources\x18\x01 \x03(\v2\x1b.wealthflow.v1.IncomeSourceR\asources\"\xf4\x01\n" +
+	" MoveBetweenVirtualBucketsRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\x122\n" +
+	"\x15destination_bucket_id\x18\x02 \x01(\tR\x13destinationBucketId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x128\n" +
+	"\x18require_sufficient_funds\x18\x05 \x01(\bR\x16requireSufficientFunds\"J\n" +
+	"!MoveBetweenVirtualBucketsResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"C\n" +
+	"\x1aGetInflowAllocationRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xb2\x01\n" +
+	"\x1bGetInflowAllocationResponse\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12A\n" +
+	"\vallocations\x18\x03 \x03(\v2\x1f.wealthflow.v1.AllocationAmountR\vallocations\"\xac\x01\n" +
+	"\x1fRecategorizeTransactionsRequest\x12,\n" +
+	"\x12source_category_id\x18\x01 \x01(\tR\x10sourceCategoryId\x12,\n" +
+	"\x12target_category_id\x18\x02 \x01(\tR\x10targetCategoryId\x12-\n" +
+	"\x12description_filter\x18\x03 \x01(\tR\x11descriptionFilter\"K\n" +
+	" RecategorizeTransactionsResponse\x12'\n" +
+	"\x0fupdated_entries\x18\x01 \x01(\x05R\x0eupdatedEntries\"\x87\x01\n" +
+	"\x13GetStatementRequest\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\x94\x02\n" +
+	"\x14GetStatementResponse\x12*\n" +
+	"\x11opening_net_worth\x18\x01 \x01(\tR\x0fopeningNetWorth\x12*\n" +
+	"\x11closing_net_worth\x18\x02 \x01(\tR\x0fclosingNetWorth\x12G\n" +
+	"\ftransactions\x18\x03 \x03(\v2#.wealthflow.v1.StatementTransactionR\ftransactions\x12[\n" +
+	"\x14market_value_changes\x18\x04 \x03(\v2).wealthflow.v1.StatementMarketValueChangeR\x12marketValueChanges\"\x7f\n" +
+	"\x14StatementTransaction\x12<\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1a.wealthflow.v1.TransactionR\vtransaction\x12)\n" +
+	"\x10liquidity_change\x18\x02 \x01(\tR\x0fliquidityChange\"\xf9\x01\n" +
+	"\x1aStatementMarketValueChange\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x1f\n" +
+	"\vbucket_name\x18\x02 \x01(\tR\n" +
+	"bucketName\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x122\n" +
+	"\x15previous_market_value\x18\x04 \x01(\tR\x13previousMarketValue\x12!\n" +
+	"\fmarket_value\x18\x05 \x01(\tR\vmarketValue\x12\x16\n" +
+	"\x06change\x18\x06 \x01(\tR\x06change\"\x8d\x01\n" +
+	"\x15CloneSplitRuleRequest\x121\n" +
+	"\x15from_source_bucket_id\x18\x01 \x01(\tR\x12fromSourceBucketId\x12-\n" +
+	"\x13to_source_bucket_id\x18\x02 \x01(\tR\x10toSourceBucketId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\xf3\x01\n" +
+	"\x16CloneSplitRuleResponse\x12\"\n" +
+	"\rsplit_rule_id\x18\x01 \x01(\tR\vsplitRuleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x10source_bucket_id\x18\x03 \x01(\tR\x0esourceBucketId\x122\n" +
+	"\x05items\x18\x04 \x03(\v2\x1c.wealthflow.v1.SplitRuleItemR\x05items\x12C\n" +
+	"\x1edestination_physical_bucket_id\x18\x05 \x01(\tR\x1bdestinationPhysicalBucketId\"B\n" +
+	"\x1dGetUpcomingObligationsRequest\x12!\n" +
+	"\fhorizon_days\x18\x01 \x01(\x05R\vhorizonDays\"\xe9\x01\n" +
+	"\x1eGetUpcomingObligationsResponse\x12+\n" +
+	"\x11current_liquidity\x18\x01 \x01(\tR\x10currentLiquidity\x127\n" +
+	"\x05items\x18\x02 \x03(\v2!.wealthflow.v1.UpcomingObligationR\x05items\x12/\n" +
+	"\x13projected_liquidity\x18\x03 \x01(\tR\x12projectedLiquidity\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"\xaa\x01\n" +
+	"\x12UpcomingObligation\x12<\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1a.wealthflow.v1.TransactionR\vtransaction\x12)\n" +
+	"\x10liquidity_change\x18\x02 \x01(\tR\x0fliquidityChange\x12+\n" +
+	"\x11projected_balance\x18\x03 \x01(\tR\x10projectedBalance\"J\n" +
+	"\x14CreateBucketsRequest\x122\n" +
+	"\abuckets\x18\x01 \x03(\v2\x18.wealthflow.v1.NewBucketR\abuckets\"\x9c\x01\n" +
+	"\tNewBucket\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"parent_key\x18\x04 \x01(\tR\tparentKey\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\"H\n" +
+	"\x15CreateBucketsResponse\x12/\n" +
+	"\abuckets\x18\x01 \x03(\v2\x15.wealthflow.v1.BucketR\abuckets\"\x1d\n" +
+	"\x1bGetUnallocatedIncomeRequest\"\x9c\x01\n" +
+	"\x1cGetUnallocatedIncomeResponse\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\x12G\n" +
+	"\rcontributions\x18\x03 \x03(\v2!.wealthflow.v1.ParkedIncomeChangeR\rcontributions\"j\n" +
+	"\x12ParkedIncomeChange\x12<\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1a.wealthflow.v1.TransactionR\vtransaction\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"\x8d\x01\n" +
+	" AllocateUnallocatedIncomeRequest\x12G\n" +
+	"\vallocations\x18\x01 \x03(\v2%.wealthflow.v1.ParkedIncomeAllocationR\vallocations\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"\\\n" +
+	"\x16ParkedIncomeAllocation\x12*\n" +
+	"\x11virtual_bucket_id\x18\x01 \x01(\tR\x0fvirtualBucketId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"J\n" +
+	"!AllocateUnallocatedIncomeResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"f\n" +
+	"$GetTransactionsBetweenBucketsRequest\x12\x1e\n" +
+	"\vbucket_a_id\x18\x01 \x01(\tR\tbucketAId\x12\x1e\n" +
+	"\vbucket_b_id\x18\x02 \x01(\tR\tbucketBId\"g\n" +
+	"%GetTransactionsBetweenBucketsResponse\x12>\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1a.wealthflow.v1.TransactionR\ftransactions\"E\n" +
+	"\x17GetFundingBucketRequest\x12*\n" +
+	"\x11virtual_bucket_id\x18\x01 \x01(\tR\x0fvirtualBucketId\"z\n" +
+	"\x18GetFundingBucketResponse\x12\x1b\n" +
+	"\tbucket_id\x18\x01 \x01(\tR\bbucketId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x19.wealthflow.v1.BucketTypeR\x04type\"B\n" +
+	"\x16EnableSplitRuleRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\"U\n" +
+	"\x17EnableSplitRuleResponse\x12\"\n" +
+	"\rsplit_rule_id\x18\x01 \x01(\tR\vsplitRuleId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"C\n" +
+	"\x17DisableSplitRuleRequest\x12(\n" +
+	"\x10source_bucket_id\x18\x01 \x01(\tR\x0esourceBucketId\"V\n" +
+	"\x18DisableSplitRuleResponse\x12\"\n" +
+	"\rsplit_rule_id\x18\x01 \x01(\tR\vsplitRuleId\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active*\xa5\x01\n" +
 	"\n" +
 	"BucketType\x12\x1b\n" +
 	"\x17BUCKET_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
@@ -1162,7 +9191,16 @@ const file_wealthflow_v1_service_proto_rawDesc = "" +
 	"\x13BUCKET_TYPE_VIRTUAL\x10\x02\x12\x16\n" +
 	"\x12BUCKET_TYPE_INCOME\x10\x03\x12\x17\n" +
 	"\x13BUCKET_TYPE_EXPENSE\x10\x04\x12\x16\n" +
-	"\x12BUCKET_TYPE_EQUITY\x10\x052\x85\x05\n" +
+	"\x12BUCKET_TYPE_EQUITY\x10\x05*\x9f\x01\n" +
+	"\x11SplitRuleItemType\x12$\n" +
+	" SPLIT_RULE_ITEM_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSPLIT_RULE_ITEM_TYPE_FIXED\x10\x01\x12 \n" +
+	"\x1cSPLIT_RULE_ITEM_TYPE_PERCENT\x10\x02\x12\"\n" +
+	"\x1eSPLIT_RULE_ITEM_TYPE_REMAINDER\x10\x03*n\n" +
+	"\x0eAllocationMode\x12\x1f\n" +
+	"\x1bALLOCATION_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ALLOCATION_MODE_STRICT\x10\x01\x12\x1f\n" +
+	"\x1bALLOCATION_MODE_SCALE_FIXED\x10\x022\xef/\n" +
 	"\x11WealthFlowService\x12W\n" +
 	"\fRecordInflow\x12\".wealthflow.v1.RecordInflowRequest\x1a#.wealthflow.v1.RecordInflowResponse\x12Q\n" +
 	"\n" +
@@ -1171,7 +9209,57 @@ const file_wealthflow_v1_service_proto_rawDesc = "" +
 	"\vListBuckets\x12!.wealthflow.v1.ListBucketsRequest\x1a\".wealthflow.v1.ListBucketsResponse\x12c\n" +
 	"\x10ListTransactions\x12&.wealthflow.v1.ListTransactionsRequest\x1a'.wealthflow.v1.ListTransactionsResponse\x12T\n" +
 	"\vGetNetWorth\x12!.wealthflow.v1.GetNetWorthRequest\x1a\".wealthflow.v1.GetNetWorthResponse\x12N\n" +
-	"\tGetBucket\x12\x1f.wealthflow.v1.GetBucketRequest\x1a .wealthflow.v1.GetBucketResponseBJZHgithub.com/simaogato/wealthflow-backend/proto/wealthflow/v1;wealthflowv1b\x06proto3"
+	"\tGetBucket\x12\x1f.wealthflow.v1.GetBucketRequest\x1a .wealthflow.v1.GetBucketResponse\x12k\n" +
+	"\x12ImportTransactions\x12(.wealthflow.v1.ImportTransactionsRequest\x1a).wealthflow.v1.ImportTransactionsResponse(\x01\x12Z\n" +
+	"\rGetBucketTree\x12#.wealthflow.v1.GetBucketTreeRequest\x1a$.wealthflow.v1.GetBucketTreeResponse\x12l\n" +
+	"\x13GetUnbucketedAmount\x12).wealthflow.v1.GetUnbucketedAmountRequest\x1a*.wealthflow.v1.GetUnbucketedAmountResponse\x12r\n" +
+	"\x15GetBucketTransactions\x12+.wealthflow.v1.GetBucketTransactionsRequest\x1a,.wealthflow.v1.GetBucketTransactionsResponse\x12f\n" +
+	"\x11PreviewAllocation\x12'.wealthflow.v1.PreviewAllocationRequest\x1a(.wealthflow.v1.PreviewAllocationResponse\x12`\n" +
+	"\x0fCreateSplitRule\x12%.wealthflow.v1.CreateSplitRuleRequest\x1a&.wealthflow.v1.CreateSplitRuleResponse\x12r\n" +
+	"\x15ReparentVirtualBucket\x12+.wealthflow.v1.ReparentVirtualBucketRequest\x1a,.wealthflow.v1.ReparentVirtualBucketResponse\x12l\n" +
+	"\x13ListBucketsByParent\x12).wealthflow.v1.ListBucketsByParentRequest\x1a*.wealthflow.v1.ListBucketsByParentResponse\x12]\n" +
+	"\x0eGetTransaction\x12$.wealthflow.v1.GetTransactionRequest\x1a%.wealthflow.v1.GetTransactionResponse\x12f\n" +
+	"\x11SetOpeningBalance\x12'.wealthflow.v1.SetOpeningBalanceRequest\x1a(.wealthflow.v1.SetOpeningBalanceResponse\x12c\n" +
+	"\x10GetProfitHistory\x12&.wealthflow.v1.GetProfitHistoryRequest\x1a'.wealthflow.v1.GetProfitHistoryResponse\x12u\n" +
+	"\x16ListMarketValueHistory\x12,.wealthflow.v1.ListMarketValueHistoryRequest\x1a-.wealthflow.v1.ListMarketValueHistoryResponse\x12f\n" +
+	"\x11DeleteTransaction\x12'.wealthflow.v1.DeleteTransactionRequest\x1a(.wealthflow.v1.DeleteTransactionResponse\x12l\n" +
+	"\x13GetTransactionCount\x12).wealthflow.v1.GetTransactionCountRequest\x1a*.wealthflow.v1.GetTransactionCountResponse\x12f\n" +
+	"\x11GetBucketsSummary\x12'.wealthflow.v1.GetBucketsSummaryRequest\x1a(.wealthflow.v1.GetBucketsSummaryResponse\x12W\n" +
+	"\fGetSplitRule\x12\".wealthflow.v1.GetSplitRuleRequest\x1a#.wealthflow.v1.GetSplitRuleResponse\x12f\n" +
+	"\x11ListTransferTasks\x12'.wealthflow.v1.ListTransferTasksRequest\x1a(.wealthflow.v1.ListTransferTasksResponse\x12o\n" +
+	"\x14MergeCategoryBuckets\x12*.wealthflow.v1.MergeCategoryBucketsRequest\x1a+.wealthflow.v1.MergeCategoryBucketsResponse\x12l\n" +
+	"\x13GetInvestmentProfit\x12).wealthflow.v1.GetInvestmentProfitRequest\x1a*.wealthflow.v1.GetInvestmentProfitResponse\x12f\n" +
+	"\x11ValidateSplitRule\x12'.wealthflow.v1.ValidateSplitRuleRequest\x1a(.wealthflow.v1.ValidateSplitRuleResponse\x12l\n" +
+	"\x13RecalculateBalances\x12).wealthflow.v1.RecalculateBalancesRequest\x1a*.wealthflow.v1.RecalculateBalancesResponse\x12Z\n" +
+	"\rSetBucketGoal\x12#.wealthflow.v1.SetBucketGoalRequest\x1a$.wealthflow.v1.SetBucketGoalResponse\x12`\n" +
+	"\x0fListBucketGoals\x12%.wealthflow.v1.ListBucketGoalsRequest\x1a&.wealthflow.v1.ListBucketGoalsResponse\x12N\n" +
+	"\tGetStatus\x12\x1f.wealthflow.v1.GetStatusRequest\x1a .wealthflow.v1.GetStatusResponse\x12o\n" +
+	"\x14CompleteTransferTask\x12*.wealthflow.v1.CompleteTransferTaskRequest\x1a+.wealthflow.v1.CompleteTransferTaskResponse\x12{\n" +
+	"\x18GetCategorySpendingTrend\x12..wealthflow.v1.GetCategorySpendingTrendRequest\x1a/.wealthflow.v1.GetCategorySpendingTrendResponse\x12i\n" +
+	"\x12CreateEquityBucket\x12(.wealthflow.v1.CreateEquityBucketRequest\x1a).wealthflow.v1.CreateEquityBucketResponse\x12f\n" +
+	"\x11AddEquityPurchase\x12'.wealthflow.v1.AddEquityPurchaseRequest\x1a(.wealthflow.v1.AddEquityPurchaseResponse\x12\x8d\x01\n" +
+	"\x1eGetTransactionsByDateHistogram\x124.wealthflow.v1.GetTransactionsByDateHistogramRequest\x1a5.wealthflow.v1.GetTransactionsByDateHistogramResponse\x12{\n" +
+	"\x18ListOverdueTransferTasks\x12..wealthflow.v1.ListOverdueTransferTasksRequest\x1a/.wealthflow.v1.ListOverdueTransferTasksResponse\x12\x9c\x01\n" +
+	"#GetExpenseBreakdownByPhysicalBucket\x129.wealthflow.v1.GetExpenseBreakdownByPhysicalBucketRequest\x1a:.wealthflow.v1.GetExpenseBreakdownByPhysicalBucketResponse\x12~\n" +
+	"\x19GetInflowAllocationShares\x12/.wealthflow.v1.GetInflowAllocationSharesRequest\x1a0.wealthflow.v1.GetInflowAllocationSharesResponse\x12r\n" +
+	"\x15VerifyLedgerIntegrity\x12+.wealthflow.v1.VerifyLedgerIntegrityRequest\x1a,.wealthflow.v1.VerifyLedgerIntegrityResponse\x12`\n" +
+	"\x0fLogSplitExpense\x12%.wealthflow.v1.LogSplitExpenseRequest\x1a&.wealthflow.v1.LogSplitExpenseResponse\x12W\n" +
+	"\fGetLiquidity\x12\".wealthflow.v1.GetLiquidityRequest\x1a#.wealthflow.v1.GetLiquidityResponse\x12\x87\x01\n" +
+	"\x1cProcessScheduledTransactions\x122.wealthflow.v1.ProcessScheduledTransactionsRequest\x1a3.wealthflow.v1.ProcessScheduledTransactionsResponse\x12f\n" +
+	"\x11ListIncomeSources\x12'.wealthflow.v1.ListIncomeSourcesRequest\x1a(.wealthflow.v1.ListIncomeSourcesResponse\x12~\n" +
+	"\x19MoveBetweenVirtualBuckets\x12/.wealthflow.v1.MoveBetweenVirtualBucketsRequest\x1a0.wealthflow.v1.MoveBetweenVirtualBucketsResponse\x12l\n" +
+	"\x13GetInflowAllocation\x12).wealthflow.v1.GetInflowAllocationRequest\x1a*.wealthflow.v1.GetInflowAllocationResponse\x12{\n" +
+	"\x18RecategorizeTransactions\x12..wealthflow.v1.RecategorizeTransactionsRequest\x1a/.wealthflow.v1.RecategorizeTransactionsResponse\x12W\n" +
+	"\fGetStatement\x12\".wealthflow.v1.GetStatementRequest\x1a#.wealthflow.v1.GetStatementResponse\x12]\n" +
+	"\x0eCloneSplitRule\x12$.wealthflow.v1.CloneSplitRuleRequest\x1a%.wealthflow.v1.CloneSplitRuleResponse\x12u\n" +
+	"\x16GetUpcomingObligations\x12,.wealthflow.v1.GetUpcomingObligationsRequest\x1a-.wealthflow.v1.GetUpcomingObligationsResponse\x12Z\n" +
+	"\rCreateBuckets\x12#.wealthflow.v1.CreateBucketsRequest\x1a$.wealthflow.v1.CreateBucketsResponse\x12o\n" +
+	"\x14GetUnallocatedIncome\x12*.wealthflow.v1.GetUnallocatedIncomeRequest\x1a+.wealthflow.v1.GetUnallocatedIncomeResponse\x12~\n" +
+	"\x19AllocateUnallocatedIncome\x12/.wealthflow.v1.AllocateUnallocatedIncomeRequest\x1a0.wealthflow.v1.AllocateUnallocatedIncomeResponse\x12\x8a\x01\n" +
+	"\x1dGetTransactionsBetweenBuckets\x123.wealthflow.v1.GetTransactionsBetweenBucketsRequest\x1a4.wealthflow.v1.GetTransactionsBetweenBucketsResponse\x12c\n" +
+	"\x10GetFundingBucket\x12&.wealthflow.v1.GetFundingBucketRequest\x1a'.wealthflow.v1.GetFundingBucketResponse\x12`\n" +
+	"\x0fEnableSplitRule\x12%.wealthflow.v1.EnableSplitRuleRequest\x1a&.wealthflow.v1.EnableSplitRuleResponse\x12c\n" +
+	"\x10DisableSplitRule\x12&.wealthflow.v1.DisableSplitRuleRequest\x1a'.wealthflow.v1.DisableSplitRuleResponseBJZHgithub.com/simaogato/wealthflow-backend/proto/wealthflow/v1;wealthflowv1b\x06proto3"
 
 var (
 	file_wealthflow_v1_service_proto_rawDescOnce sync.Once
@@ -1185,62 +9273,373 @@ func file_wealthflow_v1_service_proto_rawDescGZIP() []byte {
 	return file_wealthflow_v1_service_proto_rawDescData
 }
 
-var file_wealthflow_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_wealthflow_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_wealthflow_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_wealthflow_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 143)
 var file_wealthflow_v1_service_proto_goTypes = []any{
-	(BucketType)(0),                  // 0: wealthflow.v1.BucketType
-	(*RecordInflowRequest)(nil),      // 1: wealthflow.v1.RecordInflowRequest
-	(*RecordInflowResponse)(nil),     // 2: wealthflow.v1.RecordInflowResponse
-	(*LogExpenseRequest)(nil),        // 3: wealthflow.v1.LogExpenseRequest
-	(*LogExpenseResponse)(nil),       // 4: wealthflow.v1.LogExpenseResponse
-	(*UpdateInvestmentRequest)(nil),  // 5: wealthflow.v1.UpdateInvestmentRequest
-	(*UpdateInvestmentResponse)(nil), // 6: wealthflow.v1.UpdateInvestmentResponse
-	(*ListBucketsRequest)(nil),       // 7: wealthflow.v1.ListBucketsRequest
-	(*ListBucketsResponse)(nil),      // 8: wealthflow.v1.ListBucketsResponse
-	(*Bucket)(nil),                   // 9: wealthflow.v1.Bucket
-	(*ListTransactionsRequest)(nil),  // 10: wealthflow.v1.ListTransactionsRequest
-	(*ListTransactionsResponse)(nil), // 11: wealthflow.v1.ListTransactionsResponse
-	(*Transaction)(nil),              // 12: wealthflow.v1.Transaction
-	(*GetNetWorthRequest)(nil),       // 13: wealthflow.v1.GetNetWorthRequest
-	(*GetNetWorthResponse)(nil),      // 14: wealthflow.v1.GetNetWorthResponse
-	(*GetBucketRequest)(nil),         // 15: wealthflow.v1.GetBucketRequest
-	(*GetBucketResponse)(nil),        // 16: wealthflow.v1.GetBucketResponse
-	nil,                              // 17: wealthflow.v1.ListTransactionsResponse.BucketNamesEntry
-	(*timestamppb.Timestamp)(nil),    // 18: google.protobuf.Timestamp
+	(BucketType)(0),                                     // 0: wealthflow.v1.BucketType
+	(SplitRuleItemType)(0),                              // 1: wealthflow.v1.SplitRuleItemType
+	(AllocationMode)(0),                                 // 2: wealthflow.v1.AllocationMode
+	(*RecordInflowRequest)(nil),                         // 3: wealthflow.v1.RecordInflowRequest
+	(*RecordInflowResponse)(nil),                        // 4: wealthflow.v1.RecordInflowResponse
+	(*LogExpenseRequest)(nil),                           // 5: wealthflow.v1.LogExpenseRequest
+	(*LogExpenseResponse)(nil),                          // 6: wealthflow.v1.LogExpenseResponse
+	(*UpdateInvestmentRequest)(nil),                     // 7: wealthflow.v1.UpdateInvestmentRequest
+	(*UpdateInvestmentResponse)(nil),                    // 8: wealthflow.v1.UpdateInvestmentResponse
+	(*ListBucketsRequest)(nil),                          // 9: wealthflow.v1.ListBucketsRequest
+	(*ListBucketsResponse)(nil),                         // 10: wealthflow.v1.ListBucketsResponse
+	(*Bucket)(nil),                                      // 11: wealthflow.v1.Bucket
+	(*ListTransactionsRequest)(nil),                     // 12: wealthflow.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),                    // 13: wealthflow.v1.ListTransactionsResponse
+	(*Transaction)(nil),                                 // 14: wealthflow.v1.Transaction
+	(*GetNetWorthRequest)(nil),                          // 15: wealthflow.v1.GetNetWorthRequest
+	(*GetNetWorthResponse)(nil),                         // 16: wealthflow.v1.GetNetWorthResponse
+	(*GetBucketRequest)(nil),                            // 17: wealthflow.v1.GetBucketRequest
+	(*GetBucketResponse)(nil),                           // 18: wealthflow.v1.GetBucketResponse
+	(*ImportTransactionsRequest)(nil),                   // 19: wealthflow.v1.ImportTransactionsRequest
+	(*ImportTransactionsResponse)(nil),                  // 20: wealthflow.v1.ImportTransactionsResponse
+	(*ImportRowError)(nil),                              // 21: wealthflow.v1.ImportRowError
+	(*GetBucketTreeRequest)(nil),                        // 22: wealthflow.v1.GetBucketTreeRequest
+	(*GetBucketTreeResponse)(nil),                       // 23: wealthflow.v1.GetBucketTreeResponse
+	(*BucketTreeNode)(nil),                              // 24: wealthflow.v1.BucketTreeNode
+	(*GetUnbucketedAmountRequest)(nil),                  // 25: wealthflow.v1.GetUnbucketedAmountRequest
+	(*GetUnbucketedAmountResponse)(nil),                 // 26: wealthflow.v1.GetUnbucketedAmountResponse
+	(*GetBucketTransactionsRequest)(nil),                // 27: wealthflow.v1.GetBucketTransactionsRequest
+	(*GetBucketTransactionsResponse)(nil),               // 28: wealthflow.v1.GetBucketTransactionsResponse
+	(*PreviewAllocationRequest)(nil),                    // 29: wealthflow.v1.PreviewAllocationRequest
+	(*PreviewAllocationResponse)(nil),                   // 30: wealthflow.v1.PreviewAllocationResponse
+	(*AllocationAmount)(nil),                            // 31: wealthflow.v1.AllocationAmount
+	(*AllocationStep)(nil),                              // 32: wealthflow.v1.AllocationStep
+	(*SplitRuleItem)(nil),                               // 33: wealthflow.v1.SplitRuleItem
+	(*CreateSplitRuleRequest)(nil),                      // 34: wealthflow.v1.CreateSplitRuleRequest
+	(*CreateSplitRuleResponse)(nil),                     // 35: wealthflow.v1.CreateSplitRuleResponse
+	(*ReparentVirtualBucketRequest)(nil),                // 36: wealthflow.v1.ReparentVirtualBucketRequest
+	(*ReparentVirtualBucketResponse)(nil),               // 37: wealthflow.v1.ReparentVirtualBucketResponse
+	(*ListBucketsByParentRequest)(nil),                  // 38: wealthflow.v1.ListBucketsByParentRequest
+	(*ListBucketsByParentResponse)(nil),                 // 39: wealthflow.v1.ListBucketsByParentResponse
+	(*GetTransactionRequest)(nil),                       // 40: wealthflow.v1.GetTransactionRequest
+	(*GetTransactionResponse)(nil),                      // 41: wealthflow.v1.GetTransactionResponse
+	(*TransactionEntry)(nil),                            // 42: wealthflow.v1.TransactionEntry
+	(*SetOpeningBalanceRequest)(nil),                    // 43: wealthflow.v1.SetOpeningBalanceRequest
+	(*SetOpeningBalanceResponse)(nil),                   // 44: wealthflow.v1.SetOpeningBalanceResponse
+	(*GetProfitHistoryRequest)(nil),                     // 45: wealthflow.v1.GetProfitHistoryRequest
+	(*GetProfitHistoryResponse)(nil),                    // 46: wealthflow.v1.GetProfitHistoryResponse
+	(*ProfitPoint)(nil),                                 // 47: wealthflow.v1.ProfitPoint
+	(*ListMarketValueHistoryRequest)(nil),               // 48: wealthflow.v1.ListMarketValueHistoryRequest
+	(*ListMarketValueHistoryResponse)(nil),              // 49: wealthflow.v1.ListMarketValueHistoryResponse
+	(*MarketValueEntry)(nil),                            // 50: wealthflow.v1.MarketValueEntry
+	(*DeleteTransactionRequest)(nil),                    // 51: wealthflow.v1.DeleteTransactionRequest
+	(*DeleteTransactionResponse)(nil),                   // 52: wealthflow.v1.DeleteTransactionResponse
+	(*GetTransactionCountRequest)(nil),                  // 53: wealthflow.v1.GetTransactionCountRequest
+	(*GetTransactionCountResponse)(nil),                 // 54: wealthflow.v1.GetTransactionCountResponse
+	(*GetBucketsSummaryRequest)(nil),                    // 55: wealthflow.v1.GetBucketsSummaryRequest
+	(*GetBucketsSummaryResponse)(nil),                   // 56: wealthflow.v1.GetBucketsSummaryResponse
+	(*GetSplitRuleRequest)(nil),                         // 57: wealthflow.v1.GetSplitRuleRequest
+	(*GetSplitRuleResponse)(nil),                        // 58: wealthflow.v1.GetSplitRuleResponse
+	(*ListTransferTasksRequest)(nil),                    // 59: wealthflow.v1.ListTransferTasksRequest
+	(*ListTransferTasksResponse)(nil),                   // 60: wealthflow.v1.ListTransferTasksResponse
+	(*TransferTask)(nil),                                // 61: wealthflow.v1.TransferTask
+	(*MergeCategoryBucketsRequest)(nil),                 // 62: wealthflow.v1.MergeCategoryBucketsRequest
+	(*MergeCategoryBucketsResponse)(nil),                // 63: wealthflow.v1.MergeCategoryBucketsResponse
+	(*GetInvestmentProfitRequest)(nil),                  // 64: wealthflow.v1.GetInvestmentProfitRequest
+	(*GetInvestmentProfitResponse)(nil),                 // 65: wealthflow.v1.GetInvestmentProfitResponse
+	(*ValidateSplitRuleRequest)(nil),                    // 66: wealthflow.v1.ValidateSplitRuleRequest
+	(*ValidateSplitRuleResponse)(nil),                   // 67: wealthflow.v1.ValidateSplitRuleResponse
+	(*RecalculateBalancesRequest)(nil),                  // 68: wealthflow.v1.RecalculateBalancesRequest
+	(*RecalculateBalancesResponse)(nil),                 // 69: wealthflow.v1.RecalculateBalancesResponse
+	(*SetBucketGoalRequest)(nil),                        // 70: wealthflow.v1.SetBucketGoalRequest
+	(*SetBucketGoalResponse)(nil),                       // 71: wealthflow.v1.SetBucketGoalResponse
+	(*ListBucketGoalsRequest)(nil),                      // 72: wealthflow.v1.ListBucketGoalsRequest
+	(*BucketGoal)(nil),                                  // 73: wealthflow.v1.BucketGoal
+	(*ListBucketGoalsResponse)(nil),                     // 74: wealthflow.v1.ListBucketGoalsResponse
+	(*GetStatusRequest)(nil),                            // 75: wealthflow.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),                           // 76: wealthflow.v1.GetStatusResponse
+	(*CompleteTransferTaskRequest)(nil),                 // 77: wealthflow.v1.CompleteTransferTaskRequest
+	(*CompleteTransferTaskResponse)(nil),                // 78: wealthflow.v1.CompleteTransferTaskResponse
+	(*GetCategorySpendingTrendRequest)(nil),             // 79: wealthflow.v1.GetCategorySpendingTrendRequest
+	(*GetCategorySpendingTrendResponse)(nil),            // 80: wealthflow.v1.GetCategorySpendingTrendResponse
+	(*SpendingPeriod)(nil),                              // 81: wealthflow.v1.SpendingPeriod
+	(*CreateEquityBucketRequest)(nil),                   // 82: wealthflow.v1.CreateEquityBucketRequest
+	(*CreateEquityBucketResponse)(nil),                  // 83: wealthflow.v1.CreateEquityBucketResponse
+	(*AddEquityPurchaseRequest)(nil),                    // 84: wealthflow.v1.AddEquityPurchaseRequest
+	(*AddEquityPurchaseResponse)(nil),                   // 85: wealthflow.v1.AddEquityPurchaseResponse
+	(*GetTransactionsByDateHistogramRequest)(nil),       // 86: wealthflow.v1.GetTransactionsByDateHistogramRequest
+	(*GetTransactionsByDateHistogramResponse)(nil),      // 87: wealthflow.v1.GetTransactionsByDateHistogramResponse
+	(*TransactionDayCount)(nil),                         // 88: wealthflow.v1.TransactionDayCount
+	(*ListOverdueTransferTasksRequest)(nil),             // 89: wealthflow.v1.ListOverdueTransferTasksRequest
+	(*ListOverdueTransferTasksResponse)(nil),            // 90: wealthflow.v1.ListOverdueTransferTasksResponse
+	(*GetExpenseBreakdownByPhysicalBucketRequest)(nil),  // 91: wealthflow.v1.GetExpenseBreakdownByPhysicalBucketRequest
+	(*GetExpenseBreakdownByPhysicalBucketResponse)(nil), // 92: wealthflow.v1.GetExpenseBreakdownByPhysicalBucketResponse
+	(*PhysicalBucketSpending)(nil),                      // 93: wealthflow.v1.PhysicalBucketSpending
+	(*GetInflowAllocationSharesRequest)(nil),            // 94: wealthflow.v1.GetInflowAllocationSharesRequest
+	(*GetInflowAllocationSharesResponse)(nil),           // 95: wealthflow.v1.GetInflowAllocationSharesResponse
+	(*AllocationShare)(nil),                             // 96: wealthflow.v1.AllocationShare
+	(*VerifyLedgerIntegrityRequest)(nil),                // 97: wealthflow.v1.VerifyLedgerIntegrityRequest
+	(*VerifyLedgerIntegrityResponse)(nil),               // 98: wealthflow.v1.VerifyLedgerIntegrityResponse
+	(*LedgerViolation)(nil),                             // 99: wealthflow.v1.LedgerViolation
+	(*LogSplitExpenseRequest)(nil),                      // 100: wealthflow.v1.LogSplitExpenseRequest
+	(*ExpenseLine)(nil),                                 // 101: wealthflow.v1.ExpenseLine
+	(*LogSplitExpenseResponse)(nil),                     // 102: wealthflow.v1.LogSplitExpenseResponse
+	(*GetLiquidityRequest)(nil),                         // 103: wealthflow.v1.GetLiquidityRequest
+	(*GetLiquidityResponse)(nil),                        // 104: wealthflow.v1.GetLiquidityResponse
+	(*ProcessScheduledTransactionsRequest)(nil),         // 105: wealthflow.v1.ProcessScheduledTransactionsRequest
+	(*ProcessScheduledTransactionsResponse)(nil),        // 106: wealthflow.v1.ProcessScheduledTransactionsResponse
+	(*ListIncomeSourcesRequest)(nil),                    // 107: wealthflow.v1.ListIncomeSourcesRequest
+	(*IncomeSource)(nil),                                // 108: wealthflow.v1.IncomeSource
+	(*ListIncomeSourcesResponse)(nil),                   // 109: wealthflow.v1.ListIncomeSourcesResponse
+	(*MoveBetweenVirtualBucketsRequest)(nil),            // 110: wealthflow.v1.MoveBetweenVirtualBucketsRequest
+	(*MoveBetweenVirtualBucketsResponse)(nil),           // 111: wealthflow.v1.MoveBetweenVirtualBucketsResponse
+	(*GetInflowAllocationRequest)(nil),                  // 112: wealthflow.v1.GetInflowAllocationRequest
+	(*GetInflowAllocationResponse)(nil),                 // 113: wealthflow.v1.GetInflowAllocationResponse
+	(*RecategorizeTransactionsRequest)(nil),             // 114: wealthflow.v1.RecategorizeTransactionsRequest
+	(*RecategorizeTransactionsResponse)(nil),            // 115: wealthflow.v1.RecategorizeTransactionsResponse
+	(*GetStatementRequest)(nil),                         // 116: wealthflow.v1.GetStatementRequest
+	(*GetStatementResponse)(nil),                        // 117: wealthflow.v1.GetStatementResponse
+	(*StatementTransaction)(nil),                        // 118: wealthflow.v1.StatementTransaction
+	(*StatementMarketValueChange)(nil),                  // 119: wealthflow.v1.StatementMarketValueChange
+	(*CloneSplitRuleRequest)(nil),                       // 120: wealthflow.v1.CloneSplitRuleRequest
+	(*CloneSplitRuleResponse)(nil),                      // 121: wealthflow.v1.CloneSplitRuleResponse
+	(*GetUpcomingObligationsRequest)(nil),               // 122: wealthflow.v1.GetUpcomingObligationsRequest
+	(*GetUpcomingObligationsResponse)(nil),              // 123: wealthflow.v1.GetUpcomingObligationsResponse
+	(*UpcomingObligation)(nil),                          // 124: wealthflow.v1.UpcomingObligation
+	(*CreateBucketsRequest)(nil),                        // 125: wealthflow.v1.CreateBucketsRequest
+	(*NewBucket)(nil),                                   // 126: wealthflow.v1.NewBucket
+	(*CreateBucketsResponse)(nil),                       // 127: wealthflow.v1.CreateBucketsResponse
+	(*GetUnallocatedIncomeRequest)(nil),                 // 128: wealthflow.v1.GetUnallocatedIncomeRequest
+	(*GetUnallocatedIncomeResponse)(nil),                // 129: wealthflow.v1.GetUnallocatedIncomeResponse
+	(*ParkedIncomeChange)(nil),                          // 130: wealthflow.v1.ParkedIncomeChange
+	(*AllocateUnallocatedIncomeRequest)(nil),            // 131: wealthflow.v1.AllocateUnallocatedIncomeRequest
+	(*ParkedIncomeAllocation)(nil),                      // 132: wealthflow.v1.ParkedIncomeAllocation
+	(*AllocateUnallocatedIncomeResponse)(nil),           // 133: wealthflow.v1.AllocateUnallocatedIncomeResponse
+	(*GetTransactionsBetweenBucketsRequest)(nil),        // 134: wealthflow.v1.GetTransactionsBetweenBucketsRequest
+	(*GetTransactionsBetweenBucketsResponse)(nil),       // 135: wealthflow.v1.GetTransactionsBetweenBucketsResponse
+	(*GetFundingBucketRequest)(nil),                     // 136: wealthflow.v1.GetFundingBucketRequest
+	(*GetFundingBucketResponse)(nil),                    // 137: wealthflow.v1.GetFundingBucketResponse
+	(*EnableSplitRuleRequest)(nil),                      // 138: wealthflow.v1.EnableSplitRuleRequest
+	(*EnableSplitRuleResponse)(nil),                     // 139: wealthflow.v1.EnableSplitRuleResponse
+	(*DisableSplitRuleRequest)(nil),                     // 140: wealthflow.v1.DisableSplitRuleRequest
+	(*DisableSplitRuleResponse)(nil),                    // 141: wealthflow.v1.DisableSplitRuleResponse
+	nil,                                                 // 142: wealthflow.v1.ListTransactionsResponse.BucketNamesEntry
+	nil,                                                 // 143: wealthflow.v1.GetBucketTransactionsResponse.BucketNamesEntry
+	nil,                                                 // 144: wealthflow.v1.GetTransactionResponse.BucketNamesEntry
+	nil,                                                 // 145: wealthflow.v1.GetBucketsSummaryResponse.CountsByTypeEntry
+	(*timestamppb.Timestamp)(nil),                       // 146: google.protobuf.Timestamp
 }
 var file_wealthflow_v1_service_proto_depIdxs = []int32{
-	18, // 0: wealthflow.v1.RecordInflowRequest.date:type_name -> google.protobuf.Timestamp
-	18, // 1: wealthflow.v1.RecordInflowResponse.created_at:type_name -> google.protobuf.Timestamp
-	18, // 2: wealthflow.v1.LogExpenseRequest.date:type_name -> google.protobuf.Timestamp
-	18, // 3: wealthflow.v1.LogExpenseResponse.created_at:type_name -> google.protobuf.Timestamp
-	18, // 4: wealthflow.v1.UpdateInvestmentRequest.date:type_name -> google.protobuf.Timestamp
-	18, // 5: wealthflow.v1.UpdateInvestmentResponse.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 6: wealthflow.v1.ListBucketsRequest.bucket_type:type_name -> wealthflow.v1.BucketType
-	9,  // 7: wealthflow.v1.ListBucketsResponse.buckets:type_name -> wealthflow.v1.Bucket
-	0,  // 8: wealthflow.v1.Bucket.type:type_name -> wealthflow.v1.BucketType
-	12, // 9: wealthflow.v1.ListTransactionsResponse.transactions:type_name -> wealthflow.v1.Transaction
-	17, // 10: wealthflow.v1.ListTransactionsResponse.bucket_names:type_name -> wealthflow.v1.ListTransactionsResponse.BucketNamesEntry
-	18, // 11: wealthflow.v1.Transaction.date:type_name -> google.protobuf.Timestamp
-	9,  // 12: wealthflow.v1.GetBucketResponse.bucket:type_name -> wealthflow.v1.Bucket
-	1,  // 13: wealthflow.v1.WealthFlowService.RecordInflow:input_type -> wealthflow.v1.RecordInflowRequest
-	3,  // 14: wealthflow.v1.WealthFlowService.LogExpense:input_type -> wealthflow.v1.LogExpenseRequest
-	5,  // 15: wealthflow.v1.WealthFlowService.UpdateInvestment:input_type -> wealthflow.v1.UpdateInvestmentRequest
-	7,  // 16: wealthflow.v1.WealthFlowService.ListBuckets:input_type -> wealthflow.v1.ListBucketsRequest
-	10, // 17: wealthflow.v1.WealthFlowService.ListTransactions:input_type -> wealthflow.v1.ListTransactionsRequest
-	13, // 18: wealthflow.v1.WealthFlowService.GetNetWorth:input_type -> wealthflow.v1.GetNetWorthRequest
-	15, // 19: wealthflow.v1.WealthFlowService.GetBucket:input_type -> wealthflow.v1.GetBucketRequest
-	2,  // 20: wealthflow.v1.WealthFlowService.RecordInflow:output_type -> wealthflow.v1.RecordInflowResponse
-	4,  // 21: wealthflow.v1.WealthFlowService.LogExpense:output_type -> wealthflow.v1.LogExpenseResponse
-	6,  // 22: wealthflow.v1.WealthFlowService.UpdateInvestment:output_type -> wealthflow.v1.UpdateInvestmentResponse
-	8,  // 23: wealthflow.v1.WealthFlowService.ListBuckets:output_type -> wealthflow.v1.ListBucketsResponse
-	11, // 24: wealthflow.v1.WealthFlowService.ListTransactions:output_type -> wealthflow.v1.ListTransactionsResponse
-	14, // 25: wealthflow.v1.WealthFlowService.GetNetWorth:output_type -> wealthflow.v1.GetNetWorthResponse
-	16, // 26: wealthflow.v1.WealthFlowService.GetBucket:output_type -> wealthflow.v1.GetBucketResponse
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	146, // 0: wealthflow.v1.RecordInflowRequest.date:type_name -> google.protobuf.Timestamp
+	2,   // 1: wealthflow.v1.RecordInflowRequest.allocation_mode:type_name -> wealthflow.v1.AllocationMode
+	146, // 2: wealthflow.v1.RecordInflowResponse.created_at:type_name -> google.protobuf.Timestamp
+	31,  // 3: wealthflow.v1.RecordInflowResponse.allocations:type_name -> wealthflow.v1.AllocationAmount
+	146, // 4: wealthflow.v1.LogExpenseRequest.date:type_name -> google.protobuf.Timestamp
+	146, // 5: wealthflow.v1.LogExpenseRequest.effective_date:type_name -> google.protobuf.Timestamp
+	146, // 6: wealthflow.v1.LogExpenseResponse.created_at:type_name -> google.protobuf.Timestamp
+	146, // 7: wealthflow.v1.UpdateInvestmentRequest.date:type_name -> google.protobuf.Timestamp
+	146, // 8: wealthflow.v1.UpdateInvestmentResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 9: wealthflow.v1.ListBucketsRequest.bucket_type:type_name -> wealthflow.v1.BucketType
+	11,  // 10: wealthflow.v1.ListBucketsResponse.buckets:type_name -> wealthflow.v1.Bucket
+	0,   // 11: wealthflow.v1.Bucket.type:type_name -> wealthflow.v1.BucketType
+	14,  // 12: wealthflow.v1.ListTransactionsResponse.transactions:type_name -> wealthflow.v1.Transaction
+	142, // 13: wealthflow.v1.ListTransactionsResponse.bucket_names:type_name -> wealthflow.v1.ListTransactionsResponse.BucketNamesEntry
+	146, // 14: wealthflow.v1.Transaction.date:type_name -> google.protobuf.Timestamp
+	42,  // 15: wealthflow.v1.Transaction.entries:type_name -> wealthflow.v1.TransactionEntry
+	146, // 16: wealthflow.v1.GetNetWorthResponse.calculated_at:type_name -> google.protobuf.Timestamp
+	11,  // 17: wealthflow.v1.GetBucketResponse.bucket:type_name -> wealthflow.v1.Bucket
+	50,  // 18: wealthflow.v1.GetBucketResponse.recent_market_values:type_name -> wealthflow.v1.MarketValueEntry
+	3,   // 19: wealthflow.v1.ImportTransactionsRequest.inflow:type_name -> wealthflow.v1.RecordInflowRequest
+	5,   // 20: wealthflow.v1.ImportTransactionsRequest.expense:type_name -> wealthflow.v1.LogExpenseRequest
+	21,  // 21: wealthflow.v1.ImportTransactionsResponse.errors:type_name -> wealthflow.v1.ImportRowError
+	24,  // 22: wealthflow.v1.GetBucketTreeResponse.nodes:type_name -> wealthflow.v1.BucketTreeNode
+	11,  // 23: wealthflow.v1.BucketTreeNode.bucket:type_name -> wealthflow.v1.Bucket
+	11,  // 24: wealthflow.v1.BucketTreeNode.children:type_name -> wealthflow.v1.Bucket
+	14,  // 25: wealthflow.v1.GetBucketTransactionsResponse.transactions:type_name -> wealthflow.v1.Transaction
+	143, // 26: wealthflow.v1.GetBucketTransactionsResponse.bucket_names:type_name -> wealthflow.v1.GetBucketTransactionsResponse.BucketNamesEntry
+	2,   // 27: wealthflow.v1.PreviewAllocationRequest.allocation_mode:type_name -> wealthflow.v1.AllocationMode
+	31,  // 28: wealthflow.v1.PreviewAllocationResponse.allocations:type_name -> wealthflow.v1.AllocationAmount
+	32,  // 29: wealthflow.v1.PreviewAllocationResponse.steps:type_name -> wealthflow.v1.AllocationStep
+	1,   // 30: wealthflow.v1.AllocationStep.type:type_name -> wealthflow.v1.SplitRuleItemType
+	1,   // 31: wealthflow.v1.SplitRuleItem.type:type_name -> wealthflow.v1.SplitRuleItemType
+	33,  // 32: wealthflow.v1.CreateSplitRuleRequest.items:type_name -> wealthflow.v1.SplitRuleItem
+	11,  // 33: wealthflow.v1.ReparentVirtualBucketResponse.bucket:type_name -> wealthflow.v1.Bucket
+	11,  // 34: wealthflow.v1.ListBucketsByParentResponse.buckets:type_name -> wealthflow.v1.Bucket
+	14,  // 35: wealthflow.v1.GetTransactionResponse.transaction:type_name -> wealthflow.v1.Transaction
+	42,  // 36: wealthflow.v1.GetTransactionResponse.entries:type_name -> wealthflow.v1.TransactionEntry
+	144, // 37: wealthflow.v1.GetTransactionResponse.bucket_names:type_name -> wealthflow.v1.GetTransactionResponse.BucketNamesEntry
+	146, // 38: wealthflow.v1.GetProfitHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 39: wealthflow.v1.GetProfitHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	47,  // 40: wealthflow.v1.GetProfitHistoryResponse.points:type_name -> wealthflow.v1.ProfitPoint
+	146, // 41: wealthflow.v1.ProfitPoint.date:type_name -> google.protobuf.Timestamp
+	50,  // 42: wealthflow.v1.ListMarketValueHistoryResponse.entries:type_name -> wealthflow.v1.MarketValueEntry
+	146, // 43: wealthflow.v1.MarketValueEntry.date:type_name -> google.protobuf.Timestamp
+	145, // 44: wealthflow.v1.GetBucketsSummaryResponse.counts_by_type:type_name -> wealthflow.v1.GetBucketsSummaryResponse.CountsByTypeEntry
+	33,  // 45: wealthflow.v1.GetSplitRuleResponse.items:type_name -> wealthflow.v1.SplitRuleItem
+	61,  // 46: wealthflow.v1.ListTransferTasksResponse.tasks:type_name -> wealthflow.v1.TransferTask
+	146, // 47: wealthflow.v1.TransferTask.created_at:type_name -> google.protobuf.Timestamp
+	33,  // 48: wealthflow.v1.ValidateSplitRuleRequest.items:type_name -> wealthflow.v1.SplitRuleItem
+	11,  // 49: wealthflow.v1.SetBucketGoalResponse.bucket:type_name -> wealthflow.v1.Bucket
+	11,  // 50: wealthflow.v1.BucketGoal.bucket:type_name -> wealthflow.v1.Bucket
+	73,  // 51: wealthflow.v1.ListBucketGoalsResponse.goals:type_name -> wealthflow.v1.BucketGoal
+	61,  // 52: wealthflow.v1.CompleteTransferTaskResponse.task:type_name -> wealthflow.v1.TransferTask
+	146, // 53: wealthflow.v1.GetCategorySpendingTrendRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 54: wealthflow.v1.GetCategorySpendingTrendRequest.end_date:type_name -> google.protobuf.Timestamp
+	81,  // 55: wealthflow.v1.GetCategorySpendingTrendResponse.points:type_name -> wealthflow.v1.SpendingPeriod
+	146, // 56: wealthflow.v1.SpendingPeriod.period:type_name -> google.protobuf.Timestamp
+	11,  // 57: wealthflow.v1.CreateEquityBucketResponse.bucket:type_name -> wealthflow.v1.Bucket
+	146, // 58: wealthflow.v1.GetTransactionsByDateHistogramRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 59: wealthflow.v1.GetTransactionsByDateHistogramRequest.end_date:type_name -> google.protobuf.Timestamp
+	88,  // 60: wealthflow.v1.GetTransactionsByDateHistogramResponse.days:type_name -> wealthflow.v1.TransactionDayCount
+	146, // 61: wealthflow.v1.TransactionDayCount.date:type_name -> google.protobuf.Timestamp
+	61,  // 62: wealthflow.v1.ListOverdueTransferTasksResponse.tasks:type_name -> wealthflow.v1.TransferTask
+	146, // 63: wealthflow.v1.GetExpenseBreakdownByPhysicalBucketRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 64: wealthflow.v1.GetExpenseBreakdownByPhysicalBucketRequest.end_date:type_name -> google.protobuf.Timestamp
+	93,  // 65: wealthflow.v1.GetExpenseBreakdownByPhysicalBucketResponse.buckets:type_name -> wealthflow.v1.PhysicalBucketSpending
+	146, // 66: wealthflow.v1.GetInflowAllocationSharesRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 67: wealthflow.v1.GetInflowAllocationSharesRequest.end_date:type_name -> google.protobuf.Timestamp
+	96,  // 68: wealthflow.v1.GetInflowAllocationSharesResponse.shares:type_name -> wealthflow.v1.AllocationShare
+	11,  // 69: wealthflow.v1.AllocationShare.bucket:type_name -> wealthflow.v1.Bucket
+	99,  // 70: wealthflow.v1.VerifyLedgerIntegrityResponse.violations:type_name -> wealthflow.v1.LedgerViolation
+	101, // 71: wealthflow.v1.LogSplitExpenseRequest.lines:type_name -> wealthflow.v1.ExpenseLine
+	146, // 72: wealthflow.v1.LogSplitExpenseResponse.created_at:type_name -> google.protobuf.Timestamp
+	146, // 73: wealthflow.v1.ProcessScheduledTransactionsRequest.as_of:type_name -> google.protobuf.Timestamp
+	11,  // 74: wealthflow.v1.IncomeSource.bucket:type_name -> wealthflow.v1.Bucket
+	108, // 75: wealthflow.v1.ListIncomeSourcesResponse.sources:type_name -> wealthflow.v1.IncomeSource
+	146, // 76: wealthflow.v1.GetInflowAllocationResponse.date:type_name -> google.protobuf.Timestamp
+	31,  // 77: wealthflow.v1.GetInflowAllocationResponse.allocations:type_name -> wealthflow.v1.AllocationAmount
+	146, // 78: wealthflow.v1.GetStatementRequest.start_date:type_name -> google.protobuf.Timestamp
+	146, // 79: wealthflow.v1.GetStatementRequest.end_date:type_name -> google.protobuf.Timestamp
+	118, // 80: wealthflow.v1.GetStatementResponse.transactions:type_name -> wealthflow.v1.StatementTransaction
+	119, // 81: wealthflow.v1.GetStatementResponse.market_value_changes:type_name -> wealthflow.v1.StatementMarketValueChange
+	14,  // 82: wealthflow.v1.StatementTransaction.transaction:type_name -> wealthflow.v1.Transaction
+	146, // 83: wealthflow.v1.StatementMarketValueChange.date:type_name -> google.protobuf.Timestamp
+	33,  // 84: wealthflow.v1.CloneSplitRuleResponse.items:type_name -> wealthflow.v1.SplitRuleItem
+	124, // 85: wealthflow.v1.GetUpcomingObligationsResponse.items:type_name -> wealthflow.v1.UpcomingObligation
+	146, // 86: wealthflow.v1.GetUpcomingObligationsResponse.until:type_name -> google.protobuf.Timestamp
+	14,  // 87: wealthflow.v1.UpcomingObligation.transaction:type_name -> wealthflow.v1.Transaction
+	126, // 88: wealthflow.v1.CreateBucketsRequest.buckets:type_name -> wealthflow.v1.NewBucket
+	0,   // 89: wealthflow.v1.NewBucket.type:type_name -> wealthflow.v1.BucketType
+	11,  // 90: wealthflow.v1.CreateBucketsResponse.buckets:type_name -> wealthflow.v1.Bucket
+	130, // 91: wealthflow.v1.GetUnallocatedIncomeResponse.contributions:type_name -> wealthflow.v1.ParkedIncomeChange
+	14,  // 92: wealthflow.v1.ParkedIncomeChange.transaction:type_name -> wealthflow.v1.Transaction
+	132, // 93: wealthflow.v1.AllocateUnallocatedIncomeRequest.allocations:type_name -> wealthflow.v1.ParkedIncomeAllocation
+	14,  // 94: wealthflow.v1.GetTransactionsBetweenBucketsResponse.transactions:type_name -> wealthflow.v1.Transaction
+	0,   // 95: wealthflow.v1.GetFundingBucketResponse.type:type_name -> wealthflow.v1.BucketType
+	3,   // 96: wealthflow.v1.WealthFlowService.RecordInflow:input_type -> wealthflow.v1.RecordInflowRequest
+	5,   // 97: wealthflow.v1.WealthFlowService.LogExpense:input_type -> wealthflow.v1.LogExpenseRequest
+	7,   // 98: wealthflow.v1.WealthFlowService.UpdateInvestment:input_type -> wealthflow.v1.UpdateInvestmentRequest
+	9,   // 99: wealthflow.v1.WealthFlowService.ListBuckets:input_type -> wealthflow.v1.ListBucketsRequest
+	12,  // 100: wealthflow.v1.WealthFlowService.ListTransactions:input_type -> wealthflow.v1.ListTransactionsRequest
+	15,  // 101: wealthflow.v1.WealthFlowService.GetNetWorth:input_type -> wealthflow.v1.GetNetWorthRequest
+	17,  // 102: wealthflow.v1.WealthFlowService.GetBucket:input_type -> wealthflow.v1.GetBucketRequest
+	19,  // 103: wealthflow.v1.WealthFlowService.ImportTransactions:input_type -> wealthflow.v1.ImportTransactionsRequest
+	22,  // 104: wealthflow.v1.WealthFlowService.GetBucketTree:input_type -> wealthflow.v1.GetBucketTreeRequest
+	25,  // 105: wealthflow.v1.WealthFlowService.GetUnbucketedAmount:input_type -> wealthflow.v1.GetUnbucketedAmountRequest
+	27,  // 106: wealthflow.v1.WealthFlowService.GetBucketTransactions:input_type -> wealthflow.v1.GetBucketTransactionsRequest
+	29,  // 107: wealthflow.v1.WealthFlowService.PreviewAllocation:input_type -> wealthflow.v1.PreviewAllocationRequest
+	34,  // 108: wealthflow.v1.WealthFlowService.CreateSplitRule:input_type -> wealthflow.v1.CreateSplitRuleRequest
+	36,  // 109: wealthflow.v1.WealthFlowService.ReparentVirtualBucket:input_type -> wealthflow.v1.ReparentVirtualBucketRequest
+	38,  // 110: wealthflow.v1.WealthFlowService.ListBucketsByParent:input_type -> wealthflow.v1.ListBucketsByParentRequest
+	40,  // 111: wealthflow.v1.WealthFlowService.GetTransaction:input_type -> wealthflow.v1.GetTransactionRequest
+	43,  // 112: wealthflow.v1.WealthFlowService.SetOpeningBalance:input_type -> wealthflow.v1.SetOpeningBalanceRequest
+	45,  // 113: wealthflow.v1.WealthFlowService.GetProfitHistory:input_type -> wealthflow.v1.GetProfitHistoryRequest
+	48,  // 114: wealthflow.v1.WealthFlowService.ListMarketValueHistory:input_type -> wealthflow.v1.ListMarketValueHistoryRequest
+	51,  // 115: wealthflow.v1.WealthFlowService.DeleteTransaction:input_type -> wealthflow.v1.DeleteTransactionRequest
+	53,  // 116: wealthflow.v1.WealthFlowService.GetTransactionCount:input_type -> wealthflow.v1.GetTransactionCountRequest
+	55,  // 117: wealthflow.v1.WealthFlowService.GetBucketsSummary:input_type -> wealthflow.v1.GetBucketsSummaryRequest
+	57,  // 118: wealthflow.v1.WealthFlowService.GetSplitRule:input_type -> wealthflow.v1.GetSplitRuleRequest
+	59,  // 119: wealthflow.v1.WealthFlowService.ListTransferTasks:input_type -> wealthflow.v1.ListTransferTasksRequest
+	62,  // 120: wealthflow.v1.WealthFlowService.MergeCategoryBuckets:input_type -> wealthflow.v1.MergeCategoryBucketsRequest
+	64,  // 121: wealthflow.v1.WealthFlowService.GetInvestmentProfit:input_type -> wealthflow.v1.GetInvestmentProfitRequest
+	66,  // 122: wealthflow.v1.WealthFlowService.ValidateSplitRule:input_type -> wealthflow.v1.ValidateSplitRuleRequest
+	68,  // 123: wealthflow.v1.WealthFlowService.RecalculateBalances:input_type -> wealthflow.v1.RecalculateBalancesRequest
+	70,  // 124: wealthflow.v1.WealthFlowService.SetBucketGoal:input_type -> wealthflow.v1.SetBucketGoalRequest
+	72,  // 125: wealthflow.v1.WealthFlowService.ListBucketGoals:input_type -> wealthflow.v1.ListBucketGoalsRequest
+	75,  // 126: wealthflow.v1.WealthFlowService.GetStatus:input_type -> wealthflow.v1.GetStatusRequest
+	77,  // 127: wealthflow.v1.WealthFlowService.CompleteTransferTask:input_type -> wealthflow.v1.CompleteTransferTaskRequest
+	79,  // 128: wealthflow.v1.WealthFlowService.GetCategorySpendingTrend:input_type -> wealthflow.v1.GetCategorySpendingTrendRequest
+	82,  // 129: wealthflow.v1.WealthFlowService.CreateEquityBucket:input_type -> wealthflow.v1.CreateEquityBucketRequest
+	84,  // 130: wealthflow.v1.WealthFlowService.AddEquityPurchase:input_type -> wealthflow.v1.AddEquityPurchaseRequest
+	86,  // 131: wealthflow.v1.WealthFlowService.GetTransactionsByDateHistogram:input_type -> wealthflow.v1.GetTransactionsByDateHistogramRequest
+	89,  // 132: wealthflow.v1.WealthFlowService.ListOverdueTransferTasks:input_type -> wealthflow.v1.ListOverdueTransferTasksRequest
+	91,  // 133: wealthflow.v1.WealthFlowService.GetExpenseBreakdownByPhysicalBucket:input_type -> wealthflow.v1.GetExpenseBreakdownByPhysicalBucketRequest
+	94,  // 134: wealthflow.v1.WealthFlowService.GetInflowAllocationShares:input_type -> wealthflow.v1.GetInflowAllocationSharesRequest
+	97,  // 135: wealthflow.v1.WealthFlowService.VerifyLedgerIntegrity:input_type -> wealthflow.v1.VerifyLedgerIntegrityRequest
+	100, // 136: wealthflow.v1.WealthFlowService.LogSplitExpense:input_type -> wealthflow.v1.LogSplitExpenseRequest
+	103, // 137: wealthflow.v1.WealthFlowService.GetLiquidity:input_type -> wealthflow.v1.GetLiquidityRequest
+	105, // 138: wealthflow.v1.WealthFlowService.ProcessScheduledTransactions:input_type -> wealthflow.v1.ProcessScheduledTransactionsRequest
+	107, // 139: wealthflow.v1.WealthFlowService.ListIncomeSources:input_type -> wealthflow.v1.ListIncomeSourcesRequest
+	110, // 140: wealthflow.v1.WealthFlowService.MoveBetweenVirtualBuckets:input_type -> wealthflow.v1.MoveBetweenVirtualBucketsRequest
+	112, // 141: wealthflow.v1.WealthFlowService.GetInflowAllocation:input_type -> wealthflow.v1.GetInflowAllocationRequest
+	114, // 142: wealthflow.v1.WealthFlowService.RecategorizeTransactions:input_type -> wealthflow.v1.RecategorizeTransactionsRequest
+	116, // 143: wealthflow.v1.WealthFlowService.GetStatement:input_type -> wealthflow.v1.GetStatementRequest
+	120, // 144: wealthflow.v1.WealthFlowService.CloneSplitRule:input_type -> wealthflow.v1.CloneSplitRuleRequest
+	122, // 145: wealthflow.v1.WealthFlowService.GetUpcomingObligations:input_type -> wealthflow.v1.GetUpcomingObligationsRequest
+	125, // 146: wealthflow.v1.WealthFlowService.CreateBuckets:input_type -> wealthflow.v1.CreateBucketsRequest
+	128, // 147: wealthflow.v1.WealthFlowService.GetUnallocatedIncome:input_type -> wealthflow.v1.GetUnallocatedIncomeRequest
+	131, // 148: wealthflow.v1.WealthFlowService.AllocateUnallocatedIncome:input_type -> wealthflow.v1.AllocateUnallocatedIncomeRequest
+	134, // 149: wealthflow.v1.WealthFlowService.GetTransactionsBetweenBuckets:input_type -> wealthflow.v1.GetTransactionsBetweenBucketsRequest
+	136, // 150: wealthflow.v1.WealthFlowService.GetFundingBucket:input_type -> wealthflow.v1.GetFundingBucketRequest
+	138, // 151: wealthflow.v1.WealthFlowService.EnableSplitRule:input_type -> wealthflow.v1.EnableSplitRuleRequest
+	140, // 152: wealthflow.v1.WealthFlowService.DisableSplitRule:input_type -> wealthflow.v1.DisableSplitRuleRequest
+	4,   // 153: wealthflow.v1.WealthFlowService.RecordInflow:output_type -> wealthflow.v1.RecordInflowResponse
+	6,   // 154: wealthflow.v1.WealthFlowService.LogExpense:output_type -> wealthflow.v1.LogExpenseResponse
+	8,   // 155: wealthflow.v1.WealthFlowService.UpdateInvestment:output_type -> wealthflow.v1.UpdateInvestmentResponse
+	10,  // 156: wealthflow.v1.WealthFlowService.ListBuckets:output_type -> wealthflow.v1.ListBucketsResponse
+	13,  // 157: wealthflow.v1.WealthFlowService.ListTransactions:output_type -> wealthflow.v1.ListTransactionsResponse
+	16,  // 158: wealthflow.v1.WealthFlowService.GetNetWorth:output_type -> wealthflow.v1.GetNetWorthResponse
+	18,  // 159: wealthflow.v1.WealthFlowService.GetBucket:output_type -> wealthflow.v1.GetBucketResponse
+	20,  // 160: wealthflow.v1.WealthFlowService.ImportTransactions:output_type -> wealthflow.v1.ImportTransactionsResponse
+	23,  // 161: wealthflow.v1.WealthFlowService.GetBucketTree:output_type -> wealthflow.v1.GetBucketTreeResponse
+	26,  // 162: wealthflow.v1.WealthFlowService.GetUnbucketedAmount:output_type -> wealthflow.v1.GetUnbucketedAmountResponse
+	28,  // 163: wealthflow.v1.WealthFlowService.GetBucketTransactions:output_type -> wealthflow.v1.GetBucketTransactionsResponse
+	30,  // 164: wealthflow.v1.WealthFlowService.PreviewAllocation:output_type -> wealthflow.v1.PreviewAllocationResponse
+	35,  // 165: wealthflow.v1.WealthFlowService.CreateSplitRule:output_type -> wealthflow.v1.CreateSplitRuleResponse
+	37,  // 166: wealthflow.v1.WealthFlowService.ReparentVirtualBucket:output_type -> wealthflow.v1.ReparentVirtualBucketResponse
+	39,  // 167: wealthflow.v1.WealthFlowService.ListBucketsByParent:output_type -> wealthflow.v1.ListBucketsByParentResponse
+	41,  // 168: wealthflow.v1.WealthFlowService.GetTransaction:output_type -> wealthflow.v1.GetTransactionResponse
+	44,  // 169: wealthflow.v1.WealthFlowService.SetOpeningBalance:output_type -> wealthflow.v1.SetOpeningBalanceResponse
+	46,  // 170: wealthflow.v1.WealthFlowService.GetProfitHistory:output_type -> wealthflow.v1.GetProfitHistoryResponse
+	49,  // 171: wealthflow.v1.WealthFlowService.ListMarketValueHistory:output_type -> wealthflow.v1.ListMarketValueHistoryResponse
+	52,  // 172: wealthflow.v1.WealthFlowService.DeleteTransaction:output_type -> wealthflow.v1.DeleteTransactionResponse
+	54,  // 173: wealthflow.v1.WealthFlowService.GetTransactionCount:output_type -> wealthflow.v1.GetTransactionCountResponse
+	56,  // 174: wealthflow.v1.WealthFlowService.GetBucketsSummary:output_type -> wealthflow.v1.GetBucketsSummaryResponse
+	58,  // 175: wealthflow.v1.WealthFlowService.GetSplitRule:output_type -> wealthflow.v1.GetSplitRuleResponse
+	60,  // 176: wealthflow.v1.WealthFlowService.ListTransferTasks:output_type -> wealthflow.v1.ListTransferTasksResponse
+	63,  // 177: wealthflow.v1.WealthFlowService.MergeCategoryBuckets:output_type -> wealthflow.v1.MergeCategoryBucketsResponse
+	65,  // 178: wealthflow.v1.WealthFlowService.GetInvestmentProfit:output_type -> wealthflow.v1.GetInvestmentProfitResponse
+	67,  // 179: wealthflow.v1.WealthFlowService.ValidateSplitRule:output_type -> wealthflow.v1.ValidateSplitRuleResponse
+	69,  // 180: wealthflow.v1.WealthFlowService.RecalculateBalances:output_type -> wealthflow.v1.RecalculateBalancesResponse
+	71,  // 181: wealthflow.v1.WealthFlowService.SetBucketGoal:output_type -> wealthflow.v1.SetBucketGoalResponse
+	74,  // 182: wealthflow.v1.WealthFlowService.ListBucketGoals:output_type -> wealthflow.v1.ListBucketGoalsResponse
+	76,  // 183: wealthflow.v1.WealthFlowService.GetStatus:output_type -> wealthflow.v1.GetStatusResponse
+	78,  // 184: wealthflow.v1.WealthFlowService.CompleteTransferTask:output_type -> wealthflow.v1.CompleteTransferTaskResponse
+	80,  // 185: wealthflow.v1.WealthFlowService.GetCategorySpendingTrend:output_type -> wealthflow.v1.GetCategorySpendingTrendResponse
+	83,  // 186: wealthflow.v1.WealthFlowService.CreateEquityBucket:output_type -> wealthflow.v1.CreateEquityBucketResponse
+	85,  // 187: wealthflow.v1.WealthFlowService.AddEquityPurchase:output_type -> wealthflow.v1.AddEquityPurchaseResponse
+	87,  // 188: wealthflow.v1.WealthFlowService.GetTransactionsByDateHistogram:output_type -> wealthflow.v1.GetTransactionsByDateHistogramResponse
+	90,  // 189: wealthflow.v1.WealthFlowService.ListOverdueTransferTasks:output_type -> wealthflow.v1.ListOverdueTransferTasksResponse
+	92,  // 190: wealthflow.v1.WealthFlowService.GetExpenseBreakdownByPhysicalBucket:output_type -> wealthflow.v1.GetExpenseBreakdownByPhysicalBucketResponse
+	95,  // 191: wealthflow.v1.WealthFlowService.GetInflowAllocationShares:output_type -> wealthflow.v1.GetInflowAllocationSharesResponse
+	98,  // 192: wealthflow.v1.WealthFlowService.VerifyLedgerIntegrity:output_type -> wealthflow.v1.VerifyLedgerIntegrityResponse
+	102, // 193: wealthflow.v1.WealthFlowService.LogSplitExpense:output_type -> wealthflow.v1.LogSplitExpenseResponse
+	104, // 194: wealthflow.v1.WealthFlowService.GetLiquidity:output_type -> wealthflow.v1.GetLiquidityResponse
+	106, // 195: wealthflow.v1.WealthFlowService.ProcessScheduledTransactions:output_type -> wealthflow.v1.ProcessScheduledTransactionsResponse
+	109, // 196: wealthflow.v1.WealthFlowService.ListIncomeSources:output_type -> wealthflow.v1.ListIncomeSourcesResponse
+	111, // 197: wealthflow.v1.WealthFlowService.MoveBetweenVirtualBuckets:output_type -> wealthflow.v1.MoveBetweenVirtualBucketsResponse
+	113, // 198: wealthflow.v1.WealthFlowService.GetInflowAllocation:output_type -> wealthflow.v1.GetInflowAllocationResponse
+	115, // 199: wealthflow.v1.WealthFlowService.RecategorizeTransactions:output_type -> wealthflow.v1.RecategorizeTransactionsResponse
+	117, // 200: wealthflow.v1.WealthFlowService.GetStatement:output_type -> wealthflow.v1.GetStatementResponse
+	121, // 201: wealthflow.v1.WealthFlowService.CloneSplitRule:output_type -> wealthflow.v1.CloneSplitRuleResponse
+	123, // 202: wealthflow.v1.WealthFlowService.GetUpcomingObligations:output_type -> wealthflow.v1.GetUpcomingObligationsResponse
+	127, // 203: wealthflow.v1.WealthFlowService.CreateBuckets:output_type -> wealthflow.v1.CreateBucketsResponse
+	129, // 204: wealthflow.v1.WealthFlowService.GetUnallocatedIncome:output_type -> wealthflow.v1.GetUnallocatedIncomeResponse
+	133, // 205: wealthflow.v1.WealthFlowService.AllocateUnallocatedIncome:output_type -> wealthflow.v1.AllocateUnallocatedIncomeResponse
+	135, // 206: wealthflow.v1.WealthFlowService.GetTransactionsBetweenBuckets:output_type -> wealthflow.v1.GetTransactionsBetweenBucketsResponse
+	137, // 207: wealthflow.v1.WealthFlowService.GetFundingBucket:output_type -> wealthflow.v1.GetFundingBucketResponse
+	139, // 208: wealthflow.v1.WealthFlowService.EnableSplitRule:output_type -> wealthflow.v1.EnableSplitRuleResponse
+	141, // 209: wealthflow.v1.WealthFlowService.DisableSplitRule:output_type -> wealthflow.v1.DisableSplitRuleResponse
+	153, // [153:210] is the sub-list for method output_type
+	96,  // [96:153] is the sub-list for method input_type
+	96,  // [96:96] is the sub-list for extension type_name
+	96,  // [96:96] is the sub-list for extension extendee
+	0,   // [0:96] is the sub-list for field type_name
 }
 
 func init() { file_wealthflow_v1_service_proto_init() }
@@ -1248,13 +9647,17 @@ func file_wealthflow_v1_service_proto_init() {
 	if File_wealthflow_v1_service_proto != nil {
 		return
 	}
+	file_wealthflow_v1_service_proto_msgTypes[16].OneofWrappers = []any{
+		(*ImportTransactionsRequest_Inflow)(nil),
+		(*ImportTransactionsRequest_Expense)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wealthflow_v1_service_proto_rawDesc), len(file_wealthflow_v1_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      3,
+			NumMessages:   143,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
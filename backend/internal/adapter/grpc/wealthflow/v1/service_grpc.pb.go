@@ -19,13 +19,63 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WealthFlowService_RecordInflow_FullMethodName     = "/wealthflow.v1.WealthFlowService/RecordInflow"
-	WealthFlowService_LogExpense_FullMethodName       = "/wealthflow.v1.WealthFlowService/LogExpense"
-	WealthFlowService_UpdateInvestment_FullMethodName = "/wealthflow.v1.WealthFlowService/UpdateInvestment"
-	WealthFlowService_ListBuckets_FullMethodName      = "/wealthflow.v1.WealthFlowService/ListBuckets"
-	WealthFlowService_ListTransactions_FullMethodName = "/wealthflow.v1.WealthFlowService/ListTransactions"
-	WealthFlowService_GetNetWorth_FullMethodName      = "/wealthflow.v1.WealthFlowService/GetNetWorth"
-	WealthFlowService_GetBucket_FullMethodName        = "/wealthflow.v1.WealthFlowService/GetBucket"
+	WealthFlowService_RecordInflow_FullMethodName                        = "/wealthflow.v1.WealthFlowService/RecordInflow"
+	WealthFlowService_LogExpense_FullMethodName                          = "/wealthflow.v1.WealthFlowService/LogExpense"
+	WealthFlowService_UpdateInvestment_FullMethodName                    = "/wealthflow.v1.WealthFlowService/UpdateInvestment"
+	WealthFlowService_ListBuckets_FullMethodName                         = "/wealthflow.v1.WealthFlowService/ListBuckets"
+	WealthFlowService_ListTransactions_FullMethodName                    = "/wealthflow.v1.WealthFlowService/ListTransactions"
+	WealthFlowService_GetNetWorth_FullMethodName                         = "/wealthflow.v1.WealthFlowService/GetNetWorth"
+	WealthFlowService_GetBucket_FullMethodName                           = "/wealthflow.v1.WealthFlowService/GetBucket"
+	WealthFlowService_ImportTransactions_FullMethodName                  = "/wealthflow.v1.WealthFlowService/ImportTransactions"
+	WealthFlowService_GetBucketTree_FullMethodName                       = "/wealthflow.v1.WealthFlowService/GetBucketTree"
+	WealthFlowService_GetUnbucketedAmount_FullMethodName                 = "/wealthflow.v1.WealthFlowService/GetUnbucketedAmount"
+	WealthFlowService_GetBucketTransactions_FullMethodName               = "/wealthflow.v1.WealthFlowService/GetBucketTransactions"
+	WealthFlowService_PreviewAllocation_FullMethodName                   = "/wealthflow.v1.WealthFlowService/PreviewAllocation"
+	WealthFlowService_CreateSplitRule_FullMethodName                     = "/wealthflow.v1.WealthFlowService/CreateSplitRule"
+	WealthFlowService_ReparentVirtualBucket_FullMethodName               = "/wealthflow.v1.WealthFlowService/ReparentVirtualBucket"
+	WealthFlowService_ListBucketsByParent_FullMethodName                 = "/wealthflow.v1.WealthFlowService/ListBucketsByParent"
+	WealthFlowService_GetTransaction_FullMethodName                      = "/wealthflow.v1.WealthFlowService/GetTransaction"
+	WealthFlowService_SetOpeningBalance_FullMethodName                   = "/wealthflow.v1.WealthFlowService/SetOpeningBalance"
+	WealthFlowService_GetProfitHistory_FullMethodName                    = "/wealthflow.v1.WealthFlowService/GetProfitHistory"
+	WealthFlowService_ListMarketValueHistory_FullMethodName              = "/wealthflow.v1.WealthFlowService/ListMarketValueHistory"
+	WealthFlowService_DeleteTransaction_FullMethodName                   = "/wealthflow.v1.WealthFlowService/DeleteTransaction"
+	WealthFlowService_GetTransactionCount_FullMethodName                 = "/wealthflow.v1.WealthFlowService/GetTransactionCount"
+	WealthFlowService_GetBucketsSummary_FullMethodName                   = "/wealthflow.v1.WealthFlowService/GetBucketsSummary"
+	WealthFlowService_GetSplitRule_FullMethodName                        = "/wealthflow.v1.WealthFlowService/GetSplitRule"
+	WealthFlowService_ListTransferTasks_FullMethodName                   = "/wealthflow.v1.WealthFlowService/ListTransferTasks"
+	WealthFlowService_MergeCategoryBuckets_FullMethodName                = "/wealthflow.v1.WealthFlowService/MergeCategoryBuckets"
+	WealthFlowService_GetInvestmentProfit_FullMethodName                 = "/wealthflow.v1.WealthFlowService/GetInvestmentProfit"
+	WealthFlowService_ValidateSplitRule_FullMethodName                   = "/wealthflow.v1.WealthFlowService/ValidateSplitRule"
+	WealthFlowService_RecalculateBalances_FullMethodName                 = "/wealthflow.v1.WealthFlowService/RecalculateBalances"
+	WealthFlowService_SetBucketGoal_FullMethodName                       = "/wealthflow.v1.WealthFlowService/SetBucketGoal"
+	WealthFlowService_ListBucketGoals_FullMethodName                     = "/wealthflow.v1.WealthFlowService/ListBucketGoals"
+	WealthFlowService_GetStatus_FullMethodName                           = "/wealthflow.v1.WealthFlowService/GetStatus"
+	WealthFlowService_CompleteTransferTask_FullMethodName                = "/wealthflow.v1.WealthFlowService/CompleteTransferTask"
+	WealthFlowService_GetCategorySpendingTrend_FullMethodName            = "/wealthflow.v1.WealthFlowService/GetCategorySpendingTrend"
+	WealthFlowService_CreateEquityBucket_FullMethodName                  = "/wealthflow.v1.WealthFlowService/CreateEquityBucket"
+	WealthFlowService_AddEquityPurchase_FullMethodName                   = "/wealthflow.v1.WealthFlowService/AddEquityPurchase"
+	WealthFlowService_GetTransactionsByDateHistogram_FullMethodName      = "/wealthflow.v1.WealthFlowService/GetTransactionsByDateHistogram"
+	WealthFlowService_ListOverdueTransferTasks_FullMethodName            = "/wealthflow.v1.WealthFlowService/ListOverdueTransferTasks"
+	WealthFlowService_GetExpenseBreakdownByPhysicalBucket_FullMethodName = "/wealthflow.v1.WealthFlowService/GetExpenseBreakdownByPhysicalBucket"
+	WealthFlowService_GetInflowAllocationShares_FullMethodName           = "/wealthflow.v1.WealthFlowService/GetInflowAllocationShares"
+	WealthFlowService_VerifyLedgerIntegrity_FullMethodName               = "/wealthflow.v1.WealthFlowService/VerifyLedgerIntegrity"
+	WealthFlowService_LogSplitExpense_FullMethodName                     = "/wealthflow.v1.WealthFlowService/LogSplitExpense"
+	WealthFlowService_GetLiquidity_FullMethodName                        = "/wealthflow.v1.WealthFlowService/GetLiquidity"
+	WealthFlowService_ProcessScheduledTransactions_FullMethodName        = "/wealthflow.v1.WealthFlowService/ProcessScheduledTransactions"
+	WealthFlowService_ListIncomeSources_FullMethodName                   = "/wealthflow.v1.WealthFlowService/ListIncomeSources"
+	WealthFlowService_MoveBetweenVirtualBuckets_FullMethodName           = "/wealthflow.v1.WealthFlowService/MoveBetweenVirtualBuckets"
+	WealthFlowService_GetInflowAllocation_FullMethodName                 = "/wealthflow.v1.WealthFlowService/GetInflowAllocation"
+	WealthFlowService_RecategorizeTransactions_FullMethodName            = "/wealthflow.v1.WealthFlowService/RecategorizeTransactions"
+	WealthFlowService_GetStatement_FullMethodName                        = "/wealthflow.v1.WealthFlowService/GetStatement"
+	WealthFlowService_CloneSplitRule_FullMethodName                      = "/wealthflow.v1.WealthFlowService/CloneSplitRule"
+	WealthFlowService_GetUpcomingObligations_FullMethodName              = "/wealthflow.v1.WealthFlowService/GetUpcomingObligations"
+	WealthFlowService_CreateBuckets_FullMethodName                       = "/wealthflow.v1.WealthFlowService/CreateBuckets"
+	WealthFlowService_GetUnallocatedIncome_FullMethodName                = "/wealthflow.v1.WealthFlowService/GetUnallocatedIncome"
+	WealthFlowService_AllocateUnallocatedIncome_FullMethodName           = "/wealthflow.v1.WealthFlowService/AllocateUnallocatedIncome"
+	WealthFlowService_GetTransactionsBetweenBuckets_FullMethodName       = "/wealthflow.v1.WealthFlowService/GetTransactionsBetweenBuckets"
+	WealthFlowService_GetFundingBucket_FullMethodName                    = "/wealthflow.v1.WealthFlowService/GetFundingBucket"
+	WealthFlowService_EnableSplitRule_FullMethodName                     = "/wealthflow.v1.WealthFlowService/EnableSplitRule"
+	WealthFlowService_DisableSplitRule_FullMethodName                    = "/wealthflow.v1.WealthFlowService/DisableSplitRule"
 )
 
 // WealthFlowServiceClient is the client API for WealthFlowService service.
@@ -36,11 +86,12 @@ const (
 type WealthFlowServiceClient interface {
 	// RecordInflow records an income/inflow transaction
 	// If is_external is true, triggers the Split Rule Engine to distribute funds
+	// Income no split rule or target applies to is only parked if park_in_physical_bucket_id is sent
 	RecordInflow(ctx context.Context, in *RecordInflowRequest, opts ...grpc.CallOption) (*RecordInflowResponse, error)
 	// LogExpense records an expense transaction with double-layer accounting
 	// Creates entries in both Physical and Virtual layers
 	LogExpense(ctx context.Context, in *LogExpenseRequest, opts ...grpc.CallOption) (*LogExpenseResponse, error)
-	// UpdateInvestment updates the market value for an investment bucket
+	// UpdateInvestment updates the market value for an investment (EQUITY) bucket - other bucket types are rejected
 	// Inserts a new entry into market_value_history (does NOT create a transaction)
 	UpdateInvestment(ctx context.Context, in *UpdateInvestmentRequest, opts ...grpc.CallOption) (*UpdateInvestmentResponse, error)
 	// ListBuckets returns a list of buckets, optionally filtered by type
@@ -51,6 +102,142 @@ type WealthFlowServiceClient interface {
 	GetNetWorth(ctx context.Context, in *GetNetWorthRequest, opts ...grpc.CallOption) (*GetNetWorthResponse, error)
 	// GetBucket retrieves a single bucket by ID
 	GetBucket(ctx context.Context, in *GetBucketRequest, opts ...grpc.CallOption) (*GetBucketResponse, error)
+	// ImportTransactions bulk-imports inflows and expenses from a client stream
+	// Each row is processed independently; a failing row is reported but does not abort the batch
+	// Rows are recorded at server time: a row setting date (or an inflow setting dry_run) fails
+	ImportTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse], error)
+	// GetBucketTree returns physical buckets with their virtual children nested underneath
+	// Each node includes the sum of its virtual balances and the unbucketed amount (physical - sum of virtuals)
+	GetBucketTree(ctx context.Context, in *GetBucketTreeRequest, opts ...grpc.CallOption) (*GetBucketTreeResponse, error)
+	// GetUnbucketedAmount returns how much of a physical bucket's balance is not assigned to its virtual children
+	GetUnbucketedAmount(ctx context.Context, in *GetUnbucketedAmountRequest, opts ...grpc.CallOption) (*GetUnbucketedAmountResponse, error)
+	// GetBucketTransactions returns the most recent transactions involving a single bucket (newest first)
+	GetBucketTransactions(ctx context.Context, in *GetBucketTransactionsRequest, opts ...grpc.CallOption) (*GetBucketTransactionsResponse, error)
+	// PreviewAllocation shows how an inflow would be split by the source bucket's split rule (no transaction is created)
+	// If explain is set, the step-by-step derivation of each amount is included
+	PreviewAllocation(ctx context.Context, in *PreviewAllocationRequest, opts ...grpc.CallOption) (*PreviewAllocationResponse, error)
+	// CreateSplitRule creates the split rule applied to external inflows of an income bucket
+	// Legal but suspicious configurations are returned as warnings; the rule is still saved
+	CreateSplitRule(ctx context.Context, in *CreateSplitRuleRequest, opts ...grpc.CallOption) (*CreateSplitRuleResponse, error)
+	// ReparentVirtualBucket moves a virtual bucket to a different physical parent (e.g. when switching banks)
+	// Creates a balancing physical transaction for the bucket's balance and a transfer task for the real-world move
+	ReparentVirtualBucket(ctx context.Context, in *ReparentVirtualBucketRequest, opts ...grpc.CallOption) (*ReparentVirtualBucketResponse, error)
+	// ListBucketsByParent lists the virtual buckets held by a physical bucket (ordered by name)
+	ListBucketsByParent(ctx context.Context, in *ListBucketsByParentRequest, opts ...grpc.CallOption) (*ListBucketsByParentResponse, error)
+	// GetTransaction retrieves a single transaction with its entries and memo
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	// SetOpeningBalance initializes a physical bucket's balance (and optionally one virtual child) when onboarding
+	// Balanced against the System Extra Income bucket; only allowed once, on buckets without transactions
+	SetOpeningBalance(ctx context.Context, in *SetOpeningBalanceRequest, opts ...grpc.CallOption) (*SetOpeningBalanceResponse, error)
+	// GetProfitHistory returns an equity bucket's unrealized profit at each market value date
+	// Book value is reconstructed from transaction entries as of each date
+	GetProfitHistory(ctx context.Context, in *GetProfitHistoryRequest, opts ...grpc.CallOption) (*GetProfitHistoryResponse, error)
+	// ListMarketValueHistory lists a bucket's market value history (paginated, newest-first by default)
+	ListMarketValueHistory(ctx context.Context, in *ListMarketValueHistoryRequest, opts ...grpc.CallOption) (*ListMarketValueHistoryResponse, error)
+	// DeleteTransaction removes a transaction and its entries, reverting their effect on bucket balances
+	// Fails with FAILED_PRECONDITION if one of its transfer tasks was already completed
+	DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*DeleteTransactionResponse, error)
+	// GetTransactionCount returns the number of transactions, optionally filtered by bucket
+	GetTransactionCount(ctx context.Context, in *GetTransactionCountRequest, opts ...grpc.CallOption) (*GetTransactionCountResponse, error)
+	// GetBucketsSummary returns bucket counts per type and the total physical balance in one call
+	GetBucketsSummary(ctx context.Context, in *GetBucketsSummaryRequest, opts ...grpc.CallOption) (*GetBucketsSummaryResponse, error)
+	// GetSplitRule returns the split rule of an income bucket, including its FIXED/PERCENT totals and REMAINDER share
+	GetSplitRule(ctx context.Context, in *GetSplitRuleRequest, opts ...grpc.CallOption) (*GetSplitRuleResponse, error)
+	// ListTransferTasks lists the real-world bank moves generated by virtual transfers (pending only by default)
+	ListTransferTasks(ctx context.Context, in *ListTransferTasksRequest, opts ...grpc.CallOption) (*ListTransferTasksResponse, error)
+	// MergeCategoryBuckets re-points all entries of an expense category to another and archives the source
+	MergeCategoryBuckets(ctx context.Context, in *MergeCategoryBucketsRequest, opts ...grpc.CallOption) (*MergeCategoryBucketsResponse, error)
+	// GetInvestmentProfit returns a bucket's current profit/loss in absolute and percentage terms
+	GetInvestmentProfit(ctx context.Context, in *GetInvestmentProfitRequest, opts ...grpc.CallOption) (*GetInvestmentProfitResponse, error)
+	// ValidateSplitRule checks a split rule without saving it (for inline errors while editing)
+	ValidateSplitRule(ctx context.Context, in *ValidateSplitRuleRequest, opts ...grpc.CallOption) (*ValidateSplitRuleResponse, error)
+	// RecalculateBalances (admin) recomputes physical and virtual bucket balances from their entries
+	// and repairs any drift (e.g. if the balance trigger was ever missing)
+	RecalculateBalances(ctx context.Context, in *RecalculateBalancesRequest, opts ...grpc.CallOption) (*RecalculateBalancesResponse, error)
+	// SetBucketGoal sets (or clears) the target amount of a bucket, e.g. for savings
+	SetBucketGoal(ctx context.Context, in *SetBucketGoalRequest, opts ...grpc.CallOption) (*SetBucketGoalResponse, error)
+	// ListBucketGoals returns the virtual buckets that have a goal, sorted by progress (closest to the goal first)
+	ListBucketGoals(ctx context.Context, in *ListBucketGoalsRequest, opts ...grpc.CallOption) (*ListBucketGoalsResponse, error)
+	// GetStatus returns a readiness view (database connectivity, system bucket seeding) and build info
+	// It is exempt from authentication so operators and probes can call it without a token
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// CompleteTransferTask records the physical move of a pending transfer task and marks it completed (atomically)
+	CompleteTransferTask(ctx context.Context, in *CompleteTransferTaskRequest, opts ...grpc.CallOption) (*CompleteTransferTaskResponse, error)
+	// GetCategorySpendingTrend returns the spending in an expense category per period (e.g. month over month)
+	GetCategorySpendingTrend(ctx context.Context, in *GetCategorySpendingTrendRequest, opts ...grpc.CallOption) (*GetCategorySpendingTrendResponse, error)
+	// CreateEquityBucket creates an EQUITY bucket with an initial book value (what was paid) and, optionally,
+	// an initial market value; returns the bucket and its first profit snapshot
+	CreateEquityBucket(ctx context.Context, in *CreateEquityBucketRequest, opts ...grpc.CallOption) (*CreateEquityBucketResponse, error)
+	// AddEquityPurchase records a purchase of (possibly fractional) units of an equity bucket,
+	// updating its weighted-average cost and raising its book value by the purchase cost (paid from a virtual bucket
+	// and its bank)
+	AddEquityPurchase(ctx context.Context, in *AddEquityPurchaseRequest, opts ...grpc.CallOption) (*AddEquityPurchaseResponse, error)
+	// GetTransactionsByDateHistogram returns the number of transactions per day (e.g. for a calendar heatmap)
+	GetTransactionsByDateHistogram(ctx context.Context, in *GetTransactionsByDateHistogramRequest, opts ...grpc.CallOption) (*GetTransactionsByDateHistogramResponse, error)
+	// ListOverdueTransferTasks returns the pending transfer tasks created more than `days` days ago, oldest first
+	// (e.g. for reminders so money does not sit un-transferred between banks)
+	ListOverdueTransferTasks(ctx context.Context, in *ListOverdueTransferTasksRequest, opts ...grpc.CallOption) (*ListOverdueTransferTasksResponse, error)
+	// GetExpenseBreakdownByPhysicalBucket returns how much money left each physical bucket (bank account or card)
+	// over a date range, following the account that actually paid (including physical overrides)
+	GetExpenseBreakdownByPhysicalBucket(ctx context.Context, in *GetExpenseBreakdownByPhysicalBucketRequest, opts ...grpc.CallOption) (*GetExpenseBreakdownByPhysicalBucketResponse, error)
+	// GetInflowAllocationShares returns what fraction of the external inflows over a date range each virtual bucket
+	// actually received, reconstructed from the booked entries rather than from the current split rules
+	GetInflowAllocationShares(ctx context.Context, in *GetInflowAllocationSharesRequest, opts ...grpc.CallOption) (*GetInflowAllocationSharesResponse, error)
+	// VerifyLedgerIntegrity validates every stored transaction (balanced layers, valid entries) and reports the ones
+	// that fail, e.g. after SQL edits made outside the application
+	VerifyLedgerIntegrity(ctx context.Context, in *VerifyLedgerIntegrityRequest, opts ...grpc.CallOption) (*VerifyLedgerIntegrityResponse, error)
+	// LogSplitExpense records one expense split across several categories (e.g. a supermarket trip that is partly
+	// Groceries, partly Household) as a single transaction with a Debit per category in both layers
+	LogSplitExpense(ctx context.Context, in *LogSplitExpenseRequest, opts ...grpc.CallOption) (*LogSplitExpenseResponse, error)
+	// GetLiquidity returns the sum of all physical bucket balances, computed in the database
+	// (a cheap alternative to GetNetWorth for frequently polled widgets)
+	GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error)
+	// ProcessScheduledTransactions activates the scheduled transactions that are due, so they start counting
+	// in balances and net worth (the server also does this periodically)
+	ProcessScheduledTransactions(ctx context.Context, in *ProcessScheduledTransactionsRequest, opts ...grpc.CallOption) (*ProcessScheduledTransactionsResponse, error)
+	// ListIncomeSources lists the income buckets with whether each has a split rule and how many items it has
+	// (income buckets without a rule cannot receive external inflows)
+	ListIncomeSources(ctx context.Context, in *ListIncomeSourcesRequest, opts ...grpc.CallOption) (*ListIncomeSourcesResponse, error)
+	// MoveBetweenVirtualBuckets moves money between two virtual buckets held in the same physical bucket
+	// (virtual layer only, no transfer task); moves across physical buckets are rejected
+	MoveBetweenVirtualBuckets(ctx context.Context, in *MoveBetweenVirtualBucketsRequest, opts ...grpc.CallOption) (*MoveBetweenVirtualBucketsResponse, error)
+	// GetInflowAllocation returns how a past external inflow was actually split across virtual buckets
+	// (unlike PreviewAllocation, which applies the current split rule); NOT_FOUND if it is not an external inflow
+	GetInflowAllocation(ctx context.Context, in *GetInflowAllocationRequest, opts ...grpc.CallOption) (*GetInflowAllocationResponse, error)
+	// RecategorizeTransactions moves past expenses from one category to another (both EXPENSE buckets),
+	// optionally only those whose description matches a filter; the category balances follow the entries
+	RecategorizeTransactions(ctx context.Context, in *RecategorizeTransactionsRequest, opts ...grpc.CallOption) (*RecategorizeTransactionsResponse, error)
+	// GetStatement returns the net worth at the start and end of a period and every change in between
+	// (transactions that moved liquidity and recorded equity market values), which reconcile the two
+	GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error)
+	// CloneSplitRule copies the split rule of an income bucket to another income bucket without a rule
+	// (e.g. the same split for salary and freelance income); the copy gets fresh IDs
+	CloneSplitRule(ctx context.Context, in *CloneSplitRuleRequest, opts ...grpc.CallOption) (*CloneSplitRuleResponse, error)
+	// GetUpcomingObligations forecasts liquidity over the coming days from the pending scheduled transactions,
+	// with a running projected balance after each one. Only expenses can be scheduled (LogExpense effective_date),
+	// so expected inflows (e.g. the next salary) are not part of the forecast
+	GetUpcomingObligations(ctx context.Context, in *GetUpcomingObligationsRequest, opts ...grpc.CallOption) (*GetUpcomingObligationsResponse, error)
+	// CreateBuckets creates a batch of buckets atomically (e.g. onboarding bank accounts and their envelopes);
+	// virtual buckets reference their parent by a key within the batch or by an existing bucket ID
+	CreateBuckets(ctx context.Context, in *CreateBucketsRequest, opts ...grpc.CallOption) (*CreateBucketsResponse, error)
+	// GetUnallocatedIncome returns the income parked in System Extra Income (see RecordInflow's
+	// park_in_physical_bucket_id) and the transactions that parked or allocated it
+	GetUnallocatedIncome(ctx context.Context, in *GetUnallocatedIncomeRequest, opts ...grpc.CallOption) (*GetUnallocatedIncomeResponse, error)
+	// AllocateUnallocatedIncome distributes parked income into virtual buckets (virtual layer only; the money is
+	// already in the bank). All targets must be virtual buckets of one bank, and the lines must add up to at most
+	// the amount parked in that bank
+	AllocateUnallocatedIncome(ctx context.Context, in *AllocateUnallocatedIncomeRequest, opts ...grpc.CallOption) (*AllocateUnallocatedIncomeResponse, error)
+	// GetTransactionsBetweenBuckets returns the transactions that moved money between two physical buckets
+	// (e.g. to audit an inter-bank transfer and its completing transaction), newest first
+	GetTransactionsBetweenBuckets(ctx context.Context, in *GetTransactionsBetweenBucketsRequest, opts ...grpc.CallOption) (*GetTransactionsBetweenBucketsResponse, error)
+	// GetFundingBucket returns the physical bucket holding a virtual bucket's money
+	// (e.g. to show which bank account backs an envelope)
+	GetFundingBucket(ctx context.Context, in *GetFundingBucketRequest, opts ...grpc.CallOption) (*GetFundingBucketResponse, error)
+	// EnableSplitRule re-enables a disabled split rule of an income bucket
+	EnableSplitRule(ctx context.Context, in *EnableSplitRuleRequest, opts ...grpc.CallOption) (*EnableSplitRuleResponse, error)
+	// DisableSplitRule disables the split rule of an income bucket without deleting it
+	// (its external inflows are then handled as if it had no rule, e.g. parked)
+	DisableSplitRule(ctx context.Context, in *DisableSplitRuleRequest, opts ...grpc.CallOption) (*DisableSplitRuleResponse, error)
 }
 
 type wealthFlowServiceClient struct {
@@ -131,6 +318,509 @@ func (c *wealthFlowServiceClient) GetBucket(ctx context.Context, in *GetBucketRe
 	return out, nil
 }
 
+func (c *wealthFlowServiceClient) ImportTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WealthFlowService_ServiceDesc.Streams[0], WealthFlowService_ImportTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTransactionsRequest, ImportTransactionsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WealthFlowService_ImportTransactionsClient = grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse]
+
+func (c *wealthFlowServiceClient) GetBucketTree(ctx context.Context, in *GetBucketTreeRequest, opts ...grpc.CallOption) (*GetBucketTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketTreeResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetBucketTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetUnbucketedAmount(ctx context.Context, in *GetUnbucketedAmountRequest, opts ...grpc.CallOption) (*GetUnbucketedAmountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnbucketedAmountResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetUnbucketedAmount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetBucketTransactions(ctx context.Context, in *GetBucketTransactionsRequest, opts ...grpc.CallOption) (*GetBucketTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketTransactionsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetBucketTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) PreviewAllocation(ctx context.Context, in *PreviewAllocationRequest, opts ...grpc.CallOption) (*PreviewAllocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewAllocationResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_PreviewAllocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) CreateSplitRule(ctx context.Context, in *CreateSplitRuleRequest, opts ...grpc.CallOption) (*CreateSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_CreateSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ReparentVirtualBucket(ctx context.Context, in *ReparentVirtualBucketRequest, opts ...grpc.CallOption) (*ReparentVirtualBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReparentVirtualBucketResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ReparentVirtualBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListBucketsByParent(ctx context.Context, in *ListBucketsByParentRequest, opts ...grpc.CallOption) (*ListBucketsByParentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBucketsByParentResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListBucketsByParent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) SetOpeningBalance(ctx context.Context, in *SetOpeningBalanceRequest, opts ...grpc.CallOption) (*SetOpeningBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOpeningBalanceResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_SetOpeningBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetProfitHistory(ctx context.Context, in *GetProfitHistoryRequest, opts ...grpc.CallOption) (*GetProfitHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfitHistoryResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetProfitHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListMarketValueHistory(ctx context.Context, in *ListMarketValueHistoryRequest, opts ...grpc.CallOption) (*ListMarketValueHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMarketValueHistoryResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListMarketValueHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*DeleteTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTransactionResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_DeleteTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetTransactionCount(ctx context.Context, in *GetTransactionCountRequest, opts ...grpc.CallOption) (*GetTransactionCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionCountResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetTransactionCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetBucketsSummary(ctx context.Context, in *GetBucketsSummaryRequest, opts ...grpc.CallOption) (*GetBucketsSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBucketsSummaryResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetBucketsSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetSplitRule(ctx context.Context, in *GetSplitRuleRequest, opts ...grpc.CallOption) (*GetSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListTransferTasks(ctx context.Context, in *ListTransferTasksRequest, opts ...grpc.CallOption) (*ListTransferTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransferTasksResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListTransferTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) MergeCategoryBuckets(ctx context.Context, in *MergeCategoryBucketsRequest, opts ...grpc.CallOption) (*MergeCategoryBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCategoryBucketsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_MergeCategoryBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetInvestmentProfit(ctx context.Context, in *GetInvestmentProfitRequest, opts ...grpc.CallOption) (*GetInvestmentProfitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInvestmentProfitResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetInvestmentProfit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ValidateSplitRule(ctx context.Context, in *ValidateSplitRuleRequest, opts ...grpc.CallOption) (*ValidateSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ValidateSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) RecalculateBalances(ctx context.Context, in *RecalculateBalancesRequest, opts ...grpc.CallOption) (*RecalculateBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecalculateBalancesResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_RecalculateBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) SetBucketGoal(ctx context.Context, in *SetBucketGoalRequest, opts ...grpc.CallOption) (*SetBucketGoalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBucketGoalResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_SetBucketGoal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListBucketGoals(ctx context.Context, in *ListBucketGoalsRequest, opts ...grpc.CallOption) (*ListBucketGoalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBucketGoalsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListBucketGoals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) CompleteTransferTask(ctx context.Context, in *CompleteTransferTaskRequest, opts ...grpc.CallOption) (*CompleteTransferTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteTransferTaskResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_CompleteTransferTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetCategorySpendingTrend(ctx context.Context, in *GetCategorySpendingTrendRequest, opts ...grpc.CallOption) (*GetCategorySpendingTrendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategorySpendingTrendResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetCategorySpendingTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) CreateEquityBucket(ctx context.Context, in *CreateEquityBucketRequest, opts ...grpc.CallOption) (*CreateEquityBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateEquityBucketResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_CreateEquityBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) AddEquityPurchase(ctx context.Context, in *AddEquityPurchaseRequest, opts ...grpc.CallOption) (*AddEquityPurchaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddEquityPurchaseResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_AddEquityPurchase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetTransactionsByDateHistogram(ctx context.Context, in *GetTransactionsByDateHistogramRequest, opts ...grpc.CallOption) (*GetTransactionsByDateHistogramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionsByDateHistogramResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetTransactionsByDateHistogram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListOverdueTransferTasks(ctx context.Context, in *ListOverdueTransferTasksRequest, opts ...grpc.CallOption) (*ListOverdueTransferTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOverdueTransferTasksResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListOverdueTransferTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetExpenseBreakdownByPhysicalBucket(ctx context.Context, in *GetExpenseBreakdownByPhysicalBucketRequest, opts ...grpc.CallOption) (*GetExpenseBreakdownByPhysicalBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExpenseBreakdownByPhysicalBucketResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetExpenseBreakdownByPhysicalBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetInflowAllocationShares(ctx context.Context, in *GetInflowAllocationSharesRequest, opts ...grpc.CallOption) (*GetInflowAllocationSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInflowAllocationSharesResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetInflowAllocationShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) VerifyLedgerIntegrity(ctx context.Context, in *VerifyLedgerIntegrityRequest, opts ...grpc.CallOption) (*VerifyLedgerIntegrityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyLedgerIntegrityResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_VerifyLedgerIntegrity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) LogSplitExpense(ctx context.Context, in *LogSplitExpenseRequest, opts ...grpc.CallOption) (*LogSplitExpenseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogSplitExpenseResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_LogSplitExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLiquidityResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetLiquidity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ProcessScheduledTransactions(ctx context.Context, in *ProcessScheduledTransactionsRequest, opts ...grpc.CallOption) (*ProcessScheduledTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessScheduledTransactionsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ProcessScheduledTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) ListIncomeSources(ctx context.Context, in *ListIncomeSourcesRequest, opts ...grpc.CallOption) (*ListIncomeSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncomeSourcesResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_ListIncomeSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) MoveBetweenVirtualBuckets(ctx context.Context, in *MoveBetweenVirtualBucketsRequest, opts ...grpc.CallOption) (*MoveBetweenVirtualBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveBetweenVirtualBucketsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_MoveBetweenVirtualBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetInflowAllocation(ctx context.Context, in *GetInflowAllocationRequest, opts ...grpc.CallOption) (*GetInflowAllocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInflowAllocationResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetInflowAllocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) RecategorizeTransactions(ctx context.Context, in *RecategorizeTransactionsRequest, opts ...grpc.CallOption) (*RecategorizeTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecategorizeTransactionsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_RecategorizeTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatementResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) CloneSplitRule(ctx context.Context, in *CloneSplitRuleRequest, opts ...grpc.CallOption) (*CloneSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_CloneSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetUpcomingObligations(ctx context.Context, in *GetUpcomingObligationsRequest, opts ...grpc.CallOption) (*GetUpcomingObligationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUpcomingObligationsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetUpcomingObligations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) CreateBuckets(ctx context.Context, in *CreateBucketsRequest, opts ...grpc.CallOption) (*CreateBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBucketsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_CreateBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetUnallocatedIncome(ctx context.Context, in *GetUnallocatedIncomeRequest, opts ...grpc.CallOption) (*GetUnallocatedIncomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnallocatedIncomeResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetUnallocatedIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) AllocateUnallocatedIncome(ctx context.Context, in *AllocateUnallocatedIncomeRequest, opts ...grpc.CallOption) (*AllocateUnallocatedIncomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocateUnallocatedIncomeResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_AllocateUnallocatedIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetTransactionsBetweenBuckets(ctx context.Context, in *GetTransactionsBetweenBucketsRequest, opts ...grpc.CallOption) (*GetTransactionsBetweenBucketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionsBetweenBucketsResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetTransactionsBetweenBuckets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) GetFundingBucket(ctx context.Context, in *GetFundingBucketRequest, opts ...grpc.CallOption) (*GetFundingBucketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFundingBucketResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_GetFundingBucket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) EnableSplitRule(ctx context.Context, in *EnableSplitRuleRequest, opts ...grpc.CallOption) (*EnableSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_EnableSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wealthFlowServiceClient) DisableSplitRule(ctx context.Context, in *DisableSplitRuleRequest, opts ...grpc.CallOption) (*DisableSplitRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableSplitRuleResponse)
+	err := c.cc.Invoke(ctx, WealthFlowService_DisableSplitRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WealthFlowServiceServer is the server API for WealthFlowService service.
 // All implementations must embed UnimplementedWealthFlowServiceServer
 // for forward compatibility.
@@ -139,11 +829,12 @@ func (c *wealthFlowServiceClient) GetBucket(ctx context.Context, in *GetBucketRe
 type WealthFlowServiceServer interface {
 	// RecordInflow records an income/inflow transaction
 	// If is_external is true, triggers the Split Rule Engine to distribute funds
+	// Income no split rule or target applies to is only parked if park_in_physical_bucket_id is sent
 	RecordInflow(context.Context, *RecordInflowRequest) (*RecordInflowResponse, error)
 	// LogExpense records an expense transaction with double-layer accounting
 	// Creates entries in both Physical and Virtual layers
 	LogExpense(context.Context, *LogExpenseRequest) (*LogExpenseResponse, error)
-	// UpdateInvestment updates the market value for an investment bucket
+	// UpdateInvestment updates the market value for an investment (EQUITY) bucket - other bucket types are rejected
 	// Inserts a new entry into market_value_history (does NOT create a transaction)
 	UpdateInvestment(context.Context, *UpdateInvestmentRequest) (*UpdateInvestmentResponse, error)
 	// ListBuckets returns a list of buckets, optionally filtered by type
@@ -154,180 +845,1355 @@ type WealthFlowServiceServer interface {
 	GetNetWorth(context.Context, *GetNetWorthRequest) (*GetNetWorthResponse, error)
 	// GetBucket retrieves a single bucket by ID
 	GetBucket(context.Context, *GetBucketRequest) (*GetBucketResponse, error)
+	// ImportTransactions bulk-imports inflows and expenses from a client stream
+	// Each row is processed independently; a failing row is reported but does not abort the batch
+	// Rows are recorded at server time: a row setting date (or an inflow setting dry_run) fails
+	ImportTransactions(grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]) error
+	// GetBucketTree returns physical buckets with their virtual children nested underneath
+	// Each node includes the sum of its virtual balances and the unbucketed amount (physical - sum of virtuals)
+	GetBucketTree(context.Context, *GetBucketTreeRequest) (*GetBucketTreeResponse, error)
+	// GetUnbucketedAmount returns how much of a physical bucket's balance is not assigned to its virtual children
+	GetUnbucketedAmount(context.Context, *GetUnbucketedAmountRequest) (*GetUnbucketedAmountResponse, error)
+	// GetBucketTransactions returns the most recent transactions involving a single bucket (newest first)
+	GetBucketTransactions(context.Context, *GetBucketTransactionsRequest) (*GetBucketTransactionsResponse, error)
+	// PreviewAllocation shows how an inflow would be split by the source bucket's split rule (no transaction is created)
+	// If explain is set, the step-by-step derivation of each amount is included
+	PreviewAllocation(context.Context, *PreviewAllocationRequest) (*PreviewAllocationResponse, error)
+	// CreateSplitRule creates the split rule applied to external inflows of an income bucket
+	// Legal but suspicious configurations are returned as warnings; the rule is still saved
+	CreateSplitRule(context.Context, *CreateSplitRuleRequest) (*CreateSplitRuleResponse, error)
+	// ReparentVirtualBucket moves a virtual bucket to a different physical parent (e.g. when switching banks)
+	// Creates a balancing physical transaction for the bucket's balance and a transfer task for the real-world move
+	ReparentVirtualBucket(context.Context, *ReparentVirtualBucketRequest) (*ReparentVirtualBucketResponse, error)
+	// ListBucketsByParent lists the virtual buckets held by a physical bucket (ordered by name)
+	ListBucketsByParent(context.Context, *ListBucketsByParentRequest) (*ListBucketsByParentResponse, error)
+	// GetTransaction retrieves a single transaction with its entries and memo
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	// SetOpeningBalance initializes a physical bucket's balance (and optionally one virtual child) when onboarding
+	// Balanced against the System Extra Income bucket; only allowed once, on buckets without transactions
+	SetOpeningBalance(context.Context, *SetOpeningBalanceRequest) (*SetOpeningBalanceResponse, error)
+	// GetProfitHistory returns an equity bucket's unrealized profit at each market value date
+	// Book value is reconstructed from transaction entries as of each date
+	GetProfitHistory(context.Context, *GetProfitHistoryRequest) (*GetProfitHistoryResponse, error)
+	// ListMarketValueHistory lists a bucket's market value history (paginated, newest-first by default)
+	ListMarketValueHistory(context.Context, *ListMarketValueHistoryRequest) (*ListMarketValueHistoryResponse, error)
+	// DeleteTransaction removes a transaction and its entries, reverting their effect on bucket balances
+	// Fails with FAILED_PRECONDITION if one of its transfer tasks was already completed
+	DeleteTransaction(context.Context, *DeleteTransactionRequest) (*DeleteTransactionResponse, error)
+	// GetTransactionCount returns the number of transactions, optionally filtered by bucket
+	GetTransactionCount(context.Context, *GetTransactionCountRequest) (*GetTransactionCountResponse, error)
+	// GetBucketsSummary returns bucket counts per type and the total physical balance in one call
+	GetBucketsSummary(context.Context, *GetBucketsSummaryRequest) (*GetBucketsSummaryResponse, error)
+	// GetSplitRule returns the split rule of an income bucket, including its FIXED/PERCENT totals and REMAINDER share
+	GetSplitRule(context.Context, *GetSplitRuleRequest) (*GetSplitRuleResponse, error)
+	// ListTransferTasks lists the real-world bank moves generated by virtual transfers (pending only by default)
+	ListTransferTasks(context.Context, *ListTransferTasksRequest) (*ListTransferTasksResponse, error)
+	// MergeCategoryBuckets re-points all entries of an expense category to another and archives the source
+	MergeCategoryBuckets(context.Context, *MergeCategoryBucketsRequest) (*MergeCategoryBucketsResponse, error)
+	// GetInvestmentProfit returns a bucket's current profit/loss in absolute and percentage terms
+	GetInvestmentProfit(context.Context, *GetInvestmentProfitRequest) (*GetInvestmentProfitResponse, error)
+	// ValidateSplitRule checks a split rule without saving it (for inline errors while editing)
+	ValidateSplitRule(context.Context, *ValidateSplitRuleRequest) (*ValidateSplitRuleResponse, error)
+	// RecalculateBalances (admin) recomputes physical and virtual bucket balances from their entries
+	// and repairs any drift (e.g. if the balance trigger was ever missing)
+	RecalculateBalances(context.Context, *RecalculateBalancesRequest) (*RecalculateBalancesResponse, error)
+	// SetBucketGoal sets (or clears) the target amount of a bucket, e.g. for savings
+	SetBucketGoal(context.Context, *SetBucketGoalRequest) (*SetBucketGoalResponse, error)
+	// ListBucketGoals returns the virtual buckets that have a goal, sorted by progress (closest to the goal first)
+	ListBucketGoals(context.Context, *ListBucketGoalsRequest) (*ListBucketGoalsResponse, error)
+	// GetStatus returns a readiness view (database connectivity, system bucket seeding) and build info
+	// It is exempt from authentication so operators and probes can call it without a token
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// CompleteTransferTask records the physical move of a pending transfer task and marks it completed (atomically)
+	CompleteTransferTask(context.Context, *CompleteTransferTaskRequest) (*CompleteTransferTaskResponse, error)
+	// GetCategorySpendingTrend returns the spending in an expense category per period (e.g. month over month)
+	GetCategorySpendingTrend(context.Context, *GetCategorySpendingTrendRequest) (*GetCategorySpendingTrendResponse, error)
+	// CreateEquityBucket creates an EQUITY bucket with an initial book value (what was paid) and, optionally,
+	// an initial market value; returns the bucket and its first profit snapshot
+	CreateEquityBucket(context.Context, *CreateEquityBucketRequest) (*CreateEquityBucketResponse, error)
+	// AddEquityPurchase records a purchase of (possibly fractional) units of an equity bucket,
+	// updating its weighted-average cost and raising its book value by the purchase cost (paid from a virtual bucket
+	// and its bank)
+	AddEquityPurchase(context.Context, *AddEquityPurchaseRequest) (*AddEquityPurchaseResponse, error)
+	// GetTransactionsByDateHistogram returns the number of transactions per day (e.g. for a calendar heatmap)
+	GetTransactionsByDateHistogram(context.Context, *GetTransactionsByDateHistogramRequest) (*GetTransactionsByDateHistogramResponse, error)
+	// ListOverdueTransferTasks returns the pending transfer tasks created more than `days` days ago, oldest first
+	// (e.g. for reminders so money does not sit un-transferred between banks)
+	ListOverdueTransferTasks(context.Context, *ListOverdueTransferTasksRequest) (*ListOverdueTransferTasksResponse, error)
+	// GetExpenseBreakdownByPhysicalBucket returns how much money left each physical bucket (bank account or card)
+	// over a date range, following the account that actually paid (including physical overrides)
+	GetExpenseBreakdownByPhysicalBucket(context.Context, *GetExpenseBreakdownByPhysicalBucketRequest) (*GetExpenseBreakdownByPhysicalBucketResponse, error)
+	// GetInflowAllocationShares returns what fraction of the external inflows over a date range each virtual bucket
+	// actually received, reconstructed from the booked entries rather than from the current split rules
+	GetInflowAllocationShares(context.Context, *GetInflowAllocationSharesRequest) (*GetInflowAllocationSharesResponse, error)
+	// VerifyLedgerIntegrity validates every stored transaction (balanced layers, valid entries) and reports the ones
+	// that fail, e.g. after SQL edits made outside the application
+	VerifyLedgerIntegrity(context.Context, *VerifyLedgerIntegrityRequest) (*VerifyLedgerIntegrityResponse, error)
+	// LogSplitExpense records one expense split across several categories (e.g. a supermarket trip that is partly
+	// Groceries, partly Household) as a single transaction with a Debit per category in both layers
+	LogSplitExpense(context.Context, *LogSplitExpenseRequest) (*LogSplitExpenseResponse, error)
+	// GetLiquidity returns the sum of all physical bucket balances, computed in the database
+	// (a cheap alternative to GetNetWorth for frequently polled widgets)
+	GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error)
+	// ProcessScheduledTransactions activates the scheduled transactions that are due, so they start counting
+	// in balances and net worth (the server also does this periodically)
+	ProcessScheduledTransactions(context.Context, *ProcessScheduledTransactionsRequest) (*ProcessScheduledTransactionsResponse, error)
+	// ListIncomeSources lists the income buckets with whether each has a split rule and how many items it has
+	// (income buckets without a rule cannot receive external inflows)
+	ListIncomeSources(context.Context, *ListIncomeSourcesRequest) (*ListIncomeSourcesResponse, error)
+	// MoveBetweenVirtualBuckets moves money between two virtual buckets held in the same physical bucket
+	// (virtual layer only, no transfer task); moves across physical buckets are rejected
+	MoveBetweenVirtualBuckets(context.Context, *MoveBetweenVirtualBucketsRequest) (*MoveBetweenVirtualBucketsResponse, error)
+	// GetInflowAllocation returns how a past external inflow was actually split across virtual buckets
+	// (unlike PreviewAllocation, which applies the current split rule); NOT_FOUND if it is not an external inflow
+	GetInflowAllocation(context.Context, *GetInflowAllocationRequest) (*GetInflowAllocationResponse, error)
+	// RecategorizeTransactions moves past expenses from one category to another (both EXPENSE buckets),
+	// optionally only those whose description matches a filter; the category balances follow the entries
+	RecategorizeTransactions(context.Context, *RecategorizeTransactionsRequest) (*RecategorizeTransactionsResponse, error)
+	// GetStatement returns the net worth at the start and end of a period and every change in between
+	// (transactions that moved liquidity and recorded equity market values), which reconcile the two
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+	// CloneSplitRule copies the split rule of an income bucket to another income bucket without a rule
+	// (e.g. the same split for salary and freelance income); the copy gets fresh IDs
+	CloneSplitRule(context.Context, *CloneSplitRuleRequest) (*CloneSplitRuleResponse, error)
+	// GetUpcomingObligations forecasts liquidity over the coming days from the pending scheduled transactions,
+	// with a running projected balance after each one. Only expenses can be scheduled (LogExpense effective_date),
+	// so expected inflows (e.g. the next salary) are not part of the forecast
+	GetUpcomingObligations(context.Context, *GetUpcomingObligationsRequest) (*GetUpcomingObligationsResponse, error)
+	// CreateBuckets creates a batch of buckets atomically (e.g. onboarding bank accounts and their envelopes);
+	// virtual buckets reference their parent by a key within the batch or by an existing bucket ID
+	CreateBuckets(context.Context, *CreateBucketsRequest) (*CreateBucketsResponse, error)
+	// GetUnallocatedIncome returns the income parked in System Extra Income (see RecordInflow's
+	// park_in_physical_bucket_id) and the transactions that parked or allocated it
+	GetUnallocatedIncome(context.Context, *GetUnallocatedIncomeRequest) (*GetUnallocatedIncomeResponse, error)
+	// AllocateUnallocatedIncome distributes parked income into virtual buckets (virtual layer only; the money is
+	// already in the bank). All targets must be virtual buckets of one bank, and the lines must add up to at most
+	// the amount parked in that bank
+	AllocateUnallocatedIncome(context.Context, *AllocateUnallocatedIncomeRequest) (*AllocateUnallocatedIncomeResponse, error)
+	// GetTransactionsBetweenBuckets returns the transactions that moved money between two physical buckets
+	// (e.g. to audit an inter-bank transfer and its completing transaction), newest first
+	GetTransactionsBetweenBuckets(context.Context, *GetTransactionsBetweenBucketsRequest) (*GetTransactionsBetweenBucketsResponse, error)
+	// GetFundingBucket returns the physical bucket holding a virtual bucket's money
+	// (e.g. to show which bank account backs an envelope)
+	GetFundingBucket(context.Context, *GetFundingBucketRequest) (*GetFundingBucketResponse, error)
+	// EnableSplitRule re-enables a disabled split rule of an income bucket
+	EnableSplitRule(context.Context, *EnableSplitRuleRequest) (*EnableSplitRuleResponse, error)
+	// DisableSplitRule disables the split rule of an income bucket without deleting it
+	// (its external inflows are then handled as if it had no rule, e.g. parked)
+	DisableSplitRule(context.Context, *DisableSplitRuleRequest) (*DisableSplitRuleResponse, error)
+	mustEmbedUnimplementedWealthFlowServiceServer()
+}
+
+// UnimplementedWealthFlowServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWealthFlowServiceServer struct{}
+
+func (UnimplementedWealthFlowServiceServer) RecordInflow(context.Context, *RecordInflowRequest) (*RecordInflowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordInflow not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) LogExpense(context.Context, *LogExpenseRequest) (*LogExpenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogExpense not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) UpdateInvestment(context.Context, *UpdateInvestmentRequest) (*UpdateInvestmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateInvestment not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuckets not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetNetWorth(context.Context, *GetNetWorthRequest) (*GetNetWorthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetWorth not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetBucket(context.Context, *GetBucketRequest) (*GetBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucket not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ImportTransactions(grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTransactions not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetBucketTree(context.Context, *GetBucketTreeRequest) (*GetBucketTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketTree not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetUnbucketedAmount(context.Context, *GetUnbucketedAmountRequest) (*GetUnbucketedAmountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnbucketedAmount not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetBucketTransactions(context.Context, *GetBucketTransactionsRequest) (*GetBucketTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketTransactions not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) PreviewAllocation(context.Context, *PreviewAllocationRequest) (*PreviewAllocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewAllocation not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) CreateSplitRule(context.Context, *CreateSplitRuleRequest) (*CreateSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ReparentVirtualBucket(context.Context, *ReparentVirtualBucketRequest) (*ReparentVirtualBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReparentVirtualBucket not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListBucketsByParent(context.Context, *ListBucketsByParentRequest) (*ListBucketsByParentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBucketsByParent not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) SetOpeningBalance(context.Context, *SetOpeningBalanceRequest) (*SetOpeningBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOpeningBalance not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetProfitHistory(context.Context, *GetProfitHistoryRequest) (*GetProfitHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfitHistory not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListMarketValueHistory(context.Context, *ListMarketValueHistoryRequest) (*ListMarketValueHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMarketValueHistory not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) DeleteTransaction(context.Context, *DeleteTransactionRequest) (*DeleteTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTransaction not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetTransactionCount(context.Context, *GetTransactionCountRequest) (*GetTransactionCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionCount not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetBucketsSummary(context.Context, *GetBucketsSummaryRequest) (*GetBucketsSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketsSummary not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetSplitRule(context.Context, *GetSplitRuleRequest) (*GetSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListTransferTasks(context.Context, *ListTransferTasksRequest) (*ListTransferTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransferTasks not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) MergeCategoryBuckets(context.Context, *MergeCategoryBucketsRequest) (*MergeCategoryBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCategoryBuckets not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetInvestmentProfit(context.Context, *GetInvestmentProfitRequest) (*GetInvestmentProfitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvestmentProfit not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ValidateSplitRule(context.Context, *ValidateSplitRuleRequest) (*ValidateSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) RecalculateBalances(context.Context, *RecalculateBalancesRequest) (*RecalculateBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateBalances not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) SetBucketGoal(context.Context, *SetBucketGoalRequest) (*SetBucketGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBucketGoal not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListBucketGoals(context.Context, *ListBucketGoalsRequest) (*ListBucketGoalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBucketGoals not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) CompleteTransferTask(context.Context, *CompleteTransferTaskRequest) (*CompleteTransferTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteTransferTask not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetCategorySpendingTrend(context.Context, *GetCategorySpendingTrendRequest) (*GetCategorySpendingTrendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategorySpendingTrend not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) CreateEquityBucket(context.Context, *CreateEquityBucketRequest) (*CreateEquityBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEquityBucket not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) AddEquityPurchase(context.Context, *AddEquityPurchaseRequest) (*AddEquityPurchaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEquityPurchase not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetTransactionsByDateHistogram(context.Context, *GetTransactionsByDateHistogramRequest) (*GetTransactionsByDateHistogramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsByDateHistogram not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListOverdueTransferTasks(context.Context, *ListOverdueTransferTasksRequest) (*ListOverdueTransferTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdueTransferTasks not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetExpenseBreakdownByPhysicalBucket(context.Context, *GetExpenseBreakdownByPhysicalBucketRequest) (*GetExpenseBreakdownByPhysicalBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpenseBreakdownByPhysicalBucket not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetInflowAllocationShares(context.Context, *GetInflowAllocationSharesRequest) (*GetInflowAllocationSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInflowAllocationShares not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) VerifyLedgerIntegrity(context.Context, *VerifyLedgerIntegrityRequest) (*VerifyLedgerIntegrityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyLedgerIntegrity not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) LogSplitExpense(context.Context, *LogSplitExpenseRequest) (*LogSplitExpenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogSplitExpense not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidity not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ProcessScheduledTransactions(context.Context, *ProcessScheduledTransactionsRequest) (*ProcessScheduledTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessScheduledTransactions not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) ListIncomeSources(context.Context, *ListIncomeSourcesRequest) (*ListIncomeSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomeSources not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) MoveBetweenVirtualBuckets(context.Context, *MoveBetweenVirtualBucketsRequest) (*MoveBetweenVirtualBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveBetweenVirtualBuckets not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetInflowAllocation(context.Context, *GetInflowAllocationRequest) (*GetInflowAllocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInflowAllocation not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) RecategorizeTransactions(context.Context, *RecategorizeTransactionsRequest) (*RecategorizeTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecategorizeTransactions not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) CloneSplitRule(context.Context, *CloneSplitRuleRequest) (*CloneSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetUpcomingObligations(context.Context, *GetUpcomingObligationsRequest) (*GetUpcomingObligationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpcomingObligations not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) CreateBuckets(context.Context, *CreateBucketsRequest) (*CreateBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBuckets not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetUnallocatedIncome(context.Context, *GetUnallocatedIncomeRequest) (*GetUnallocatedIncomeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnallocatedIncome not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) AllocateUnallocatedIncome(context.Context, *AllocateUnallocatedIncomeRequest) (*AllocateUnallocatedIncomeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateUnallocatedIncome not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetTransactionsBetweenBuckets(context.Context, *GetTransactionsBetweenBucketsRequest) (*GetTransactionsBetweenBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsBetweenBuckets not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) GetFundingBucket(context.Context, *GetFundingBucketRequest) (*GetFundingBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFundingBucket not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) EnableSplitRule(context.Context, *EnableSplitRuleRequest) (*EnableSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) DisableSplitRule(context.Context, *DisableSplitRuleRequest) (*DisableSplitRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableSplitRule not implemented")
+}
+func (UnimplementedWealthFlowServiceServer) mustEmbedUnimplementedWealthFlowServiceServer() {}
+func (UnimplementedWealthFlowServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeWealthFlowServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WealthFlowServiceServer will
+// result in compilation errors.
+type UnsafeWealthFlowServiceServer interface {
 	mustEmbedUnimplementedWealthFlowServiceServer()
 }
 
-// UnimplementedWealthFlowServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedWealthFlowServiceServer struct{}
+func RegisterWealthFlowServiceServer(s grpc.ServiceRegistrar, srv WealthFlowServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWealthFlowServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WealthFlowService_ServiceDesc, srv)
+}
+
+func _WealthFlowService_RecordInflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordInflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).RecordInflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_RecordInflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).RecordInflow(ctx, req.(*RecordInflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_LogExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).LogExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_LogExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).LogExpense(ctx, req.(*LogExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_UpdateInvestment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInvestmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).UpdateInvestment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_UpdateInvestment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).UpdateInvestment(ctx, req.(*UpdateInvestmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListBuckets(ctx, req.(*ListBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetNetWorth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetWorthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetNetWorth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetNetWorth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetNetWorth(ctx, req.(*GetNetWorthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetBucket(ctx, req.(*GetBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ImportTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WealthFlowServiceServer).ImportTransactions(&grpc.GenericServerStream[ImportTransactionsRequest, ImportTransactionsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WealthFlowService_ImportTransactionsServer = grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]
+
+func _WealthFlowService_GetBucketTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetBucketTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetBucketTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetBucketTree(ctx, req.(*GetBucketTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetUnbucketedAmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnbucketedAmountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetUnbucketedAmount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetUnbucketedAmount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetUnbucketedAmount(ctx, req.(*GetUnbucketedAmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetBucketTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetBucketTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetBucketTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetBucketTransactions(ctx, req.(*GetBucketTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_PreviewAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).PreviewAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_PreviewAllocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).PreviewAllocation(ctx, req.(*PreviewAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_CreateSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSplitRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).CreateSplitRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_CreateSplitRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).CreateSplitRule(ctx, req.(*CreateSplitRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ReparentVirtualBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReparentVirtualBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ReparentVirtualBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ReparentVirtualBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ReparentVirtualBucket(ctx, req.(*ReparentVirtualBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListBucketsByParent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketsByParentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListBucketsByParent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListBucketsByParent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListBucketsByParent(ctx, req.(*ListBucketsByParentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_SetOpeningBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOpeningBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).SetOpeningBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_SetOpeningBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).SetOpeningBalance(ctx, req.(*SetOpeningBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetProfitHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfitHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetProfitHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetProfitHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetProfitHistory(ctx, req.(*GetProfitHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListMarketValueHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMarketValueHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListMarketValueHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListMarketValueHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListMarketValueHistory(ctx, req.(*ListMarketValueHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_DeleteTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).DeleteTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_DeleteTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).DeleteTransaction(ctx, req.(*DeleteTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetTransactionCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetTransactionCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetTransactionCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetTransactionCount(ctx, req.(*GetTransactionCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetBucketsSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketsSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetBucketsSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetBucketsSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetBucketsSummary(ctx, req.(*GetBucketsSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSplitRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetSplitRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetSplitRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetSplitRule(ctx, req.(*GetSplitRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListTransferTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListTransferTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListTransferTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListTransferTasks(ctx, req.(*ListTransferTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedWealthFlowServiceServer) RecordInflow(context.Context, *RecordInflowRequest) (*RecordInflowResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RecordInflow not implemented")
+func _WealthFlowService_MergeCategoryBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoryBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).MergeCategoryBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_MergeCategoryBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).MergeCategoryBuckets(ctx, req.(*MergeCategoryBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetInvestmentProfit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvestmentProfitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetInvestmentProfit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetInvestmentProfit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetInvestmentProfit(ctx, req.(*GetInvestmentProfitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ValidateSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateSplitRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ValidateSplitRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ValidateSplitRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ValidateSplitRule(ctx, req.(*ValidateSplitRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_RecalculateBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).RecalculateBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_RecalculateBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).RecalculateBalances(ctx, req.(*RecalculateBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_SetBucketGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBucketGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).SetBucketGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_SetBucketGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).SetBucketGoal(ctx, req.(*SetBucketGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListBucketGoals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketGoalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListBucketGoals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListBucketGoals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListBucketGoals(ctx, req.(*ListBucketGoalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_CompleteTransferTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTransferTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).CompleteTransferTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_CompleteTransferTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).CompleteTransferTask(ctx, req.(*CompleteTransferTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetCategorySpendingTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategorySpendingTrendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetCategorySpendingTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetCategorySpendingTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetCategorySpendingTrend(ctx, req.(*GetCategorySpendingTrendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_CreateEquityBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEquityBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).CreateEquityBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_CreateEquityBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).CreateEquityBucket(ctx, req.(*CreateEquityBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_AddEquityPurchase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddEquityPurchaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).AddEquityPurchase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_AddEquityPurchase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).AddEquityPurchase(ctx, req.(*AddEquityPurchaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetTransactionsByDateHistogram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsByDateHistogramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetTransactionsByDateHistogram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetTransactionsByDateHistogram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetTransactionsByDateHistogram(ctx, req.(*GetTransactionsByDateHistogramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_ListOverdueTransferTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdueTransferTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListOverdueTransferTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListOverdueTransferTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListOverdueTransferTasks(ctx, req.(*ListOverdueTransferTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetExpenseBreakdownByPhysicalBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpenseBreakdownByPhysicalBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetExpenseBreakdownByPhysicalBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetExpenseBreakdownByPhysicalBucket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetExpenseBreakdownByPhysicalBucket(ctx, req.(*GetExpenseBreakdownByPhysicalBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetInflowAllocationShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInflowAllocationSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetInflowAllocationShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetInflowAllocationShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetInflowAllocationShares(ctx, req.(*GetInflowAllocationSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_VerifyLedgerIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyLedgerIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).VerifyLedgerIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_VerifyLedgerIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).VerifyLedgerIntegrity(ctx, req.(*VerifyLedgerIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_LogSplitExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogSplitExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).LogSplitExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_LogSplitExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).LogSplitExpense(ctx, req.(*LogSplitExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WealthFlowService_GetLiquidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiquidityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetLiquidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetLiquidity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetLiquidity(ctx, req.(*GetLiquidityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) LogExpense(context.Context, *LogExpenseRequest) (*LogExpenseResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LogExpense not implemented")
+
+func _WealthFlowService_ProcessScheduledTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessScheduledTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ProcessScheduledTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ProcessScheduledTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ProcessScheduledTransactions(ctx, req.(*ProcessScheduledTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) UpdateInvestment(context.Context, *UpdateInvestmentRequest) (*UpdateInvestmentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateInvestment not implemented")
+
+func _WealthFlowService_ListIncomeSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomeSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).ListIncomeSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_ListIncomeSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).ListIncomeSources(ctx, req.(*ListIncomeSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListBuckets not implemented")
+
+func _WealthFlowService_MoveBetweenVirtualBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveBetweenVirtualBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).MoveBetweenVirtualBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_MoveBetweenVirtualBuckets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).MoveBetweenVirtualBuckets(ctx, req.(*MoveBetweenVirtualBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+
+func _WealthFlowService_GetInflowAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInflowAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetInflowAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetInflowAllocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetInflowAllocation(ctx, req.(*GetInflowAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) GetNetWorth(context.Context, *GetNetWorthRequest) (*GetNetWorthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNetWorth not implemented")
+
+func _WealthFlowService_RecategorizeTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecategorizeTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).RecategorizeTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_RecategorizeTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).RecategorizeTransactions(ctx, req.(*RecategorizeTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) GetBucket(context.Context, *GetBucketRequest) (*GetBucketResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBucket not implemented")
+
+func _WealthFlowService_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetStatement(ctx, req.(*GetStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedWealthFlowServiceServer) mustEmbedUnimplementedWealthFlowServiceServer() {}
-func (UnimplementedWealthFlowServiceServer) testEmbeddedByValue()                           {}
 
-// UnsafeWealthFlowServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to WealthFlowServiceServer will
-// result in compilation errors.
-type UnsafeWealthFlowServiceServer interface {
-	mustEmbedUnimplementedWealthFlowServiceServer()
+func _WealthFlowService_CloneSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneSplitRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).CloneSplitRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_CloneSplitRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).CloneSplitRule(ctx, req.(*CloneSplitRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterWealthFlowServiceServer(s grpc.ServiceRegistrar, srv WealthFlowServiceServer) {
-	// If the following call pancis, it indicates UnimplementedWealthFlowServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _WealthFlowService_GetUpcomingObligations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUpcomingObligationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&WealthFlowService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(WealthFlowServiceServer).GetUpcomingObligations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WealthFlowService_GetUpcomingObligations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WealthFlowServiceServer).GetUpcomingObligations(ctx, req.(*GetUpcomingObligationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_RecordInflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RecordInflowRequest)
+func _WealthFlowService_CreateBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).RecordInflow(ctx, in)
+		return srv.(WealthFlowServiceServer).CreateBuckets(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_RecordInflow_FullMethodName,
+		FullMethod: WealthFlowService_CreateBuckets_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).RecordInflow(ctx, req.(*RecordInflowRequest))
+		return srv.(WealthFlowServiceServer).CreateBuckets(ctx, req.(*CreateBucketsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_LogExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogExpenseRequest)
+func _WealthFlowService_GetUnallocatedIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnallocatedIncomeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).LogExpense(ctx, in)
+		return srv.(WealthFlowServiceServer).GetUnallocatedIncome(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_LogExpense_FullMethodName,
+		FullMethod: WealthFlowService_GetUnallocatedIncome_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).LogExpense(ctx, req.(*LogExpenseRequest))
+		return srv.(WealthFlowServiceServer).GetUnallocatedIncome(ctx, req.(*GetUnallocatedIncomeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_UpdateInvestment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInvestmentRequest)
+func _WealthFlowService_AllocateUnallocatedIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateUnallocatedIncomeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).UpdateInvestment(ctx, in)
+		return srv.(WealthFlowServiceServer).AllocateUnallocatedIncome(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_UpdateInvestment_FullMethodName,
+		FullMethod: WealthFlowService_AllocateUnallocatedIncome_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).UpdateInvestment(ctx, req.(*UpdateInvestmentRequest))
+		return srv.(WealthFlowServiceServer).AllocateUnallocatedIncome(ctx, req.(*AllocateUnallocatedIncomeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBucketsRequest)
+func _WealthFlowService_GetTransactionsBetweenBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsBetweenBucketsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).ListBuckets(ctx, in)
+		return srv.(WealthFlowServiceServer).GetTransactionsBetweenBuckets(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_ListBuckets_FullMethodName,
+		FullMethod: WealthFlowService_GetTransactionsBetweenBuckets_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).ListBuckets(ctx, req.(*ListBucketsRequest))
+		return srv.(WealthFlowServiceServer).GetTransactionsBetweenBuckets(ctx, req.(*GetTransactionsBetweenBucketsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTransactionsRequest)
+func _WealthFlowService_GetFundingBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFundingBucketRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).ListTransactions(ctx, in)
+		return srv.(WealthFlowServiceServer).GetFundingBucket(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_ListTransactions_FullMethodName,
+		FullMethod: WealthFlowService_GetFundingBucket_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+		return srv.(WealthFlowServiceServer).GetFundingBucket(ctx, req.(*GetFundingBucketRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_GetNetWorth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNetWorthRequest)
+func _WealthFlowService_EnableSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableSplitRuleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).GetNetWorth(ctx, in)
+		return srv.(WealthFlowServiceServer).EnableSplitRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_GetNetWorth_FullMethodName,
+		FullMethod: WealthFlowService_EnableSplitRule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).GetNetWorth(ctx, req.(*GetNetWorthRequest))
+		return srv.(WealthFlowServiceServer).EnableSplitRule(ctx, req.(*EnableSplitRuleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WealthFlowService_GetBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBucketRequest)
+func _WealthFlowService_DisableSplitRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableSplitRuleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WealthFlowServiceServer).GetBucket(ctx, in)
+		return srv.(WealthFlowServiceServer).DisableSplitRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: WealthFlowService_GetBucket_FullMethodName,
+		FullMethod: WealthFlowService_DisableSplitRule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WealthFlowServiceServer).GetBucket(ctx, req.(*GetBucketRequest))
+		return srv.(WealthFlowServiceServer).DisableSplitRule(ctx, req.(*DisableSplitRuleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -367,7 +2233,209 @@ var WealthFlowService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBucket",
 			Handler:    _WealthFlowService_GetBucket_Handler,
 		},
+		{
+			MethodName: "GetBucketTree",
+			Handler:    _WealthFlowService_GetBucketTree_Handler,
+		},
+		{
+			MethodName: "GetUnbucketedAmount",
+			Handler:    _WealthFlowService_GetUnbucketedAmount_Handler,
+		},
+		{
+			MethodName: "GetBucketTransactions",
+			Handler:    _WealthFlowService_GetBucketTransactions_Handler,
+		},
+		{
+			MethodName: "PreviewAllocation",
+			Handler:    _WealthFlowService_PreviewAllocation_Handler,
+		},
+		{
+			MethodName: "CreateSplitRule",
+			Handler:    _WealthFlowService_CreateSplitRule_Handler,
+		},
+		{
+			MethodName: "ReparentVirtualBucket",
+			Handler:    _WealthFlowService_ReparentVirtualBucket_Handler,
+		},
+		{
+			MethodName: "ListBucketsByParent",
+			Handler:    _WealthFlowService_ListBucketsByParent_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _WealthFlowService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "SetOpeningBalance",
+			Handler:    _WealthFlowService_SetOpeningBalance_Handler,
+		},
+		{
+			MethodName: "GetProfitHistory",
+			Handler:    _WealthFlowService_GetProfitHistory_Handler,
+		},
+		{
+			MethodName: "ListMarketValueHistory",
+			Handler:    _WealthFlowService_ListMarketValueHistory_Handler,
+		},
+		{
+			MethodName: "DeleteTransaction",
+			Handler:    _WealthFlowService_DeleteTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransactionCount",
+			Handler:    _WealthFlowService_GetTransactionCount_Handler,
+		},
+		{
+			MethodName: "GetBucketsSummary",
+			Handler:    _WealthFlowService_GetBucketsSummary_Handler,
+		},
+		{
+			MethodName: "GetSplitRule",
+			Handler:    _WealthFlowService_GetSplitRule_Handler,
+		},
+		{
+			MethodName: "ListTransferTasks",
+			Handler:    _WealthFlowService_ListTransferTasks_Handler,
+		},
+		{
+			MethodName: "MergeCategoryBuckets",
+			Handler:    _WealthFlowService_MergeCategoryBuckets_Handler,
+		},
+		{
+			MethodName: "GetInvestmentProfit",
+			Handler:    _WealthFlowService_GetInvestmentProfit_Handler,
+		},
+		{
+			MethodName: "ValidateSplitRule",
+			Handler:    _WealthFlowService_ValidateSplitRule_Handler,
+		},
+		{
+			MethodName: "RecalculateBalances",
+			Handler:    _WealthFlowService_RecalculateBalances_Handler,
+		},
+		{
+			MethodName: "SetBucketGoal",
+			Handler:    _WealthFlowService_SetBucketGoal_Handler,
+		},
+		{
+			MethodName: "ListBucketGoals",
+			Handler:    _WealthFlowService_ListBucketGoals_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _WealthFlowService_GetStatus_Handler,
+		},
+		{
+			MethodName: "CompleteTransferTask",
+			Handler:    _WealthFlowService_CompleteTransferTask_Handler,
+		},
+		{
+			MethodName: "GetCategorySpendingTrend",
+			Handler:    _WealthFlowService_GetCategorySpendingTrend_Handler,
+		},
+		{
+			MethodName: "CreateEquityBucket",
+			Handler:    _WealthFlowService_CreateEquityBucket_Handler,
+		},
+		{
+			MethodName: "AddEquityPurchase",
+			Handler:    _WealthFlowService_AddEquityPurchase_Handler,
+		},
+		{
+			MethodName: "GetTransactionsByDateHistogram",
+			Handler:    _WealthFlowService_GetTransactionsByDateHistogram_Handler,
+		},
+		{
+			MethodName: "ListOverdueTransferTasks",
+			Handler:    _WealthFlowService_ListOverdueTransferTasks_Handler,
+		},
+		{
+			MethodName: "GetExpenseBreakdownByPhysicalBucket",
+			Handler:    _WealthFlowService_GetExpenseBreakdownByPhysicalBucket_Handler,
+		},
+		{
+			MethodName: "GetInflowAllocationShares",
+			Handler:    _WealthFlowService_GetInflowAllocationShares_Handler,
+		},
+		{
+			MethodName: "VerifyLedgerIntegrity",
+			Handler:    _WealthFlowService_VerifyLedgerIntegrity_Handler,
+		},
+		{
+			MethodName: "LogSplitExpense",
+			Handler:    _WealthFlowService_LogSplitExpense_Handler,
+		},
+		{
+			MethodName: "GetLiquidity",
+			Handler:    _WealthFlowService_GetLiquidity_Handler,
+		},
+		{
+			MethodName: "ProcessScheduledTransactions",
+			Handler:    _WealthFlowService_ProcessScheduledTransactions_Handler,
+		},
+		{
+			MethodName: "ListIncomeSources",
+			Handler:    _WealthFlowService_ListIncomeSources_Handler,
+		},
+		{
+			MethodName: "MoveBetweenVirtualBuckets",
+			Handler:    _WealthFlowService_MoveBetweenVirtualBuckets_Handler,
+		},
+		{
+			MethodName: "GetInflowAllocation",
+			Handler:    _WealthFlowService_GetInflowAllocation_Handler,
+		},
+		{
+			MethodName: "RecategorizeTransactions",
+			Handler:    _WealthFlowService_RecategorizeTransactions_Handler,
+		},
+		{
+			MethodName: "GetStatement",
+			Handler:    _WealthFlowService_GetStatement_Handler,
+		},
+		{
+			MethodName: "CloneSplitRule",
+			Handler:    _WealthFlowService_CloneSplitRule_Handler,
+		},
+		{
+			MethodName: "GetUpcomingObligations",
+			Handler:    _WealthFlowService_GetUpcomingObligations_Handler,
+		},
+		{
+			MethodName: "CreateBuckets",
+			Handler:    _WealthFlowService_CreateBuckets_Handler,
+		},
+		{
+			MethodName: "GetUnallocatedIncome",
+			Handler:    _WealthFlowService_GetUnallocatedIncome_Handler,
+		},
+		{
+			MethodName: "AllocateUnallocatedIncome",
+			Handler:    _WealthFlowService_AllocateUnallocatedIncome_Handler,
+		},
+		{
+			MethodName: "GetTransactionsBetweenBuckets",
+			Handler:    _WealthFlowService_GetTransactionsBetweenBuckets_Handler,
+		},
+		{
+			MethodName: "GetFundingBucket",
+			Handler:    _WealthFlowService_GetFundingBucket_Handler,
+		},
+		{
+			MethodName: "EnableSplitRule",
+			Handler:    _WealthFlowService_EnableSplitRule_Handler,
+		},
+		{
+			MethodName: "DisableSplitRule",
+			Handler:    _WealthFlowService_DisableSplitRule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportTransactions",
+			Handler:       _WealthFlowService_ImportTransactions_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "wealthflow/v1/service.proto",
 }
@@ -7,13 +7,14 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 )
 
 // bucketRepository implements domain.BucketRepository
 type bucketRepository struct {
-	db *DB
+	db querier
 }
 
 // NewBucketRepository creates a new bucket repository
@@ -24,25 +25,29 @@ func NewBucketRepository(db *DB) domain.BucketRepository {
 // GetByID retrieves a bucket by its ID
 func (r *bucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
 	query := `
-		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
 		FROM buckets
 		WHERE id = $1
 	`
 
 	var bucket domain.Bucket
 	var parentID sql.NullString
-	var balanceStr string
+	var balanceStr sql.NullString
+	var goalStr sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := queryRowContext(ctx, r.db, query, id).Scan(
 		&bucket.ID,
 		&bucket.Name,
 		&bucket.BucketType,
 		&parentID,
 		&balanceStr,
+		&bucket.IsArchived,
+		&goalStr,
+		&bucket.Currency,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("bucket not found: %w", err)
+			return nil, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get bucket by ID: %w", err)
 	}
@@ -56,21 +61,29 @@ func (r *bucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.B
 		bucket.ParentPhysicalBucketID = &parentUUID
 	}
 
-	// Parse current_balance (DECIMAL)
-	balance, err := decimal.NewFromString(balanceStr)
+	// Parse current_balance (DECIMAL, nullable in the schema)
+	if !balanceStr.Valid {
+		return nil, fmt.Errorf("bucket %s has null balance", bucket.ID)
+	}
+	balance, err := decimal.NewFromString(balanceStr.String)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse current_balance: %w", err)
 	}
 	bucket.CurrentBalance = balance
 
+	// Parse goal_amount (nullable)
+	if bucket.GoalAmount, err = parseGoalAmount(goalStr); err != nil {
+		return nil, err
+	}
+
 	return &bucket, nil
 }
 
 // Create creates a new bucket
 func (r *bucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
 	query := `
-		INSERT INTO buckets (id, name, bucket_type, parent_physical_bucket_id, current_balance)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO buckets (id, name, bucket_type, parent_physical_bucket_id, current_balance, goal_amount, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	var parentID interface{}
@@ -78,12 +91,19 @@ func (r *bucketRepository) Create(ctx context.Context, bucket *domain.Bucket) er
 		parentID = bucket.ParentPhysicalBucketID
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	var goalAmount interface{}
+	if bucket.GoalAmount != nil {
+		goalAmount = bucket.GoalAmount.String()
+	}
+
+	_, err := execContext(ctx, r.db, query,
 		bucket.ID,
 		bucket.Name,
 		string(bucket.BucketType),
 		parentID,
 		bucket.CurrentBalance.String(),
+		goalAmount,
+		bucket.Balance().Currency,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create bucket: %w", err)
@@ -92,24 +112,268 @@ func (r *bucketRepository) Create(ctx context.Context, bucket *domain.Bucket) er
 	return nil
 }
 
+// UpdateParent sets the parent physical bucket of a virtual bucket
+func (r *bucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	query := `
+		UPDATE buckets
+		SET parent_physical_bucket_id = $2
+		WHERE id = $1
+	`
+
+	result, err := execContext(ctx, r.db, query, bucketID, parentPhysicalBucketID)
+	if err != nil {
+		return fmt.Errorf("failed to update bucket parent: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrBucketNotFound, bucketID)
+	}
+
+	return nil
+}
+
+// UpdateGoal sets (or clears, if goal is nil) a bucket's goal amount
+func (r *bucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	query := `
+		UPDATE buckets
+		SET goal_amount = $2
+		WHERE id = $1
+	`
+
+	var goalAmount interface{}
+	if goal != nil {
+		goalAmount = goal.String()
+	}
+
+	result, err := execContext(ctx, r.db, query, bucketID, goalAmount)
+	if err != nil {
+		return fmt.Errorf("failed to update bucket goal: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrBucketNotFound, bucketID)
+	}
+
+	return nil
+}
+
+// SumBalances returns the summed current_balance of the non-archived buckets of bucketType
+// The first bucket (by ID) in a currency other than domain.DefaultCurrency fails the sum with ErrCurrencyMismatch
+func (r *bucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(current_balance), 0),
+			(ARRAY_AGG(id ORDER BY id) FILTER (WHERE currency <> $2))[1],
+			(ARRAY_AGG(currency ORDER BY id) FILTER (WHERE currency <> $2))[1]
+		FROM buckets
+		WHERE bucket_type = $1 AND is_archived = FALSE
+	`
+
+	var totalStr string
+	var mismatchID, mismatchCurrency sql.NullString
+	if err := queryRowContext(ctx, r.db, query, string(bucketType), domain.DefaultCurrency).Scan(&totalStr, &mismatchID, &mismatchCurrency); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to sum bucket balances: %w", err)
+	}
+	if mismatchID.Valid {
+		return decimal.Zero, fmt.Errorf("bucket %s: %w: %s and %s", mismatchID.String, domain.ErrCurrencyMismatch, domain.DefaultCurrency, mismatchCurrency.String)
+	}
+
+	total, err := decimal.NewFromString(totalStr)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to parse bucket balance sum: %w", err)
+	}
+
+	return total, nil
+}
+
+// RecalculateBalances recomputes the balances of the given bucket types from their entries in one database transaction
+// The buckets are locked first (FOR UPDATE): a booking whose balance trigger runs meanwhile waits, and then adds its
+// amount on top of the corrected balance instead of being overwritten
+func (r *bucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	types := make([]string, 0, len(bucketTypes))
+	for _, bucketType := range bucketTypes {
+		types = append(types, string(bucketType))
+	}
+
+	// Start a database transaction
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Lock the buckets so no balance update interleaves with the recalculation
+	if _, err := execContext(ctx, dbTx, `
+		SELECT id
+		FROM buckets
+		WHERE bucket_type = ANY($1) AND is_archived = FALSE
+		ORDER BY id
+		FOR UPDATE
+	`, pq.Array(types)); err != nil {
+		return nil, fmt.Errorf("failed to lock buckets: %w", err)
+	}
+
+	// Overwrite the drifted balances in a single statement, same sign convention as the balance trigger
+	rows, err := queryContext(ctx, dbTx, `
+		UPDATE buckets b
+		SET current_balance = s.expected
+		FROM (
+			SELECT bk.id, bk.current_balance AS previous,
+				COALESCE(SUM(CASE WHEN te.type = 'DEBIT' THEN te.amount ELSE -te.amount END), 0) AS expected
+			FROM buckets bk
+			LEFT JOIN transaction_entries te
+				ON te.bucket_id = bk.id
+				AND te.transaction_id NOT IN (SELECT id FROM transactions WHERE scheduled)
+			WHERE bk.bucket_type = ANY($1) AND bk.is_archived = FALSE
+			GROUP BY bk.id, bk.current_balance
+		) s
+		WHERE b.id = s.id AND b.current_balance <> s.expected
+		RETURNING b.id, s.previous, s.expected
+	`, pq.Array(types))
+	if err != nil {
+		return nil, fmt.Errorf("failed to recalculate bucket balances: %w", err)
+	}
+
+	corrections := make([]domain.BalanceCorrection, 0)
+	for rows.Next() {
+		var correction domain.BalanceCorrection
+		var previousStr, correctedStr string
+		if err := rows.Scan(&correction.BucketID, &previousStr, &correctedStr); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan balance correction: %w", err)
+		}
+		if correction.Previous, err = decimal.NewFromString(previousStr); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to parse previous balance: %w", err)
+		}
+		if correction.Corrected, err = decimal.NewFromString(correctedStr); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to parse corrected balance: %w", err)
+		}
+		corrections = append(corrections, correction)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating balance corrections: %w", err)
+	}
+
+	// Commit the transaction
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return corrections, nil
+}
+
+// MergeInto re-points the source bucket's entries to the target inside a single database transaction
+// The balance_update_trigger only fires on INSERT, so the source balance is moved onto the target here
+func (r *bucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	// Start a database transaction
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Lock both buckets (and verify they exist) so no balance update interleaves with the merge
+	rows, err := queryContext(ctx, dbTx, `
+		SELECT id
+		FROM buckets
+		WHERE id IN ($1, $2)
+		ORDER BY id
+		FOR UPDATE
+	`, sourceID, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock buckets: %w", err)
+	}
+	locked := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		locked[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating buckets: %w", err)
+	}
+	for _, id := range []uuid.UUID{sourceID, targetID} {
+		if !locked[id] {
+			return 0, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, id)
+		}
+	}
+
+	// Re-point the entries
+	result, err := execContext(ctx, dbTx, `
+		UPDATE transaction_entries
+		SET bucket_id = $2
+		WHERE bucket_id = $1
+	`, sourceID, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-point transaction entries: %w", err)
+	}
+	repointed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Move the balance onto the target, then zero and archive the source
+	_, err = execContext(ctx, dbTx, `
+		UPDATE buckets
+		SET current_balance = current_balance + (SELECT current_balance FROM buckets WHERE id = $1)
+		WHERE id = $2
+	`, sourceID, targetID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move bucket balance: %w", err)
+	}
+	_, err = execContext(ctx, dbTx, `
+		UPDATE buckets
+		SET current_balance = 0, is_archived = TRUE
+		WHERE id = $1
+	`, sourceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive bucket: %w", err)
+	}
+
+	// Commit the transaction
+	if err := dbTx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(repointed), nil
+}
+
 // GetSystemBucket retrieves a system bucket by its type
 func (r *bucketRepository) GetSystemBucket(ctx context.Context, bucketType domain.BucketType) (*domain.Bucket, error) {
 	query := `
-		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
 		FROM buckets
 		WHERE bucket_type = $1
 	`
 
 	var bucket domain.Bucket
 	var parentID sql.NullString
-	var balanceStr string
+	var balanceStr sql.NullString
+	var goalStr sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, string(bucketType)).Scan(
+	err := queryRowContext(ctx, r.db, query, string(bucketType)).Scan(
 		&bucket.ID,
 		&bucket.Name,
 		&bucket.BucketType,
 		&parentID,
 		&balanceStr,
+		&bucket.IsArchived,
+		&goalStr,
+		&bucket.Currency,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -127,13 +391,21 @@ func (r *bucketRepository) GetSystemBucket(ctx context.Context, bucketType domai
 		bucket.ParentPhysicalBucketID = &parentUUID
 	}
 
-	// Parse current_balance (DECIMAL)
-	balance, err := decimal.NewFromString(balanceStr)
+	// Parse current_balance (DECIMAL, nullable in the schema)
+	if !balanceStr.Valid {
+		return nil, fmt.Errorf("bucket %s has null balance", bucket.ID)
+	}
+	balance, err := decimal.NewFromString(balanceStr.String)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse current_balance: %w", err)
 	}
 	bucket.CurrentBalance = balance
 
+	// Parse goal_amount (nullable)
+	if bucket.GoalAmount, err = parseGoalAmount(goalStr); err != nil {
+		return nil, err
+	}
+
 	return &bucket, nil
 }
 
@@ -144,61 +416,166 @@ func (r *bucketRepository) List(ctx context.Context, typeFilter domain.BucketTyp
 
 	if typeFilter != "" {
 		query = `
-			SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance
+			SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
 			FROM buckets
-			WHERE bucket_type = $1
+			WHERE bucket_type = $1 AND is_archived = FALSE
 			ORDER BY name
 		`
 		args = []interface{}{string(typeFilter)}
 	} else {
 		query = `
-			SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance
+			SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
 			FROM buckets
+			WHERE is_archived = FALSE
 			ORDER BY name
 		`
 		args = []interface{}{}
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	return r.queryBuckets(ctx, query, args...)
+}
+
+// GetByIDForUpdate retrieves a bucket by its ID, locking its row when called inside a unit of work
+func (r *bucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	query := `
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
+		FROM buckets
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	buckets, err := r.queryBuckets(ctx, query, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list buckets: %w", err)
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, id)
+	}
+
+	return buckets[0], nil
+}
+
+// GetByIDs retrieves the given buckets in a single query, keyed by ID
+func (r *bucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	query := `
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
+		FROM buckets
+		WHERE id = ANY($1)
+	`
+
+	buckets, err := r.queryBuckets(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.Bucket, len(buckets))
+	for _, bucket := range buckets {
+		byID[bucket.ID] = bucket
+	}
+
+	return byID, nil
+}
+
+// ListByParent retrieves the virtual buckets whose parent is the given physical bucket, ordered by name
+func (r *bucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	query := `
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
+		FROM buckets
+		WHERE parent_physical_bucket_id = $1
+		ORDER BY name
+	`
+
+	return r.queryBuckets(ctx, query, parentID)
+}
+
+// ListPhysicalWithChildren retrieves the non-archived physical buckets with their non-archived virtual children
+// A single self-join returns one row per child (or one row with NULL child columns for a physical bucket
+// without children), ordered so that each physical bucket's rows are contiguous and the tree is assembled in one pass
+func (r *bucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	query := `
+		SELECT p.id, p.name, p.bucket_type, p.parent_physical_bucket_id, p.current_balance, p.is_archived, p.goal_amount, p.currency,
+		       c.id, c.name, c.bucket_type, c.parent_physical_bucket_id, c.current_balance, c.is_archived, c.goal_amount, c.currency
+		FROM buckets p
+		LEFT JOIN buckets c
+			ON c.parent_physical_bucket_id = p.id AND c.bucket_type = $2 AND c.is_archived = FALSE
+		WHERE p.bucket_type = $1 AND p.is_archived = FALSE
+		ORDER BY p.name, p.id, c.name
+	`
+
+	rows, err := queryContext(ctx, r.db, query, domain.BucketTypePhysical, domain.BucketTypeVirtual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket tree: %w", err)
 	}
 	defer rows.Close()
 
-	var buckets []*domain.Bucket
+	var families []domain.BucketFamily
 	for rows.Next() {
-		var bucket domain.Bucket
-		var parentID sql.NullString
-		var balanceStr string
-
-		err := rows.Scan(
-			&bucket.ID,
-			&bucket.Name,
-			&bucket.BucketType,
-			&parentID,
-			&balanceStr,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		var physical, child bucketRow
+		if err := rows.Scan(append(physical.dest(), child.dest()...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket tree row: %w", err)
 		}
 
-		// Parse parent_physical_bucket_id (nullable)
-		if parentID.Valid {
-			parentUUID, err := uuid.Parse(parentID.String)
+		// Rows of the same physical bucket are contiguous: start a new family when the ID changes
+		if len(families) == 0 || families[len(families)-1].Physical.ID != physical.id.UUID {
+			bucket, err := physical.toBucket()
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse parent_physical_bucket_id: %w", err)
+				return nil, err
 			}
-			bucket.ParentPhysicalBucketID = &parentUUID
+			families = append(families, domain.BucketFamily{Physical: bucket, Children: []*domain.Bucket{}})
 		}
 
-		// Parse current_balance (DECIMAL)
-		balance, err := decimal.NewFromString(balanceStr)
+		// The LEFT JOIN yields NULL child columns for a physical bucket without children
+		if !child.id.Valid {
+			continue
+		}
+		bucket, err := child.toBucket()
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse current_balance: %w", err)
+			return nil, err
 		}
-		bucket.CurrentBalance = balance
+		family := &families[len(families)-1]
+		family.Children = append(family.Children, bucket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bucket tree: %w", err)
+	}
+
+	return families, nil
+}
+
+// ListWithGoals retrieves the non-archived virtual buckets that have a goal amount set, ordered by name
+func (r *bucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	query := `
+		SELECT id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency
+		FROM buckets
+		WHERE goal_amount IS NOT NULL AND bucket_type = $1 AND is_archived = FALSE
+		ORDER BY name
+	`
+
+	return r.queryBuckets(ctx, query, domain.BucketTypeVirtual)
+}
 
-		buckets = append(buckets, &bucket)
+// queryBuckets runs a bucket query and scans the rows
+// The query must select id, name, bucket_type, parent_physical_bucket_id, current_balance, is_archived, goal_amount, currency (in that order)
+func (r *bucketRepository) queryBuckets(ctx context.Context, query string, args ...interface{}) ([]*domain.Bucket, error) {
+	rows, err := queryContext(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []*domain.Bucket
+	for rows.Next() {
+		var row bucketRow
+		if err := rows.Scan(row.dest()...); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+
+		bucket, err := row.toBucket()
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -207,3 +584,65 @@ func (r *bucketRepository) List(ctx context.Context, typeFilter domain.BucketTyp
 
 	return buckets, nil
 }
+
+// bucketRow holds the scanned bucket columns of a query that may yield NULLs for the whole bucket (e.g. a LEFT JOIN)
+type bucketRow struct {
+	id         uuid.NullUUID
+	name       sql.NullString
+	bucketType sql.NullString
+	parentID   uuid.NullUUID
+	balance    sql.NullString
+	isArchived sql.NullBool
+	goal       sql.NullString
+	currency   sql.NullString
+}
+
+// dest returns the scan destinations for id, name, bucket_type, parent_physical_bucket_id, current_balance,
+// is_archived, goal_amount, currency (in that order)
+func (b *bucketRow) dest() []interface{} {
+	return []interface{}{&b.id, &b.name, &b.bucketType, &b.parentID, &b.balance, &b.isArchived, &b.goal, &b.currency}
+}
+
+// toBucket converts a scanned row into a domain bucket
+func (b *bucketRow) toBucket() (*domain.Bucket, error) {
+	bucket := &domain.Bucket{
+		ID:         b.id.UUID,
+		Name:       b.name.String,
+		BucketType: domain.BucketType(b.bucketType.String),
+		IsArchived: b.isArchived.Bool,
+		Currency:   b.currency.String,
+	}
+	if b.parentID.Valid {
+		parentID := b.parentID.UUID
+		bucket.ParentPhysicalBucketID = &parentID
+	}
+
+	// Parse current_balance (DECIMAL, nullable in the schema)
+	if !b.balance.Valid {
+		return nil, fmt.Errorf("bucket %s has null balance", bucket.ID)
+	}
+	balance, err := decimal.NewFromString(b.balance.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current_balance: %w", err)
+	}
+	bucket.CurrentBalance = balance
+
+	// Parse goal_amount (nullable)
+	if bucket.GoalAmount, err = parseGoalAmount(b.goal); err != nil {
+		return nil, err
+	}
+
+	return bucket, nil
+}
+
+// parseGoalAmount parses the nullable goal_amount column
+func parseGoalAmount(goalStr sql.NullString) (*decimal.Decimal, error) {
+	if !goalStr.Valid {
+		return nil, nil
+	}
+	goal, err := decimal.NewFromString(goalStr.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse goal_amount: %w", err)
+	}
+	return &goal, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -31,3 +32,51 @@ func NewDB(connectionString string) (*DB, error) {
 func (db *DB) Close() error {
 	return db.DB.Close()
 }
+
+// querier is the subset of *sql.DB and *sql.Tx the repositories use
+// Repositories hold a querier so the same code runs standalone or inside a unit of work
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txScope is a database transaction used by a single repository method
+// If the repository already runs inside a unit of work, the scope joins that transaction
+// and leaves Commit and Rollback to the unit of work
+type txScope struct {
+	*sql.Tx
+	owned bool
+}
+
+// Commit commits the transaction if this scope opened it
+func (t *txScope) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+// Rollback rolls the transaction back if this scope opened it
+func (t *txScope) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// beginTx opens a transaction on q, or joins it if q is already a transaction
+func beginTx(ctx context.Context, q querier) (*txScope, error) {
+	switch db := q.(type) {
+	case *sql.Tx:
+		return &txScope{Tx: db}, nil
+	case *DB:
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &txScope{Tx: tx, owned: true}, nil
+	default:
+		return nil, fmt.Errorf("unsupported querier type %T", q)
+	}
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+)
+
+// holdingRepository implements domain.HoldingRepository
+type holdingRepository struct {
+	db querier
+}
+
+// NewHoldingRepository creates a new equity holding repository
+func NewHoldingRepository(db *DB) domain.HoldingRepository {
+	return &holdingRepository{db: db}
+}
+
+// GetByBucketID retrieves the holding of an equity bucket
+// The row is locked (FOR UPDATE) so a purchase inside a unit of work can update it safely
+func (r *holdingRepository) GetByBucketID(ctx context.Context, bucketID uuid.UUID) (*domain.Holding, error) {
+	query := `
+		SELECT bucket_id, quantity, avg_cost
+		FROM equity_holdings
+		WHERE bucket_id = $1
+		FOR UPDATE
+	`
+
+	var holding domain.Holding
+	var quantityStr, avgCostStr string
+
+	err := queryRowContext(ctx, r.db, query, bucketID).Scan(
+		&holding.BucketID,
+		&quantityStr,
+		&avgCostStr,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrHoldingNotFound, bucketID)
+		}
+		return nil, fmt.Errorf("failed to get holding: %w", err)
+	}
+
+	// Parse quantity and avg_cost (DECIMAL)
+	if holding.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+		return nil, fmt.Errorf("failed to parse quantity: %w", err)
+	}
+	if holding.AvgCost, err = decimal.NewFromString(avgCostStr); err != nil {
+		return nil, fmt.Errorf("failed to parse avg_cost: %w", err)
+	}
+
+	return &holding, nil
+}
+
+// GetForBuckets retrieves the holdings of the given equity buckets in a single query, without locking them
+func (r *holdingRepository) GetForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.Holding, error) {
+	query := `
+		SELECT bucket_id, quantity, avg_cost
+		FROM equity_holdings
+		WHERE bucket_id = ANY($1)
+	`
+
+	rows, err := queryContext(ctx, r.db, query, pq.Array(bucketIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holdings: %w", err)
+	}
+	defer rows.Close()
+
+	holdings := make(map[uuid.UUID]*domain.Holding, len(bucketIDs))
+	for rows.Next() {
+		var holding domain.Holding
+		var quantityStr, avgCostStr string
+		if err := rows.Scan(&holding.BucketID, &quantityStr, &avgCostStr); err != nil {
+			return nil, fmt.Errorf("failed to scan holding: %w", err)
+		}
+		if holding.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse quantity: %w", err)
+		}
+		if holding.AvgCost, err = decimal.NewFromString(avgCostStr); err != nil {
+			return nil, fmt.Errorf("failed to parse avg_cost: %w", err)
+		}
+		holdings[holding.BucketID] = &holding
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating holdings: %w", err)
+	}
+
+	return holdings, nil
+}
+
+// Save creates or replaces the holding of an equity bucket
+func (r *holdingRepository) Save(ctx context.Context, holding *domain.Holding) error {
+	query := `
+		INSERT INTO equity_holdings (bucket_id, quantity, avg_cost, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (bucket_id) DO UPDATE
+		SET quantity = EXCLUDED.quantity, avg_cost = EXCLUDED.avg_cost, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := execContext(ctx, r.db, query,
+		holding.BucketID,
+		holding.Quantity.String(),
+		holding.AvgCost.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save holding: %w", err)
+	}
+
+	return nil
+}
@@ -5,15 +5,17 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 )
 
 // marketValueRepository implements domain.MarketValueRepository
 type marketValueRepository struct {
-	db *DB
+	db querier
 }
 
 // NewMarketValueRepository creates a new market value repository
@@ -24,15 +26,22 @@ func NewMarketValueRepository(db *DB) domain.MarketValueRepository {
 // Add creates a new market value history entry
 func (r *marketValueRepository) Add(ctx context.Context, entry *domain.MarketValueHistory) error {
 	query := `
-		INSERT INTO market_value_history (id, bucket_id, date, market_value)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO market_value_history (id, bucket_id, date, market_value, unit_price)
+		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	// Handle nullable unit_price
+	var unitPrice interface{}
+	if entry.UnitPrice != nil {
+		unitPrice = entry.UnitPrice.String()
+	}
+
+	_, err := execContext(ctx, r.db, query,
 		entry.ID,
 		entry.BucketID,
 		entry.Date,
 		entry.MarketValue.String(),
+		unitPrice,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert market value history entry: %w", err)
@@ -44,7 +53,7 @@ func (r *marketValueRepository) Add(ctx context.Context, entry *domain.MarketVal
 // GetLatest retrieves the most recent market value entry for a given bucket
 func (r *marketValueRepository) GetLatest(ctx context.Context, bucketID uuid.UUID) (*domain.MarketValueHistory, error) {
 	query := `
-		SELECT id, bucket_id, date, market_value
+		SELECT id, bucket_id, date, market_value, unit_price
 		FROM market_value_history
 		WHERE bucket_id = $1
 		ORDER BY date DESC
@@ -52,13 +61,14 @@ func (r *marketValueRepository) GetLatest(ctx context.Context, bucketID uuid.UUI
 	`
 
 	var entry domain.MarketValueHistory
-	var marketValueStr string
+	var marketValueStr, unitPriceStr sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, bucketID).Scan(
+	err := queryRowContext(ctx, r.db, query, bucketID).Scan(
 		&entry.ID,
 		&entry.BucketID,
 		&entry.Date,
 		&marketValueStr,
+		&unitPriceStr,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -68,11 +78,158 @@ func (r *marketValueRepository) GetLatest(ctx context.Context, bucketID uuid.UUI
 	}
 
 	// Parse market_value (DECIMAL)
-	marketValue, err := decimal.NewFromString(marketValueStr)
+	if !marketValueStr.Valid {
+		return nil, fmt.Errorf("market value history entry %s has null market value", entry.ID)
+	}
+	marketValue, err := decimal.NewFromString(marketValueStr.String)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse market_value: %w", err)
 	}
 	entry.MarketValue = marketValue
 
+	// Parse unit_price (nullable)
+	entry.UnitPrice, err = parseUnitPrice(unitPriceStr)
+	if err != nil {
+		return nil, err
+	}
+
 	return &entry, nil
 }
+
+// GetLatestForBuckets retrieves the most recent market value entry of each given bucket in a single query
+func (r *marketValueRepository) GetLatestForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.MarketValueHistory, error) {
+	query := `
+		SELECT DISTINCT ON (bucket_id) id, bucket_id, date, market_value, unit_price
+		FROM market_value_history
+		WHERE bucket_id = ANY($1)
+		ORDER BY bucket_id, date DESC
+	`
+
+	entries, err := r.queryMarketValues(ctx, query, pq.Array(bucketIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[uuid.UUID]*domain.MarketValueHistory, len(entries))
+	for _, entry := range entries {
+		latest[entry.BucketID] = entry
+	}
+
+	return latest, nil
+}
+
+// ListByBucket retrieves the market value entries of a bucket dated within [start, end], ordered by date ascending
+func (r *marketValueRepository) ListByBucket(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]*domain.MarketValueHistory, error) {
+	query := `
+		SELECT id, bucket_id, date, market_value, unit_price
+		FROM market_value_history
+		WHERE bucket_id = $1 AND date >= $2 AND date <= $3
+		ORDER BY date ASC
+	`
+
+	return r.queryMarketValues(ctx, query, bucketID, start, end)
+}
+
+// ListForBuckets retrieves the market value entries of the given buckets dated before the given time, ordered by date ascending
+func (r *marketValueRepository) ListForBuckets(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) ([]*domain.MarketValueHistory, error) {
+	query := `
+		SELECT id, bucket_id, date, market_value, unit_price
+		FROM market_value_history
+		WHERE bucket_id = ANY($1) AND date < $2
+		ORDER BY date ASC, id
+	`
+
+	return r.queryMarketValues(ctx, query, pq.Array(bucketIDs), before)
+}
+
+// List retrieves a paginated list of a bucket's market value entries
+// Ordered newest-first unless ascending is true
+func (r *marketValueRepository) List(ctx context.Context, bucketID uuid.UUID, limit, offset int, ascending bool) ([]*domain.MarketValueHistory, error) {
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	// id breaks ties so pages stay stable for entries sharing a date
+	query := `
+		SELECT id, bucket_id, date, market_value, unit_price
+		FROM market_value_history
+		WHERE bucket_id = $1
+		ORDER BY date ` + direction + `, id ` + direction + `
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryMarketValues(ctx, query, bucketID, limit, offset)
+}
+
+// Count returns the number of market value entries of a bucket
+func (r *marketValueRepository) Count(ctx context.Context, bucketID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM market_value_history
+		WHERE bucket_id = $1
+	`
+
+	var count int
+	if err := queryRowContext(ctx, r.db, query, bucketID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count market value history: %w", err)
+	}
+
+	return count, nil
+}
+
+// queryMarketValues runs a market value history query and scans the resulting rows
+// The query must select id, bucket_id, date, market_value, unit_price (in that order)
+func (r *marketValueRepository) queryMarketValues(ctx context.Context, query string, args ...interface{}) ([]*domain.MarketValueHistory, error) {
+	rows, err := queryContext(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market value history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.MarketValueHistory, 0)
+	for rows.Next() {
+		var entry domain.MarketValueHistory
+		var marketValueStr, unitPriceStr sql.NullString
+
+		if err := rows.Scan(&entry.ID, &entry.BucketID, &entry.Date, &marketValueStr, &unitPriceStr); err != nil {
+			return nil, fmt.Errorf("failed to scan market value history entry: %w", err)
+		}
+
+		// Parse market_value (DECIMAL)
+		if !marketValueStr.Valid {
+			return nil, fmt.Errorf("market value history entry %s has null market value", entry.ID)
+		}
+		marketValue, err := decimal.NewFromString(marketValueStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse market_value: %w", err)
+		}
+		entry.MarketValue = marketValue
+
+		// Parse unit_price (nullable)
+		entry.UnitPrice, err = parseUnitPrice(unitPriceStr)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating market value history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// parseUnitPrice parses the nullable unit_price column
+func parseUnitPrice(unitPriceStr sql.NullString) (*decimal.Decimal, error) {
+	if !unitPriceStr.Valid {
+		return nil, nil
+	}
+	unitPrice, err := decimal.NewFromString(unitPriceStr.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse unit_price: %w", err)
+	}
+	return &unitPrice, nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSlowQueryThreshold is how long a statement may take before it is logged as slow
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// slowQueryThreshold holds the current threshold in nanoseconds (0 disables the slow-query log)
+// It is package-wide because repositories run on either *DB or a unit-of-work *sql.Tx
+var slowQueryThreshold atomic.Int64
+
+// slowQueryLogger receives the slow-query log lines (replaced in tests)
+var slowQueryLogger = log.Default()
+
+func init() {
+	slowQueryThreshold.Store(int64(DefaultSlowQueryThreshold))
+}
+
+// SetSlowQueryThreshold sets how long a statement may take before it is logged
+// A threshold of zero or less disables the slow-query log
+func SetSlowQueryThreshold(threshold time.Duration) {
+	if threshold < 0 {
+		threshold = 0
+	}
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// execContext runs q.ExecContext and logs the statement if it exceeds the slow-query threshold
+func execContext(ctx context.Context, q querier, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := q.ExecContext(ctx, query, args...)
+	logIfSlow(query, time.Since(start))
+	return result, err
+}
+
+// queryContext runs q.QueryContext and logs the query if it exceeds the slow-query threshold
+// Only the time until the first rows are available is measured, not the caller's iteration
+func queryContext(ctx context.Context, q querier, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := q.QueryContext(ctx, query, args...)
+	logIfSlow(query, time.Since(start))
+	return rows, err
+}
+
+// queryRowContext runs q.QueryRowContext and logs the query if it exceeds the slow-query threshold
+func queryRowContext(ctx context.Context, q querier, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := q.QueryRowContext(ctx, query, args...)
+	logIfSlow(query, time.Since(start))
+	return row
+}
+
+// logIfSlow logs the query text and its duration when it took at least the threshold
+// The arguments are never logged, since they carry amounts and descriptions
+func logIfSlow(query string, elapsed time.Duration) {
+	threshold := time.Duration(slowQueryThreshold.Load())
+	if threshold <= 0 || elapsed < threshold {
+		return
+	}
+	slowQueryLogger.Printf("Slow query (%s, threshold %s): %s", elapsed, threshold, strings.Join(strings.Fields(query), " "))
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sleepingQuerier is a querier whose statements take a fixed time and return nothing
+type sleepingQuerier struct {
+	delay time.Duration
+}
+
+func (q *sleepingQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	time.Sleep(q.delay)
+	return nil, nil
+}
+
+func (q *sleepingQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	time.Sleep(q.delay)
+	return nil, nil
+}
+
+func (q *sleepingQuerier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	time.Sleep(q.delay)
+	return nil
+}
+
+// captureSlowQueryLog redirects the slow-query log into a buffer and sets the threshold for one test
+func captureSlowQueryLog(t *testing.T, threshold time.Duration) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previousLogger := slowQueryLogger
+	previousThreshold := time.Duration(slowQueryThreshold.Load())
+	slowQueryLogger = log.New(&buf, "", 0)
+	SetSlowQueryThreshold(threshold)
+	t.Cleanup(func() {
+		slowQueryLogger = previousLogger
+		SetSlowQueryThreshold(previousThreshold)
+	})
+	return &buf
+}
+
+func TestSlowQueryLog_LogsSlowStatements(t *testing.T) {
+	buf := captureSlowQueryLog(t, time.Millisecond)
+	q := &sleepingQuerier{delay: 5 * time.Millisecond}
+
+	_, err := execContext(context.Background(), q, `
+		UPDATE buckets
+		SET current_balance = $2
+		WHERE id = $1`, "bucket-id", "secret-amount")
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Slow query")
+	assert.Contains(t, output, "threshold 1ms")
+	// The statement is logged on one line, without its arguments
+	assert.Contains(t, output, "UPDATE buckets SET current_balance = $2 WHERE id = $1")
+	assert.NotContains(t, output, "secret-amount")
+}
+
+func TestSlowQueryLog_AllWrappers(t *testing.T) {
+	buf := captureSlowQueryLog(t, time.Millisecond)
+	q := &sleepingQuerier{delay: 5 * time.Millisecond}
+	ctx := context.Background()
+
+	_, _ = queryContext(ctx, q, "SELECT 1")
+	_ = queryRowContext(ctx, q, "SELECT 2")
+
+	output := buf.String()
+	assert.Contains(t, output, "SELECT 1")
+	assert.Contains(t, output, "SELECT 2")
+}
+
+func TestSlowQueryLog_FastStatementNotLogged(t *testing.T) {
+	buf := captureSlowQueryLog(t, time.Hour)
+	q := &sleepingQuerier{}
+
+	_, err := execContext(context.Background(), q, "SELECT 1")
+	require.NoError(t, err)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestSlowQueryLog_Disabled(t *testing.T) {
+	buf := captureSlowQueryLog(t, 0)
+	q := &sleepingQuerier{delay: 5 * time.Millisecond}
+
+	_, err := execContext(context.Background(), q, "SELECT 1")
+	require.NoError(t, err)
+
+	assert.Empty(t, buf.String())
+}
@@ -14,7 +14,7 @@ import (
 
 // splitRuleRepository implements domain.SplitRuleRepository
 type splitRuleRepository struct {
-	db *DB
+	db querier
 }
 
 // NewSplitRuleRepository creates a new split rule repository
@@ -27,33 +27,39 @@ func NewSplitRuleRepository(db *DB) domain.SplitRuleRepository {
 func (r *splitRuleRepository) GetBySourceBucketID(ctx context.Context, bucketID uuid.UUID) (*domain.SplitRule, error) {
 	// First, get the split rule
 	ruleQuery := `
-		SELECT id, name, source_bucket_id
+		SELECT id, name, source_bucket_id, destination_physical_bucket_id, is_active
 		FROM split_rules
 		WHERE source_bucket_id = $1
 	`
 
 	var splitRule domain.SplitRule
-	err := r.db.QueryRowContext(ctx, ruleQuery, bucketID).Scan(
+	var destinationID uuid.NullUUID
+	err := queryRowContext(ctx, r.db, ruleQuery, bucketID).Scan(
 		&splitRule.ID,
 		&splitRule.Name,
 		&splitRule.SourceBucketID,
+		&destinationID,
+		&splitRule.Active,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("split rule not found for source bucket ID %s: %w", bucketID, err)
+			return nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, bucketID)
 		}
 		return nil, fmt.Errorf("failed to get split rule: %w", err)
 	}
+	if destinationID.Valid {
+		splitRule.DestinationPhysicalBucketID = &destinationID.UUID
+	}
 
 	// Then, get all split rule items
 	itemsQuery := `
-		SELECT id, split_rule_id, target_bucket_id, rule_type, value, priority
+		SELECT id, split_rule_id, target_bucket_id, rule_type, value, priority, relative_to_bucket_id
 		FROM split_rule_items
 		WHERE split_rule_id = $1
 		ORDER BY priority ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, itemsQuery, splitRule.ID)
+	rows, err := queryContext(ctx, r.db, itemsQuery, splitRule.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query split rule items: %w", err)
 	}
@@ -62,7 +68,8 @@ func (r *splitRuleRepository) GetBySourceBucketID(ctx context.Context, bucketID
 	var items []domain.SplitRuleItem
 	for rows.Next() {
 		var item domain.SplitRuleItem
-		var valueStr string
+		var valueStr sql.NullString
+		var relativeToBucketID sql.NullString
 
 		err := rows.Scan(
 			&item.ID,
@@ -71,18 +78,31 @@ func (r *splitRuleRepository) GetBySourceBucketID(ctx context.Context, bucketID
 			&item.Type,
 			&valueStr,
 			&item.Priority,
+			&relativeToBucketID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan split rule item: %w", err)
 		}
 
 		// Parse value (DECIMAL)
-		value, err := decimal.NewFromString(valueStr)
+		if !valueStr.Valid {
+			return nil, fmt.Errorf("split rule item %s has null value", item.ID)
+		}
+		value, err := decimal.NewFromString(valueStr.String)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse split rule item value: %w", err)
 		}
 		item.Value = value
 
+		// Parse relative_to_bucket_id (nullable)
+		if relativeToBucketID.Valid {
+			relativeUUID, err := uuid.Parse(relativeToBucketID.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse relative_to_bucket_id: %w", err)
+			}
+			item.RelativeToBucketID = &relativeUUID
+		}
+
 		items = append(items, item)
 	}
 
@@ -90,10 +110,6 @@ func (r *splitRuleRepository) GetBySourceBucketID(ctx context.Context, bucketID
 		return nil, fmt.Errorf("error iterating split rule items: %w", err)
 	}
 
-	if len(items) == 0 {
-		return nil, fmt.Errorf("split rule %s has no items", splitRule.ID)
-	}
-
 	// Sort items by priority (lower number = higher priority)
 	sort.Slice(items, func(i, j int) bool {
 		return items[i].Priority < items[j].Priority
@@ -103,3 +119,124 @@ func (r *splitRuleRepository) GetBySourceBucketID(ctx context.Context, bucketID
 
 	return &splitRule, nil
 }
+
+// Create creates a new split rule with all its items in a database transaction
+func (r *splitRuleRepository) Create(ctx context.Context, rule *domain.SplitRule) error {
+	// Start a database transaction
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Insert the split rule header
+	insertRuleQuery := `
+		INSERT INTO split_rules (id, name, source_bucket_id, destination_physical_bucket_id, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	var destinationID interface{}
+	if rule.DestinationPhysicalBucketID != nil {
+		destinationID = rule.DestinationPhysicalBucketID
+	}
+
+	_, err = execContext(ctx, dbTx, insertRuleQuery,
+		rule.ID,
+		rule.Name,
+		rule.SourceBucketID,
+		destinationID,
+		rule.Active,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert split rule: %w", err)
+	}
+
+	// Insert all split rule items
+	insertItemQuery := `
+		INSERT INTO split_rule_items (id, split_rule_id, target_bucket_id, rule_type, value, priority, relative_to_bucket_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	for _, item := range rule.Items {
+		var relativeToBucketID interface{}
+		if item.RelativeToBucketID != nil {
+			relativeToBucketID = item.RelativeToBucketID
+		}
+
+		_, err = execContext(ctx, dbTx, insertItemQuery,
+			item.ID,
+			item.SplitRuleID,
+			item.TargetBucketID,
+			string(item.Type),
+			item.Value.String(),
+			item.Priority,
+			relativeToBucketID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert split rule item: %w", err)
+		}
+	}
+
+	// Commit the transaction
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountItemsBySourceBucket returns the number of items of every split rule, keyed by source bucket ID
+func (r *splitRuleRepository) CountItemsBySourceBucket(ctx context.Context) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT sr.source_bucket_id, COUNT(sri.id)
+		FROM split_rules sr
+		LEFT JOIN split_rule_items sri ON sri.split_rule_id = sr.id
+		GROUP BY sr.source_bucket_id
+	`
+
+	rows, err := queryContext(ctx, r.db, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count split rule items: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var sourceBucketID uuid.UUID
+		var count int
+		if err := rows.Scan(&sourceBucketID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan split rule item count: %w", err)
+		}
+		counts[sourceBucketID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating split rule item counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// SetActive enables or disables the split rule of a source bucket, keeping its items
+func (r *splitRuleRepository) SetActive(ctx context.Context, sourceBucketID uuid.UUID, active bool) error {
+	query := `
+		UPDATE split_rules
+		SET is_active = $2
+		WHERE source_bucket_id = $1
+	`
+
+	result, err := execContext(ctx, r.db, query, sourceBucketID, active)
+	if err != nil {
+		return fmt.Errorf("failed to update split rule active flag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, sourceBucketID)
+	}
+
+	return nil
+}
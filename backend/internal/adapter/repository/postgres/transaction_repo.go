@@ -2,7 +2,11 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -10,9 +14,28 @@ import (
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 )
 
+// maxTransactionPageSize caps the page size of transaction listings regardless of the caller
+// It is a safety net well above the gRPC LIST_MAX_LIMIT default, not the user-facing bound
+const maxTransactionPageSize = 10000
+
+// clampPage bounds pagination arguments so a careless caller cannot produce a SQL error
+// (negative OFFSET or LIMIT) or an unbounded query: offset >= 0, limit within [1, maxTransactionPageSize]
+func clampPage(limit, offset int) (int, int) {
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > maxTransactionPageSize {
+		limit = maxTransactionPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
 // transactionRepository implements domain.TransactionRepository
 type transactionRepository struct {
-	db *DB
+	db querier
 }
 
 // NewTransactionRepository creates a new transaction repository
@@ -23,7 +46,7 @@ func NewTransactionRepository(db *DB) domain.TransactionRepository {
 // Create creates a new transaction with all its entries in a database transaction
 func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
 	// Start a database transaction
-	dbTx, err := r.db.BeginTx(ctx, nil)
+	dbTx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -31,35 +54,46 @@ func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 
 	// Insert the transaction header
 	insertTxQuery := `
-		INSERT INTO transactions (id, description, date, is_internal_transfer, is_external_inflow)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO transactions (id, description, date, is_internal_transfer, is_external_inflow, memo, is_refund, scheduled, effective_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err = dbTx.ExecContext(ctx, insertTxQuery,
+	// Store an empty memo as NULL
+	var memo interface{}
+	if tx.Memo != "" {
+		memo = tx.Memo
+	}
+
+	_, err = execContext(ctx, dbTx, insertTxQuery,
 		tx.ID,
 		tx.Description,
 		tx.Date,
 		tx.IsInternalTransfer,
 		tx.IsExternalInflow,
+		memo,
+		tx.IsRefund,
+		tx.Scheduled,
+		tx.EffectiveDate,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert transaction: %w", err)
 	}
 
-	// Insert all transaction entries
+	// Insert all transaction entries (sequence keeps the order they were built in for display)
 	insertEntryQuery := `
-		INSERT INTO transaction_entries (id, transaction_id, bucket_id, amount, type, layer)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO transaction_entries (id, transaction_id, bucket_id, amount, type, layer, sequence)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
-	for _, entry := range tx.Entries {
-		_, err = dbTx.ExecContext(ctx, insertEntryQuery,
+	for i, entry := range tx.Entries {
+		_, err = execContext(ctx, dbTx, insertEntryQuery,
 			entry.ID,
 			entry.TransactionID,
 			entry.BucketID,
 			entry.Amount.String(),
 			string(entry.Type),
 			string(entry.Layer),
+			i,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert transaction entry: %w", err)
@@ -74,15 +108,121 @@ func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 	return nil
 }
 
+// GetByID retrieves a transaction with all its entries (including the memo)
+func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	query := `
+		SELECT id, description, date, is_internal_transfer, is_external_inflow, is_refund, scheduled, effective_date, memo
+		FROM transactions
+		WHERE id = $1
+	`
+
+	var tx domain.Transaction
+	var memo sql.NullString
+	var effectiveDate sql.NullTime
+
+	err := queryRowContext(ctx, r.db, query, id).Scan(
+		&tx.ID,
+		&tx.Description,
+		&tx.Date,
+		&tx.IsInternalTransfer,
+		&tx.IsExternalInflow,
+		&tx.IsRefund,
+		&tx.Scheduled,
+		&effectiveDate,
+		&memo,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrTransactionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
+	}
+	tx.Memo = memo.String
+	if effectiveDate.Valid {
+		tx.EffectiveDate = &effectiveDate.Time
+	}
+	tx.Entries = []domain.TransactionEntry{}
+
+	if err := r.loadEntries(ctx, []*domain.Transaction{&tx}); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// Delete removes a transaction and its entries inside a single database transaction
+// The balance_update_trigger only fires on INSERT, so the inverse balance adjustments are applied here
+func (r *transactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	// Start a database transaction
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Lock the transaction header (and verify it exists)
+	var scheduled bool
+	err = queryRowContext(ctx, dbTx, `SELECT scheduled FROM transactions WHERE id = $1 FOR UPDATE`, id).Scan(&scheduled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", domain.ErrTransactionNotFound, id)
+		}
+		return fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	// Refuse to delete once the real-world transfer has been completed
+	var completedTasks int
+	err = queryRowContext(ctx, dbTx, `
+		SELECT COUNT(*)
+		FROM transfer_tasks
+		WHERE (related_transaction_id = $1 OR completed_transaction_id = $1) AND is_completed = TRUE
+	`, id).Scan(&completedTasks)
+	if err != nil {
+		return fmt.Errorf("failed to check transfer tasks: %w", err)
+	}
+	if completedTasks > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTransactionHasCompletedTransfer, id)
+	}
+
+	// Revert balances: DEBIT entries were added and CREDIT entries subtracted on insert
+	// A scheduled transaction never touched the balances, so there is nothing to revert
+	if !scheduled {
+		if err := applyBalanceDeltas(ctx, dbTx, id, -1); err != nil {
+			return fmt.Errorf("failed to revert bucket balances: %w", err)
+		}
+	}
+
+	// Remove pending transfer tasks, entries and the header
+	if _, err := execContext(ctx, dbTx, `DELETE FROM transfer_tasks WHERE related_transaction_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete transfer tasks: %w", err)
+	}
+	if _, err := execContext(ctx, dbTx, `DELETE FROM transaction_entries WHERE transaction_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete transaction entries: %w", err)
+	}
+	if _, err := execContext(ctx, dbTx, `DELETE FROM transactions WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	// Commit the transaction
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // List retrieves a paginated list of transactions
+// The memo is not loaded (summary view); use GetByID for the full transaction
 func (r *transactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
+	limit, offset = clampPage(limit, offset)
+
 	var query string
 	var args []interface{}
 
 	// Build query based on whether bucketID filter is provided
 	if bucketID != nil {
 		query = `
-			SELECT DISTINCT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow
+			SELECT DISTINCT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow, t.is_refund, t.scheduled, t.effective_date
 			FROM transactions t
 			INNER JOIN transaction_entries te ON t.id = te.transaction_id
 			WHERE te.bucket_id = $1
@@ -92,7 +232,7 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 		args = []interface{}{*bucketID, limit, offset}
 	} else {
 		query = `
-			SELECT id, description, date, is_internal_transfer, is_external_inflow
+			SELECT id, description, date, is_internal_transfer, is_external_inflow, is_refund, scheduled, effective_date
 			FROM transactions
 			ORDER BY date DESC, id
 			LIMIT $1 OFFSET $2
@@ -100,31 +240,262 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 		args = []interface{}{limit, offset}
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	return r.queryTransactions(ctx, query, args...)
+}
+
+// ListForBuckets retrieves a paginated list of transactions involving any of the given buckets
+// Each transaction appears once even if it touches several of the buckets
+func (r *transactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	limit, offset = clampPage(limit, offset)
+	query := `
+		SELECT DISTINCT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow, t.is_refund, t.scheduled, t.effective_date
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE te.bucket_id = ANY($1)
+		ORDER BY t.date DESC, t.id
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryTransactions(ctx, query, pq.Array(bucketIDs), limit, offset)
+}
+
+// ListScheduledDue retrieves the scheduled transactions whose effective date is at or before asOf, oldest first
+func (r *transactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, description, date, is_internal_transfer, is_external_inflow, is_refund, scheduled, effective_date
+		FROM transactions
+		WHERE scheduled AND effective_date <= $1
+		ORDER BY effective_date ASC, id
+	`
+
+	return r.queryTransactions(ctx, query, asOf)
+}
+
+// Activate clears a scheduled transaction's flag and applies its entries to the bucket balances
+// (the balance_update_trigger skipped them on insert) inside a single database transaction
+func (r *transactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Only a still-scheduled transaction is activated, so a concurrent run cannot apply it twice
+	result, err := execContext(ctx, dbTx, `UPDATE transactions SET scheduled = FALSE WHERE id = $1 AND scheduled`, id)
+	if err != nil {
+		return fmt.Errorf("failed to activate transaction: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: no scheduled transaction %s", domain.ErrTransactionNotFound, id)
+	}
+
+	if err := applyBalanceDeltas(ctx, dbTx, id, 1); err != nil {
+		return fmt.Errorf("failed to apply bucket balances: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// applyBalanceDeltas adds (sign 1) or removes (sign -1) a transaction's entries from the bucket balances
+// using the balance_update_trigger convention: DEBIT entries add, CREDIT entries subtract
+func applyBalanceDeltas(ctx context.Context, q querier, transactionID uuid.UUID, sign int) error {
+	_, err := execContext(ctx, q, `
+		UPDATE buckets b
+		SET current_balance = b.current_balance + $2 * d.delta
+		FROM (
+			SELECT bucket_id, SUM(CASE WHEN type = 'DEBIT' THEN amount ELSE -amount END) AS delta
+			FROM transaction_entries
+			WHERE transaction_id = $1
+			GROUP BY bucket_id
+		) d
+		WHERE b.id = d.bucket_id
+	`, transactionID, sign)
+	return err
+}
+
+// Recategorize re-points a category's entries (DEBIT and CREDIT, e.g. refunds) to another category inside a single database transaction
+// The balance_update_trigger only fires on INSERT, so the re-pointed amounts are moved between the balances here
+// (entries of scheduled transactions are re-pointed too, but never counted in the balances yet)
+func (r *transactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	dbTx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	// Lock both categories (and verify they exist) so no balance update interleaves with the move
+	rows, err := queryContext(ctx, dbTx, `
+		SELECT id
+		FROM buckets
+		WHERE id IN ($1, $2)
+		ORDER BY id
+		FOR UPDATE
+	`, sourceCategoryID, targetCategoryID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock buckets: %w", err)
+	}
+	locked := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		locked[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating buckets: %w", err)
+	}
+	for _, id := range []uuid.UUID{sourceCategoryID, targetCategoryID} {
+		if !locked[id] {
+			return 0, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, id)
+		}
+	}
+
+	// Re-point the matching entries, summing what they contributed to the source balance (DEBIT adds, CREDIT subtracts)
+	// POSITION rather than LIKE, so '%' and '_' in the filter match literally (an empty filter matches everything)
+	var repointed int
+	var movedStr string
+	err = queryRowContext(ctx, dbTx, `
+		WITH moved AS (
+			UPDATE transaction_entries te
+			SET bucket_id = $2
+			FROM transactions t
+			WHERE te.transaction_id = t.id
+			  AND te.bucket_id = $1
+			  AND POSITION(LOWER($3) IN LOWER(t.description)) > 0
+			RETURNING CASE WHEN te.type = 'DEBIT' THEN te.amount ELSE -te.amount END AS delta, t.scheduled
+		)
+		SELECT COUNT(*), COALESCE(SUM(delta) FILTER (WHERE NOT scheduled), 0)
+		FROM moved
+	`, sourceCategoryID, targetCategoryID, descriptionFilter).Scan(&repointed, &movedStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-point transaction entries: %w", err)
+	}
+	moved, err := decimal.NewFromString(movedStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse moved amount: %w", err)
+	}
+
+	// The net of the entries was added to the source balance on insert, so it moves over to the target
+	if !moved.IsZero() {
+		_, err = execContext(ctx, dbTx, `
+			UPDATE buckets
+			SET current_balance = current_balance + CASE WHEN id = $2 THEN $3::numeric ELSE -$3::numeric END
+			WHERE id IN ($1, $2)
+		`, sourceCategoryID, targetCategoryID, moved.String())
+		if err != nil {
+			return 0, fmt.Errorf("failed to move category balances: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return repointed, nil
+}
+
+// ListFiltered retrieves a paginated list of transactions matching every filter that is set
+func (r *transactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	limit, offset = clampPage(limit, offset)
+	where, args := transactionFilterClause(filter)
+	query := fmt.Sprintf(`
+		SELECT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow, t.is_refund, t.scheduled, t.effective_date
+		FROM transactions t
+		%s
+		ORDER BY t.date DESC, t.id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	return r.queryTransactions(ctx, query, append(args, limit, offset)...)
+}
+
+// CountFiltered returns the number of transactions matching every filter that is set
+func (r *transactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	where, args := transactionFilterClause(filter)
+	query := `
+		SELECT COUNT(*)
+		FROM transactions t
+		` + where
+
+	var count int
+	err := queryRowContext(ctx, r.db, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+// transactionFilterClause builds the WHERE clause (on alias t) and its arguments for a transaction filter
+// Placeholders are numbered from $1; the clause is empty when no filter is set
+func transactionFilterClause(filter domain.TransactionFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.BucketIDs) > 0 {
+		args = append(args, pq.Array(filter.BucketIDs))
+		conditions = append(conditions, fmt.Sprintf(
+			"t.id IN (SELECT transaction_id FROM transaction_entries WHERE bucket_id = ANY($%d))", len(args)))
+	}
+
+	switch filter.Kind {
+	case domain.TransactionKindRefund:
+		conditions = append(conditions, "t.is_refund = TRUE")
+	case domain.TransactionKindExternalInflow:
+		conditions = append(conditions, "t.is_external_inflow = TRUE")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// queryTransactions runs a transaction header query and loads the entries of every returned transaction
+// The query must select id, description, date, is_internal_transfer, is_external_inflow, is_refund, scheduled,
+// effective_date (in that order)
+func (r *transactionRepository) queryTransactions(ctx context.Context, query string, args ...interface{}) ([]*domain.Transaction, error) {
+	rows, err := queryContext(ctx, r.db, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list transactions: %w", err)
 	}
 	defer rows.Close()
 
 	var transactions []*domain.Transaction
-	var transactionIDs []uuid.UUID
 
 	// First, collect all transaction headers
 	for rows.Next() {
 		var tx domain.Transaction
+		var effectiveDate sql.NullTime
 		err := rows.Scan(
 			&tx.ID,
 			&tx.Description,
 			&tx.Date,
 			&tx.IsInternalTransfer,
 			&tx.IsExternalInflow,
+			&tx.IsRefund,
+			&tx.Scheduled,
+			&effectiveDate,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
+		if effectiveDate.Valid {
+			tx.EffectiveDate = &effectiveDate.Time
+		}
 		tx.Entries = []domain.TransactionEntry{} // Initialize empty entries
 		transactions = append(transactions, &tx)
-		transactionIDs = append(transactionIDs, tx.ID)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -132,15 +503,27 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 	}
 
 	// If no transactions found, return empty slice
-	if len(transactionIDs) == 0 {
+	if len(transactions) == 0 {
 		return transactions, nil
 	}
 
 	// Now load all entries for these transactions
+	if err := r.loadEntries(ctx, transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// loadEntries loads the entries of the given transactions in a single query and appends them to each transaction
+// Entries keep the order they were created in (older entries without a sequence are ordered by ID)
+func (r *transactionRepository) loadEntries(ctx context.Context, transactions []*domain.Transaction) error {
 	// Build a map for quick lookup
 	txMap := make(map[uuid.UUID]*domain.Transaction)
+	transactionIDs := make([]uuid.UUID, 0, len(transactions))
 	for _, tx := range transactions {
 		txMap[tx.ID] = tx
+		transactionIDs = append(transactionIDs, tx.ID)
 	}
 
 	// Query all entries for the transactions we found
@@ -148,18 +531,18 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 		SELECT id, transaction_id, bucket_id, amount, type, layer
 		FROM transaction_entries
 		WHERE transaction_id = ANY($1)
-		ORDER BY transaction_id, id
+		ORDER BY transaction_id, sequence, id
 	`
-	entriesRows, err := r.db.QueryContext(ctx, entriesQuery, pq.Array(transactionIDs))
+	entriesRows, err := queryContext(ctx, r.db, entriesQuery, pq.Array(transactionIDs))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query transaction entries: %w", err)
+		return fmt.Errorf("failed to query transaction entries: %w", err)
 	}
 	defer entriesRows.Close()
 
 	// Load entries into their respective transactions
 	for entriesRows.Next() {
 		var entry domain.TransactionEntry
-		var amountStr string
+		var amountStr sql.NullString
 
 		err := entriesRows.Scan(
 			&entry.ID,
@@ -170,13 +553,16 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 			&entry.Layer,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan transaction entry: %w", err)
+			return fmt.Errorf("failed to scan transaction entry: %w", err)
 		}
 
 		// Parse amount (DECIMAL)
-		amount, err := decimal.NewFromString(amountStr)
+		if !amountStr.Valid {
+			return fmt.Errorf("transaction entry %s has null amount", entry.ID)
+		}
+		amount, err := decimal.NewFromString(amountStr.String)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse entry amount: %w", err)
+			return fmt.Errorf("failed to parse entry amount: %w", err)
 		}
 		entry.Amount = amount
 
@@ -187,10 +573,10 @@ func (r *transactionRepository) List(ctx context.Context, limit, offset int, buc
 	}
 
 	if err := entriesRows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating transaction entries: %w", err)
+		return fmt.Errorf("error iterating transaction entries: %w", err)
 	}
 
-	return transactions, nil
+	return nil
 }
 
 // Count returns the total number of transactions
@@ -216,10 +602,378 @@ func (r *transactionRepository) Count(ctx context.Context, bucketID *uuid.UUID)
 	}
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := queryRowContext(ctx, r.db, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountForBuckets returns the number of distinct transactions involving any of the given buckets
+func (r *transactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT t.id)
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE te.bucket_id = ANY($1)
+	`
+
+	var count int
+	err := queryRowContext(ctx, r.db, query, pq.Array(bucketIDs)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count transactions: %w", err)
 	}
 
 	return count, nil
 }
+
+// ListBalanceChanges returns the per-transaction entry totals of a bucket dated up to and including until
+// The sign is applied by the caller (see domain.BalanceEffect)
+func (r *transactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	query := `
+		SELECT t.date,
+			COALESCE(SUM(te.amount) FILTER (WHERE te.type = 'DEBIT'), 0),
+			COALESCE(SUM(te.amount) FILTER (WHERE te.type = 'CREDIT'), 0)
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE te.bucket_id = $1 AND t.date <= $2 AND NOT t.scheduled
+		GROUP BY t.id, t.date
+		ORDER BY t.date ASC
+	`
+
+	rows, err := queryContext(ctx, r.db, query, bucketID, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balance changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]domain.BalanceChange, 0)
+	for rows.Next() {
+		var change domain.BalanceChange
+		var debitsStr, creditsStr string
+
+		if err := rows.Scan(&change.Date, &debitsStr, &creditsStr); err != nil {
+			return nil, fmt.Errorf("failed to scan balance change: %w", err)
+		}
+
+		totals, err := parseEntryTotals(debitsStr, creditsStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse balance change: %w", err)
+		}
+		change.Totals = totals
+
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating balance changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// SumNetDebitsByPeriod sums a bucket's DEBIT minus CREDIT entries on the given layer per period for transactions dated in [start, end]
+func (r *transactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	query := `
+		SELECT date_trunc($1, t.date) AS period, SUM(CASE WHEN te.type = 'DEBIT' THEN te.amount ELSE -te.amount END)
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE te.bucket_id = $2 AND te.layer = $3 AND t.date >= $4 AND t.date <= $5 AND NOT t.scheduled
+		GROUP BY period
+		ORDER BY period ASC
+	`
+
+	rows, err := queryContext(ctx, r.db, query, string(granularity), bucketID, string(layer), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum net debits by period: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]domain.PeriodTotal, 0)
+	for rows.Next() {
+		var total domain.PeriodTotal
+		var totalStr string
+
+		if err := rows.Scan(&total.Period, &totalStr); err != nil {
+			return nil, fmt.Errorf("failed to scan period total: %w", err)
+		}
+
+		amount, err := decimal.NewFromString(totalStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period total: %w", err)
+		}
+		total.Total = amount
+
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating period totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// SumEntriesByBucket returns the summed DEBIT and CREDIT entries of each given bucket in a single query
+func (r *transactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	query := `
+		SELECT bucket_id,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'DEBIT'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE type = 'CREDIT'), 0)
+		FROM transaction_entries
+		WHERE bucket_id = ANY($1)
+		  AND transaction_id NOT IN (SELECT id FROM transactions WHERE scheduled)
+		GROUP BY bucket_id
+	`
+
+	rows, err := queryContext(ctx, r.db, query, pq.Array(bucketIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum entries by bucket: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[uuid.UUID]domain.EntryTotals, len(bucketIDs))
+	for rows.Next() {
+		var bucketID uuid.UUID
+		var debitsStr, creditsStr string
+
+		if err := rows.Scan(&bucketID, &debitsStr, &creditsStr); err != nil {
+			return nil, fmt.Errorf("failed to scan entry sum: %w", err)
+		}
+
+		totals, err := parseEntryTotals(debitsStr, creditsStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry sum: %w", err)
+		}
+		sums[bucketID] = totals
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entry sums: %w", err)
+	}
+
+	return sums, nil
+}
+
+// SumParkedIncome returns the unallocated income parked in parkingBucketID that landed in physicalBucketID
+// The sum is computed in the database, so the parked income can be checked cheaply while the parking bucket is locked
+func (r *transactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN t.is_external_inflow AND te.type = 'DEBIT' THEN te.amount
+			WHEN t.is_internal_transfer AND te.type = 'CREDIT' THEN -te.amount END), 0)
+		FROM transaction_entries te
+		INNER JOIN transactions t ON t.id = te.transaction_id
+		WHERE te.bucket_id = $1 AND te.layer = 'VIRTUAL' AND NOT t.scheduled
+		  AND (
+			(t.is_external_inflow AND EXISTS (
+				SELECT 1 FROM transaction_entries p
+				WHERE p.transaction_id = t.id AND p.layer = 'PHYSICAL' AND p.type = 'DEBIT' AND p.bucket_id = $2
+			))
+			OR (t.is_internal_transfer AND EXISTS (
+				SELECT 1 FROM transaction_entries v
+				INNER JOIN buckets b ON b.id = v.bucket_id
+				WHERE v.transaction_id = t.id AND v.layer = 'VIRTUAL' AND v.type = 'DEBIT' AND b.parent_physical_bucket_id = $2
+			))
+		  )
+	`
+
+	var totalStr string
+	if err := queryRowContext(ctx, r.db, query, parkingBucketID, physicalBucketID).Scan(&totalStr); err != nil {
+		return decimal.Zero, fmt.Errorf("failed to sum parked income: %w", err)
+	}
+
+	total, err := decimal.NewFromString(totalStr)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to parse parked income: %w", err)
+	}
+
+	return total, nil
+}
+
+// SumEntriesByBucketBefore returns the summed DEBIT and CREDIT entries of each given bucket
+// for non-scheduled transactions dated before the given time
+func (r *transactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	query := `
+		SELECT te.bucket_id,
+			COALESCE(SUM(te.amount) FILTER (WHERE te.type = 'DEBIT'), 0),
+			COALESCE(SUM(te.amount) FILTER (WHERE te.type = 'CREDIT'), 0)
+		FROM transaction_entries te
+		INNER JOIN transactions t ON t.id = te.transaction_id
+		WHERE te.bucket_id = ANY($1) AND t.date < $2 AND NOT t.scheduled
+		GROUP BY te.bucket_id
+	`
+
+	rows, err := queryContext(ctx, r.db, query, pq.Array(bucketIDs), before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum entries by bucket: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[uuid.UUID]domain.EntryTotals, len(bucketIDs))
+	for rows.Next() {
+		var bucketID uuid.UUID
+		var debitsStr, creditsStr string
+
+		if err := rows.Scan(&bucketID, &debitsStr, &creditsStr); err != nil {
+			return nil, fmt.Errorf("failed to scan entry sum: %w", err)
+		}
+
+		totals, err := parseEntryTotals(debitsStr, creditsStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry sum: %w", err)
+		}
+		sums[bucketID] = totals
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating entry sums: %w", err)
+	}
+
+	return sums, nil
+}
+
+// ListInPeriod retrieves the non-scheduled transactions dated within [start, end) that involve any of bucketIDs, oldest first
+func (r *transactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow, t.is_refund, t.scheduled, t.effective_date
+		FROM transactions t
+		WHERE t.date >= $2 AND t.date < $3 AND NOT t.scheduled
+		  AND t.id IN (SELECT transaction_id FROM transaction_entries WHERE bucket_id = ANY($1))
+		ORDER BY t.date ASC, t.id
+	`
+
+	return r.queryTransactions(ctx, query, pq.Array(bucketIDs), start, end)
+}
+
+// ListBetweenBuckets retrieves every non-scheduled transaction with entries for both bucketA and bucketB
+func (r *transactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	query := `
+		SELECT t.id, t.description, t.date, t.is_internal_transfer, t.is_external_inflow, t.is_refund, t.scheduled, t.effective_date
+		FROM transactions t
+		WHERE NOT t.scheduled
+		  AND EXISTS (SELECT 1 FROM transaction_entries te WHERE te.transaction_id = t.id AND te.bucket_id = $1)
+		  AND EXISTS (SELECT 1 FROM transaction_entries te WHERE te.transaction_id = t.id AND te.bucket_id = $2)
+		ORDER BY t.date DESC, t.id
+	`
+
+	return r.queryTransactions(ctx, query, bucketA, bucketB)
+}
+
+// CountByDay counts the non-scheduled transactions dated in [start, end] per day, optionally only those touching bucketID
+func (r *transactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	query := `
+		SELECT date_trunc('day', t.date) AS day, COUNT(*)
+		FROM transactions t
+		WHERE t.date >= $1 AND t.date <= $2 AND NOT t.scheduled
+		  AND ($3::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM transaction_entries te WHERE te.transaction_id = t.id AND te.bucket_id = $3
+		  ))
+		GROUP BY day
+		ORDER BY day ASC
+	`
+
+	// Handle nullable bucket filter
+	var bucketFilter interface{}
+	if bucketID != nil {
+		bucketFilter = *bucketID
+	}
+
+	rows, err := queryContext(ctx, r.db, query, start, end, bucketFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions by day: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]domain.DateCount, 0)
+	for rows.Next() {
+		var count domain.DateCount
+		if err := rows.Scan(&count.Date, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan day count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating day counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// SumCreditsByBucketType sums the CREDIT entries on the given layer per bucket of bucketType for transactions dated in [start, end]
+func (r *transactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	query := `
+		SELECT te.bucket_id, SUM(te.amount)
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		INNER JOIN buckets b ON b.id = te.bucket_id
+		WHERE b.bucket_type = $1 AND te.layer = $2 AND te.type = 'CREDIT' AND t.date >= $3 AND t.date <= $4 AND NOT t.scheduled
+		GROUP BY te.bucket_id
+	`
+
+	rows, err := queryContext(ctx, r.db, query, string(bucketType), string(layer), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum credits by bucket: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBucketTotals(rows)
+}
+
+// SumExternalInflowDebitsByBucket sums the DEBIT entries on the given layer per bucket for external inflows dated in [start, end]
+func (r *transactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	query := `
+		SELECT te.bucket_id, SUM(te.amount)
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE t.is_external_inflow = true AND te.layer = $1 AND te.type = 'DEBIT' AND t.date >= $2 AND t.date <= $3 AND NOT t.scheduled
+		GROUP BY te.bucket_id
+	`
+
+	rows, err := queryContext(ctx, r.db, query, string(layer), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum external inflow debits by bucket: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBucketTotals(rows)
+}
+
+// scanBucketTotals reads (bucket_id, summed amount) rows into a map
+func scanBucketTotals(rows *sql.Rows) (map[uuid.UUID]decimal.Decimal, error) {
+	totals := make(map[uuid.UUID]decimal.Decimal)
+	for rows.Next() {
+		var bucketID uuid.UUID
+		var totalStr string
+
+		if err := rows.Scan(&bucketID, &totalStr); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket total: %w", err)
+		}
+
+		total, err := decimal.NewFromString(totalStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bucket total: %w", err)
+		}
+		totals[bucketID] = total
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bucket totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// parseEntryTotals parses summed DEBIT and CREDIT amounts
+func parseEntryTotals(debitsStr, creditsStr string) (domain.EntryTotals, error) {
+	debits, err := decimal.NewFromString(debitsStr)
+	if err != nil {
+		return domain.EntryTotals{}, err
+	}
+	credits, err := decimal.NewFromString(creditsStr)
+	if err != nil {
+		return domain.EntryTotals{}, err
+	}
+	return domain.EntryTotals{Debits: debits, Credits: credits}, nil
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+)
+
+// transferTaskRepository implements domain.TransferTaskRepository
+type transferTaskRepository struct {
+	db querier
+}
+
+// NewTransferTaskRepository creates a new transfer task repository
+func NewTransferTaskRepository(db *DB) domain.TransferTaskRepository {
+	return &transferTaskRepository{db: db}
+}
+
+// Create creates a new transfer task
+func (r *transferTaskRepository) Create(ctx context.Context, task *domain.TransferTask) error {
+	query := `
+		INSERT INTO transfer_tasks (id, related_transaction_id, completed_transaction_id, from_physical_bucket_id, to_physical_bucket_id, amount, is_completed, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+
+	var completedTransactionID interface{}
+	if task.CompletedTransactionID != nil {
+		completedTransactionID = task.CompletedTransactionID
+	}
+
+	_, err := execContext(ctx, r.db, query,
+		task.ID,
+		task.RelatedTransactionID,
+		completedTransactionID,
+		task.FromPhysicalBucketID,
+		task.ToPhysicalBucketID,
+		task.Amount.String(),
+		task.IsCompleted,
+		task.Description,
+		task.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer task: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves transfer tasks ordered by creation time (newest first)
+// Completed tasks are only included if includeCompleted is true
+func (r *transferTaskRepository) List(ctx context.Context, includeCompleted bool) ([]domain.TransferTask, error) {
+	query := `
+		SELECT id, related_transaction_id, completed_transaction_id, from_physical_bucket_id, to_physical_bucket_id, amount, is_completed, description, created_at
+		FROM transfer_tasks
+		WHERE $1 OR is_completed = FALSE
+		ORDER BY created_at DESC, id
+	`
+
+	return r.queryTransferTasks(ctx, query, includeCompleted)
+}
+
+// ListPendingCreatedBefore retrieves the incomplete transfer tasks created before the given time (oldest first)
+func (r *transferTaskRepository) ListPendingCreatedBefore(ctx context.Context, before time.Time) ([]domain.TransferTask, error) {
+	query := `
+		SELECT id, related_transaction_id, completed_transaction_id, from_physical_bucket_id, to_physical_bucket_id, amount, is_completed, description, created_at
+		FROM transfer_tasks
+		WHERE is_completed = FALSE AND created_at < $1
+		ORDER BY created_at ASC, id
+	`
+
+	return r.queryTransferTasks(ctx, query, before)
+}
+
+// GetByID retrieves a transfer task, locking its row when called inside a unit of work
+func (r *transferTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferTask, error) {
+	query := `
+		SELECT id, related_transaction_id, completed_transaction_id, from_physical_bucket_id, to_physical_bucket_id, amount, is_completed, description, created_at
+		FROM transfer_tasks
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	tasks, err := r.queryTransferTasks(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTransferTaskNotFound, id)
+	}
+
+	return &tasks[0], nil
+}
+
+// MarkCompleted flags a pending transfer task as completed by the given transaction
+// Returns domain.ErrTransferTaskNotFound if no pending task with that ID exists
+func (r *transferTaskRepository) MarkCompleted(ctx context.Context, id, completedTransactionID uuid.UUID) error {
+	query := `
+		UPDATE transfer_tasks
+		SET is_completed = TRUE, completed_transaction_id = $2
+		WHERE id = $1 AND is_completed = FALSE
+	`
+
+	result, err := execContext(ctx, r.db, query, id, completedTransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to complete transfer task: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTransferTaskNotFound, id)
+	}
+
+	return nil
+}
+
+// queryTransferTasks runs a transfer task query and scans the rows
+// The query must select the columns in the order used by List
+func (r *transferTaskRepository) queryTransferTasks(ctx context.Context, query string, args ...interface{}) ([]domain.TransferTask, error) {
+	rows, err := queryContext(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.TransferTask, 0)
+	for rows.Next() {
+		var task domain.TransferTask
+		var completedTransactionID sql.NullString
+		var amountStr sql.NullString
+
+		err := rows.Scan(
+			&task.ID,
+			&task.RelatedTransactionID,
+			&completedTransactionID,
+			&task.FromPhysicalBucketID,
+			&task.ToPhysicalBucketID,
+			&amountStr,
+			&task.IsCompleted,
+			&task.Description,
+			&task.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer task: %w", err)
+		}
+
+		// Parse completed_transaction_id (NULL until the task is completed)
+		if completedTransactionID.Valid {
+			completedID, err := uuid.Parse(completedTransactionID.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse completed_transaction_id: %w", err)
+			}
+			task.CompletedTransactionID = &completedID
+		}
+
+		// Parse amount (DECIMAL, nullable in the schema)
+		if !amountStr.Valid {
+			return nil, fmt.Errorf("transfer task %s has null amount", task.ID)
+		}
+		amount, err := decimal.NewFromString(amountStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %w", err)
+		}
+		task.Amount = amount
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer tasks: %w", err)
+	}
+
+	return tasks, nil
+}
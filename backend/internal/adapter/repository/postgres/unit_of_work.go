@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+)
+
+// unitOfWork implements domain.UnitOfWork
+type unitOfWork struct {
+	db *DB
+}
+
+// NewUnitOfWork creates a new unit of work backed by database transactions
+func NewUnitOfWork(db *DB) domain.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn with repositories bound to a single database transaction
+// The transaction is committed if fn returns nil and rolled back otherwise
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos domain.Repositories) error) error {
+	// Start a database transaction
+	dbTx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	repos := domain.Repositories{
+		Buckets:       &bucketRepository{db: dbTx},
+		Transactions:  &transactionRepository{db: dbTx},
+		TransferTasks: &transferTaskRepository{db: dbTx},
+		Holdings:      &holdingRepository{db: dbTx},
+		SplitRules:    &splitRuleRepository{db: dbTx},
+		MarketValues:  &marketValueRepository{db: dbTx},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	// Commit the transaction
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -19,32 +21,173 @@ const (
 	BucketTypeSystem   BucketType = "SYSTEM"
 )
 
+// MaxBucketNameLength is the maximum number of characters in a bucket name
+const MaxBucketNameLength = 100
+
+// BucketRole represents the role a bucket plays in a transaction flow
+type BucketRole string
+
+const (
+	BucketRoleInflowSource      BucketRole = "inflow source"      // Income bucket an inflow comes from
+	BucketRoleSplitTarget       BucketRole = "split target"       // Virtual bucket receiving part of an inflow
+	BucketRoleInflowTarget      BucketRole = "inflow target"      // Virtual bucket receiving a whole inflow without a split rule
+	BucketRoleExpenseSource     BucketRole = "expense source"     // Virtual bucket an expense is paid from
+	BucketRoleExpenseCategory   BucketRole = "expense category"   // Expense bucket an expense is booked against
+	BucketRolePhysicalOverride  BucketRole = "physical override"  // Physical bucket actually charged for an expense
+	BucketRoleInflowDestination BucketRole = "inflow destination" // Physical bucket a split rule's inflows land in
+	BucketRolePurchaseSource    BucketRole = "purchase source"    // Virtual bucket paying for an equity purchase
+)
+
+// bucketRoleRule describes which bucket types may fill a role and the message reported otherwise
+type bucketRoleRule struct {
+	allowed []BucketType
+	message string
+}
+
+// bucketRoleRules is the single source of truth for which bucket types each role accepts
+// Anything not listed (e.g. EQUITY or SYSTEM buckets) is rejected for that role
+var bucketRoleRules = map[BucketRole]bucketRoleRule{
+	BucketRoleInflowSource:      {allowed: []BucketType{BucketTypeIncome}, message: "source bucket must be an income bucket"},
+	BucketRoleSplitTarget:       {allowed: []BucketType{BucketTypeVirtual}, message: "split rule target buckets must be virtual buckets"},
+	BucketRoleInflowTarget:      {allowed: []BucketType{BucketTypeVirtual}, message: "target virtual bucket ID must reference a virtual bucket"},
+	BucketRoleExpenseSource:     {allowed: []BucketType{BucketTypeVirtual}, message: "virtual bucket ID must reference a virtual bucket"},
+	BucketRoleExpenseCategory:   {allowed: []BucketType{BucketTypeExpense}, message: "category bucket ID must reference an expense bucket"},
+	BucketRolePhysicalOverride:  {allowed: []BucketType{BucketTypePhysical}, message: "physical override bucket must be a physical bucket"},
+	BucketRoleInflowDestination: {allowed: []BucketType{BucketTypePhysical}, message: "destination physical bucket must be a physical bucket"},
+	BucketRolePurchaseSource:    {allowed: []BucketType{BucketTypeVirtual}, message: "source bucket must be a virtual bucket"},
+}
+
 // Bucket represents a bucket entity in the domain layer
 // Adheres to the data model defined in specs.md
 type Bucket struct {
 	ID                     uuid.UUID
 	Name                   string
 	BucketType             BucketType
-	ParentPhysicalBucketID *uuid.UUID      // NULL if PHYSICAL/INCOME/EXPENSE. NOT NULL if VIRTUAL.
-	CurrentBalance         decimal.Decimal // Represents BOOK VALUE (Cash in/out)
+	ParentPhysicalBucketID *uuid.UUID       // NULL if PHYSICAL/INCOME/EXPENSE. NOT NULL if VIRTUAL.
+	CurrentBalance         decimal.Decimal  // Represents BOOK VALUE (Cash in/out)
+	IsArchived             bool             // Archived buckets keep their history but are no longer listed or booked against
+	GoalAmount             *decimal.Decimal // Optional target amount (e.g. for savings buckets); NULL if no goal is set
+	Currency               string           // ISO 4217 code of the balance; empty means DefaultCurrency
+}
+
+// Balance returns the bucket's CurrentBalance in its currency
+func (b *Bucket) Balance() Money {
+	return NewMoney(b.CurrentBalance, b.Currency)
 }
 
 // Validate ensures the bucket adheres to domain rules
 // Returns an error if validation fails
 func (b *Bucket) Validate() error {
+	// Trim first so whitespace-only names are treated as empty
+	b.Name = strings.TrimSpace(b.Name)
 	if b.Name == "" {
-		return errors.New("bucket name cannot be empty")
+		return NewValidationError("bucket name cannot be empty")
+	}
+	if utf8.RuneCountInString(b.Name) > MaxBucketNameLength {
+		return NewValidationErrorf("bucket name cannot exceed %d characters", MaxBucketNameLength)
 	}
 
 	// Virtual Buckets MUST have a Parent Physical Bucket ID
 	if b.BucketType == BucketTypeVirtual {
 		if b.ParentPhysicalBucketID == nil {
-			return errors.New("virtual bucket must have a parent physical bucket ID")
+			return NewValidationError("virtual bucket must have a parent physical bucket ID")
 		}
+		return nil
 	}
 
-	// Physical, Income, Expense, Equity, and System buckets do NOT need a Parent ID
-	// (This is implicit - we only validate the constraint for Virtual buckets)
+	// Physical, Income, Expense, Equity, and System buckets must NOT have a Parent ID
+	// (a stray parent would e.g. make the task generator treat the bucket as held in that account)
+	if b.ParentPhysicalBucketID != nil {
+		return NewValidationErrorf("%s bucket cannot have a parent physical bucket ID", b.BucketType)
+	}
+
+	return nil
+}
 
+// ValidateParent ensures parent can hold the bucket: it must be a physical bucket other than the bucket itself
+// Only physical buckets can be parents, so a virtual parent (and with it any parent cycle) is rejected
+func (b *Bucket) ValidateParent(parent *Bucket) error {
+	if parent.ID == b.ID {
+		return NewValidationError("bucket cannot be its own parent")
+	}
+	if parent.BucketType != BucketTypePhysical {
+		return NewValidationErrorf("parent bucket must be a physical bucket (got %s bucket %s)", parent.BucketType, parent.ID)
+	}
 	return nil
 }
+
+// SplitTargetParent returns the physical bucket holding a split rule target
+// The target must be a virtual bucket (not e.g. an income, expense or equity bucket) with a parent
+func (b *Bucket) SplitTargetParent() (uuid.UUID, error) {
+	if err := b.ValidateRole(BucketRoleSplitTarget); err != nil {
+		return uuid.Nil, err
+	}
+	if b.ParentPhysicalBucketID == nil {
+		return uuid.Nil, NewValidationErrorf("split rule target bucket %s has no parent physical bucket", b.ID)
+	}
+	return *b.ParentPhysicalBucketID, nil
+}
+
+// ValidateSplitTarget ensures the bucket can receive part of an inflow landing in destinationID:
+// it must be a virtual bucket held in that physical bucket
+func (b *Bucket) ValidateSplitTarget(destinationID uuid.UUID) error {
+	parentID, err := b.SplitTargetParent()
+	if err != nil {
+		return err
+	}
+	if parentID != destinationID {
+		return NewValidationErrorf(
+			"split rule target bucket %s is held in physical bucket %s, but all split rule target buckets must belong to the same parent physical bucket %s",
+			b.ID, parentID, destinationID,
+		)
+	}
+	return nil
+}
+
+// ValidateGoal ensures a goal amount is positive
+func ValidateGoal(goal decimal.Decimal) error {
+	if !goal.IsPositive() {
+		return NewValidationError("goal amount must be positive")
+	}
+	return nil
+}
+
+// GoalProgress returns the percentage of the goal reached (CurrentBalance / GoalAmount * 100, 2 decimals)
+// Returns nil if the bucket has no goal
+func (b *Bucket) GoalProgress() *decimal.Decimal {
+	if b.GoalAmount == nil || b.GoalAmount.IsZero() {
+		return nil
+	}
+	progress := b.CurrentBalance.Div(*b.GoalAmount).Mul(decimal.NewFromInt(100)).Round(2)
+	return &progress
+}
+
+// ValidateRole ensures the bucket's type is allowed for the given role in a transaction flow
+// Returns an error naming the role, the expected type and the offending bucket if it is not
+func (b *Bucket) ValidateRole(role BucketRole) error {
+	rule, ok := bucketRoleRules[role]
+	if !ok {
+		return fmt.Errorf("invalid bucket role: %s", role)
+	}
+
+	for _, allowed := range rule.allowed {
+		if b.BucketType == allowed {
+			return nil
+		}
+	}
+
+	return NewValidationErrorf("invalid %s: %s (got %s bucket %s)", role, rule.message, b.BucketType, b.ID)
+}
+
+// BalanceCorrection records a bucket balance that was recomputed from its transaction entries
+type BalanceCorrection struct {
+	BucketID  uuid.UUID
+	Previous  decimal.Decimal // Stored balance before the correction
+	Corrected decimal.Decimal // Balance computed from the entries
+}
+
+// BucketFamily represents a physical bucket together with its virtual children
+type BucketFamily struct {
+	Physical *Bucket
+	Children []*Bucket // Ordered by name; empty if the physical bucket holds no virtual buckets
+}
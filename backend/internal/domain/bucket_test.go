@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -96,6 +97,36 @@ func TestBucket_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Income Bucket with Parent ID should fail",
+			bucket: Bucket{
+				ID:         uuid.New(),
+				Name:       "Test Income Bucket",
+				BucketType: BucketTypeIncome,
+				ParentPhysicalBucketID: func() *uuid.UUID {
+					id := uuid.New()
+					return &id
+				}(),
+				CurrentBalance: decimal.Zero,
+			},
+			wantErr: true,
+			errMsg:  "INCOME bucket cannot have a parent physical bucket ID",
+		},
+		{
+			name: "Physical Bucket with Parent ID should fail",
+			bucket: Bucket{
+				ID:         uuid.New(),
+				Name:       "Test Physical Bucket",
+				BucketType: BucketTypePhysical,
+				ParentPhysicalBucketID: func() *uuid.UUID {
+					id := uuid.New()
+					return &id
+				}(),
+				CurrentBalance: decimal.Zero,
+			},
+			wantErr: true,
+			errMsg:  "PHYSICAL bucket cannot have a parent physical bucket ID",
+		},
 		{
 			name: "Bucket with empty name should fail",
 			bucket: Bucket{
@@ -107,13 +138,36 @@ func TestBucket_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "bucket name cannot be empty",
 		},
+		{
+			name: "Bucket with whitespace-only name should fail",
+			bucket: Bucket{
+				ID:             uuid.New(),
+				Name:           "   ",
+				BucketType:     BucketTypePhysical,
+				CurrentBalance: decimal.Zero,
+			},
+			wantErr: true,
+			errMsg:  "bucket name cannot be empty",
+		},
+		{
+			name: "Bucket with over-length name should fail",
+			bucket: Bucket{
+				ID:             uuid.New(),
+				Name:           strings.Repeat("a", MaxBucketNameLength+1),
+				BucketType:     BucketTypePhysical,
+				CurrentBalance: decimal.Zero,
+			},
+			wantErr: true,
+			errMsg:  "bucket name cannot exceed 100 characters",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.bucket.Validate()
 			if tt.wantErr {
-				assert.Error(t, err)
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
@@ -123,3 +177,165 @@ func TestBucket_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBucket_Validate_NameTooLongIsValidationError(t *testing.T) {
+	bucket := Bucket{ID: uuid.New(), Name: strings.Repeat("a", MaxBucketNameLength+1), BucketType: BucketTypePhysical}
+
+	err := bucket.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestBucket_Validate_StrayParentIsValidationError(t *testing.T) {
+	parentID := uuid.New()
+	bucket := Bucket{ID: uuid.New(), Name: "Brokerage", BucketType: BucketTypeEquity, ParentPhysicalBucketID: &parentID}
+
+	err := bucket.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.EqualError(t, err, "EQUITY bucket cannot have a parent physical bucket ID")
+}
+
+func TestBucket_ValidateRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucketType BucketType
+		role       BucketRole
+		wantErr    bool
+		errMsg     string
+	}{
+		{"Income bucket as inflow source", BucketTypeIncome, BucketRoleInflowSource, false, ""},
+		{"Equity bucket as inflow source", BucketTypeEquity, BucketRoleInflowSource, true, "source bucket must be an income bucket"},
+		{"Virtual bucket as split target", BucketTypeVirtual, BucketRoleSplitTarget, false, ""},
+		{"Equity bucket as split target", BucketTypeEquity, BucketRoleSplitTarget, true, "split rule target buckets must be virtual buckets"},
+		{"Virtual bucket as inflow target", BucketTypeVirtual, BucketRoleInflowTarget, false, ""},
+		{"Income bucket as inflow target", BucketTypeIncome, BucketRoleInflowTarget, true, "target virtual bucket ID must reference a virtual bucket"},
+		{"Virtual bucket as expense source", BucketTypeVirtual, BucketRoleExpenseSource, false, ""},
+		{"Equity bucket as expense source", BucketTypeEquity, BucketRoleExpenseSource, true, "virtual bucket ID must reference a virtual bucket"},
+		{"Expense bucket as expense category", BucketTypeExpense, BucketRoleExpenseCategory, false, ""},
+		{"Equity bucket as expense category", BucketTypeEquity, BucketRoleExpenseCategory, true, "category bucket ID must reference an expense bucket"},
+		{"Physical bucket as physical override", BucketTypePhysical, BucketRolePhysicalOverride, false, ""},
+		{"Equity bucket as physical override", BucketTypeEquity, BucketRolePhysicalOverride, true, "physical override bucket must be a physical bucket"},
+		{"Physical bucket as inflow destination", BucketTypePhysical, BucketRoleInflowDestination, false, ""},
+		{"Virtual bucket as inflow destination", BucketTypeVirtual, BucketRoleInflowDestination, true, "destination physical bucket must be a physical bucket"},
+		{"Virtual bucket as purchase source", BucketTypeVirtual, BucketRolePurchaseSource, false, ""},
+		{"Physical bucket as purchase source", BucketTypePhysical, BucketRolePurchaseSource, true, "source bucket must be a virtual bucket"},
+		{"Unknown role", BucketTypePhysical, BucketRole("unknown"), true, "invalid bucket role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := Bucket{
+				ID:             uuid.New(),
+				Name:           "Test Bucket",
+				BucketType:     tt.bucketType,
+				CurrentBalance: decimal.Zero,
+			}
+
+			err := bucket.ValidateRole(tt.role)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				// The message names the role and the offending bucket type
+				if tt.role != BucketRole("unknown") {
+					assert.Contains(t, err.Error(), string(tt.role))
+					assert.Contains(t, err.Error(), string(tt.bucketType))
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBucket_GoalProgress(t *testing.T) {
+	goal := decimal.NewFromInt(3000)
+	bucket := Bucket{CurrentBalance: decimal.NewFromInt(1000)}
+
+	assert.Nil(t, bucket.GoalProgress(), "No goal means no progress")
+
+	bucket.GoalAmount = &goal
+	progress := bucket.GoalProgress()
+	if assert.NotNil(t, progress) {
+		assert.True(t, progress.Equal(decimal.RequireFromString("33.33")), "got %s", progress)
+	}
+}
+
+func TestBucket_Balance(t *testing.T) {
+	bucket := Bucket{CurrentBalance: decimal.NewFromInt(250)}
+
+	balance := bucket.Balance()
+	assert.True(t, balance.Amount.Equal(decimal.NewFromInt(250)))
+	assert.Equal(t, DefaultCurrency, balance.Currency, "Buckets without a currency are in the default currency")
+
+	bucket.Currency = "USD"
+	assert.Equal(t, "USD", bucket.Balance().Currency)
+}
+
+func TestValidateGoal(t *testing.T) {
+	assert.NoError(t, ValidateGoal(decimal.NewFromInt(500)))
+	assert.Error(t, ValidateGoal(decimal.Zero))
+	assert.Error(t, ValidateGoal(decimal.NewFromInt(-10)))
+}
+
+func TestBucket_Validate_TrimsName(t *testing.T) {
+	bucket := Bucket{
+		ID:             uuid.New(),
+		Name:           "  Main Bank \t",
+		BucketType:     BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}
+
+	assert.NoError(t, bucket.Validate())
+	assert.Equal(t, "Main Bank", bucket.Name)
+}
+
+func TestBucket_ValidateParent(t *testing.T) {
+	bankID := uuid.New()
+	bucket := Bucket{ID: uuid.New(), Name: "Holidays", BucketType: BucketTypeVirtual, ParentPhysicalBucketID: &bankID}
+
+	assert.NoError(t, bucket.ValidateParent(&Bucket{ID: bankID, Name: "Main Bank", BucketType: BucketTypePhysical}))
+
+	var validationErr *ValidationError
+	err := bucket.ValidateParent(&bucket)
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "bucket cannot be its own parent")
+
+	otherVirtual := &Bucket{ID: uuid.New(), Name: "Groceries", BucketType: BucketTypeVirtual, ParentPhysicalBucketID: &bankID}
+	err = bucket.ValidateParent(otherVirtual)
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "parent bucket must be a physical bucket")
+}
+
+func TestBucket_ValidateSplitTarget(t *testing.T) {
+	bankID, otherBankID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name          string
+		bucket        Bucket
+		expectedError string
+	}{
+		{"Virtual bucket of the destination", Bucket{BucketType: BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, ""},
+		{"Income bucket", Bucket{BucketType: BucketTypeIncome}, "split rule target buckets must be virtual buckets"},
+		{"Expense bucket", Bucket{BucketType: BucketTypeExpense}, "split rule target buckets must be virtual buckets"},
+		{"Equity bucket", Bucket{BucketType: BucketTypeEquity}, "split rule target buckets must be virtual buckets"},
+		{"Virtual bucket without parent", Bucket{BucketType: BucketTypeVirtual}, "has no parent physical bucket"},
+		{"Virtual bucket of another physical bucket", Bucket{BucketType: BucketTypeVirtual, ParentPhysicalBucketID: &otherBankID}, "must belong to the same parent physical bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bucket.ID = uuid.New()
+			err := tt.bucket.ValidateSplitTarget(bankID)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBucketNotFound is returned by repositories when a requested bucket does not exist
+// Callers should check for it with errors.Is, as repositories wrap it with context (e.g. the bucket ID)
+var ErrBucketNotFound = errors.New("bucket not found")
+
+// ErrTransactionNotFound is returned by repositories when a requested transaction does not exist
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// ErrSplitRuleNotFound is returned by repositories when a source bucket has no split rule
+// It is distinct from ErrBucketNotFound so clients can tell a missing rule from a missing bucket
+var ErrSplitRuleNotFound = errors.New("split rule not found")
+
+// ErrSplitRuleHasNoItems is returned when a stored split rule has a header but no items
+// (e.g. left behind by a failed write) and is used to allocate, which it cannot do
+var ErrSplitRuleHasNoItems = errors.New("split rule has no items")
+
+// ErrSplitRuleInactive is returned when an external inflow's source bucket has a split rule that is disabled
+// Like a missing rule, it lets RecordInflow park the income instead
+var ErrSplitRuleInactive = errors.New("split rule is disabled")
+
+// ErrTransferTaskNotFound is returned by repositories when a requested (pending) transfer task does not exist
+var ErrTransferTaskNotFound = errors.New("transfer task not found")
+
+// ErrTransferTaskAlreadyCompleted is returned when completing a transfer task that was already completed
+var ErrTransferTaskAlreadyCompleted = errors.New("transfer task is already completed")
+
+// ErrHoldingNotFound is returned by repositories when a bucket has no quantity-tracked holding
+var ErrHoldingNotFound = errors.New("holding not found")
+
+// ErrTransactionHasCompletedTransfer is returned when deleting a transaction whose transfer task was already completed
+// The real-world money movement has happened, so the transaction must be reversed instead
+var ErrTransactionHasCompletedTransfer = errors.New("transaction has a completed transfer task")
+
+// ErrInsufficientFunds is returned when a bucket's balance cannot cover a requested amount
+// Callers wrap it with the available balance and the amount requested
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrCurrencyMismatch is returned when combining amounts of different currencies (e.g. adding EUR to USD)
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ValidationError is returned when caller-supplied input violates a business rule
+// Callers should check for it with errors.As; the message is meant to be shown to the client as-is
+type ValidationError struct {
+	Message string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// NewValidationError creates a ValidationError with the given message
+func NewValidationError(message string) error {
+	return &ValidationError{Message: message}
+}
+
+// NewValidationErrorf creates a ValidationError with a formatted message
+func NewValidationErrorf(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
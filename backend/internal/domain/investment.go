@@ -14,5 +14,53 @@ type MarketValueHistory struct {
 	ID          uuid.UUID
 	BucketID    uuid.UUID
 	Date        time.Time
-	MarketValue decimal.Decimal // The actual value at this point in time
+	MarketValue decimal.Decimal  // The actual value at this point in time
+	UnitPrice   *decimal.Decimal // Optional: per-unit price the value was derived from (quantity-tracked buckets only)
+}
+
+// Holding tracks the quantity and per-unit cost basis of an equity bucket
+// Quantities may be fractional (e.g. 0.25 shares)
+type Holding struct {
+	BucketID uuid.UUID
+	Quantity decimal.Decimal // Units held
+	AvgCost  decimal.Decimal // Weighted-average cost per unit
+}
+
+// AddPurchase adds a purchase of quantity units at unitPrice and updates the weighted-average cost:
+// AvgCost = (Quantity * AvgCost + quantity * unitPrice) / (Quantity + quantity)
+func (h *Holding) AddPurchase(quantity, unitPrice decimal.Decimal) error {
+	if !quantity.IsPositive() {
+		return NewValidationError("quantity must be positive")
+	}
+	if !unitPrice.IsPositive() {
+		return NewValidationError("unit price must be positive")
+	}
+
+	totalCost := h.Quantity.Mul(h.AvgCost).Add(quantity.Mul(unitPrice))
+	h.Quantity = h.Quantity.Add(quantity)
+	h.AvgCost = totalCost.Div(h.Quantity)
+
+	return nil
+}
+
+// MarketValueAt returns the value of the holding at the given per-unit price (Quantity * unitPrice)
+func (h *Holding) MarketValueAt(unitPrice decimal.Decimal) decimal.Decimal {
+	return h.Quantity.Mul(unitPrice)
+}
+
+// CurrentMarketValue returns the market value of an equity bucket given its latest market value entry
+// A quantity-tracked bucket priced per unit is valued at Quantity * the latest unit price (2 decimals),
+// so purchases made since that price was recorded count; otherwise the recorded market value is used.
+// holding may be nil for buckets without a quantity
+func CurrentMarketValue(latest *MarketValueHistory, holding *Holding) decimal.Decimal {
+	if latest.UnitPrice != nil && holding != nil {
+		return holding.MarketValueAt(*latest.UnitPrice).Round(2)
+	}
+	return latest.MarketValue
+}
+
+// CalculateProfit returns the embedded profit/loss of an equity position
+// Profit = MarketValue - BookValue (negative for a loss)
+func CalculateProfit(bookValue, marketValue decimal.Decimal) decimal.Decimal {
+	return marketValue.Sub(bookValue)
 }
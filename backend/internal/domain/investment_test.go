@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolding_AddPurchase(t *testing.T) {
+	holding := &Holding{BucketID: uuid.New(), Quantity: decimal.Zero, AvgCost: decimal.Zero}
+
+	// First purchase: 2 units at 100
+	assert.NoError(t, holding.AddPurchase(decimal.NewFromInt(2), decimal.NewFromInt(100)))
+	assert.True(t, holding.Quantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, holding.AvgCost.Equal(decimal.NewFromInt(100)))
+
+	// Fractional purchase: 0.5 units at 150 -> (200 + 75) / 2.5 = 110
+	assert.NoError(t, holding.AddPurchase(decimal.RequireFromString("0.5"), decimal.NewFromInt(150)))
+	assert.True(t, holding.Quantity.Equal(decimal.RequireFromString("2.5")))
+	assert.True(t, holding.AvgCost.Equal(decimal.NewFromInt(110)), "got %s", holding.AvgCost)
+
+	// Market value at 120 per unit
+	assert.True(t, holding.MarketValueAt(decimal.NewFromInt(120)).Equal(decimal.NewFromInt(300)))
+}
+
+func TestHolding_AddPurchase_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantity  decimal.Decimal
+		unitPrice decimal.Decimal
+		errMsg    string
+	}{
+		{"zero quantity", decimal.Zero, decimal.NewFromInt(10), "quantity must be positive"},
+		{"negative quantity", decimal.NewFromInt(-1), decimal.NewFromInt(10), "quantity must be positive"},
+		{"zero unit price", decimal.NewFromInt(1), decimal.Zero, "unit price must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holding := &Holding{Quantity: decimal.NewFromInt(1), AvgCost: decimal.NewFromInt(50)}
+
+			err := holding.AddPurchase(tt.quantity, tt.unitPrice)
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.EqualError(t, err, tt.errMsg)
+			// The holding is left unchanged
+			assert.True(t, holding.Quantity.Equal(decimal.NewFromInt(1)))
+			assert.True(t, holding.AvgCost.Equal(decimal.NewFromInt(50)))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency is the currency of buckets that do not set one (every bucket created before currencies were tracked)
+const DefaultCurrency = "EUR"
+
+// Money represents an amount in a given currency
+// Amounts of different currencies must never be combined, so Add and Sub return ErrCurrencyMismatch instead
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string // ISO 4217 code (e.g. "EUR")
+}
+
+// NewMoney creates a Money of amount in currency (upper-cased, DefaultCurrency if empty)
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ZeroMoney returns a zero amount in currency, the starting point for a sum
+func ZeroMoney(currency string) Money {
+	return NewMoney(decimal.Zero, currency)
+}
+
+// Add returns m + other
+// Returns ErrCurrencyMismatch if the currencies differ
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - other
+// Returns ErrCurrencyMismatch if the currencies differ
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: m.Currency}, nil
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String formats the money as "<amount> <currency>" (e.g. "12.5 EUR")
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// checkCurrency ensures other is in the same currency as m
+func (m Money) checkCurrency(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return nil
+}
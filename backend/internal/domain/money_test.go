@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMoney_NormalizesCurrency(t *testing.T) {
+	assert.Equal(t, "USD", NewMoney(decimal.NewFromInt(1), " usd ").Currency)
+	assert.Equal(t, DefaultCurrency, NewMoney(decimal.NewFromInt(1), "").Currency, "Empty currency means the default")
+	assert.True(t, ZeroMoney("EUR").IsZero())
+}
+
+func TestMoney_AddSub(t *testing.T) {
+	a := NewMoney(decimal.RequireFromString("12.50"), "EUR")
+	b := NewMoney(decimal.RequireFromString("7.25"), "EUR")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.True(t, sum.Amount.Equal(decimal.RequireFromString("19.75")), "got %s", sum)
+	assert.Equal(t, "EUR", sum.Currency)
+
+	diff, err := b.Sub(a)
+	assert.NoError(t, err)
+	assert.True(t, diff.Amount.Equal(decimal.RequireFromString("-5.25")), "got %s", diff)
+
+	// The operands are not modified
+	assert.True(t, a.Amount.Equal(decimal.RequireFromString("12.50")))
+}
+
+func TestMoney_CurrencyMismatch(t *testing.T) {
+	eur := NewMoney(decimal.NewFromInt(10), "EUR")
+	usd := NewMoney(decimal.NewFromInt(10), "USD")
+
+	_, err := eur.Add(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	assert.Contains(t, err.Error(), "EUR and USD")
+
+	_, err = usd.Sub(eur)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "12.5 EUR", NewMoney(decimal.RequireFromString("12.50"), "EUR").String())
+}
@@ -2,8 +2,10 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // BucketRepository defines the interface for bucket persistence operations
@@ -11,6 +13,10 @@ type BucketRepository interface {
 	// GetByID retrieves a bucket by its ID
 	GetByID(ctx context.Context, id uuid.UUID) (*Bucket, error)
 
+	// GetByIDForUpdate retrieves a bucket by its ID, locking its row until the surrounding unit of work ends
+	// (e.g. so a check against its balance holds until the dependent transaction is created)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Bucket, error)
+
 	// Create creates a new bucket
 	Create(ctx context.Context, bucket *Bucket) error
 
@@ -19,8 +25,44 @@ type BucketRepository interface {
 	GetSystemBucket(ctx context.Context, bucketType BucketType) (*Bucket, error)
 
 	// List retrieves a list of buckets, optionally filtered by type
-	// If typeFilter is empty, returns all buckets
+	// If typeFilter is empty, returns all buckets. Archived buckets are excluded
 	List(ctx context.Context, typeFilter BucketType) ([]*Bucket, error)
+
+	// GetByIDs retrieves the given buckets in a single query, keyed by ID
+	// Buckets that do not exist are absent from the returned map
+	GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*Bucket, error)
+
+	// ListByParent retrieves the buckets whose parent is the given physical bucket, ordered by name
+	ListByParent(ctx context.Context, parentID uuid.UUID) ([]*Bucket, error)
+
+	// ListPhysicalWithChildren retrieves the non-archived physical buckets (ordered by name), each with its
+	// non-archived virtual children, in a single query
+	ListPhysicalWithChildren(ctx context.Context) ([]BucketFamily, error)
+
+	// ListWithGoals retrieves the non-archived virtual buckets that have a goal amount set, ordered by name
+	ListWithGoals(ctx context.Context) ([]*Bucket, error)
+
+	// UpdateParent sets the parent physical bucket of a virtual bucket
+	UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error
+
+	// UpdateGoal sets a bucket's goal amount (nil clears it)
+	UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error
+
+	// RecalculateBalances atomically recomputes the balance of every unarchived bucket of the given types from
+	// its transaction entries (DEBIT minus CREDIT, scheduled transactions excluded) and overwrites the ones that
+	// drifted. The buckets are locked while this runs, so no booking made meanwhile is overwritten.
+	// Returns one correction per bucket whose balance changed
+	RecalculateBalances(ctx context.Context, bucketTypes []BucketType) ([]BalanceCorrection, error)
+
+	// MergeInto atomically re-points all transaction entries of sourceID to targetID,
+	// moves the source balance onto the target and archives the source bucket
+	// Returns the number of re-pointed entries
+	MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error)
+
+	// SumBalances returns the summed current_balance of the non-archived buckets of bucketType,
+	// computed in the database so callers don't load every bucket (zero when there are none)
+	// Like GetNetWorth, every bucket must be in DefaultCurrency (a wrapped ErrCurrencyMismatch otherwise)
+	SumBalances(ctx context.Context, bucketType BucketType) (decimal.Decimal, error)
 }
 
 // TransactionRepository defines the interface for transaction persistence operations
@@ -28,21 +70,114 @@ type TransactionRepository interface {
 	// Create creates a new transaction
 	Create(ctx context.Context, tx *Transaction) error
 
+	// GetByID retrieves a transaction with all its entries (including the memo)
+	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
+
+	// Delete removes a transaction and its entries, reverting their effect on bucket balances
+	// Pending transfer tasks of the transaction are removed as well
+	// Returns ErrTransactionNotFound if it does not exist and ErrTransactionHasCompletedTransfer
+	// if one of its transfer tasks was already completed
+	Delete(ctx context.Context, id uuid.UUID) error
+
 	// List retrieves a paginated list of transactions
 	// If bucketID is nil, returns all transactions
-	// limit and offset are used for pagination
+	// limit and offset are used for pagination; out-of-range values are clamped (see ListFiltered)
 	List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*Transaction, error)
 
 	// Count returns the total number of transactions
 	// If bucketID is nil, returns count of all transactions
 	// If bucketID is provided, returns count of transactions involving that bucket
 	Count(ctx context.Context, bucketID *uuid.UUID) (int, error)
+
+	// ListForBuckets retrieves a paginated list of transactions involving any of the given buckets
+	// Transactions touching several of the buckets are returned once
+	ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*Transaction, error)
+
+	// CountForBuckets returns the number of distinct transactions involving any of the given buckets
+	CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error)
+
+	// ListFiltered retrieves a paginated list of transactions matching every filter that is set
+	// Like List and ListForBuckets, it clamps a negative offset to 0 and limit into [1, implementation maximum]
+	ListFiltered(ctx context.Context, limit, offset int, filter TransactionFilter) ([]*Transaction, error)
+
+	// CountFiltered returns the number of transactions matching every filter that is set
+	CountFiltered(ctx context.Context, filter TransactionFilter) (int, error)
+
+	// ListBalanceChanges returns the per-transaction balance changes of a bucket dated up to and including until
+	// Ordered by date ascending, so a running sum reconstructs the historical book value
+	ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]BalanceChange, error)
+
+	// SumNetDebitsByPeriod sums a bucket's DEBIT entries minus its CREDIT entries (e.g. refunds) on the given layer
+	// per period (date_trunc by granularity) for transactions dated in [start, end]
+	// Ordered by period ascending; periods without entries are absent
+	SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer Layer, start, end time.Time, granularity TrendGranularity) ([]PeriodTotal, error)
+
+	// CountByDay counts the non-scheduled transactions dated in [start, end] per day (date_trunc('day'))
+	// If bucketID is set, only transactions with an entry for that bucket are counted
+	// Ordered by day ascending; days without transactions are absent
+	CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]DateCount, error)
+
+	// SumCreditsByBucketType sums the CREDIT entries on the given layer per bucket of bucketType
+	// for transactions dated in [start, end]. Buckets without such entries are absent from the returned map
+	SumCreditsByBucketType(ctx context.Context, bucketType BucketType, layer Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error)
+
+	// SumExternalInflowDebitsByBucket sums the DEBIT entries on the given layer per bucket for external
+	// inflow transactions dated in [start, end]. Buckets without such entries are absent from the returned map
+	SumExternalInflowDebitsByBucket(ctx context.Context, layer Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error)
+
+	// ListScheduledDue retrieves the scheduled transactions (with entries) whose effective date is at or before asOf,
+	// ordered by effective date ascending
+	ListScheduledDue(ctx context.Context, asOf time.Time) ([]*Transaction, error)
+
+	// Activate marks a scheduled transaction as active and applies its entries to the bucket balances atomically
+	// Returns ErrTransactionNotFound if no scheduled transaction has this ID (e.g. it was already activated)
+	Activate(ctx context.Context, id uuid.UUID) error
+
+	// SumEntriesByBucket returns the summed DEBIT and CREDIT entries of each given bucket
+	// Buckets without entries are absent from the returned map
+	// Like every sum above, entries of still-scheduled transactions are excluded (they are not in the balances yet)
+	SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]EntryTotals, error)
+
+	// SumEntriesByBucketBefore is SumEntriesByBucket restricted to transactions dated strictly before the given time
+	// (i.e. the entries making up each bucket's balance at that moment)
+	SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]EntryTotals, error)
+
+	// ListInPeriod retrieves every non-scheduled transaction dated within [start, end) that involves any of bucketIDs,
+	// with all of its entries, ordered by date ascending
+	ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*Transaction, error)
+
+	// ListBetweenBuckets retrieves every non-scheduled transaction with entries for both bucketA and bucketB
+	// (e.g. transfers between two bank accounts), with all of its entries, ordered by date descending
+	ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*Transaction, error)
+
+	// Recategorize atomically re-points the entries (both layers, DEBIT and CREDIT) of sourceCategoryID to targetCategoryID
+	// and moves their amounts between the two category balances. If descriptionFilter is not empty, only
+	// entries of transactions whose description contains it (case-insensitive) are re-pointed
+	// Returns the number of re-pointed entries
+	Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error)
+
+	// SumParkedIncome returns the income parked in parkingBucketID (virtual layer) that landed in physicalBucketID
+	// and is not allocated yet: external inflows debiting both buckets, minus internal transfers crediting
+	// parkingBucketID into virtual buckets held in physicalBucketID. Scheduled transactions are excluded
+	SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error)
 }
 
 // SplitRuleRepository defines the interface for split rule persistence operations
 type SplitRuleRepository interface {
 	// GetBySourceBucketID retrieves a split rule by its source bucket ID
+	// A rule header without items is returned as is; callers that allocate reject it with ErrSplitRuleHasNoItems
 	GetBySourceBucketID(ctx context.Context, bucketID uuid.UUID) (*SplitRule, error)
+
+	// Create creates a new split rule with all its items
+	Create(ctx context.Context, rule *SplitRule) error
+
+	// CountItemsBySourceBucket returns the number of items of every split rule, keyed by source bucket ID
+	// Source buckets without a split rule are absent from the returned map (items are counted, not loaded)
+	CountItemsBySourceBucket(ctx context.Context) (map[uuid.UUID]int, error)
+
+	// SetActive enables or disables the split rule of a source bucket, keeping its items
+	// Returns ErrSplitRuleNotFound if the source bucket has no split rule
+	SetActive(ctx context.Context, sourceBucketID uuid.UUID, active bool) error
 }
 
 // MarketValueRepository defines the interface for market value history persistence operations
@@ -52,4 +187,74 @@ type MarketValueRepository interface {
 
 	// GetLatest retrieves the most recent market value entry for a given bucket
 	GetLatest(ctx context.Context, bucketID uuid.UUID) (*MarketValueHistory, error)
+
+	// GetLatestForBuckets retrieves the most recent market value entry of each given bucket in a single query
+	// Buckets without market value history are absent from the returned map
+	GetLatestForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*MarketValueHistory, error)
+
+	// ListByBucket retrieves the market value entries of a bucket dated within [start, end], ordered by date ascending
+	ListByBucket(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]*MarketValueHistory, error)
+
+	// ListForBuckets retrieves the market value entries of the given buckets dated strictly before the given time
+	// in a single query, ordered by date ascending
+	ListForBuckets(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) ([]*MarketValueHistory, error)
+
+	// List retrieves a paginated list of a bucket's market value entries
+	// Ordered newest-first unless ascending is true (e.g. for charting)
+	List(ctx context.Context, bucketID uuid.UUID, limit, offset int, ascending bool) ([]*MarketValueHistory, error)
+
+	// Count returns the number of market value entries of a bucket
+	Count(ctx context.Context, bucketID uuid.UUID) (int, error)
+}
+
+// HoldingRepository defines the interface for equity holding persistence operations
+type HoldingRepository interface {
+	// GetByBucketID retrieves the holding of an equity bucket
+	// Returns ErrHoldingNotFound if the bucket has no quantity-tracked holding
+	GetByBucketID(ctx context.Context, bucketID uuid.UUID) (*Holding, error)
+
+	// GetForBuckets retrieves the holdings of the given equity buckets in a single query, without locking them
+	// Buckets without a quantity-tracked holding are absent from the returned map
+	GetForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*Holding, error)
+
+	// Save creates or replaces the holding of an equity bucket
+	Save(ctx context.Context, holding *Holding) error
+}
+
+// TransferTaskRepository defines the interface for transfer task persistence operations
+type TransferTaskRepository interface {
+	// Create creates a new transfer task
+	Create(ctx context.Context, task *TransferTask) error
+
+	// List retrieves transfer tasks ordered by creation time (newest first)
+	// Completed tasks are only included if includeCompleted is true
+	List(ctx context.Context, includeCompleted bool) ([]TransferTask, error)
+
+	// ListPendingCreatedBefore retrieves the incomplete transfer tasks created before the given time
+	// ordered by creation time (oldest first)
+	ListPendingCreatedBefore(ctx context.Context, before time.Time) ([]TransferTask, error)
+
+	// GetByID retrieves a transfer task (locking it when called inside a unit of work)
+	GetByID(ctx context.Context, id uuid.UUID) (*TransferTask, error)
+
+	// MarkCompleted flags a pending transfer task as completed by the given transaction
+	// Returns ErrTransferTaskNotFound if no pending task with that ID exists
+	MarkCompleted(ctx context.Context, id, completedTransactionID uuid.UUID) error
+}
+
+// Repositories groups the repositories available inside a unit of work
+type Repositories struct {
+	Buckets       BucketRepository
+	Transactions  TransactionRepository
+	TransferTasks TransferTaskRepository
+	Holdings      HoldingRepository
+	SplitRules    SplitRuleRepository
+	MarketValues  MarketValueRepository
+}
+
+// UnitOfWork runs several repository operations atomically in one database transaction
+type UnitOfWork interface {
+	// Do runs fn with transaction-scoped repositories
+	// The transaction is committed if fn returns nil and rolled back otherwise
+	Do(ctx context.Context, fn func(repos Repositories) error) error
 }
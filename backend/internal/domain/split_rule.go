@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -16,13 +16,26 @@ const (
 	SplitRuleItemTypeRemainder SplitRuleItemType = "REMAINDER"
 )
 
+// LargeFixedSplitAmount is the FIXED item value above which Lint warns that the amount
+// likely exceeds typical inflows (FIXED items larger than the inflow make the allocation fail)
+var LargeFixedSplitAmount = decimal.NewFromInt(10000)
+
+// MeaningfulRemainderPercent is the share of the amount left after FIXED items below which Summarize
+// reports that the REMAINDER item only catches leftovers rather than receiving a meaningful part
+var MeaningfulRemainderPercent = decimal.NewFromInt(5)
+
 // SplitRule represents a split rule entity in the domain layer
 // Adheres to the data model defined in specs.md
 type SplitRule struct {
 	ID             uuid.UUID
 	Name           string
 	SourceBucketID uuid.UUID
-	Items          []SplitRuleItem
+	// DestinationPhysicalBucketID is the physical bucket inflows land in; every target must be one of its children.
+	// NULL for rules that leave it to be inferred from the parent of the first target bucket.
+	DestinationPhysicalBucketID *uuid.UUID
+	// Active is false while the rule is disabled: inflows of its source bucket are then handled as if it had no rule
+	Active bool
+	Items  []SplitRuleItem
 }
 
 // SplitRuleItem represents a single item in a split rule
@@ -34,6 +47,56 @@ type SplitRuleItem struct {
 	Type           SplitRuleItemType // 'FIXED', 'PERCENT' (of Remainder), or 'REMAINDER' (Catch-all)
 	Value          decimal.Decimal   // Amount for FIXED, percentage (0-100) for PERCENT, ignored for REMAINDER
 	Priority       int               // Lower number = Executed first (Important for Fixed logic)
+	// RelativeToBucketID makes a PERCENT item a percentage of what another item's target received
+	// (e.g. "tithe = 10% of Salary-Net") instead of the remainder. NULL for regular items.
+	RelativeToBucketID *uuid.UUID
+}
+
+// TotalFixed returns the sum of the rule's FIXED item values
+// An inflow smaller than this cannot be allocated (FIXED items are deducted first)
+func (sr *SplitRule) TotalFixed() decimal.Decimal {
+	total := decimal.Zero
+	for _, item := range sr.Items {
+		if item.Type == SplitRuleItemTypeFixed {
+			total = total.Add(item.Value)
+		}
+	}
+	return total
+}
+
+// SplitRuleSummary describes how a split rule distributes an inflow
+type SplitRuleSummary struct {
+	FixedTotal   decimal.Decimal // Sum of FIXED item values, deducted first
+	PercentTotal decimal.Decimal // Sum of PERCENT-of-remainder item values (relative items excluded)
+	// RemainderPercent is the share of the amount left after FIXED items that reaches the REMAINDER item
+	// (100 - PercentTotal, never negative). Relative PERCENT items are taken from this share on top.
+	RemainderPercent decimal.Decimal
+	// HasMeaningfulRemainder is true when RemainderPercent reaches MeaningfulRemainderPercent
+	HasMeaningfulRemainder bool
+}
+
+// Summarize returns the rule's FIXED and PERCENT totals and how much is left for the REMAINDER item
+// Every rule allocates 100% through its REMAINDER item; the summary tells whether that item is a
+// deliberate sink or a catch-all receiving most of the money
+func (sr *SplitRule) Summarize() SplitRuleSummary {
+	percentTotal := decimal.Zero
+	for _, item := range sr.Items {
+		if item.Type == SplitRuleItemTypePercent && item.RelativeToBucketID == nil {
+			percentTotal = percentTotal.Add(item.Value)
+		}
+	}
+
+	remainderPercent := decimal.NewFromInt(100).Sub(percentTotal)
+	if remainderPercent.IsNegative() {
+		remainderPercent = decimal.Zero
+	}
+
+	return SplitRuleSummary{
+		FixedTotal:             sr.TotalFixed(),
+		PercentTotal:           percentTotal,
+		RemainderPercent:       remainderPercent,
+		HasMeaningfulRemainder: remainderPercent.GreaterThanOrEqual(MeaningfulRemainderPercent),
+	}
 }
 
 // Validate ensures the split rule adheres to domain rules
@@ -41,40 +104,113 @@ type SplitRuleItem struct {
 // CRITICAL: Ensures exactly one item is type 'REMAINDER'
 func (sr *SplitRule) Validate() error {
 	if len(sr.Items) == 0 {
-		return errors.New("split rule must have at least one item")
+		return NewValidationError("split rule must have at least one item")
 	}
 
 	remainderCount := 0
+	seenTargets := make(map[uuid.UUID]bool, len(sr.Items))
 	for _, item := range sr.Items {
 		if item.Type == SplitRuleItemTypeRemainder {
 			remainderCount++
 		}
 
+		// Allocations are keyed by target bucket, so a second item for the same bucket would be collapsed
+		if seenTargets[item.TargetBucketID] {
+			return NewValidationErrorf("bucket %s is targeted by more than one split rule item", item.TargetBucketID)
+		}
+		seenTargets[item.TargetBucketID] = true
+
 		// Validate item type
 		if item.Type != SplitRuleItemTypeFixed &&
 			item.Type != SplitRuleItemTypePercent &&
 			item.Type != SplitRuleItemTypeRemainder {
-			return errors.New("split rule item type must be FIXED, PERCENT, or REMAINDER")
+			return NewValidationError("split rule item type must be FIXED, PERCENT, or REMAINDER")
 		}
 
 		// Validate FIXED value is positive
 		if item.Type == SplitRuleItemTypeFixed {
 			if item.Value.LessThanOrEqual(decimal.Zero) {
-				return errors.New("FIXED split rule item value must be positive")
+				return NewValidationError("FIXED split rule item value must be positive")
 			}
 		}
 
 		// Validate PERCENT value is between 0 and 100
 		if item.Type == SplitRuleItemTypePercent {
 			if item.Value.LessThan(decimal.Zero) || item.Value.GreaterThan(decimal.NewFromInt(100)) {
-				return errors.New("PERCENT split rule item value must be between 0 and 100")
+				return NewValidationError("PERCENT split rule item value must be between 0 and 100")
 			}
 		}
+
+		// Only PERCENT items can be relative to another bucket
+		if item.RelativeToBucketID != nil && item.Type != SplitRuleItemTypePercent {
+			return NewValidationError("only PERCENT split rule items can be relative to another bucket")
+		}
 	}
 
 	if remainderCount != 1 {
-		return errors.New("split rule must have exactly one REMAINDER item")
+		return NewValidationError("split rule must have exactly one REMAINDER item")
+	}
+
+	return sr.validateRelativeItems()
+}
+
+// validateRelativeItems ensures relative PERCENT items reference a sibling that is allocated
+// before the REMAINDER, and that following the references never loops back (no cycles)
+func (sr *SplitRule) validateRelativeItems() error {
+	itemsByTarget := make(map[uuid.UUID]SplitRuleItem, len(sr.Items))
+	for _, item := range sr.Items {
+		itemsByTarget[item.TargetBucketID] = item
+	}
+
+	for _, item := range sr.Items {
+		if item.RelativeToBucketID == nil {
+			continue
+		}
+
+		// Walk the reference chain; revisiting a bucket means a cycle
+		visited := map[uuid.UUID]bool{item.TargetBucketID: true}
+		current := item
+		for current.RelativeToBucketID != nil {
+			referenced, ok := itemsByTarget[*current.RelativeToBucketID]
+			if !ok {
+				return NewValidationError("relative PERCENT split rule item must reference another item's target bucket")
+			}
+			if referenced.Type == SplitRuleItemTypeRemainder {
+				return NewValidationError("relative PERCENT split rule item cannot reference the REMAINDER item")
+			}
+			if visited[referenced.TargetBucketID] {
+				return NewValidationError("relative PERCENT split rule items must not form a cycle")
+			}
+			visited[referenced.TargetBucketID] = true
+			current = referenced
+		}
 	}
 
 	return nil
 }
+
+// Lint returns advisory warnings for configurations that are legal but likely mistakes
+// Unlike Validate, warnings never block saving the rule
+func (sr *SplitRule) Lint() []string {
+	var warnings []string
+
+	for _, item := range sr.Items {
+		switch item.Type {
+		case SplitRuleItemTypeFixed:
+			if item.Value.GreaterThan(LargeFixedSplitAmount) {
+				warnings = append(warnings, fmt.Sprintf("FIXED amount %s for bucket %s is very large and may exceed typical inflows", item.Value, item.TargetBucketID))
+			}
+		case SplitRuleItemTypePercent:
+			if item.Value.IsZero() {
+				warnings = append(warnings, fmt.Sprintf("PERCENT item for bucket %s is 0%% and will never receive money", item.TargetBucketID))
+			}
+		}
+	}
+
+	// PERCENT items of the remainder adding up to 100% leave nothing for the REMAINDER item
+	if summary := sr.Summarize(); summary.RemainderPercent.IsZero() {
+		warnings = append(warnings, fmt.Sprintf("PERCENT items add up to %s%%; the REMAINDER item will never receive money", summary.PercentTotal))
+	}
+
+	return warnings
+}
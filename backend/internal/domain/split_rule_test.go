@@ -308,13 +308,79 @@ func TestSplitRule_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "valid relative percent item",
+			rule:    relativeSplitRule(false),
+			wantErr: false,
+		},
+		{
+			name:    "relative percent items forming a cycle",
+			rule:    relativeSplitRule(true),
+			wantErr: true,
+			errMsg:  "must not form a cycle",
+		},
+		{
+			name: "relative percent item referencing an unknown bucket",
+			rule: SplitRule{
+				ID:             uuid.New(),
+				Name:           "Test Rule",
+				SourceBucketID: uuid.New(),
+				Items: []SplitRuleItem{
+					{
+						ID:                 uuid.New(),
+						TargetBucketID:     uuid.New(),
+						Type:               SplitRuleItemTypePercent,
+						Value:              decimal.NewFromInt(10),
+						Priority:           1,
+						RelativeToBucketID: uuidPtr(uuid.New()),
+					},
+					{
+						ID:             uuid.New(),
+						TargetBucketID: uuid.New(),
+						Type:           SplitRuleItemTypeRemainder,
+						Value:          decimal.Zero,
+						Priority:       2,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "must reference another item's target bucket",
+		},
+		{
+			name: "relative item that is not PERCENT",
+			rule: SplitRule{
+				ID:             uuid.New(),
+				Name:           "Test Rule",
+				SourceBucketID: uuid.New(),
+				Items: []SplitRuleItem{
+					{
+						ID:                 uuid.New(),
+						TargetBucketID:     uuid.New(),
+						Type:               SplitRuleItemTypeFixed,
+						Value:              decimal.NewFromInt(10),
+						Priority:           1,
+						RelativeToBucketID: uuidPtr(uuid.New()),
+					},
+					{
+						ID:             uuid.New(),
+						TargetBucketID: uuid.New(),
+						Type:           SplitRuleItemTypeRemainder,
+						Value:          decimal.Zero,
+						Priority:       2,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "only PERCENT split rule items can be relative",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.rule.Validate()
 			if tt.wantErr {
-				assert.Error(t, err)
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
@@ -324,3 +390,239 @@ func TestSplitRule_Validate(t *testing.T) {
 		})
 	}
 }
+
+// relativeSplitRule builds "Salary-Net (50%) <- Tithe (10% of Salary-Net)" plus a REMAINDER
+// If cyclic is true, Salary-Net is made relative to Tithe as well
+func relativeSplitRule(cyclic bool) SplitRule {
+	salaryNetID := uuid.New()
+	titheID := uuid.New()
+
+	salaryNet := SplitRuleItem{
+		ID:             uuid.New(),
+		TargetBucketID: salaryNetID,
+		Type:           SplitRuleItemTypePercent,
+		Value:          decimal.NewFromInt(50),
+		Priority:       1,
+	}
+	if cyclic {
+		salaryNet.RelativeToBucketID = &titheID
+	}
+
+	return SplitRule{
+		ID:             uuid.New(),
+		Name:           "Relative Rule",
+		SourceBucketID: uuid.New(),
+		Items: []SplitRuleItem{
+			salaryNet,
+			{
+				ID:                 uuid.New(),
+				TargetBucketID:     titheID,
+				Type:               SplitRuleItemTypePercent,
+				Value:              decimal.NewFromInt(10),
+				Priority:           2,
+				RelativeToBucketID: &salaryNetID,
+			},
+			{
+				ID:             uuid.New(),
+				TargetBucketID: uuid.New(),
+				Type:           SplitRuleItemTypeRemainder,
+				Value:          decimal.Zero,
+				Priority:       3,
+			},
+		},
+	}
+}
+
+func uuidPtr(id uuid.UUID) *uuid.UUID {
+	return &id
+}
+
+func TestSplitRule_Lint(t *testing.T) {
+	remainder := SplitRuleItem{
+		ID:             uuid.New(),
+		TargetBucketID: uuid.New(),
+		Type:           SplitRuleItemTypeRemainder,
+		Value:          decimal.Zero,
+		Priority:       99,
+	}
+	tests := []struct {
+		name         string
+		items        []SplitRuleItem
+		wantWarnings []string
+	}{
+		{
+			name: "sensible rule has no warnings",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50), Priority: 1},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2},
+				remainder,
+			},
+		},
+		{
+			name: "zero percent item",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.Zero, Priority: 1},
+				remainder,
+			},
+			wantWarnings: []string{"is 0% and will never receive money"},
+		},
+		{
+			name: "very large fixed amount",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50000), Priority: 1},
+				remainder,
+			},
+			wantWarnings: []string{"is very large"},
+		},
+		{
+			name: "percent items leave nothing for remainder",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(60), Priority: 1},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(40), Priority: 2},
+				remainder,
+			},
+			wantWarnings: []string{"the REMAINDER item will never receive money"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := SplitRule{ID: uuid.New(), Name: "Test Rule", SourceBucketID: uuid.New(), Items: tt.items}
+
+			// Lint is advisory: every case here is still a valid rule
+			assert.NoError(t, rule.Validate())
+
+			warnings := rule.Lint()
+			assert.Len(t, warnings, len(tt.wantWarnings))
+			for i, want := range tt.wantWarnings {
+				if i < len(warnings) {
+					assert.Contains(t, warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitRule_Validate_DuplicateTarget(t *testing.T) {
+	duplicateTargetID := uuid.New()
+	rule := SplitRule{
+		ID:             uuid.New(),
+		Name:           "Test Rule",
+		SourceBucketID: uuid.New(),
+		Items: []SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: duplicateTargetID, Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: duplicateTargetID, Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 99},
+		},
+	}
+
+	err := rule.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "is targeted by more than one split rule item")
+}
+
+func TestSplitRule_TotalFixed(t *testing.T) {
+	rule := SplitRule{
+		ID:             uuid.New(),
+		Name:           "Salary Split",
+		SourceBucketID: uuid.New(),
+		Items: []SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(500), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.RequireFromString("250.50"), Priority: 2},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(30), Priority: 3},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(100), Priority: 4},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 99},
+		},
+	}
+
+	// Only FIXED items count: 500 + 250.50 + 100
+	assert.True(t, rule.TotalFixed().Equal(decimal.RequireFromString("850.50")), "got %s", rule.TotalFixed())
+
+	noFixed := SplitRule{Items: []SplitRuleItem{
+		{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeRemainder, Priority: 1},
+	}}
+	assert.True(t, noFixed.TotalFixed().IsZero())
+}
+
+func TestSplitRule_Summarize(t *testing.T) {
+	referencedID := uuid.New()
+	remainder := SplitRuleItem{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeRemainder, Priority: 99}
+
+	tests := []struct {
+		name                    string
+		items                   []SplitRuleItem
+		wantFixed               string
+		wantPercent             string
+		wantRemainderPercent    string
+		wantMeaningfulRemainder bool
+	}{
+		{
+			name:                    "remainder only",
+			items:                   []SplitRuleItem{remainder},
+			wantFixed:               "0",
+			wantPercent:             "0",
+			wantRemainderPercent:    "100",
+			wantMeaningfulRemainder: true,
+		},
+		{
+			name: "fixed and percent leave a meaningful remainder",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypeFixed, Value: decimal.NewFromInt(500), Priority: 1},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(30), Priority: 2},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.RequireFromString("12.5"), Priority: 3},
+				remainder,
+			},
+			wantFixed:               "500",
+			wantPercent:             "42.5",
+			wantRemainderPercent:    "57.5",
+			wantMeaningfulRemainder: true,
+		},
+		{
+			name: "remainder only catches leftovers",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(97), Priority: 1},
+				remainder,
+			},
+			wantFixed:            "0",
+			wantPercent:          "97",
+			wantRemainderPercent: "3",
+		},
+		{
+			name: "percent items reach 100",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(60), Priority: 1},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(60), Priority: 2},
+				remainder,
+			},
+			wantFixed:            "0",
+			wantPercent:          "120",
+			wantRemainderPercent: "0",
+		},
+		{
+			name: "relative percent items are not part of the percent total",
+			items: []SplitRuleItem{
+				{ID: uuid.New(), TargetBucketID: referencedID, Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(80), Priority: 1},
+				{ID: uuid.New(), TargetBucketID: uuid.New(), Type: SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2, RelativeToBucketID: &referencedID},
+				remainder,
+			},
+			wantFixed:               "0",
+			wantPercent:             "80",
+			wantRemainderPercent:    "20",
+			wantMeaningfulRemainder: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := SplitRule{ID: uuid.New(), Name: "Test Rule", SourceBucketID: uuid.New(), Items: tt.items}
+
+			summary := rule.Summarize()
+			assert.True(t, summary.FixedTotal.Equal(decimal.RequireFromString(tt.wantFixed)), "fixed total: got %s", summary.FixedTotal)
+			assert.True(t, summary.PercentTotal.Equal(decimal.RequireFromString(tt.wantPercent)), "percent total: got %s", summary.PercentTotal)
+			assert.True(t, summary.RemainderPercent.Equal(decimal.RequireFromString(tt.wantRemainderPercent)), "remainder percent: got %s", summary.RemainderPercent)
+			assert.Equal(t, tt.wantMeaningfulRemainder, summary.HasMeaningfulRemainder)
+		})
+	}
+}
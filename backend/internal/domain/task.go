@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -15,4 +17,6 @@ type TransferTask struct {
 	ToPhysicalBucketID     uuid.UUID
 	Amount                 decimal.Decimal
 	IsCompleted            bool
+	Description            string    // Copied from the originating transaction (why the move is pending)
+	CreatedAt              time.Time // When the task was generated
 }
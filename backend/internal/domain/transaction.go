@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +16,24 @@ const (
 	EntryTypeCredit EntryType = "CREDIT"
 )
 
+// BalanceEffect returns how an entry of entryType changes the balance of the bucket it is booked on:
+// +1 if it increases the balance, -1 if it decreases it, 0 for an unknown entry type
+// This is the single source of truth for sign conventions, mirroring the balance_update_trigger,
+// which applies the same sign to every bucket type:
+//   - PHYSICAL, VIRTUAL, EQUITY: DEBIT is money coming in (+), CREDIT money going out (-)
+//   - EXPENSE: DEBIT is spending booked against the category (+), CREDIT a refund (-)
+//   - INCOME, SYSTEM: DEBIT (+) and CREDIT (-) as well, so an income bucket's balance is
+//     the negative of what it has paid out (e.g. -3000 after a 3000 salary)
+func BalanceEffect(entryType EntryType) int {
+	switch entryType {
+	case EntryTypeDebit:
+		return 1
+	case EntryTypeCredit:
+		return -1
+	}
+	return 0
+}
+
 // Layer represents the accounting layer
 type Layer string
 
@@ -24,15 +42,66 @@ const (
 	LayerVirtual  Layer = "VIRTUAL"
 )
 
+// TrendGranularity is the period length spending trends are grouped by (a Postgres date_trunc field)
+type TrendGranularity string
+
+const (
+	TrendGranularityDay   TrendGranularity = "day"
+	TrendGranularityWeek  TrendGranularity = "week"
+	TrendGranularityMonth TrendGranularity = "month"
+	TrendGranularityYear  TrendGranularity = "year"
+)
+
+// IsValid reports whether the granularity is one of the supported periods
+func (g TrendGranularity) IsValid() bool {
+	switch g {
+	case TrendGranularityDay, TrendGranularityWeek, TrendGranularityMonth, TrendGranularityYear:
+		return true
+	}
+	return false
+}
+
+// TransactionKind narrows a transaction listing to one kind of transaction
+type TransactionKind string
+
+const (
+	TransactionKindAll            TransactionKind = ""                // No kind filter
+	TransactionKindRefund         TransactionKind = "REFUND"          // Refunds logged through LogExpense
+	TransactionKindExternalInflow TransactionKind = "EXTERNAL_INFLOW" // Money entering from an income bucket
+)
+
+// IsValid reports whether the kind is one of the supported filters
+func (k TransactionKind) IsValid() bool {
+	switch k {
+	case TransactionKindAll, TransactionKindRefund, TransactionKindExternalInflow:
+		return true
+	}
+	return false
+}
+
+// TransactionFilter combines the optional filters of a transaction listing
+type TransactionFilter struct {
+	BucketIDs []uuid.UUID     // Transactions involving any of these buckets (all transactions if empty)
+	Kind      TransactionKind // Only transactions of this kind (all kinds if empty)
+}
+
 // Transaction represents a transaction entity in the domain layer
 // Adheres to the data model defined in specs.md
 type Transaction struct {
 	ID                 uuid.UUID
 	Description        string
+	Memo               string // Optional long-form note (e.g. receipt details); empty if not set
 	Date               time.Time
 	IsInternalTransfer bool
 	IsExternalInflow   bool
-	Entries            []TransactionEntry
+	IsRefund           bool // Expense logged in reverse (money flowing back from a category)
+
+	// Scheduled transactions are stored but not counted in balances (or net worth) until activated
+	// on or after EffectiveDate; Date is set to EffectiveDate so the transaction lands in the right period
+	Scheduled     bool
+	EffectiveDate *time.Time // Set for transactions created as scheduled, nil otherwise
+
+	Entries []TransactionEntry
 }
 
 // TransactionEntry represents a single entry in a transaction
@@ -46,12 +115,56 @@ type TransactionEntry struct {
 	Layer         Layer           // 'PHYSICAL' or 'VIRTUAL'
 }
 
+// EntryTotals represents the summed DEBIT and CREDIT entry amounts of a bucket
+type EntryTotals struct {
+	Debits  decimal.Decimal
+	Credits decimal.Decimal
+}
+
+// BalanceDelta returns the net change the entries make to the balance of their bucket (see BalanceEffect)
+func (t EntryTotals) BalanceDelta() decimal.Decimal {
+	debits := t.Debits.Mul(decimal.NewFromInt(int64(BalanceEffect(EntryTypeDebit))))
+	credits := t.Credits.Mul(decimal.NewFromInt(int64(BalanceEffect(EntryTypeCredit))))
+	return debits.Add(credits)
+}
+
+// BalanceChange represents the entries of a single transaction on a bucket
+// Totals.BalanceDelta gives its net effect on the bucket's balance
+type BalanceChange struct {
+	Date   time.Time
+	Totals EntryTotals
+}
+
+// PeriodTotal represents the sum of amounts booked within the period starting at Period
+type PeriodTotal struct {
+	Period time.Time
+	Total  decimal.Decimal
+}
+
+// DateCount represents the number of transactions dated on the day starting at Date
+type DateCount struct {
+	Date  time.Time
+	Count int
+}
+
+// ValidateDescription ensures a transaction description is not blank (empty or only whitespace),
+// so history never shows unlabelled rows
+func ValidateDescription(description string) error {
+	if strings.TrimSpace(description) == "" {
+		return NewValidationError("description must not be blank")
+	}
+	return nil
+}
+
 // Validate ensures the transaction adheres to domain rules
 // Returns an error if validation fails
 // CRITICAL: Ensures sum of debits equals sum of credits for Physical Layer AND Virtual Layer separately
 func (t *Transaction) Validate() error {
 	if len(t.Entries) == 0 {
-		return errors.New("transaction must have at least one entry")
+		return NewValidationError("transaction must have at least one entry")
+	}
+	if t.Scheduled && t.EffectiveDate == nil {
+		return NewValidationError("scheduled transaction must have an effective date")
 	}
 
 	// Separate entries by layer
@@ -64,17 +177,17 @@ func (t *Transaction) Validate() error {
 		} else if entry.Layer == LayerVirtual {
 			virtualEntries = append(virtualEntries, entry)
 		} else {
-			return errors.New("entry layer must be PHYSICAL or VIRTUAL")
+			return NewValidationError("entry layer must be PHYSICAL or VIRTUAL")
 		}
 
 		// Validate entry amount is positive (absolute value)
 		if entry.Amount.LessThanOrEqual(decimal.Zero) {
-			return errors.New("entry amount must be positive (absolute value)")
+			return NewValidationError("entry amount must be positive (absolute value)")
 		}
 
 		// Validate entry type
 		if entry.Type != EntryTypeDebit && entry.Type != EntryTypeCredit {
-			return errors.New("entry type must be DEBIT or CREDIT")
+			return NewValidationError("entry type must be DEBIT or CREDIT")
 		}
 	}
 
@@ -88,6 +201,29 @@ func (t *Transaction) Validate() error {
 		return err
 	}
 
+	// Reject self-transfers: a bucket debited and credited within one layer balances trivially
+	if err := validateNoSelfTransfer(physicalEntries); err != nil {
+		return err
+	}
+	if err := validateNoSelfTransfer(virtualEntries); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateNoSelfTransfer ensures no bucket appears as both DEBIT and CREDIT among the entries of a single layer
+// The same bucket may still appear on different sides in different layers (e.g. an income bucket is credited in both)
+func validateNoSelfTransfer(entries []TransactionEntry) error {
+	entryTypes := make(map[uuid.UUID]EntryType, len(entries))
+
+	for _, entry := range entries {
+		if existing, ok := entryTypes[entry.BucketID]; ok && existing != entry.Type {
+			return NewValidationError("bucket cannot be both debited and credited in the same layer")
+		}
+		entryTypes[entry.BucketID] = entry.Type
+	}
+
 	return nil
 }
 
@@ -110,7 +246,7 @@ func validateLayerBalance(entries []TransactionEntry, layer Layer) error {
 	}
 
 	if !totalDebits.Equal(totalCredits) {
-		return errors.New("sum of debits must equal sum of credits for " + string(layer) + " layer")
+		return NewValidationErrorf("sum of debits must equal sum of credits for %s layer", layer)
 	}
 
 	return nil
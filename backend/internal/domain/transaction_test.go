@@ -10,6 +10,9 @@ import (
 )
 
 func TestTransaction_Validate(t *testing.T) {
+	sameBucketID := uuid.New()
+	otherBucketID := uuid.New()
+
 	tests := []struct {
 		name    string
 		tx      Transaction
@@ -325,13 +328,116 @@ func TestTransaction_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Same bucket debited and credited in the same layer should fail",
+			tx: Transaction{
+				ID:          uuid.New(),
+				Description: "Self Transfer",
+				Date:        time.Now(),
+				Entries: []TransactionEntry{
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      sameBucketID,
+						Amount:        decimal.NewFromInt(100),
+						Type:          EntryTypeDebit,
+						Layer:         LayerPhysical,
+					},
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      sameBucketID,
+						Amount:        decimal.NewFromInt(100),
+						Type:          EntryTypeCredit,
+						Layer:         LayerPhysical,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "bucket cannot be both debited and credited in the same layer",
+		},
+		{
+			name: "Same bucket on opposite sides in different layers should pass",
+			tx: Transaction{
+				ID:          uuid.New(),
+				Description: "Cross Layer",
+				Date:        time.Now(),
+				Entries: []TransactionEntry{
+					// Physical Layer: Debit A, Credit B
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      sameBucketID,
+						Amount:        decimal.NewFromInt(80),
+						Type:          EntryTypeDebit,
+						Layer:         LayerPhysical,
+					},
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      otherBucketID,
+						Amount:        decimal.NewFromInt(80),
+						Type:          EntryTypeCredit,
+						Layer:         LayerPhysical,
+					},
+					// Virtual Layer: Debit B, Credit A
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      otherBucketID,
+						Amount:        decimal.NewFromInt(80),
+						Type:          EntryTypeDebit,
+						Layer:         LayerVirtual,
+					},
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      sameBucketID,
+						Amount:        decimal.NewFromInt(80),
+						Type:          EntryTypeCredit,
+						Layer:         LayerVirtual,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Scheduled transaction without an effective date should fail",
+			tx: Transaction{
+				ID:          uuid.New(),
+				Description: "Scheduled",
+				Date:        time.Now(),
+				Scheduled:   true,
+				Entries: []TransactionEntry{
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      sameBucketID,
+						Amount:        decimal.NewFromInt(30),
+						Type:          EntryTypeDebit,
+						Layer:         LayerPhysical,
+					},
+					{
+						ID:            uuid.New(),
+						TransactionID: uuid.New(),
+						BucketID:      otherBucketID,
+						Amount:        decimal.NewFromInt(30),
+						Type:          EntryTypeCredit,
+						Layer:         LayerPhysical,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "scheduled transaction must have an effective date",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := tt.tx.Validate()
 			if tt.wantErr {
-				assert.Error(t, err)
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
@@ -341,3 +447,69 @@ func TestTransaction_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionKind_IsValid(t *testing.T) {
+	assert.True(t, TransactionKindAll.IsValid())
+	assert.True(t, TransactionKindRefund.IsValid())
+	assert.True(t, TransactionKindExternalInflow.IsValid())
+	assert.False(t, TransactionKind("EXPENSE").IsValid())
+	assert.False(t, TransactionKind("refund").IsValid(), "Kinds are case-sensitive")
+}
+
+func TestBalanceEffect(t *testing.T) {
+	tests := []struct {
+		entryType EntryType
+		expected  int
+	}{
+		{EntryTypeDebit, 1},
+		{EntryTypeCredit, -1},
+		{EntryType("UNKNOWN"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.entryType), func(t *testing.T) {
+			assert.Equal(t, tt.expected, BalanceEffect(tt.entryType))
+		})
+	}
+}
+
+func TestEntryTotals_BalanceDelta(t *testing.T) {
+	totals := EntryTotals{Debits: decimal.NewFromInt(300), Credits: decimal.NewFromInt(120)}
+	assert.True(t, totals.BalanceDelta().Equal(decimal.NewFromInt(180)))
+
+	// An income bucket that paid out a salary carries a negative balance
+	salary := EntryTotals{Debits: decimal.Zero, Credits: decimal.NewFromInt(3000)}
+	assert.True(t, salary.BalanceDelta().Equal(decimal.NewFromInt(-3000)))
+
+	// Zero totals (a bucket without entries) leave the balance at 0
+	assert.True(t, EntryTotals{}.BalanceDelta().IsZero())
+}
+
+func TestValidateDescription(t *testing.T) {
+	assert.NoError(t, ValidateDescription("Weekly groceries"))
+	assert.NoError(t, ValidateDescription("  Padded  "))
+
+	for _, description := range []string{"", "   ", "\t\n"} {
+		err := ValidateDescription(description)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr, "description %q", description)
+	}
+}
+
+func TestTransaction_Validate_SelfTransferIsValidationError(t *testing.T) {
+	bucketID := uuid.New()
+	tx := Transaction{
+		ID:          uuid.New(),
+		Description: "Self Transfer",
+		Date:        time.Now(),
+		Entries: []TransactionEntry{
+			{ID: uuid.New(), BucketID: bucketID, Amount: decimal.NewFromInt(10), Type: EntryTypeDebit, Layer: LayerVirtual},
+			{ID: uuid.New(), BucketID: bucketID, Amount: decimal.NewFromInt(10), Type: EntryTypeCredit, Layer: LayerVirtual},
+		},
+	}
+
+	err := tx.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
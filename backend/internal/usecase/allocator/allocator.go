@@ -9,22 +9,113 @@ import (
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 )
 
+// AllocationMode controls how CalculateAllocation handles FIXED items that exceed the total amount
+type AllocationMode string
+
+const (
+	// AllocationModeStrict fails when the FIXED items exceed the total (the default)
+	AllocationModeStrict AllocationMode = "STRICT"
+	// AllocationModeScaleFixed reduces FIXED items proportionally so they sum to the total,
+	// leaving zero for PERCENT (including relative PERCENT) and REMAINDER items (e.g. for small paydays)
+	AllocationModeScaleFixed AllocationMode = "SCALE_FIXED"
+)
+
+// AllocationStep describes how a single split rule item's amount was derived
+type AllocationStep struct {
+	Item             domain.SplitRuleItem
+	Type             domain.SplitRuleItemType
+	BaseAmount       decimal.Decimal // Amount the item's value was applied to (remaining balance, remainder or referenced allocation)
+	ComputedAmount   decimal.Decimal // Amount allocated to the item's target bucket
+	RunningRemainder decimal.Decimal // Total amount minus everything allocated so far (including this step)
+}
+
 // CalculateAllocation calculates the allocation of a total amount across split rule items
 // Returns a map of bucket ID to allocated amount
 // Logic:
 //  1. Sort items by Priority (Lower = First)
 //  2. Deduct FIXED amounts first
 //  3. Calculate PERCENT amounts based on the *Remainder* (Total - Fixed), NOT the original total
-//  4. Assign the final leftover amount to the REMAINDER item
+//  4. Calculate relative PERCENT amounts based on what the referenced bucket received
+//  5. Assign the final leftover amount to the REMAINDER item
+//
+// If the FIXED items exceed the total, mode decides between failing (STRICT, also used when mode is empty)
+// and scaling them down proportionally (SCALE_FIXED)
 //
 // Safety: Ensures total allocation equals total inflow exactly (no penny lost)
-func CalculateAllocation(totalAmount decimal.Decimal, items []domain.SplitRuleItem) (map[uuid.UUID]decimal.Decimal, error) {
+func CalculateAllocation(
+	totalAmount decimal.Decimal,
+	items []domain.SplitRuleItem,
+	mode AllocationMode,
+) (map[uuid.UUID]decimal.Decimal, error) {
+	return allocate(totalAmount, items, mode, nil)
+}
+
+// CalculateMoneyAllocation runs CalculateAllocation on an amount carrying a currency
+// Split rule values are currency-less, so every allocated amount is in the total's currency;
+// the allocated amounts are summed back as Money to confirm they add up to the total
+func CalculateMoneyAllocation(
+	total domain.Money,
+	items []domain.SplitRuleItem,
+	mode AllocationMode,
+) (map[uuid.UUID]domain.Money, error) {
+	amounts, err := CalculateAllocation(total.Amount, items, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	allocation := make(map[uuid.UUID]domain.Money, len(amounts))
+	allocated := domain.ZeroMoney(total.Currency)
+	for bucketID, amount := range amounts {
+		allocation[bucketID] = domain.NewMoney(amount, total.Currency)
+		if allocated, err = allocated.Add(allocation[bucketID]); err != nil {
+			return nil, err
+		}
+	}
+	if !allocated.Amount.Equal(total.Amount) {
+		return nil, errors.New("total allocation does not equal total amount")
+	}
+
+	return allocation, nil
+}
+
+// ExplainAllocation runs the same logic as CalculateAllocation and returns the ordered steps
+// that produced each amount (e.g. to show why "Missions got 95 not 100")
+func ExplainAllocation(
+	totalAmount decimal.Decimal,
+	items []domain.SplitRuleItem,
+	mode AllocationMode,
+) ([]AllocationStep, error) {
+	var steps []AllocationStep
+	_, err := allocate(totalAmount, items, mode, func(step AllocationStep) {
+		steps = append(steps, step)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return steps, nil
+}
+
+// allocate implements CalculateAllocation, calling record (if not nil) after each item is allocated
+func allocate(
+	totalAmount decimal.Decimal,
+	items []domain.SplitRuleItem,
+	mode AllocationMode,
+	record func(AllocationStep),
+) (map[uuid.UUID]decimal.Decimal, error) {
 	if totalAmount.LessThanOrEqual(decimal.Zero) {
-		return nil, errors.New("total amount must be positive")
+		return nil, domain.NewValidationError("total amount must be positive")
+	}
+
+	if mode == "" {
+		mode = AllocationModeStrict
+	}
+	if mode != AllocationModeStrict && mode != AllocationModeScaleFixed {
+		return nil, domain.NewValidationErrorf("invalid allocation mode %q", mode)
 	}
 
 	if len(items) == 0 {
-		return nil, errors.New("items list cannot be empty")
+		return nil, domain.NewValidationError("items list cannot be empty")
 	}
 
 	// Create a copy of items to avoid mutating the original slice
@@ -39,42 +130,87 @@ func CalculateAllocation(totalAmount decimal.Decimal, items []domain.SplitRuleIt
 	// Initialize allocation map
 	allocation := make(map[uuid.UUID]decimal.Decimal)
 	remaining := totalAmount
+	allocatedSoFar := decimal.Zero
+
+	// assign stores an item's amount and reports the step
+	assign := func(item domain.SplitRuleItem, baseAmount, amount decimal.Decimal) {
+		allocation[item.TargetBucketID] = amount
+		allocatedSoFar = allocatedSoFar.Add(amount)
+		if record != nil {
+			record(AllocationStep{
+				Item:             item,
+				Type:             item.Type,
+				BaseAmount:       baseAmount,
+				ComputedAmount:   amount,
+				RunningRemainder: totalAmount.Sub(allocatedSoFar),
+			})
+		}
+	}
 
 	// Step 1: Deduct FIXED amounts first
-	for _, item := range sortedItems {
+	fixedAmounts, scaled := fixedItemAmounts(sortedItems, totalAmount, mode)
+	for i, item := range sortedItems {
 		if item.Type == domain.SplitRuleItemTypeFixed {
-			if item.Value.GreaterThan(remaining) {
-				return nil, errors.New("FIXED amount exceeds remaining balance")
+			amount := fixedAmounts[i]
+			if amount.GreaterThan(remaining) {
+				return nil, domain.NewValidationError("FIXED amount exceeds remaining balance")
 			}
-			allocation[item.TargetBucketID] = item.Value
-			remaining = remaining.Sub(item.Value)
+			assign(item, remaining, amount)
+			remaining = remaining.Sub(amount)
 		}
 	}
 
 	// Step 2: Calculate PERCENT amounts based on the Remainder
-	percentTotal := decimal.Zero
 	for _, item := range sortedItems {
-		if item.Type == domain.SplitRuleItemTypePercent {
+		if item.Type == domain.SplitRuleItemTypePercent && item.RelativeToBucketID == nil {
 			// Calculate percentage of the remainder (not the original total)
 			percentAmount := remaining.Mul(item.Value).Div(decimal.NewFromInt(100))
-			allocation[item.TargetBucketID] = percentAmount
-			percentTotal = percentTotal.Add(percentAmount)
+			assign(item, remaining, percentAmount)
 		}
 	}
 
-	// Step 3: Assign the final leftover amount to the REMAINDER item
+	// Step 3: Calculate relative PERCENT amounts based on the referenced bucket's allocation
+	// An item is resolved once its reference is allocated, so chains (A -> B -> C) work regardless of priority
+	// When the FIXED items were scaled down they already use the whole total, so relative items get zero
+	pending := relativePercentItems(sortedItems)
+	for len(pending) > 0 {
+		var unresolved []domain.SplitRuleItem
+		for _, item := range pending {
+			baseAmount, ok := allocation[*item.RelativeToBucketID]
+			if !ok {
+				unresolved = append(unresolved, item)
+				continue
+			}
+			amount := baseAmount.Mul(item.Value).Div(decimal.NewFromInt(100))
+			if scaled {
+				amount = decimal.Zero
+			}
+			assign(item, baseAmount, amount)
+		}
+
+		// No progress means the references point to an unknown bucket, the REMAINDER, or each other
+		if len(unresolved) == len(pending) {
+			return nil, domain.NewValidationError("relative PERCENT items must reference an allocated bucket without cycles")
+		}
+		pending = unresolved
+	}
+
+	// Step 4: Assign the final leftover amount to the REMAINDER item
 	remainderItem := findRemainderItem(sortedItems)
 	if remainderItem == nil {
-		return nil, errors.New("no REMAINDER item found")
+		return nil, domain.NewValidationError("no REMAINDER item found")
 	}
 
 	// Calculate what's left after FIXED and PERCENT allocations
-	allocatedSoFar := decimal.Zero
+	allocatedBeforeRemainder := decimal.Zero
 	for _, amount := range allocation {
-		allocatedSoFar = allocatedSoFar.Add(amount)
+		allocatedBeforeRemainder = allocatedBeforeRemainder.Add(amount)
 	}
-	remainderAmount := totalAmount.Sub(allocatedSoFar)
-	allocation[remainderItem.TargetBucketID] = remainderAmount
+	remainderAmount := totalAmount.Sub(allocatedBeforeRemainder)
+	if remainderAmount.LessThan(decimal.Zero) {
+		return nil, domain.NewValidationError("allocated amounts exceed total amount")
+	}
+	assign(*remainderItem, remainderAmount, remainderAmount)
 
 	// Safety check: Ensure total allocation equals total inflow exactly
 	totalAllocated := decimal.Zero
@@ -89,6 +225,49 @@ func CalculateAllocation(totalAmount decimal.Decimal, items []domain.SplitRuleIt
 	return allocation, nil
 }
 
+// fixedItemAmounts returns the amount to allocate to each FIXED item, indexed like items
+// In SCALE_FIXED mode, when the FIXED values exceed the total, each is scaled by total/sum (rounded down to cents)
+// and the rounding residue goes to the last FIXED item so the scaled amounts sum to the total exactly
+// Reports whether the amounts were scaled
+func fixedItemAmounts(items []domain.SplitRuleItem, totalAmount decimal.Decimal, mode AllocationMode) ([]decimal.Decimal, bool) {
+	amounts := make([]decimal.Decimal, len(items))
+	totalFixed := decimal.Zero
+	lastFixed := -1
+	for i, item := range items {
+		if item.Type == domain.SplitRuleItemTypeFixed {
+			amounts[i] = item.Value
+			totalFixed = totalFixed.Add(item.Value)
+			lastFixed = i
+		}
+	}
+
+	if mode != AllocationModeScaleFixed || !totalFixed.GreaterThan(totalAmount) {
+		return amounts, false
+	}
+
+	scaledTotal := decimal.Zero
+	for i, item := range items {
+		if item.Type == domain.SplitRuleItemTypeFixed {
+			amounts[i] = item.Value.Mul(totalAmount).Div(totalFixed).RoundDown(2)
+			scaledTotal = scaledTotal.Add(amounts[i])
+		}
+	}
+	amounts[lastFixed] = amounts[lastFixed].Add(totalAmount.Sub(scaledTotal))
+
+	return amounts, true
+}
+
+// relativePercentItems returns the PERCENT items that are relative to another bucket, in priority order
+func relativePercentItems(items []domain.SplitRuleItem) []domain.SplitRuleItem {
+	var relative []domain.SplitRuleItem
+	for _, item := range items {
+		if item.Type == domain.SplitRuleItemTypePercent && item.RelativeToBucketID != nil {
+			relative = append(relative, item)
+		}
+	}
+	return relative
+}
+
 // findRemainderItem finds the REMAINDER item in the items slice
 func findRemainderItem(items []domain.SplitRuleItem) *domain.SplitRuleItem {
 	for i := range items {
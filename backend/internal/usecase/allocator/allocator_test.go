@@ -48,7 +48,7 @@ func TestCalculateAllocation_ChurchFootballScenario(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(1000)
-	allocation, err := CalculateAllocation(totalAmount, items)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	require.NoError(t, err)
 	require.NotNil(t, allocation)
@@ -96,7 +96,7 @@ func TestCalculateAllocation_OnlyFixed(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(500)
-	allocation, err := CalculateAllocation(totalAmount, items)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	require.NoError(t, err)
 	assert.True(t, allocation[bucket1ID].Equal(decimal.NewFromInt(100)))
@@ -134,7 +134,7 @@ func TestCalculateAllocation_OnlyPercent(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(1000)
-	allocation, err := CalculateAllocation(totalAmount, items)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	require.NoError(t, err)
 	// 30% of 1000 = 300
@@ -183,7 +183,7 @@ func TestCalculateAllocation_MixedWithPriority(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(1000)
-	allocation, err := CalculateAllocation(totalAmount, items)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	require.NoError(t, err)
 	// Fixed: 50 (priority 1) + 100 (priority 2) = 150
@@ -218,12 +218,84 @@ func TestCalculateAllocation_FixedExceedsTotal(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(500)
-	_, err := CalculateAllocation(totalAmount, items)
+	_, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "FIXED amount exceeds remaining balance")
 }
 
+func TestCalculateAllocation_FixedExceedsTotalModes(t *testing.T) {
+	rentID := uuid.New()
+	savingsID := uuid.New()
+	freeCashID := uuid.New()
+	catchAllID := uuid.New()
+
+	// FIXED = 800 + 400 = 1200 against a 1000 total
+	items := []domain.SplitRuleItem{
+		{ID: uuid.New(), TargetBucketID: rentID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(800), Priority: 1},
+		{ID: uuid.New(), TargetBucketID: savingsID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(400), Priority: 2},
+		{ID: uuid.New(), TargetBucketID: freeCashID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(50), Priority: 3},
+		{ID: uuid.New(), TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 4},
+	}
+	totalAmount := decimal.NewFromInt(1000)
+
+	t.Run("STRICT fails", func(t *testing.T) {
+		_, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "FIXED amount exceeds remaining balance")
+	})
+
+	t.Run("Empty mode defaults to STRICT", func(t *testing.T) {
+		_, err := CalculateAllocation(totalAmount, items, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "FIXED amount exceeds remaining balance")
+	})
+
+	t.Run("SCALE_FIXED scales proportionally", func(t *testing.T) {
+		allocation, err := CalculateAllocation(totalAmount, items, AllocationModeScaleFixed)
+		assert.NoError(t, err)
+
+		// 800 * 1000/1200 = 666.66 (rounded down), 400 * 1000/1200 = 333.33 + 0.01 residue
+		assert.True(t, allocation[rentID].Equal(decimal.RequireFromString("666.66")), "got %s", allocation[rentID])
+		assert.True(t, allocation[savingsID].Equal(decimal.RequireFromString("333.34")), "got %s", allocation[savingsID])
+		assert.True(t, allocation[freeCashID].IsZero(), "got %s", allocation[freeCashID])
+		assert.True(t, allocation[catchAllID].IsZero(), "got %s", allocation[catchAllID])
+	})
+
+	t.Run("SCALE_FIXED leaves affordable rules unchanged", func(t *testing.T) {
+		allocation, err := CalculateAllocation(decimal.NewFromInt(2000), items, AllocationModeScaleFixed)
+		assert.NoError(t, err)
+
+		// Same as STRICT: 800 + 400 fixed, 50% of the 800 remainder, 400 to catch-all
+		assert.True(t, allocation[rentID].Equal(decimal.NewFromInt(800)))
+		assert.True(t, allocation[savingsID].Equal(decimal.NewFromInt(400)))
+		assert.True(t, allocation[freeCashID].Equal(decimal.NewFromInt(400)))
+		assert.True(t, allocation[catchAllID].Equal(decimal.NewFromInt(400)))
+	})
+
+	t.Run("SCALE_FIXED zeroes relative items referencing a scaled FIXED item", func(t *testing.T) {
+		titheID := uuid.New()
+		withRelative := append([]domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: titheID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 5, RelativeToBucketID: &rentID},
+		}, items...)
+
+		allocation, err := CalculateAllocation(totalAmount, withRelative, AllocationModeScaleFixed)
+		assert.NoError(t, err)
+
+		// The scaled FIXED items already use the whole 1000, so nothing is left for 10% of rent
+		assert.True(t, allocation[rentID].Equal(decimal.RequireFromString("666.66")), "got %s", allocation[rentID])
+		assert.True(t, allocation[savingsID].Equal(decimal.RequireFromString("333.34")), "got %s", allocation[savingsID])
+		assert.True(t, allocation[titheID].IsZero(), "got %s", allocation[titheID])
+		assert.True(t, allocation[catchAllID].IsZero(), "got %s", allocation[catchAllID])
+	})
+
+	t.Run("Unknown mode fails", func(t *testing.T) {
+		_, err := CalculateAllocation(totalAmount, items, AllocationMode("LENIENT"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid allocation mode")
+	})
+}
+
 func TestCalculateAllocation_NoRemainderItem(t *testing.T) {
 	bucketID := uuid.New()
 
@@ -239,7 +311,7 @@ func TestCalculateAllocation_NoRemainderItem(t *testing.T) {
 	}
 
 	totalAmount := decimal.NewFromInt(500)
-	_, err := CalculateAllocation(totalAmount, items)
+	_, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no REMAINDER item found")
@@ -259,18 +331,24 @@ func TestCalculateAllocation_ZeroAmount(t *testing.T) {
 	}
 
 	totalAmount := decimal.Zero
-	_, err := CalculateAllocation(totalAmount, items)
+	_, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "total amount must be positive")
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr, "error should be a ValidationError")
 }
 
 func TestCalculateAllocation_EmptyItems(t *testing.T) {
 	totalAmount := decimal.NewFromInt(1000)
-	_, err := CalculateAllocation(totalAmount, []domain.SplitRuleItem{})
+	_, err := CalculateAllocation(totalAmount, []domain.SplitRuleItem{}, AllocationModeStrict)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "items list cannot be empty")
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr, "error should be a ValidationError")
 }
 
 func TestCalculateAllocation_DecimalPrecision(t *testing.T) {
@@ -304,7 +382,7 @@ func TestCalculateAllocation_DecimalPrecision(t *testing.T) {
 	}
 
 	totalAmount := decimal.RequireFromString("100.00")
-	allocation, err := CalculateAllocation(totalAmount, items)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
 
 	require.NoError(t, err)
 	// Fixed: 33.33
@@ -318,3 +396,182 @@ func TestCalculateAllocation_DecimalPrecision(t *testing.T) {
 	}
 	assert.True(t, totalAllocated.Equal(totalAmount), "Total allocated should equal total amount even with decimal precision")
 }
+
+func TestCalculateAllocation_RelativePercent(t *testing.T) {
+	// Input: 1000€
+	// Rule: 60% of Remainder (Salary-Net)
+	// Rule: 10% of Salary-Net (Tithe)
+	// Rule: Remainder (Catch-All)
+	// Expected: Salary-Net=600, Tithe=60, Catch-All=340
+
+	salaryNetBucketID := uuid.New()
+	titheBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	items := []domain.SplitRuleItem{
+		{
+			ID:                 uuid.New(),
+			TargetBucketID:     titheBucketID,
+			Type:               domain.SplitRuleItemTypePercent,
+			Value:              decimal.NewFromInt(10),
+			Priority:           1, // Runs before Salary-Net by priority, but must wait for it
+			RelativeToBucketID: &salaryNetBucketID,
+		},
+		{
+			ID:             uuid.New(),
+			TargetBucketID: salaryNetBucketID,
+			Type:           domain.SplitRuleItemTypePercent,
+			Value:          decimal.NewFromInt(60),
+			Priority:       2,
+		},
+		{
+			ID:             uuid.New(),
+			TargetBucketID: catchAllBucketID,
+			Type:           domain.SplitRuleItemTypeRemainder,
+			Value:          decimal.Zero,
+			Priority:       3,
+		},
+	}
+
+	totalAmount := decimal.NewFromInt(1000)
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
+
+	require.NoError(t, err)
+	assert.True(t, allocation[salaryNetBucketID].Equal(decimal.NewFromInt(600)), "Salary-Net should be 600€")
+	assert.True(t, allocation[titheBucketID].Equal(decimal.NewFromInt(60)), "Tithe should be 60€ (10% of 600)")
+	assert.True(t, allocation[catchAllBucketID].Equal(decimal.NewFromInt(340)), "Catch-All should be 340€")
+}
+
+func TestCalculateMoneyAllocation(t *testing.T) {
+	// Input: 1000 USD, Rule: 100 FIXED, Remainder
+	// Expected: both amounts in USD, summing to 1000
+	fixedBucketID := uuid.New()
+	remainderBucketID := uuid.New()
+	items := []domain.SplitRuleItem{
+		{ID: uuid.New(), TargetBucketID: fixedBucketID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(100), Priority: 1},
+		{ID: uuid.New(), TargetBucketID: remainderBucketID, Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 2},
+	}
+
+	allocation, err := CalculateMoneyAllocation(domain.NewMoney(decimal.NewFromInt(1000), "USD"), items, AllocationModeStrict)
+
+	require.NoError(t, err)
+	require.Len(t, allocation, 2)
+	assert.Equal(t, "USD", allocation[fixedBucketID].Currency)
+	assert.True(t, allocation[fixedBucketID].Amount.Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, "USD", allocation[remainderBucketID].Currency)
+	assert.True(t, allocation[remainderBucketID].Amount.Equal(decimal.NewFromInt(900)))
+
+	_, err = CalculateMoneyAllocation(domain.NewMoney(decimal.NewFromInt(50), "USD"), items, AllocationModeStrict)
+	assert.Error(t, err, "Errors of the underlying allocation are returned")
+}
+
+func TestCalculateAllocation_RelativePercentCycle(t *testing.T) {
+	bucketAID := uuid.New()
+	bucketBID := uuid.New()
+
+	items := []domain.SplitRuleItem{
+		{
+			ID:                 uuid.New(),
+			TargetBucketID:     bucketAID,
+			Type:               domain.SplitRuleItemTypePercent,
+			Value:              decimal.NewFromInt(10),
+			Priority:           1,
+			RelativeToBucketID: &bucketBID,
+		},
+		{
+			ID:                 uuid.New(),
+			TargetBucketID:     bucketBID,
+			Type:               domain.SplitRuleItemTypePercent,
+			Value:              decimal.NewFromInt(20),
+			Priority:           2,
+			RelativeToBucketID: &bucketAID,
+		},
+		{
+			ID:             uuid.New(),
+			TargetBucketID: uuid.New(),
+			Type:           domain.SplitRuleItemTypeRemainder,
+			Value:          decimal.Zero,
+			Priority:       3,
+		},
+	}
+
+	_, err := CalculateAllocation(decimal.NewFromInt(1000), items, AllocationModeStrict)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "without cycles")
+}
+
+func TestExplainAllocation_ChurchFootballScenario(t *testing.T) {
+	// Same rule as TestCalculateAllocation_ChurchFootballScenario
+	// Explains why Missions got 95€ (10% of 950) and not 100€ (10% of 1000)
+
+	coffeeBucketID := uuid.New()
+	missionsBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	items := []domain.SplitRuleItem{
+		{
+			ID:             uuid.New(),
+			TargetBucketID: catchAllBucketID,
+			Type:           domain.SplitRuleItemTypeRemainder,
+			Value:          decimal.Zero,
+			Priority:       3,
+		},
+		{
+			ID:             uuid.New(),
+			TargetBucketID: missionsBucketID,
+			Type:           domain.SplitRuleItemTypePercent,
+			Value:          decimal.NewFromInt(10),
+			Priority:       2,
+		},
+		{
+			ID:             uuid.New(),
+			TargetBucketID: coffeeBucketID,
+			Type:           domain.SplitRuleItemTypeFixed,
+			Value:          decimal.NewFromInt(50),
+			Priority:       1,
+		},
+	}
+
+	totalAmount := decimal.NewFromInt(1000)
+	steps, err := ExplainAllocation(totalAmount, items, AllocationModeStrict)
+
+	require.NoError(t, err)
+	require.Len(t, steps, 3)
+
+	expected := []struct {
+		bucketID  uuid.UUID
+		itemType  domain.SplitRuleItemType
+		base      int64
+		computed  int64
+		remainder int64
+	}{
+		{coffeeBucketID, domain.SplitRuleItemTypeFixed, 1000, 50, 950},
+		{missionsBucketID, domain.SplitRuleItemTypePercent, 950, 95, 855},
+		{catchAllBucketID, domain.SplitRuleItemTypeRemainder, 855, 855, 0},
+	}
+
+	for i, want := range expected {
+		step := steps[i]
+		assert.Equal(t, want.bucketID, step.Item.TargetBucketID, "step %d target", i)
+		assert.Equal(t, want.itemType, step.Type, "step %d type", i)
+		assert.True(t, step.BaseAmount.Equal(decimal.NewFromInt(want.base)), "step %d base: got %s", i, step.BaseAmount)
+		assert.True(t, step.ComputedAmount.Equal(decimal.NewFromInt(want.computed)), "step %d computed: got %s", i, step.ComputedAmount)
+		assert.True(t, step.RunningRemainder.Equal(decimal.NewFromInt(want.remainder)), "step %d remainder: got %s", i, step.RunningRemainder)
+	}
+
+	// Explain must agree with CalculateAllocation
+	allocation, err := CalculateAllocation(totalAmount, items, AllocationModeStrict)
+	require.NoError(t, err)
+	for _, step := range steps {
+		assert.True(t, allocation[step.Item.TargetBucketID].Equal(step.ComputedAmount))
+	}
+}
+
+func TestExplainAllocation_Error(t *testing.T) {
+	steps, err := ExplainAllocation(decimal.NewFromInt(1000), []domain.SplitRuleItem{}, AllocationModeStrict)
+
+	assert.Error(t, err)
+	assert.Nil(t, steps)
+	assert.Contains(t, err.Error(), "items list cannot be empty")
+}
@@ -0,0 +1,750 @@
+package bucket_manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
+)
+
+// ReparentResult represents the outcome of moving a virtual bucket to a new physical parent
+type ReparentResult struct {
+	Bucket       *domain.Bucket
+	Transaction  *domain.Transaction  // nil if the bucket had no balance to move
+	TransferTask *domain.TransferTask // nil if the bucket had no balance to move
+}
+
+// SetOpeningBalanceInput represents the input for setting a bucket's opening balance
+type SetOpeningBalanceInput struct {
+	BucketID        uuid.UUID       // Physical bucket to initialize
+	Amount          decimal.Decimal // Desired starting balance (negative for e.g. credit card debt)
+	VirtualBucketID *uuid.UUID      // Optional: virtual child of BucketID that receives the same balance
+}
+
+// CreateEquityBucketInput represents the input for creating an equity bucket with an initial book value
+type CreateEquityBucketInput struct {
+	Name        string
+	BookValue   decimal.Decimal  // What was paid for the holding; becomes the bucket's current_balance
+	MarketValue *decimal.Decimal // Optional: initial market value, recorded in market_value_history
+}
+
+// CreateEquityBucketResult represents the outcome of creating an equity bucket
+type CreateEquityBucketResult struct {
+	Bucket      *domain.Bucket
+	Transaction *domain.Transaction        // Transaction booking the initial book value
+	MarketValue *domain.MarketValueHistory // nil unless an initial market value was given
+}
+
+// NewBucketInput describes one bucket of a CreateBuckets batch
+type NewBucketInput struct {
+	Key        string // Client-supplied key other items of the batch use as ParentKey; optional, unique within the batch
+	Name       string
+	BucketType domain.BucketType // PHYSICAL, VIRTUAL, INCOME or EXPENSE (equity buckets need a book value, see CreateEquityBucket)
+	// Virtual buckets set exactly one of ParentKey (a physical bucket of the same batch) or
+	// ParentID (an existing physical bucket); other types set neither
+	ParentKey string
+	ParentID  *uuid.UUID
+}
+
+// MoveBetweenVirtualBucketsInput represents the input for moving money between two virtual buckets of the same bank
+type MoveBetweenVirtualBucketsInput struct {
+	SourceBucketID      uuid.UUID
+	DestinationBucketID uuid.UUID
+	Amount              decimal.Decimal
+	Description         string // Optional; defaults to "Move from <source> to <destination>"
+
+	// RequireSufficientFunds rejects the move with domain.ErrInsufficientFunds if the source balance is below Amount
+	// (off by default, so envelopes may go negative)
+	RequireSufficientFunds bool
+}
+
+// BucketService handles bucket management operations
+type BucketService struct {
+	BucketRepo       domain.BucketRepository
+	TransactionRepo  domain.TransactionRepository
+	TransferTaskRepo domain.TransferTaskRepository
+	UnitOfWork       domain.UnitOfWork
+}
+
+// NewBucketService creates a new BucketService instance
+func NewBucketService(
+	bucketRepo domain.BucketRepository,
+	transactionRepo domain.TransactionRepository,
+	transferTaskRepo domain.TransferTaskRepository,
+	unitOfWork domain.UnitOfWork,
+) *BucketService {
+	return &BucketService{
+		BucketRepo:       bucketRepo,
+		TransactionRepo:  transactionRepo,
+		TransferTaskRepo: transferTaskRepo,
+		UnitOfWork:       unitOfWork,
+	}
+}
+
+// ReparentVirtualBucket moves a virtual bucket to a different physical parent (e.g. when switching banks)
+// Logic:
+//  1. Fetch and validate: the bucket must be virtual, the old and new parents must be physical
+//  2. If the bucket holds money, create a balancing transaction so physical balances stay consistent:
+//     - Physical Layer: Credit Old Parent, Debit New Parent (for the bucket's balance)
+//  3. Update the bucket's parent_physical_bucket_id
+//  4. Create a TransferTask for the real-world move (Old Parent -> New Parent)
+func (s *BucketService) ReparentVirtualBucket(ctx context.Context, bucketID, newParentID uuid.UUID) (*ReparentResult, error) {
+	if newParentID == bucketID {
+		return nil, domain.NewValidationError("bucket cannot be its own parent")
+	}
+
+	// The balancing transaction, the re-parent and the transfer task are written atomically,
+	// so money never moves without the bucket following it (or the other way around)
+	var result *ReparentResult
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 1. Fetch and validate
+		bucket, err := repos.Buckets.GetByID(ctx, bucketID)
+		if err != nil {
+			return err
+		}
+		if bucket.BucketType != domain.BucketTypeVirtual {
+			return domain.NewValidationError("bucket ID must reference a virtual bucket")
+		}
+		if bucket.ParentPhysicalBucketID == nil {
+			return domain.NewValidationError("virtual bucket must have a parent physical bucket ID")
+		}
+		oldParentID := *bucket.ParentPhysicalBucketID
+		if oldParentID == newParentID {
+			return domain.NewValidationError("new parent must be different from the current parent")
+		}
+
+		oldParent, err := repos.Buckets.GetByID(ctx, oldParentID)
+		if err != nil {
+			return err
+		}
+		if oldParent.BucketType != domain.BucketTypePhysical {
+			return domain.NewValidationError("current parent must reference a physical bucket")
+		}
+
+		newParent, err := repos.Buckets.GetByID(ctx, newParentID)
+		if err != nil {
+			return err
+		}
+		if err := bucket.ValidateParent(newParent); err != nil {
+			return err
+		}
+
+		reparented := &ReparentResult{Bucket: bucket}
+
+		// 2. Balancing transaction (only if there is money to move)
+		// A negative balance moves in the opposite direction
+		fromID, toID := oldParentID, newParentID
+		if bucket.CurrentBalance.LessThan(decimal.Zero) {
+			fromID, toID = newParentID, oldParentID
+		}
+		amount := bucket.CurrentBalance.Abs()
+
+		if amount.GreaterThan(decimal.Zero) {
+			txID := uuid.New()
+			tx := &domain.Transaction{
+				ID:                 txID,
+				Description:        "Move " + bucket.Name + " from " + oldParent.Name + " to " + newParent.Name,
+				Date:               time.Now(),
+				IsInternalTransfer: true,
+				IsExternalInflow:   false,
+				Entries: []domain.TransactionEntry{
+					{
+						ID:            uuid.New(),
+						TransactionID: txID,
+						BucketID:      fromID,
+						Amount:        amount,
+						Type:          domain.EntryTypeCredit,
+						Layer:         domain.LayerPhysical,
+					},
+					{
+						ID:            uuid.New(),
+						TransactionID: txID,
+						BucketID:      toID,
+						Amount:        amount,
+						Type:          domain.EntryTypeDebit,
+						Layer:         domain.LayerPhysical,
+					},
+				},
+			}
+
+			if err := tx.Validate(); err != nil {
+				return err
+			}
+			if err := repos.Transactions.Create(ctx, tx); err != nil {
+				return err
+			}
+			reparented.Transaction = tx
+		}
+
+		// 3. Update parent
+		if err := repos.Buckets.UpdateParent(ctx, bucketID, newParentID); err != nil {
+			return err
+		}
+		bucket.ParentPhysicalBucketID = &newParentID
+
+		// 4. Transfer task for the real-world move
+		if reparented.Transaction != nil {
+			task := &domain.TransferTask{
+				ID:                   uuid.New(),
+				RelatedTransactionID: reparented.Transaction.ID,
+				FromPhysicalBucketID: fromID,
+				ToPhysicalBucketID:   toID,
+				Amount:               amount,
+				IsCompleted:          false,
+				Description:          reparented.Transaction.Description,
+				CreatedAt:            time.Now(),
+			}
+			if err := repos.TransferTasks.Create(ctx, task); err != nil {
+				return err
+			}
+			reparented.TransferTask = task
+		}
+
+		result = reparented
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetOpeningBalance initializes a physical bucket's balance when onboarding an existing account
+// The balancing side is the SYS_EXTRA_INCOME system bucket, so double-entry integrity holds
+// without inventing an income transaction. Only allowed on buckets without any transactions.
+// Logic:
+//  1. Fetch and validate: the bucket must be physical, the optional virtual bucket must be its child
+//  2. Reject buckets that already have transaction entries (opening balance can only be set once)
+//  3. Create Transaction (directions are swapped for a negative amount):
+//     - Physical Layer: Debit Physical Bucket, Credit SYS_EXTRA_INCOME
+//     - Virtual Layer (only if a virtual bucket is given): Debit Virtual Bucket, Credit SYS_EXTRA_INCOME
+//
+// Steps 1-3 run in one unit of work with the physical bucket locked, so two concurrent calls cannot both set it
+func (s *BucketService) SetOpeningBalance(ctx context.Context, input SetOpeningBalanceInput) (*domain.Transaction, error) {
+	if input.Amount.IsZero() {
+		return nil, domain.NewValidationError("opening balance amount must not be zero")
+	}
+
+	var tx *domain.Transaction
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 1. Fetch and validate; the physical bucket is locked so concurrent calls cannot both pass step 2
+		bucket, err := repos.Buckets.GetByIDForUpdate(ctx, input.BucketID)
+		if err != nil {
+			return err
+		}
+		if bucket.BucketType != domain.BucketTypePhysical {
+			return domain.NewValidationError("bucket ID must reference a physical bucket")
+		}
+
+		bucketIDs := []uuid.UUID{input.BucketID}
+		if input.VirtualBucketID != nil {
+			virtualBucket, err := repos.Buckets.GetByID(ctx, *input.VirtualBucketID)
+			if err != nil {
+				return err
+			}
+			if virtualBucket.BucketType != domain.BucketTypeVirtual {
+				return domain.NewValidationError("virtual bucket ID must reference a virtual bucket")
+			}
+			if virtualBucket.ParentPhysicalBucketID == nil || *virtualBucket.ParentPhysicalBucketID != input.BucketID {
+				return domain.NewValidationError("virtual bucket must be a child of the physical bucket")
+			}
+			bucketIDs = append(bucketIDs, *input.VirtualBucketID)
+		}
+
+		// 2. Opening balance can only be set once
+		count, err := repos.Transactions.CountForBuckets(ctx, bucketIDs)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.NewValidationError("opening balance can only be set on buckets without transactions")
+		}
+
+		// 3. Create Transaction
+		tx = openingBalanceTransaction(bucket, input)
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		return repos.Transactions.Create(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// openingBalanceTransaction builds the transaction setting bucket's opening balance (see SetOpeningBalance)
+func openingBalanceTransaction(bucket *domain.Bucket, input SetOpeningBalanceInput) *domain.Transaction {
+	bucketEntryType, systemEntryType := domain.EntryTypeDebit, domain.EntryTypeCredit
+	if input.Amount.LessThan(decimal.Zero) {
+		bucketEntryType, systemEntryType = domain.EntryTypeCredit, domain.EntryTypeDebit
+	}
+	amount := input.Amount.Abs()
+
+	txID := uuid.New()
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        "Opening balance for " + bucket.Name,
+		Date:               time.Now(),
+		IsInternalTransfer: false,
+		IsExternalInflow:   false,
+		Entries: []domain.TransactionEntry{
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      input.BucketID,
+				Amount:        amount,
+				Type:          bucketEntryType,
+				Layer:         domain.LayerPhysical,
+			},
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      seeder.SYS_EXTRA_INCOME,
+				Amount:        amount,
+				Type:          systemEntryType,
+				Layer:         domain.LayerPhysical,
+			},
+		},
+	}
+
+	if input.VirtualBucketID != nil {
+		tx.Entries = append(tx.Entries,
+			domain.TransactionEntry{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      *input.VirtualBucketID,
+				Amount:        amount,
+				Type:          bucketEntryType,
+				Layer:         domain.LayerVirtual,
+			},
+			domain.TransactionEntry{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      seeder.SYS_EXTRA_INCOME,
+				Amount:        amount,
+				Type:          systemEntryType,
+				Layer:         domain.LayerVirtual,
+			},
+		)
+	}
+
+	return tx
+}
+
+// MergeCategoryBuckets folds a redundant expense category into another (e.g. "Food" into "Groceries")
+// Logic:
+//  1. Validate: source and target must be distinct, non-archived EXPENSE buckets
+//  2. Atomically re-point all of the source's transaction entries to the target,
+//     move its balance onto the target and archive the source
+//
+// Returns the number of re-pointed entries.
+func (s *BucketService) MergeCategoryBuckets(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	if sourceID == targetID {
+		return 0, domain.NewValidationError("cannot merge a bucket into itself")
+	}
+
+	for _, id := range []uuid.UUID{sourceID, targetID} {
+		bucket, err := s.BucketRepo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if err := bucket.ValidateRole(domain.BucketRoleExpenseCategory); err != nil {
+			return 0, err
+		}
+		if bucket.IsArchived {
+			return 0, domain.NewValidationErrorf("bucket %s is archived", bucket.ID)
+		}
+	}
+
+	return s.BucketRepo.MergeInto(ctx, sourceID, targetID)
+}
+
+// SetBucketGoal sets the target amount of a bucket (e.g. a savings bucket), or clears it if goal is nil
+// Returns the updated bucket so the caller can show the progress right away
+func (s *BucketService) SetBucketGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) (*domain.Bucket, error) {
+	if goal != nil {
+		if err := domain.ValidateGoal(*goal); err != nil {
+			return nil, err
+		}
+	}
+
+	bucket, err := s.BucketRepo.GetByID(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.BucketRepo.UpdateGoal(ctx, bucketID, goal); err != nil {
+		return nil, err
+	}
+	bucket.GoalAmount = goal
+
+	return bucket, nil
+}
+
+// CompleteTransferTask records the real-world move of a transfer task and marks the task completed
+// Both writes happen in one unit of work, so a task is never completed without its transaction (or vice versa)
+// Logic:
+//  1. Load the task (locked for the rest of the unit of work) and reject completed tasks
+//  2. Create the internal transfer: Physical Layer: Credit From Bucket, Debit To Bucket
+//  3. Mark the task completed, referencing the new transaction
+func (s *BucketService) CompleteTransferTask(ctx context.Context, taskID uuid.UUID) (*domain.TransferTask, error) {
+	var completed *domain.TransferTask
+
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 1. Load and validate
+		task, err := repos.TransferTasks.GetByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if task.IsCompleted {
+			return fmt.Errorf("%w: %s", domain.ErrTransferTaskAlreadyCompleted, taskID)
+		}
+
+		// 2. Physical move
+		description := "Completed transfer"
+		if task.Description != "" {
+			description += ": " + task.Description
+		}
+		txID := uuid.New()
+		tx := &domain.Transaction{
+			ID:                 txID,
+			Description:        description,
+			Date:               time.Now(),
+			IsInternalTransfer: true,
+			IsExternalInflow:   false,
+			Entries: []domain.TransactionEntry{
+				{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      task.FromPhysicalBucketID,
+					Amount:        task.Amount,
+					Type:          domain.EntryTypeCredit,
+					Layer:         domain.LayerPhysical,
+				},
+				{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      task.ToPhysicalBucketID,
+					Amount:        task.Amount,
+					Type:          domain.EntryTypeDebit,
+					Layer:         domain.LayerPhysical,
+				},
+			},
+		}
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		if err := repos.Transactions.Create(ctx, tx); err != nil {
+			return err
+		}
+
+		// 3. Mark completed
+		if err := repos.TransferTasks.MarkCompleted(ctx, task.ID, txID); err != nil {
+			return err
+		}
+		task.IsCompleted = true
+		task.CompletedTransactionID = &txID
+		completed = task
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return completed, nil
+}
+
+// ListOverdueTransferTasks returns the pending transfer tasks created more than days days ago (oldest first)
+// so money does not sit un-transferred between banks
+func (s *BucketService) ListOverdueTransferTasks(ctx context.Context, days int) ([]domain.TransferTask, error) {
+	if days < 0 {
+		return nil, domain.NewValidationError("days must be non-negative")
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -days)
+	return s.TransferTaskRepo.ListPendingCreatedBefore(ctx, cutoff)
+}
+
+// CreateEquityBucket creates an EQUITY bucket and records its initial book value (atomically)
+// Logic:
+//  1. Validate the name and that the book value (and the optional market value) is positive
+//  2. Create the bucket with a zero balance
+//  3. Book the initial value against the system extra income bucket:
+//     - Physical Layer: Debit Equity Bucket, Credit SYS_EXTRA_INCOME (for BookValue)
+//  4. Record the initial market value, if given
+//
+// The balance trigger raises current_balance to BookValue; the returned bucket reflects that
+func (s *BucketService) CreateEquityBucket(ctx context.Context, input CreateEquityBucketInput) (*CreateEquityBucketResult, error) {
+	// 1. Validate
+	if !input.BookValue.IsPositive() {
+		return nil, domain.NewValidationError("book value must be positive")
+	}
+	if input.MarketValue != nil && !input.MarketValue.IsPositive() {
+		return nil, domain.NewValidationError("market value must be positive")
+	}
+	bucket := &domain.Bucket{
+		ID:             uuid.New(),
+		Name:           input.Name,
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}
+	if err := bucket.Validate(); err != nil {
+		return nil, err
+	}
+
+	txID := uuid.New()
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        "Initial book value for " + bucket.Name,
+		Date:               time.Now(),
+		IsInternalTransfer: false,
+		IsExternalInflow:   false,
+		Entries: []domain.TransactionEntry{
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      bucket.ID,
+				Amount:        input.BookValue,
+				Type:          domain.EntryTypeDebit,
+				Layer:         domain.LayerPhysical,
+			},
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      seeder.SYS_EXTRA_INCOME,
+				Amount:        input.BookValue,
+				Type:          domain.EntryTypeCredit,
+				Layer:         domain.LayerPhysical,
+			},
+		},
+	}
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &CreateEquityBucketResult{Bucket: bucket, Transaction: tx}
+	if input.MarketValue != nil {
+		result.MarketValue = &domain.MarketValueHistory{
+			ID:          uuid.New(),
+			BucketID:    bucket.ID,
+			Date:        time.Now(),
+			MarketValue: *input.MarketValue,
+		}
+	}
+
+	// 2-4. Create the bucket, its initial book value and market value together
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		if err := repos.Buckets.Create(ctx, bucket); err != nil {
+			return err
+		}
+		if err := repos.Transactions.Create(ctx, tx); err != nil {
+			return err
+		}
+		if result.MarketValue != nil {
+			return repos.MarketValues.Add(ctx, result.MarketValue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	bucket.CurrentBalance = input.BookValue
+
+	return result, nil
+}
+
+// MoveBetweenVirtualBuckets moves money between two virtual buckets held in the same physical bucket
+// (e.g. from Free Cash to Vacation). No money leaves the bank, so only the virtual layer changes and no
+// transfer task is needed; moves across banks are rejected here
+// Logic:
+//  1. Validate the amount and that source and destination differ
+//  2. Fetch both buckets: both must be virtual and share the same physical parent
+//     If RequireSufficientFunds is set, the source balance must cover the amount
+//  3. Create Transaction (IsInternalTransfer):
+//     - Virtual Layer: Credit Source, Debit Destination
+//
+// Steps 2 and 3 run in one unit of work with the source bucket locked, so concurrent moves cannot
+// both pass the funds check on the same balance
+func (s *BucketService) MoveBetweenVirtualBuckets(ctx context.Context, input MoveBetweenVirtualBucketsInput) (*domain.Transaction, error) {
+	// 1. Validate input
+	if !input.Amount.IsPositive() {
+		return nil, domain.NewValidationError("move amount must be positive")
+	}
+	if input.SourceBucketID == input.DestinationBucketID {
+		return nil, domain.NewValidationError("source and destination buckets must differ")
+	}
+
+	var tx *domain.Transaction
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 2. Fetch and validate both buckets
+		source, err := repos.Buckets.GetByIDForUpdate(ctx, input.SourceBucketID)
+		if err != nil {
+			return err
+		}
+		destination, err := repos.Buckets.GetByID(ctx, input.DestinationBucketID)
+		if err != nil {
+			return err
+		}
+		if source.BucketType != domain.BucketTypeVirtual {
+			return domain.NewValidationError("source bucket ID must reference a virtual bucket")
+		}
+		if destination.BucketType != domain.BucketTypeVirtual {
+			return domain.NewValidationError("destination bucket ID must reference a virtual bucket")
+		}
+		if source.ParentPhysicalBucketID == nil || destination.ParentPhysicalBucketID == nil ||
+			*source.ParentPhysicalBucketID != *destination.ParentPhysicalBucketID {
+			return domain.NewValidationError("source and destination buckets must be held in the same physical bucket")
+		}
+		if input.RequireSufficientFunds && source.CurrentBalance.LessThan(input.Amount) {
+			return fmt.Errorf("%w: %s holds %s, cannot move %s", domain.ErrInsufficientFunds, source.Name, source.CurrentBalance, input.Amount)
+		}
+
+		// 3. Create Transaction: Virtual Layer only
+		tx = virtualMoveTransaction(source, destination, input)
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		return repos.Transactions.Create(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// virtualMoveTransaction builds the virtual-layer transaction of a move (see MoveBetweenVirtualBuckets)
+func virtualMoveTransaction(source, destination *domain.Bucket, input MoveBetweenVirtualBucketsInput) *domain.Transaction {
+	description := strings.TrimSpace(input.Description)
+	if description == "" {
+		description = fmt.Sprintf("Move from %s to %s", source.Name, destination.Name)
+	}
+
+	txID := uuid.New()
+	return &domain.Transaction{
+		ID:                 txID,
+		Description:        description,
+		Date:               time.Now(),
+		IsInternalTransfer: true,
+		IsExternalInflow:   false,
+		Entries: []domain.TransactionEntry{
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      source.ID,
+				Amount:        input.Amount,
+				Type:          domain.EntryTypeCredit,
+				Layer:         domain.LayerVirtual,
+			},
+			{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      destination.ID,
+				Amount:        input.Amount,
+				Type:          domain.EntryTypeDebit,
+				Layer:         domain.LayerVirtual,
+			},
+		},
+	}
+}
+
+// CreateBuckets creates a batch of buckets atomically (e.g. a new user's bank accounts and their envelopes)
+// Logic:
+//  1. Validate the whole batch before writing: types, unique keys, names, and that every virtual bucket has
+//     exactly one parent which is a physical bucket of the batch (by key) or an existing physical bucket
+//  2. Create the buckets inside one unit of work, parents before children
+//
+// Returns the created buckets in input order with their server-assigned IDs
+func (s *BucketService) CreateBuckets(ctx context.Context, inputs []NewBucketInput) ([]*domain.Bucket, error) {
+	if len(inputs) == 0 {
+		return nil, domain.NewValidationError("at least one bucket is required")
+	}
+
+	// 1a. Assign IDs and index the batch by key
+	buckets := make([]*domain.Bucket, len(inputs))
+	byKey := make(map[string]*domain.Bucket, len(inputs))
+	for i, input := range inputs {
+		switch input.BucketType {
+		case domain.BucketTypePhysical, domain.BucketTypeVirtual, domain.BucketTypeIncome, domain.BucketTypeExpense:
+		default:
+			return nil, domain.NewValidationErrorf("bucket %d: bucket type must be PHYSICAL, VIRTUAL, INCOME or EXPENSE (got %q)", i, input.BucketType)
+		}
+
+		buckets[i] = &domain.Bucket{
+			ID:             uuid.New(),
+			Name:           input.Name,
+			BucketType:     input.BucketType,
+			CurrentBalance: decimal.Zero,
+		}
+
+		if input.Key == "" {
+			continue
+		}
+		if _, ok := byKey[input.Key]; ok {
+			return nil, domain.NewValidationErrorf("bucket %d: duplicate key %q", i, input.Key)
+		}
+		byKey[input.Key] = buckets[i]
+	}
+
+	// 1b. Resolve parents and validate every bucket
+	for i, input := range inputs {
+		bucket := buckets[i]
+		if input.ParentKey != "" && input.ParentID != nil {
+			return nil, domain.NewValidationErrorf("bucket %d: set either a parent key or a parent ID, not both", i)
+		}
+
+		var parent *domain.Bucket
+		switch {
+		case input.ParentKey != "":
+			var ok bool
+			if parent, ok = byKey[input.ParentKey]; !ok {
+				return nil, domain.NewValidationErrorf("bucket %d: parent key %q does not match any bucket in the batch", i, input.ParentKey)
+			}
+		case input.ParentID != nil:
+			existing, err := s.BucketRepo.GetByID(ctx, *input.ParentID)
+			if err != nil {
+				return nil, err
+			}
+			parent = existing
+		}
+		if parent != nil {
+			if err := bucket.ValidateParent(parent); err != nil {
+				return nil, domain.NewValidationErrorf("bucket %d: %v", i, err)
+			}
+			bucket.ParentPhysicalBucketID = &parent.ID
+		}
+
+		if err := bucket.Validate(); err != nil {
+			return nil, domain.NewValidationErrorf("bucket %d: %v", i, err)
+		}
+	}
+
+	// 2. Create parents first so the foreign keys resolve within the transaction
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		for _, virtual := range []bool{false, true} {
+			for _, bucket := range buckets {
+				if (bucket.BucketType == domain.BucketTypeVirtual) != virtual {
+					continue
+				}
+				if err := repos.Buckets.Create(ctx, bucket); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,1280 @@
+package bucket_manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBucketRepository is a mock implementation of BucketRepository for testing
+type MockBucketRepository struct {
+	mock.Mock
+}
+
+func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
+	args := m.Called(ctx, bucket)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) GetSystemBucket(ctx context.Context, bucketType domain.BucketType) (*domain.Bucket, error) {
+	args := m.Called(ctx, bucketType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.BucketType) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, typeFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+// MockTransactionRepository is a mock implementation of TransactionRepository for testing
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+// MockTransferTaskRepository is a mock implementation of TransferTaskRepository for testing
+type MockTransferTaskRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferTaskRepository) Create(ctx context.Context, task *domain.TransferTask) error {
+	args := m.Called(ctx, task)
+	return args.Error(0)
+}
+
+func (m *MockTransferTaskRepository) List(ctx context.Context, includeCompleted bool) ([]domain.TransferTask, error) {
+	args := m.Called(ctx, includeCompleted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TransferTask), args.Error(1)
+}
+
+func (m *MockTransferTaskRepository) ListPendingCreatedBefore(ctx context.Context, before time.Time) ([]domain.TransferTask, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TransferTask), args.Error(1)
+}
+
+func (m *MockTransferTaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferTask, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransferTask), args.Error(1)
+}
+
+func (m *MockTransferTaskRepository) MarkCompleted(ctx context.Context, id, completedTransactionID uuid.UUID) error {
+	args := m.Called(ctx, id, completedTransactionID)
+	return args.Error(0)
+}
+
+// MockMarketValueRepository is a mock implementation of MarketValueRepository for testing
+type MockMarketValueRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketValueRepository) Add(ctx context.Context, entry *domain.MarketValueHistory) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockMarketValueRepository) GetLatest(ctx context.Context, bucketID uuid.UUID) (*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) GetLatestForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListByBucket(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListForBuckets(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) List(ctx context.Context, bucketID uuid.UUID, limit, offset int, ascending bool) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, limit, offset, ascending)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) Count(ctx context.Context, bucketID uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockUnitOfWork runs the function directly against the given (mock) repositories
+type MockUnitOfWork struct {
+	Repos domain.Repositories
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(repos domain.Repositories) error) error {
+	return fn(m.Repos)
+}
+
+func TestReparentVirtualBucket_MovesBalance(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockTaskRepo := new(MockTransferTaskRepository)
+	// All reads and writes go through the unit of work, so a failed step rolls back the earlier ones
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	oldBankID := uuid.New()
+	newBankID := uuid.New()
+	virtualID := uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Holidays",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &oldBankID,
+		CurrentBalance:         decimal.NewFromInt(300),
+	}, nil)
+	mockBucketRepo.On("GetByID", ctx, oldBankID).Return(&domain.Bucket{ID: oldBankID, Name: "Old Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, newBankID).Return(&domain.Bucket{ID: newBankID, Name: "New Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("UpdateParent", ctx, virtualID, newBankID).Return(nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+	mockTaskRepo.On("Create", ctx, mock.AnythingOfType("*domain.TransferTask")).Return(nil)
+
+	result, err := service.ReparentVirtualBucket(ctx, virtualID, newBankID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, newBankID, *result.Bucket.ParentPhysicalBucketID)
+
+	// Physical Layer: Credit Old Bank, Debit New Bank for the bucket's balance
+	tx := result.Transaction
+	assert.NotNil(t, tx)
+	assert.Len(t, tx.Entries, 2)
+	for _, entry := range tx.Entries {
+		assert.Equal(t, domain.LayerPhysical, entry.Layer)
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(300)))
+		if entry.Type == domain.EntryTypeCredit {
+			assert.Equal(t, oldBankID, entry.BucketID)
+		} else {
+			assert.Equal(t, newBankID, entry.BucketID)
+		}
+	}
+
+	// Transfer task for the real-world move
+	task := result.TransferTask
+	assert.NotNil(t, task)
+	assert.Equal(t, oldBankID, task.FromPhysicalBucketID)
+	assert.Equal(t, newBankID, task.ToPhysicalBucketID)
+	assert.Equal(t, tx.ID, task.RelatedTransactionID)
+	assert.True(t, task.Amount.Equal(decimal.NewFromInt(300)))
+	assert.False(t, task.IsCompleted)
+	assert.Equal(t, tx.Description, task.Description)
+
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestReparentVirtualBucket_EmptyBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockTaskRepo := new(MockTransferTaskRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	oldBankID := uuid.New()
+	newBankID := uuid.New()
+	virtualID := uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Holidays",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &oldBankID,
+		CurrentBalance:         decimal.Zero,
+	}, nil)
+	mockBucketRepo.On("GetByID", ctx, oldBankID).Return(&domain.Bucket{ID: oldBankID, Name: "Old Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, newBankID).Return(&domain.Bucket{ID: newBankID, Name: "New Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("UpdateParent", ctx, virtualID, newBankID).Return(nil)
+
+	result, err := service.ReparentVirtualBucket(ctx, virtualID, newBankID)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Transaction)
+	assert.Nil(t, result.TransferTask)
+	mockTxRepo.AssertNotCalled(t, "Create")
+	mockTaskRepo.AssertNotCalled(t, "Create")
+}
+
+func TestReparentVirtualBucket_TransferTaskFails(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockTaskRepo := new(MockTransferTaskRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	oldBankID, newBankID, virtualID := uuid.New(), uuid.New(), uuid.New()
+	mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Holidays",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &oldBankID,
+		CurrentBalance:         decimal.NewFromInt(300),
+	}, nil)
+	mockBucketRepo.On("GetByID", ctx, oldBankID).Return(&domain.Bucket{ID: oldBankID, Name: "Old Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, newBankID).Return(&domain.Bucket{ID: newBankID, Name: "New Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("UpdateParent", ctx, virtualID, newBankID).Return(nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+	mockTaskRepo.On("Create", ctx, mock.AnythingOfType("*domain.TransferTask")).Return(errors.New("connection reset"))
+
+	// The error is returned from the unit of work, which rolls back the transaction and the re-parent
+	result, err := service.ReparentVirtualBucket(ctx, virtualID, newBankID)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestReparentVirtualBucket_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	oldBankID := uuid.New()
+	virtualID := uuid.New()
+	otherVirtualID := uuid.New()
+	physicalID := uuid.New()
+
+	virtualBucket := &domain.Bucket{ID: virtualID, Name: "Holidays", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &oldBankID}
+	otherVirtual := &domain.Bucket{ID: otherVirtualID, Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &oldBankID}
+	physicalBucket := &domain.Bucket{ID: physicalID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}
+	oldBank := &domain.Bucket{ID: oldBankID, Name: "Old Bank", BucketType: domain.BucketTypePhysical}
+
+	tests := []struct {
+		name        string
+		bucketID    uuid.UUID
+		newParentID uuid.UUID
+		errMsg      string
+	}{
+		{
+			name:        "Bucket is not virtual",
+			bucketID:    physicalID,
+			newParentID: oldBankID,
+			errMsg:      "must reference a virtual bucket",
+		},
+		{
+			name:        "New parent is not physical",
+			bucketID:    virtualID,
+			newParentID: otherVirtualID,
+			errMsg:      "parent bucket must be a physical bucket (got VIRTUAL bucket",
+		},
+		{
+			name:        "New parent is the bucket itself",
+			bucketID:    virtualID,
+			newParentID: virtualID,
+			errMsg:      "bucket cannot be its own parent",
+		},
+		{
+			name:        "New parent is the current parent",
+			bucketID:    virtualID,
+			newParentID: oldBankID,
+			errMsg:      "must be different from the current parent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			mockTaskRepo := new(MockTransferTaskRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+			service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			mockBucketRepo.On("GetByID", ctx, virtualID).Return(virtualBucket, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, otherVirtualID).Return(otherVirtual, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, physicalID).Return(physicalBucket, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, oldBankID).Return(oldBank, nil).Maybe()
+
+			result, err := service.ReparentVirtualBucket(ctx, tt.bucketID, tt.newParentID)
+
+			assert.Nil(t, result)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			mockBucketRepo.AssertNotCalled(t, "UpdateParent")
+			mockTxRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestSetOpeningBalance_WithVirtualChild(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	bankID := uuid.New()
+	virtualID := uuid.New()
+
+	mockBucketRepo.On("GetByIDForUpdate", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &bankID,
+	}, nil)
+	mockTxRepo.On("CountForBuckets", ctx, []uuid.UUID{bankID, virtualID}).Return(0, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	tx, err := service.SetOpeningBalance(ctx, SetOpeningBalanceInput{
+		BucketID:        bankID,
+		Amount:          decimal.NewFromInt(2500),
+		VirtualBucketID: &virtualID,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, tx.Entries, 4)
+
+	// Both layers: Debit the initialized bucket, Credit SYS_EXTRA_INCOME
+	for _, entry := range tx.Entries {
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(2500)))
+		if entry.Type == domain.EntryTypeCredit {
+			assert.Equal(t, seeder.SYS_EXTRA_INCOME, entry.BucketID)
+		} else if entry.Layer == domain.LayerPhysical {
+			assert.Equal(t, bankID, entry.BucketID)
+		} else {
+			assert.Equal(t, virtualID, entry.BucketID)
+		}
+	}
+
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestSetOpeningBalance_NegativeAmount(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	cardID := uuid.New()
+	mockBucketRepo.On("GetByIDForUpdate", ctx, cardID).Return(&domain.Bucket{ID: cardID, Name: "Credit Card", BucketType: domain.BucketTypePhysical}, nil)
+	mockTxRepo.On("CountForBuckets", ctx, []uuid.UUID{cardID}).Return(0, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	tx, err := service.SetOpeningBalance(ctx, SetOpeningBalanceInput{
+		BucketID: cardID,
+		Amount:   decimal.NewFromInt(-400),
+	})
+
+	// Physical Layer only: Credit the card, Debit SYS_EXTRA_INCOME
+	assert.NoError(t, err)
+	assert.Len(t, tx.Entries, 2)
+	for _, entry := range tx.Entries {
+		assert.Equal(t, domain.LayerPhysical, entry.Layer)
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(400)))
+		if entry.Type == domain.EntryTypeCredit {
+			assert.Equal(t, cardID, entry.BucketID)
+		} else {
+			assert.Equal(t, seeder.SYS_EXTRA_INCOME, entry.BucketID)
+		}
+	}
+}
+
+func TestSetOpeningBalance_Validation(t *testing.T) {
+	ctx := context.Background()
+	bankID := uuid.New()
+	otherBankID := uuid.New()
+	virtualID := uuid.New()
+	bank := &domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}
+
+	tests := []struct {
+		name          string
+		input         SetOpeningBalanceInput
+		setup         func(*MockBucketRepository, *MockTransactionRepository)
+		expectedError string
+	}{
+		{
+			name:          "Zero amount",
+			input:         SetOpeningBalanceInput{BucketID: bankID, Amount: decimal.Zero},
+			setup:         func(*MockBucketRepository, *MockTransactionRepository) {},
+			expectedError: "opening balance amount must not be zero",
+		},
+		{
+			name:  "Not a physical bucket",
+			input: SetOpeningBalanceInput{BucketID: virtualID, Amount: decimal.NewFromInt(10)},
+			setup: func(b *MockBucketRepository, _ *MockTransactionRepository) {
+				b.On("GetByIDForUpdate", ctx, virtualID).Return(&domain.Bucket{ID: virtualID, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+			},
+			expectedError: "bucket ID must reference a physical bucket",
+		},
+		{
+			name:  "Virtual bucket of another parent",
+			input: SetOpeningBalanceInput{BucketID: bankID, Amount: decimal.NewFromInt(10), VirtualBucketID: &virtualID},
+			setup: func(b *MockBucketRepository, _ *MockTransactionRepository) {
+				b.On("GetByIDForUpdate", ctx, bankID).Return(bank, nil)
+				b.On("GetByID", ctx, virtualID).Return(&domain.Bucket{ID: virtualID, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &otherBankID}, nil)
+			},
+			expectedError: "virtual bucket must be a child of the physical bucket",
+		},
+		{
+			name:  "Already has transactions",
+			input: SetOpeningBalanceInput{BucketID: bankID, Amount: decimal.NewFromInt(10)},
+			setup: func(b *MockBucketRepository, tx *MockTransactionRepository) {
+				b.On("GetByIDForUpdate", ctx, bankID).Return(bank, nil)
+				tx.On("CountForBuckets", ctx, []uuid.UUID{bankID}).Return(1, nil)
+			},
+			expectedError: "opening balance can only be set on buckets without transactions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			tt.setup(mockBucketRepo, mockTxRepo)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+			service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			tx, err := service.SetOpeningBalance(ctx, tt.input)
+
+			assert.Nil(t, tx)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMergeCategoryBuckets_Success(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewBucketService(mockBucketRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), new(MockUnitOfWork))
+
+	foodID := uuid.New()
+	groceriesID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, foodID).Return(&domain.Bucket{ID: foodID, Name: "Food", BucketType: domain.BucketTypeExpense}, nil)
+	mockBucketRepo.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+	mockBucketRepo.On("MergeInto", ctx, foodID, groceriesID).Return(3, nil)
+
+	repointed, err := service.MergeCategoryBuckets(ctx, foodID, groceriesID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, repointed)
+	mockBucketRepo.AssertExpectations(t)
+}
+
+func TestMergeCategoryBuckets_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	expenseBucket := func(id uuid.UUID) *domain.Bucket {
+		return &domain.Bucket{ID: id, BucketType: domain.BucketTypeExpense}
+	}
+
+	tests := []struct {
+		name          string
+		targetID      uuid.UUID
+		setup         func(*MockBucketRepository)
+		expectedError string
+	}{
+		{
+			name:          "Same bucket",
+			targetID:      sourceID,
+			setup:         func(*MockBucketRepository) {},
+			expectedError: "cannot merge a bucket into itself",
+		},
+		{
+			name:     "Source is not an expense bucket",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByID", ctx, sourceID).Return(&domain.Bucket{ID: sourceID, BucketType: domain.BucketTypeVirtual}, nil)
+			},
+			expectedError: "category bucket ID must reference an expense bucket",
+		},
+		{
+			name:     "Target is not an expense bucket",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByID", ctx, sourceID).Return(expenseBucket(sourceID), nil)
+				b.On("GetByID", ctx, targetID).Return(&domain.Bucket{ID: targetID, BucketType: domain.BucketTypeIncome}, nil)
+			},
+			expectedError: "category bucket ID must reference an expense bucket",
+		},
+		{
+			name:     "Target is archived",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				archived := expenseBucket(targetID)
+				archived.IsArchived = true
+				b.On("GetByID", ctx, sourceID).Return(expenseBucket(sourceID), nil)
+				b.On("GetByID", ctx, targetID).Return(archived, nil)
+			},
+			expectedError: "is archived",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			tt.setup(mockBucketRepo)
+			service := NewBucketService(mockBucketRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), new(MockUnitOfWork))
+
+			repointed, err := service.MergeCategoryBuckets(ctx, sourceID, tt.targetID)
+
+			assert.Zero(t, repointed)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockBucketRepo.AssertNotCalled(t, "MergeInto", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSetBucketGoal(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewBucketService(mockBucketRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), new(MockUnitOfWork))
+
+	savingsID := uuid.New()
+	goal := decimal.NewFromInt(5000)
+	mockBucketRepo.On("GetByID", ctx, savingsID).Return(&domain.Bucket{ID: savingsID, Name: "Savings", BucketType: domain.BucketTypeVirtual, CurrentBalance: decimal.NewFromInt(1250)}, nil)
+	mockBucketRepo.On("UpdateGoal", ctx, savingsID, &goal).Return(nil)
+
+	bucket, err := service.SetBucketGoal(ctx, savingsID, &goal)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &goal, bucket.GoalAmount)
+	assert.True(t, bucket.GoalProgress().Equal(decimal.NewFromInt(25)))
+	mockBucketRepo.AssertExpectations(t)
+}
+
+func TestSetBucketGoal_NonPositive(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewBucketService(mockBucketRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), new(MockUnitOfWork))
+
+	goal := decimal.Zero
+	bucket, err := service.SetBucketGoal(ctx, uuid.New(), &goal)
+
+	assert.Nil(t, bucket)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockBucketRepo.AssertNotCalled(t, "UpdateGoal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteTransferTask(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	mockTaskRepo := new(MockTransferTaskRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	taskID := uuid.New()
+	fromID := uuid.New()
+	toID := uuid.New()
+	mockTaskRepo.On("GetByID", ctx, taskID).Return(&domain.TransferTask{
+		ID:                   taskID,
+		FromPhysicalBucketID: fromID,
+		ToPhysicalBucketID:   toID,
+		Amount:               decimal.NewFromInt(50),
+		Description:          "Groceries (wrong card)",
+	}, nil)
+	mockTxRepo.On("Create", ctx, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.IsInternalTransfer &&
+			len(tx.Entries) == 2 &&
+			tx.Entries[0].BucketID == fromID && tx.Entries[0].Type == domain.EntryTypeCredit &&
+			tx.Entries[1].BucketID == toID && tx.Entries[1].Type == domain.EntryTypeDebit &&
+			tx.Entries[0].Layer == domain.LayerPhysical && tx.Entries[1].Layer == domain.LayerPhysical
+	})).Return(nil)
+	mockTaskRepo.On("MarkCompleted", ctx, taskID, mock.AnythingOfType("uuid.UUID")).Return(nil)
+
+	task, err := service.CompleteTransferTask(ctx, taskID)
+
+	assert.NoError(t, err)
+	assert.True(t, task.IsCompleted)
+	if assert.NotNil(t, task.CompletedTransactionID) {
+		mockTaskRepo.AssertCalled(t, "MarkCompleted", ctx, taskID, *task.CompletedTransactionID)
+	}
+	mockTxRepo.AssertExpectations(t)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestCompleteTransferTask_AlreadyCompleted(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	mockTaskRepo := new(MockTransferTaskRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Transactions: mockTxRepo, TransferTasks: mockTaskRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	taskID := uuid.New()
+	mockTaskRepo.On("GetByID", ctx, taskID).Return(&domain.TransferTask{ID: taskID, Amount: decimal.NewFromInt(50), IsCompleted: true}, nil)
+
+	task, err := service.CompleteTransferTask(ctx, taskID)
+
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, domain.ErrTransferTaskAlreadyCompleted)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockTaskRepo.AssertNotCalled(t, "MarkCompleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateEquityBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	bookValue := decimal.NewFromInt(1500)
+	mockBucketRepo.On("Create", ctx, mock.MatchedBy(func(b *domain.Bucket) bool {
+		return b.Name == "Tesla Stock" && b.BucketType == domain.BucketTypeEquity && b.CurrentBalance.IsZero()
+	})).Return(nil)
+	mockTxRepo.On("Create", ctx, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return len(tx.Entries) == 2 &&
+			tx.Entries[0].Type == domain.EntryTypeDebit && tx.Entries[0].Amount.Equal(bookValue) &&
+			tx.Entries[1].BucketID == seeder.SYS_EXTRA_INCOME && tx.Entries[1].Type == domain.EntryTypeCredit &&
+			tx.Entries[0].Layer == domain.LayerPhysical && tx.Entries[1].Layer == domain.LayerPhysical
+	})).Return(nil)
+
+	result, err := service.CreateEquityBucket(ctx, CreateEquityBucketInput{Name: "  Tesla Stock ", BookValue: bookValue})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Tesla Stock", result.Bucket.Name)
+	assert.Equal(t, domain.BucketTypeEquity, result.Bucket.BucketType)
+	assert.True(t, result.Bucket.CurrentBalance.Equal(bookValue))
+	assert.Equal(t, result.Bucket.ID, result.Transaction.Entries[0].BucketID)
+	assert.Nil(t, result.MarketValue)
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestCreateEquityBucket_WithMarketValue(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, MarketValues: mockMarketValueRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	marketValue := decimal.NewFromInt(1800)
+	mockBucketRepo.On("Create", ctx, mock.AnythingOfType("*domain.Bucket")).Return(nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+	// The market value is written in the same unit of work as the bucket
+	mockMarketValueRepo.On("Add", ctx, mock.MatchedBy(func(entry *domain.MarketValueHistory) bool {
+		return entry.MarketValue.Equal(marketValue) && entry.UnitPrice == nil
+	})).Return(nil)
+
+	result, err := service.CreateEquityBucket(ctx, CreateEquityBucketInput{Name: "Tesla Stock", BookValue: decimal.NewFromInt(1500), MarketValue: &marketValue})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.NotNil(t, result.MarketValue) {
+		assert.Equal(t, result.Bucket.ID, result.MarketValue.BucketID)
+	}
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestCreateEquityBucket_Invalid(t *testing.T) {
+	zero := decimal.Zero
+	tests := []struct {
+		name  string
+		input CreateEquityBucketInput
+	}{
+		{"zero book value", CreateEquityBucketInput{Name: "Tesla Stock", BookValue: decimal.Zero}},
+		{"negative book value", CreateEquityBucketInput{Name: "Tesla Stock", BookValue: decimal.NewFromInt(-10)}},
+		{"empty name", CreateEquityBucketInput{Name: "   ", BookValue: decimal.NewFromInt(10)}},
+		{"zero market value", CreateEquityBucketInput{Name: "Tesla Stock", BookValue: decimal.NewFromInt(10), MarketValue: &zero}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+			service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			result, err := service.CreateEquityBucket(ctx, tt.input)
+
+			assert.Nil(t, result)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			mockBucketRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListOverdueTransferTasks(t *testing.T) {
+	ctx := context.Background()
+	mockTaskRepo := new(MockTransferTaskRepository)
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), mockTaskRepo, new(MockUnitOfWork))
+
+	tasks := []domain.TransferTask{
+		{ID: uuid.New(), Amount: decimal.NewFromInt(50), CreatedAt: time.Now().AddDate(0, 0, -10)},
+		{ID: uuid.New(), Amount: decimal.NewFromInt(20), CreatedAt: time.Now().AddDate(0, 0, -4)},
+	}
+	// The cutoff is 3 days before now
+	mockTaskRepo.On("ListPendingCreatedBefore", ctx, mock.MatchedBy(func(before time.Time) bool {
+		cutoff := time.Now().AddDate(0, 0, -3)
+		return before.Sub(cutoff).Abs() < time.Minute
+	})).Return(tasks, nil)
+
+	result, err := service.ListOverdueTransferTasks(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tasks, result)
+	mockTaskRepo.AssertExpectations(t)
+}
+
+func TestListOverdueTransferTasks_NegativeDays(t *testing.T) {
+	ctx := context.Background()
+	mockTaskRepo := new(MockTransferTaskRepository)
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), mockTaskRepo, new(MockUnitOfWork))
+
+	result, err := service.ListOverdueTransferTasks(ctx, -1)
+
+	assert.Nil(t, result)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockTaskRepo.AssertNotCalled(t, "ListPendingCreatedBefore", mock.Anything, mock.Anything)
+}
+
+func TestMoveBetweenVirtualBuckets(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	bankID := uuid.New()
+	freeCashID := uuid.New()
+	vacationID := uuid.New()
+	mockBucketRepo.On("GetByIDForUpdate", ctx, freeCashID).Return(&domain.Bucket{ID: freeCashID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockBucketRepo.On("GetByID", ctx, vacationID).Return(&domain.Bucket{ID: vacationID, Name: "Vacation", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	tx, err := service.MoveBetweenVirtualBuckets(ctx, MoveBetweenVirtualBucketsInput{
+		SourceBucketID:      freeCashID,
+		DestinationBucketID: vacationID,
+		Amount:              decimal.NewFromInt(150),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, tx.IsInternalTransfer)
+	assert.Equal(t, "Move from Free Cash to Vacation", tx.Description)
+
+	// Virtual Layer only: Credit Free Cash, Debit Vacation
+	assert.Len(t, tx.Entries, 2)
+	for _, entry := range tx.Entries {
+		assert.Equal(t, domain.LayerVirtual, entry.Layer)
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(150)))
+		if entry.Type == domain.EntryTypeCredit {
+			assert.Equal(t, freeCashID, entry.BucketID)
+		} else {
+			assert.Equal(t, vacationID, entry.BucketID)
+		}
+	}
+
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestMoveBetweenVirtualBuckets_Validation(t *testing.T) {
+	ctx := context.Background()
+	bankID := uuid.New()
+	otherBankID := uuid.New()
+	freeCashID := uuid.New()
+	vacationID := uuid.New()
+	savingsID := uuid.New()
+	groceriesID := uuid.New()
+	freeCash := &domain.Bucket{ID: freeCashID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}
+
+	tests := []struct {
+		name          string
+		input         MoveBetweenVirtualBucketsInput
+		setup         func(*MockBucketRepository)
+		expectedError string
+	}{
+		{
+			name:          "Non-positive amount",
+			input:         MoveBetweenVirtualBucketsInput{SourceBucketID: freeCashID, DestinationBucketID: vacationID, Amount: decimal.Zero},
+			setup:         func(*MockBucketRepository) {},
+			expectedError: "move amount must be positive",
+		},
+		{
+			name:          "Same bucket",
+			input:         MoveBetweenVirtualBucketsInput{SourceBucketID: freeCashID, DestinationBucketID: freeCashID, Amount: decimal.NewFromInt(10)},
+			setup:         func(*MockBucketRepository) {},
+			expectedError: "source and destination buckets must differ",
+		},
+		{
+			name:  "Destination is not virtual",
+			input: MoveBetweenVirtualBucketsInput{SourceBucketID: freeCashID, DestinationBucketID: groceriesID, Amount: decimal.NewFromInt(10)},
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByIDForUpdate", ctx, freeCashID).Return(freeCash, nil)
+				b.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+			},
+			expectedError: "destination bucket ID must reference a virtual bucket",
+		},
+		{
+			name:  "Cross-bank move",
+			input: MoveBetweenVirtualBucketsInput{SourceBucketID: freeCashID, DestinationBucketID: savingsID, Amount: decimal.NewFromInt(10)},
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByIDForUpdate", ctx, freeCashID).Return(freeCash, nil)
+				b.On("GetByID", ctx, savingsID).Return(&domain.Bucket{ID: savingsID, Name: "Savings", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &otherBankID}, nil)
+			},
+			expectedError: "source and destination buckets must be held in the same physical bucket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			tt.setup(mockBucketRepo)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+			service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			tx, err := service.MoveBetweenVirtualBuckets(ctx, tt.input)
+
+			assert.Nil(t, tx)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMoveBetweenVirtualBuckets_RequireSufficientFunds(t *testing.T) {
+	ctx := context.Background()
+	bankID := uuid.New()
+	freeCashID := uuid.New()
+	vacationID := uuid.New()
+
+	tests := []struct {
+		name      string
+		balance   decimal.Decimal
+		wantError bool
+	}{
+		{"Balance covers the amount", decimal.NewFromInt(100), false},
+		{"Balance equals the amount", decimal.NewFromInt(60), false},
+		{"Balance below the amount", decimal.NewFromInt(59), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+			service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			mockBucketRepo.On("GetByIDForUpdate", ctx, freeCashID).Return(&domain.Bucket{ID: freeCashID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID, CurrentBalance: tt.balance}, nil)
+			mockBucketRepo.On("GetByID", ctx, vacationID).Return(&domain.Bucket{ID: vacationID, Name: "Vacation", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+			mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+			tx, err := service.MoveBetweenVirtualBuckets(ctx, MoveBetweenVirtualBucketsInput{
+				SourceBucketID:         freeCashID,
+				DestinationBucketID:    vacationID,
+				Amount:                 decimal.NewFromInt(60),
+				RequireSufficientFunds: true,
+			})
+
+			if tt.wantError {
+				assert.Nil(t, tx)
+				assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+				mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, tx)
+			}
+		})
+	}
+}
+
+func TestMoveBetweenVirtualBuckets_OverdrawAllowedByDefault(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewBucketService(new(MockBucketRepository), new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	bankID := uuid.New()
+	freeCashID := uuid.New()
+	vacationID := uuid.New()
+	mockBucketRepo.On("GetByIDForUpdate", ctx, freeCashID).Return(&domain.Bucket{ID: freeCashID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID, CurrentBalance: decimal.Zero}, nil)
+	mockBucketRepo.On("GetByID", ctx, vacationID).Return(&domain.Bucket{ID: vacationID, Name: "Vacation", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	tx, err := service.MoveBetweenVirtualBuckets(ctx, MoveBetweenVirtualBucketsInput{
+		SourceBucketID:      freeCashID,
+		DestinationBucketID: vacationID,
+		Amount:              decimal.NewFromInt(60),
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, tx)
+}
+
+func TestCreateBuckets(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo}}
+	existingRepo := new(MockBucketRepository)
+	service := NewBucketService(existingRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+	savingsBankID := uuid.New()
+	existingRepo.On("GetByID", ctx, savingsBankID).Return(&domain.Bucket{ID: savingsBankID, Name: "Savings Bank", BucketType: domain.BucketTypePhysical}, nil)
+
+	var created []*domain.Bucket
+	mockBucketRepo.On("Create", ctx, mock.AnythingOfType("*domain.Bucket")).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(*domain.Bucket))
+	}).Return(nil)
+
+	// The envelope is listed before its bank; the batch still resolves and creates the bank first
+	buckets, err := service.CreateBuckets(ctx, []NewBucketInput{
+		{Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentKey: "bank"},
+		{Key: "bank", Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+		{Name: "Emergency Fund", BucketType: domain.BucketTypeVirtual, ParentID: &savingsBankID},
+		{Name: "Employer", BucketType: domain.BucketTypeIncome},
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, buckets, 4)
+	assert.Equal(t, "Groceries", buckets[0].Name)
+	assert.Equal(t, buckets[1].ID, *buckets[0].ParentPhysicalBucketID)
+	assert.Nil(t, buckets[1].ParentPhysicalBucketID)
+	assert.Equal(t, savingsBankID, *buckets[2].ParentPhysicalBucketID)
+	assert.NotEqual(t, uuid.Nil, buckets[3].ID)
+
+	// Non-virtual buckets are written before virtual ones
+	if assert.Len(t, created, 4) {
+		assert.Equal(t, "Main Bank", created[0].Name)
+		assert.Equal(t, "Employer", created[1].Name)
+		assert.Equal(t, domain.BucketTypeVirtual, created[2].BucketType)
+		assert.Equal(t, domain.BucketTypeVirtual, created[3].BucketType)
+	}
+}
+
+func TestCreateBuckets_Invalid(t *testing.T) {
+	virtualID := uuid.New()
+	bankID := uuid.New()
+
+	tests := []struct {
+		name   string
+		inputs []NewBucketInput
+		errMsg string
+	}{
+		{"empty batch", nil, "at least one bucket is required"},
+		{"equity bucket", []NewBucketInput{{Name: "Tesla Stock", BucketType: domain.BucketTypeEquity}}, "bucket type must be"},
+		{"duplicate key", []NewBucketInput{
+			{Key: "bank", Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+			{Key: "bank", Name: "Other Bank", BucketType: domain.BucketTypePhysical},
+		}, `duplicate key "bank"`},
+		{"unknown parent key", []NewBucketInput{{Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentKey: "bank"}}, "does not match any bucket"},
+		{"virtual without parent", []NewBucketInput{{Name: "Groceries", BucketType: domain.BucketTypeVirtual}}, "virtual bucket must have a parent"},
+		{"virtual parent in batch", []NewBucketInput{
+			{Key: "bank", Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+			{Key: "free", Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentKey: "bank"},
+			{Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentKey: "free"},
+		}, "parent bucket must be a physical bucket"},
+		{"existing virtual parent", []NewBucketInput{{Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentID: &virtualID}}, "parent bucket must be a physical bucket"},
+		{"self parent", []NewBucketInput{{Key: "self", Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentKey: "self"}}, "bucket cannot be its own parent"},
+		{"both parent key and ID", []NewBucketInput{
+			{Key: "bank", Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+			{Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentKey: "bank", ParentID: &bankID},
+		}, "not both"},
+		{"physical with parent", []NewBucketInput{
+			{Key: "bank", Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+			{Name: "Card", BucketType: domain.BucketTypePhysical, ParentKey: "bank"},
+		}, "cannot have a parent"},
+		{"empty name", []NewBucketInput{{Name: "  ", BucketType: domain.BucketTypeIncome}}, "bucket name cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo}}
+			existingRepo := new(MockBucketRepository)
+			existingRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{ID: virtualID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil).Maybe()
+			service := NewBucketService(existingRepo, new(MockTransactionRepository), new(MockTransferTaskRepository), uow)
+
+			buckets, err := service.CreateBuckets(ctx, tt.inputs)
+
+			assert.Nil(t, buckets)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			mockBucketRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -3,16 +3,134 @@ package dashboard
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 )
 
+// ledgerIntegrityPageSize is how many transactions VerifyLedgerIntegrity loads at a time, bounding memory
+const ledgerIntegrityPageSize = 500
+
 // NetWorthResult represents the calculated net worth
 type NetWorthResult struct {
-	Total     decimal.Decimal
-	Liquidity decimal.Decimal
-	Equity    decimal.Decimal
+	Total           decimal.Decimal
+	Liquidity       decimal.Decimal
+	Equity          decimal.Decimal
+	EquityBookValue decimal.Decimal // Sum of EQUITY bucket balances (what was paid), for buckets with a market value
+	EquityProfit    decimal.Decimal // Sum of per-bucket profit (MarketValue - BookValue)
+}
+
+// BucketTreeNode represents a physical bucket together with its virtual children
+type BucketTreeNode struct {
+	Physical       *domain.Bucket
+	Children       []*domain.Bucket
+	VirtualBalance decimal.Decimal // Sum of the children's balances
+	Unbucketed     decimal.Decimal // Physical balance - VirtualBalance
+}
+
+// UnbucketedResult represents the allocation consistency of a single physical bucket
+type UnbucketedResult struct {
+	PhysicalBalance decimal.Decimal
+	VirtualBalance  decimal.Decimal // Sum of the virtual children's balances
+	Unbucketed      decimal.Decimal // PhysicalBalance - VirtualBalance
+	HasDrift        bool            // True when Unbucketed is non-zero
+}
+
+// BucketsSummary represents aggregate bucket counts and total liquidity
+type BucketsSummary struct {
+	CountsByType   map[domain.BucketType]int
+	TotalLiquidity decimal.Decimal // Sum of all PHYSICAL bucket balances
+}
+
+// ProfitPoint represents the unrealized profit of an equity bucket at a market value date
+type ProfitPoint struct {
+	Date        time.Time
+	BookValue   decimal.Decimal // Balance reconstructed from transaction entries dated up to Date
+	MarketValue decimal.Decimal
+	Profit      decimal.Decimal // MarketValue - BookValue
+}
+
+// BalanceRecalculation represents the outcome of recomputing bucket balances from their entries
+type BalanceRecalculation struct {
+	BucketsCorrected int             // Number of buckets whose stored balance differed
+	TotalDrift       decimal.Decimal // Sum of the absolute differences that were corrected
+}
+
+// BucketGoal represents a virtual bucket's progress towards its goal amount
+type BucketGoal struct {
+	Bucket   *domain.Bucket
+	Progress decimal.Decimal // CurrentBalance / GoalAmount * 100
+	Met      bool            // True when CurrentBalance has reached GoalAmount
+}
+
+// PhysicalBucketSpending represents how much money left a physical bucket (bank account or card)
+type PhysicalBucketSpending struct {
+	Bucket     *domain.Bucket
+	TotalSpent decimal.Decimal // Sum of PHYSICAL-layer CREDIT entries on the bucket
+}
+
+// AllocationShare represents how much of the external inflows a virtual bucket actually received
+type AllocationShare struct {
+	Bucket       *domain.Bucket
+	Total        decimal.Decimal // Sum of the bucket's VIRTUAL DEBIT entries in external inflows
+	SharePercent decimal.Decimal // Total / sum of all buckets' totals * 100, rounded to 2 decimal places
+}
+
+// LedgerViolation represents a stored transaction that fails domain validation
+type LedgerViolation struct {
+	TransactionID uuid.UUID
+	Reason        string // The Transaction.Validate error
+}
+
+// LedgerIntegrityReport represents the outcome of validating every stored transaction
+type LedgerIntegrityReport struct {
+	TransactionsChecked int
+	Violations          []LedgerViolation
+}
+
+// Statement represents how net worth moved over a period [Start, End)
+// OpeningNetWorth + every LiquidityChange + every MarketValueChange.Change = ClosingNetWorth
+type Statement struct {
+	Start              time.Time
+	End                time.Time
+	OpeningNetWorth    decimal.Decimal // Net worth from everything dated before Start
+	ClosingNetWorth    decimal.Decimal // Net worth from everything dated before End
+	Transactions       []StatementTransaction
+	MarketValueChanges []MarketValueChange
+}
+
+// StatementTransaction represents a transaction that changed liquidity within a statement period
+type StatementTransaction struct {
+	Transaction     *domain.Transaction
+	LiquidityChange decimal.Decimal // Net change to the PHYSICAL bucket balances, negative for money leaving
+}
+
+// MarketValueChange represents a market value recorded for an equity bucket within a statement period
+type MarketValueChange struct {
+	Bucket        *domain.Bucket
+	Date          time.Time
+	PreviousValue decimal.Decimal // The bucket's market value before this entry (0 if it had none)
+	MarketValue   decimal.Decimal
+	Change        decimal.Decimal // MarketValue - PreviousValue
+}
+
+// UpcomingForecast represents projected liquidity over the coming days
+// CurrentLiquidity + every item's LiquidityChange = ProjectedLiquidity
+type UpcomingForecast struct {
+	Until              time.Time
+	CurrentLiquidity   decimal.Decimal // Sum of all PHYSICAL bucket balances now
+	Items              []UpcomingItem  // Ordered by effective date
+	ProjectedLiquidity decimal.Decimal // Liquidity once every item has happened
+}
+
+// UpcomingItem represents a pending scheduled transaction in a forecast
+type UpcomingItem struct {
+	Transaction      *domain.Transaction
+	LiquidityChange  decimal.Decimal // Net change it will make to the PHYSICAL bucket balances, negative for an obligation
+	ProjectedBalance decimal.Decimal // Liquidity once this item and every earlier one has happened
 }
 
 // DashboardService handles dashboard-related operations
@@ -20,6 +138,7 @@ type DashboardService struct {
 	BucketRepo      domain.BucketRepository
 	TransactionRepo domain.TransactionRepository
 	MarketValueRepo domain.MarketValueRepository
+	HoldingRepo     domain.HoldingRepository
 }
 
 // NewDashboardService creates a new DashboardService instance
@@ -27,19 +146,26 @@ func NewDashboardService(
 	bucketRepo domain.BucketRepository,
 	transactionRepo domain.TransactionRepository,
 	marketValueRepo domain.MarketValueRepository,
+	holdingRepo domain.HoldingRepository,
 ) *DashboardService {
 	return &DashboardService{
 		BucketRepo:      bucketRepo,
 		TransactionRepo: transactionRepo,
 		MarketValueRepo: marketValueRepo,
+		HoldingRepo:     holdingRepo,
 	}
 }
 
 // GetNetWorth calculates the total net worth
 // Logic:
 //   - Liquidity: Sum of all PHYSICAL bucket balances
-//   - Equity: Sum of all EQUITY bucket market values (using latest market_value from market_value_history)
+//   - Equity: Sum of all EQUITY bucket market values (latest market value, see domain.CurrentMarketValue)
+//   - EquityBookValue: Sum of all EQUITY bucket balances
+//   - EquityProfit: Sum of MarketValue - BookValue for EQUITY buckets with market value history
+//     (buckets without history contribute 0, matching InvestmentService.CalculateProfit)
 //   - Total: Liquidity + Equity
+//
+// Every amount is in domain.DefaultCurrency; a bucket in another currency fails the sum with ErrCurrencyMismatch
 func (s *DashboardService) GetNetWorth(ctx context.Context) (*NetWorthResult, error) {
 	// 1. Get all PHYSICAL buckets and sum their balances
 	physicalBuckets, err := s.BucketRepo.List(ctx, domain.BucketTypePhysical)
@@ -47,9 +173,9 @@ func (s *DashboardService) GetNetWorth(ctx context.Context) (*NetWorthResult, er
 		return nil, fmt.Errorf("failed to list physical buckets: %w", err)
 	}
 
-	liquidity := decimal.Zero
-	for _, bucket := range physicalBuckets {
-		liquidity = liquidity.Add(bucket.CurrentBalance)
+	liquidity, err := sumBalances(physicalBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum physical bucket balances: %w", err)
 	}
 
 	// 2. Get all EQUITY buckets and sum their latest market values
@@ -58,24 +184,802 @@ func (s *DashboardService) GetNetWorth(ctx context.Context) (*NetWorthResult, er
 		return nil, fmt.Errorf("failed to list equity buckets: %w", err)
 	}
 
-	equity := decimal.Zero
+	// Fetch the current market value of every equity bucket in bulk (avoids N+1)
+	equityBucketIDs := make([]uuid.UUID, 0, len(equityBuckets))
 	for _, bucket := range equityBuckets {
-		// Get latest market value for this bucket
-		marketValueEntry, err := s.MarketValueRepo.GetLatest(ctx, bucket.ID)
-		if err != nil {
+		equityBucketIDs = append(equityBucketIDs, bucket.ID)
+	}
+	marketValues, err := s.currentMarketValues(ctx, equityBucketIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Market values are recorded in the bucket's currency
+	// Equity, EquityBookValue and EquityProfit are all built from the buckets that have a market value
+	equity := domain.ZeroMoney(domain.DefaultCurrency)
+	equityProfit := domain.ZeroMoney(domain.DefaultCurrency)
+	valuedBuckets := make([]*domain.Bucket, 0, len(equityBuckets))
+	for _, bucket := range equityBuckets {
+		marketValue, ok := marketValues[bucket.ID]
+		if !ok {
 			// If no market value history exists, skip this bucket (or use book value?)
 			// Per requirements: use latest market_value, so if none exists, we skip it
 			continue
 		}
-		equity = equity.Add(marketValueEntry.MarketValue)
+		valuedBuckets = append(valuedBuckets, bucket)
+		currency := bucket.Balance().Currency
+		if equity, err = equity.Add(domain.NewMoney(marketValue, currency)); err != nil {
+			return nil, fmt.Errorf("failed to sum equity market values: %w", err)
+		}
+		profit := domain.NewMoney(domain.CalculateProfit(bucket.CurrentBalance, marketValue), currency)
+		if equityProfit, err = equityProfit.Add(profit); err != nil {
+			return nil, fmt.Errorf("failed to sum equity profit: %w", err)
+		}
+	}
+
+	equityBookValue, err := sumBalances(valuedBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum equity bucket balances: %w", err)
 	}
 
 	// 3. Calculate total
-	total := liquidity.Add(equity)
+	total, err := liquidity.Add(equity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum net worth: %w", err)
+	}
 
 	return &NetWorthResult{
-		Total:     total,
-		Liquidity: liquidity,
-		Equity:    equity,
+		Total:           total.Amount,
+		Liquidity:       liquidity.Amount,
+		Equity:          equity.Amount,
+		EquityBookValue: equityBookValue.Amount,
+		EquityProfit:    equityProfit.Amount,
+	}, nil
+}
+
+// sumBalances adds up the balances of buckets, all of which must be in domain.DefaultCurrency
+// Returns a wrapped domain.ErrCurrencyMismatch naming the first bucket in another currency
+func sumBalances(buckets []*domain.Bucket) (domain.Money, error) {
+	total := domain.ZeroMoney(domain.DefaultCurrency)
+	for _, bucket := range buckets {
+		var err error
+		if total, err = total.Add(bucket.Balance()); err != nil {
+			return domain.Money{}, fmt.Errorf("bucket %s: %w", bucket.ID, err)
+		}
+	}
+	return total, nil
+}
+
+// GetLiquidity returns the sum of all PHYSICAL bucket balances (the Liquidity part of GetNetWorth)
+// The sum is computed in the database, making it cheap enough for frequently polled widgets
+// As in GetNetWorth, a physical bucket in another currency fails the sum with ErrCurrencyMismatch
+func (s *DashboardService) GetLiquidity(ctx context.Context) (decimal.Decimal, error) {
+	liquidity, err := s.BucketRepo.SumBalances(ctx, domain.BucketTypePhysical)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to sum physical bucket balances: %w", err)
+	}
+	return liquidity, nil
+}
+
+// GetBucketsSummary counts buckets per type and sums physical balances
+// Logic: List all buckets once, group by BucketType, sum PHYSICAL balances (same as GetNetWorth's Liquidity)
+func (s *DashboardService) GetBucketsSummary(ctx context.Context) (*BucketsSummary, error) {
+	buckets, err := s.BucketRepo.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	summary := &BucketsSummary{
+		CountsByType: make(map[domain.BucketType]int),
+	}
+	physicalBuckets := make([]*domain.Bucket, 0)
+	for _, bucket := range buckets {
+		summary.CountsByType[bucket.BucketType]++
+		if bucket.BucketType == domain.BucketTypePhysical {
+			physicalBuckets = append(physicalBuckets, bucket)
+		}
+	}
+
+	liquidity, err := sumBalances(physicalBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum physical bucket balances: %w", err)
+	}
+	summary.TotalLiquidity = liquidity.Amount
+
+	return summary, nil
+}
+
+// RecalculateAllBalances recomputes every PHYSICAL and VIRTUAL bucket balance from its transaction entries
+// and persists the ones that drifted (e.g. if the balance trigger was ever missing)
+// Logic:
+//   - BucketRepo.RecalculateBalances locks the buckets, sums each one's entries (DEBIT minus CREDIT, same as
+//     the balance trigger) and overwrites current_balance where it differs, all in one database transaction,
+//     so an inflow or expense booked during the run is not lost; buckets without entries are expected to be 0
+//   - Report how many buckets were corrected and by how much in total
+func (s *DashboardService) RecalculateAllBalances(ctx context.Context) (*BalanceRecalculation, error) {
+	corrections, err := s.BucketRepo.RecalculateBalances(ctx, []domain.BucketType{domain.BucketTypePhysical, domain.BucketTypeVirtual})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recalculate bucket balances: %w", err)
+	}
+
+	result := &BalanceRecalculation{TotalDrift: decimal.Zero}
+	for _, correction := range corrections {
+		result.BucketsCorrected++
+		result.TotalDrift = result.TotalDrift.Add(correction.Previous.Sub(correction.Corrected).Abs())
+	}
+
+	return result, nil
+}
+
+// GetBucketTree builds the physical -> virtual bucket hierarchy
+// Logic:
+//   - Load all PHYSICAL buckets (one node each, ordered by name) with their VIRTUAL children in a single query
+//   - VirtualBalance: Sum of the children's balances
+//   - Unbucketed: Physical balance - VirtualBalance
+//
+// Virtual buckets hold part of their parent's money, so a child in another currency fails with ErrCurrencyMismatch
+func (s *DashboardService) GetBucketTree(ctx context.Context) ([]*BucketTreeNode, error) {
+	families, err := s.BucketRepo.ListPhysicalWithChildren(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket tree: %w", err)
+	}
+
+	nodes := make([]*BucketTreeNode, 0, len(families))
+	for _, family := range families {
+		virtualBalance, unbucketed, err := splitPhysicalBalance(family.Physical, family.Children)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &BucketTreeNode{
+			Physical:       family.Physical,
+			Children:       family.Children,
+			VirtualBalance: virtualBalance,
+			Unbucketed:     unbucketed,
+		})
+	}
+
+	return nodes, nil
+}
+
+// GetUnbucketedAmount checks that a physical bucket's balance is fully assigned to its virtual children
+// Logic: Unbucketed = Physical balance - Sum(virtual children balances)
+// In the two-layer model every euro is allocated, so a non-zero result flags allocation drift
+func (s *DashboardService) GetUnbucketedAmount(ctx context.Context, physicalBucketID uuid.UUID) (*UnbucketedResult, error) {
+	physicalBucket, err := s.BucketRepo.GetByID(ctx, physicalBucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if physicalBucket.BucketType != domain.BucketTypePhysical {
+		return nil, domain.NewValidationError("bucket ID must reference a physical bucket")
+	}
+
+	virtualBuckets, err := s.BucketRepo.List(ctx, domain.BucketTypeVirtual)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list virtual buckets: %w", err)
+	}
+
+	children := make([]*domain.Bucket, 0)
+	for _, bucket := range virtualBuckets {
+		if bucket.ParentPhysicalBucketID != nil && *bucket.ParentPhysicalBucketID == physicalBucketID {
+			children = append(children, bucket)
+		}
+	}
+	virtualBalance, unbucketed, err := splitPhysicalBalance(physicalBucket, children)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnbucketedResult{
+		PhysicalBalance: physicalBucket.CurrentBalance,
+		VirtualBalance:  virtualBalance,
+		Unbucketed:      unbucketed,
+		HasDrift:        !unbucketed.IsZero(),
 	}, nil
 }
+
+// splitPhysicalBalance returns the summed balance of a physical bucket's virtual children
+// and the part of the physical balance not assigned to any of them (physical - virtual)
+// The children must be in the physical bucket's currency (ErrCurrencyMismatch otherwise)
+func splitPhysicalBalance(physical *domain.Bucket, children []*domain.Bucket) (decimal.Decimal, decimal.Decimal, error) {
+	virtualBalance := domain.ZeroMoney(physical.Balance().Currency)
+	for _, child := range children {
+		var err error
+		if virtualBalance, err = virtualBalance.Add(child.Balance()); err != nil {
+			return decimal.Zero, decimal.Zero, fmt.Errorf("failed to sum virtual balances of bucket %s: %w", physical.ID, err)
+		}
+	}
+	unbucketed, err := physical.Balance().Sub(virtualBalance)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	return virtualBalance.Amount, unbucketed.Amount, nil
+}
+
+// LatestMarketValues returns the current market value of each EQUITY bucket among buckets (see domain.CurrentMarketValue)
+// Other bucket types and equity buckets without market value history are absent from the result.
+// The values are fetched in bulk, and no query is made if buckets holds no equity bucket.
+func (s *DashboardService) LatestMarketValues(ctx context.Context, buckets []*domain.Bucket) (map[uuid.UUID]decimal.Decimal, error) {
+	equityBucketIDs := make([]uuid.UUID, 0, len(buckets))
+	for _, bucket := range buckets {
+		if bucket.BucketType == domain.BucketTypeEquity {
+			equityBucketIDs = append(equityBucketIDs, bucket.ID)
+		}
+	}
+
+	if len(equityBucketIDs) == 0 {
+		return make(map[uuid.UUID]decimal.Decimal), nil
+	}
+
+	return s.currentMarketValues(ctx, equityBucketIDs)
+}
+
+// currentMarketValues values the given equity buckets as InvestmentService.CalculateProfitDetail does:
+// quantity-tracked buckets priced per unit at Quantity * latest unit price, others at their latest market value
+// Buckets without market value history are absent; holdings are only fetched if a latest entry has a unit price
+func (s *DashboardService) currentMarketValues(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]decimal.Decimal, error) {
+	latest, err := s.MarketValueRepo.GetLatestForBuckets(ctx, bucketIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest market values: %w", err)
+	}
+
+	pricedBucketIDs := make([]uuid.UUID, 0, len(latest))
+	for bucketID, entry := range latest {
+		if entry.UnitPrice != nil {
+			pricedBucketIDs = append(pricedBucketIDs, bucketID)
+		}
+	}
+	holdings := make(map[uuid.UUID]*domain.Holding)
+	if len(pricedBucketIDs) > 0 {
+		if holdings, err = s.HoldingRepo.GetForBuckets(ctx, pricedBucketIDs); err != nil {
+			return nil, fmt.Errorf("failed to get holdings: %w", err)
+		}
+	}
+
+	marketValues := make(map[uuid.UUID]decimal.Decimal, len(latest))
+	for bucketID, entry := range latest {
+		marketValues[bucketID] = domain.CurrentMarketValue(entry, holdings[bucketID])
+	}
+
+	return marketValues, nil
+}
+
+// ListBucketsByParent returns the virtual buckets held by a physical bucket, ordered by name
+// Returns domain.ErrBucketNotFound if the parent does not exist
+func (s *DashboardService) ListBucketsByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	parent, err := s.BucketRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.BucketType != domain.BucketTypePhysical {
+		return nil, domain.NewValidationError("parent ID must reference a physical bucket")
+	}
+
+	children, err := s.BucketRepo.ListByParent(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child buckets: %w", err)
+	}
+
+	return children, nil
+}
+
+// GetFundingBucket returns the physical bucket that holds a virtual bucket's money (e.g. the bank account
+// backing an envelope). Returns domain.ErrBucketNotFound if the virtual bucket does not exist
+func (s *DashboardService) GetFundingBucket(ctx context.Context, virtualBucketID uuid.UUID) (*domain.Bucket, error) {
+	bucket, err := s.BucketRepo.GetByID(ctx, virtualBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if bucket.BucketType != domain.BucketTypeVirtual {
+		return nil, domain.NewValidationErrorf("bucket %s must be a virtual bucket (got %s bucket)", virtualBucketID, bucket.BucketType)
+	}
+	if bucket.ParentPhysicalBucketID == nil {
+		return nil, fmt.Errorf("virtual bucket %s has no parent physical bucket", virtualBucketID)
+	}
+
+	return s.BucketRepo.GetByID(ctx, *bucket.ParentPhysicalBucketID)
+}
+
+// GetTransactionsBetweenBuckets returns the transactions that moved money between two physical buckets
+// (e.g. to reconcile an inter-bank transfer and the transaction completing its transfer task), newest first
+func (s *DashboardService) GetTransactionsBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	if bucketA == bucketB {
+		return nil, domain.NewValidationError("buckets must differ")
+	}
+	for _, id := range []uuid.UUID{bucketA, bucketB} {
+		bucket, err := s.BucketRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if bucket.BucketType != domain.BucketTypePhysical {
+			return nil, domain.NewValidationErrorf("bucket %s must be a physical bucket", id)
+		}
+	}
+
+	return s.TransactionRepo.ListBetweenBuckets(ctx, bucketA, bucketB)
+}
+
+// ListBucketGoals returns the virtual buckets that have a goal, sorted by progress (closest to the goal first)
+// Buckets that have met or exceeded their goal are included with Met set
+func (s *DashboardService) ListBucketGoals(ctx context.Context) ([]BucketGoal, error) {
+	buckets, err := s.BucketRepo.ListWithGoals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets with goals: %w", err)
+	}
+
+	goals := make([]BucketGoal, 0, len(buckets))
+	for _, bucket := range buckets {
+		progress := bucket.GoalProgress()
+		if progress == nil {
+			continue
+		}
+		goals = append(goals, BucketGoal{
+			Bucket:   bucket,
+			Progress: *progress,
+			Met:      bucket.CurrentBalance.GreaterThanOrEqual(*bucket.GoalAmount),
+		})
+	}
+
+	// Stable sort keeps the repository's name order for equal progress
+	sort.SliceStable(goals, func(i, j int) bool {
+		return goals[i].Progress.GreaterThan(goals[j].Progress)
+	})
+
+	return goals, nil
+}
+
+// GetCategoryTrend returns how much was spent in an expense category per period within [start, end]
+// Spending is the sum of VIRTUAL DEBIT entries into the category net of CREDIT entries (refunds),
+// grouped by granularity (e.g. per month). Periods without entries are omitted
+func (s *DashboardService) GetCategoryTrend(ctx context.Context, categoryBucketID uuid.UUID, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	if end.Before(start) {
+		return nil, domain.NewValidationError("end date must not be before start date")
+	}
+	if !granularity.IsValid() {
+		return nil, domain.NewValidationErrorf("invalid granularity %q: must be day, week, month or year", granularity)
+	}
+
+	category, err := s.BucketRepo.GetByID(ctx, categoryBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := category.ValidateRole(domain.BucketRoleExpenseCategory); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.TransactionRepo.SumNetDebitsByPeriod(ctx, categoryBucketID, domain.LayerVirtual, start, end, granularity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum category spending: %w", err)
+	}
+
+	return totals, nil
+}
+
+// GetTransactionHistogram returns the number of transactions per day within [start, end] (e.g. for a calendar heatmap)
+// If bucketID is set, only transactions involving that bucket are counted
+// Scheduled transactions are not counted until they take effect; days without transactions are omitted
+func (s *DashboardService) GetTransactionHistogram(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	if end.Before(start) {
+		return nil, domain.NewValidationError("end date must not be before start date")
+	}
+
+	// Verify the bucket exists so an unknown ID is reported instead of an empty histogram
+	if bucketID != nil {
+		if _, err := s.BucketRepo.GetByID(ctx, *bucketID); err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := s.TransactionRepo.CountByDay(ctx, start, end, bucketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions by day: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetExpenseBreakdownByPhysicalBucket returns how much money left each physical bucket within [start, end]
+// Spending is the sum of PHYSICAL-layer CREDIT entries, so it follows the account that actually paid
+// (including a physical override) rather than the virtual bucket's parent
+// Buckets without spending are omitted; ordered by TotalSpent descending
+func (s *DashboardService) GetExpenseBreakdownByPhysicalBucket(ctx context.Context, start, end time.Time) ([]PhysicalBucketSpending, error) {
+	if end.Before(start) {
+		return nil, domain.NewValidationError("end date must not be before start date")
+	}
+
+	totals, err := s.TransactionRepo.SumCreditsByBucketType(ctx, domain.BucketTypePhysical, domain.LayerPhysical, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum physical bucket spending: %w", err)
+	}
+	if len(totals) == 0 {
+		return []PhysicalBucketSpending{}, nil
+	}
+
+	bucketIDs := make([]uuid.UUID, 0, len(totals))
+	for bucketID := range totals {
+		bucketIDs = append(bucketIDs, bucketID)
+	}
+	buckets, err := s.BucketRepo.GetByIDs(ctx, bucketIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get physical buckets: %w", err)
+	}
+
+	breakdown := make([]PhysicalBucketSpending, 0, len(totals))
+	for bucketID, total := range totals {
+		bucket, ok := buckets[bucketID]
+		if !ok {
+			return nil, fmt.Errorf("physical bucket %s not found", bucketID)
+		}
+		breakdown = append(breakdown, PhysicalBucketSpending{Bucket: bucket, TotalSpent: total})
+	}
+
+	// Map iteration order is random, so break ties by name for a stable result
+	sort.Slice(breakdown, func(i, j int) bool {
+		if !breakdown[i].TotalSpent.Equal(breakdown[j].TotalSpent) {
+			return breakdown[i].TotalSpent.GreaterThan(breakdown[j].TotalSpent)
+		}
+		return breakdown[i].Bucket.Name < breakdown[j].Bucket.Name
+	})
+
+	return breakdown, nil
+}
+
+// GetInflowAllocationShares returns what fraction of the external inflows within [start, end] each virtual bucket received
+// Allocations are reconstructed from the inflows' VIRTUAL DEBIT entries rather than from the current split rules,
+// so rule changes and targeted inflows are reflected as they were booked
+// Buckets that received nothing are omitted; ordered by Total descending
+func (s *DashboardService) GetInflowAllocationShares(ctx context.Context, start, end time.Time) ([]AllocationShare, error) {
+	if end.Before(start) {
+		return nil, domain.NewValidationError("end date must not be before start date")
+	}
+
+	totals, err := s.TransactionRepo.SumExternalInflowDebitsByBucket(ctx, domain.LayerVirtual, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum inflow allocations: %w", err)
+	}
+	if len(totals) == 0 {
+		return []AllocationShare{}, nil
+	}
+
+	bucketIDs := make([]uuid.UUID, 0, len(totals))
+	grandTotal := decimal.Zero
+	for bucketID, total := range totals {
+		bucketIDs = append(bucketIDs, bucketID)
+		grandTotal = grandTotal.Add(total)
+	}
+	buckets, err := s.BucketRepo.GetByIDs(ctx, bucketIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocation buckets: %w", err)
+	}
+
+	shares := make([]AllocationShare, 0, len(totals))
+	for bucketID, total := range totals {
+		bucket, ok := buckets[bucketID]
+		if !ok {
+			return nil, fmt.Errorf("allocation bucket %s not found", bucketID)
+		}
+		share := AllocationShare{Bucket: bucket, Total: total, SharePercent: decimal.Zero}
+		if !grandTotal.IsZero() {
+			share.SharePercent = total.Div(grandTotal).Mul(decimal.NewFromInt(100)).Round(2)
+		}
+		shares = append(shares, share)
+	}
+
+	// Map iteration order is random, so break ties by name for a stable result
+	sort.Slice(shares, func(i, j int) bool {
+		if !shares[i].Total.Equal(shares[j].Total) {
+			return shares[i].Total.GreaterThan(shares[j].Total)
+		}
+		return shares[i].Bucket.Name < shares[j].Bucket.Name
+	})
+
+	return shares, nil
+}
+
+// VerifyLedgerIntegrity runs Transaction.Validate on every stored transaction and reports the ones that fail
+// (e.g. unbalanced layers or zero-amount entries introduced by SQL edits outside the application)
+// Transactions are paged through ledgerIntegrityPageSize at a time so memory stays bounded
+func (s *DashboardService) VerifyLedgerIntegrity(ctx context.Context) (*LedgerIntegrityReport, error) {
+	report := &LedgerIntegrityReport{Violations: []LedgerViolation{}}
+
+	for offset := 0; ; offset += ledgerIntegrityPageSize {
+		transactions, err := s.TransactionRepo.List(ctx, ledgerIntegrityPageSize, offset, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			report.TransactionsChecked++
+			if err := tx.Validate(); err != nil {
+				report.Violations = append(report.Violations, LedgerViolation{
+					TransactionID: tx.ID,
+					Reason:        err.Error(),
+				})
+			}
+		}
+
+		if len(transactions) < ledgerIntegrityPageSize {
+			return report, nil
+		}
+	}
+}
+
+// GetProfitHistory returns the unrealized profit of an equity bucket for each market value date in [start, end]
+// Logic:
+//   - Market values come from market_value_history (ordered by date)
+//   - Book value is reconstructed historically: running sum of the bucket's balance changes
+//     (from transaction entries) dated up to and including each market value date
+//   - Profit: MarketValue - BookValue (see domain.CalculateProfit)
+func (s *DashboardService) GetProfitHistory(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]ProfitPoint, error) {
+	if end.Before(start) {
+		return nil, domain.NewValidationError("end date must not be before start date")
+	}
+
+	bucket, err := s.BucketRepo.GetByID(ctx, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	if bucket.BucketType != domain.BucketTypeEquity {
+		return nil, domain.NewValidationError("bucket ID must reference an equity bucket")
+	}
+
+	marketValues, err := s.MarketValueRepo.ListByBucket(ctx, bucketID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list market value history: %w", err)
+	}
+
+	changes, err := s.TransactionRepo.ListBalanceChanges(ctx, bucketID, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list balance changes: %w", err)
+	}
+
+	// Both slices are ordered by date, so a single pass accumulates the book value
+	points := make([]ProfitPoint, 0, len(marketValues))
+	bookValue := decimal.Zero
+	next := 0
+	for _, marketValue := range marketValues {
+		for next < len(changes) && !changes[next].Date.After(marketValue.Date) {
+			bookValue = bookValue.Add(changes[next].Totals.BalanceDelta())
+			next++
+		}
+
+		points = append(points, ProfitPoint{
+			Date:        marketValue.Date,
+			BookValue:   bookValue,
+			MarketValue: marketValue.MarketValue,
+			Profit:      domain.CalculateProfit(bookValue, marketValue.MarketValue),
+		})
+	}
+
+	return points, nil
+}
+
+// GetStatement reconstructs how net worth moved over [start, end), e.g. for a monthly statement
+// Logic (matching GetNetWorth at each end of the period):
+//   - Liquidity: PHYSICAL bucket balances rebuilt from the entries of transactions dated before the boundary;
+//     every transaction in the period with a non-zero net effect on them is listed with that effect
+//   - Equity: latest market value of each EQUITY bucket dated before the boundary (buckets without one count 0);
+//     every market value recorded in the period is listed with its change from the bucket's previous value
+//
+// The closing net worth is computed independently of the listed changes, and a statement whose
+// opening + changes does not add up to it is reported as an error rather than returned
+func (s *DashboardService) GetStatement(ctx context.Context, start, end time.Time) (*Statement, error) {
+	if !end.After(start) {
+		return nil, domain.NewValidationError("end date must be after start date")
+	}
+
+	physicalBuckets, err := s.BucketRepo.List(ctx, domain.BucketTypePhysical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list physical buckets: %w", err)
+	}
+	equityBuckets, err := s.BucketRepo.List(ctx, domain.BucketTypeEquity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equity buckets: %w", err)
+	}
+
+	statement := &Statement{
+		Start:              start,
+		End:                end,
+		Transactions:       make([]StatementTransaction, 0),
+		MarketValueChanges: make([]MarketValueChange, 0),
+	}
+
+	// 1. Liquidity at both ends, and the transactions in between
+	physicalByID := make(map[uuid.UUID]*domain.Bucket, len(physicalBuckets))
+	physicalIDs := make([]uuid.UUID, 0, len(physicalBuckets))
+	for _, bucket := range physicalBuckets {
+		physicalByID[bucket.ID] = bucket
+		physicalIDs = append(physicalIDs, bucket.ID)
+	}
+
+	openingLiquidity, err := s.liquidityBefore(ctx, physicalBuckets, start)
+	if err != nil {
+		return nil, err
+	}
+	closingLiquidity, err := s.liquidityBefore(ctx, physicalBuckets, end)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := decimal.Zero
+	if len(physicalIDs) > 0 {
+		transactions, err := s.TransactionRepo.ListInPeriod(ctx, physicalIDs, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions: %w", err)
+		}
+		for _, tx := range transactions {
+			liquidityChange := liquidityChangeOf(tx, physicalByID)
+			// Moves between physical buckets leave net worth unchanged
+			if liquidityChange.IsZero() {
+				continue
+			}
+			statement.Transactions = append(statement.Transactions, StatementTransaction{
+				Transaction:     tx,
+				LiquidityChange: liquidityChange,
+			})
+			changes = changes.Add(liquidityChange)
+		}
+	}
+
+	// 2. Equity at both ends, and the market values recorded in between
+	openingEquity := domain.ZeroMoney(domain.DefaultCurrency)
+	closingEquity := domain.ZeroMoney(domain.DefaultCurrency)
+	if len(equityBuckets) > 0 {
+		equityByID := make(map[uuid.UUID]*domain.Bucket, len(equityBuckets))
+		equityIDs := make([]uuid.UUID, 0, len(equityBuckets))
+		for _, bucket := range equityBuckets {
+			equityByID[bucket.ID] = bucket
+			equityIDs = append(equityIDs, bucket.ID)
+		}
+
+		// Entries are ordered by date, so the last one seen per bucket is its latest value so far
+		entries, err := s.MarketValueRepo.ListForBuckets(ctx, equityIDs, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list market value history: %w", err)
+		}
+		opening := make(map[uuid.UUID]decimal.Decimal, len(equityBuckets))
+		latest := make(map[uuid.UUID]decimal.Decimal, len(equityBuckets))
+		for _, entry := range entries {
+			if entry.Date.Before(start) {
+				opening[entry.BucketID] = entry.MarketValue
+			} else {
+				previous := latest[entry.BucketID]
+				change := entry.MarketValue.Sub(previous)
+				statement.MarketValueChanges = append(statement.MarketValueChanges, MarketValueChange{
+					Bucket:        equityByID[entry.BucketID],
+					Date:          entry.Date,
+					PreviousValue: previous,
+					MarketValue:   entry.MarketValue,
+					Change:        change,
+				})
+				changes = changes.Add(change)
+			}
+			latest[entry.BucketID] = entry.MarketValue
+		}
+
+		// Market values are recorded in the bucket's currency
+		for _, bucket := range equityBuckets {
+			currency := bucket.Balance().Currency
+			if openingEquity, err = openingEquity.Add(domain.NewMoney(opening[bucket.ID], currency)); err != nil {
+				return nil, fmt.Errorf("failed to sum equity market values: %w", err)
+			}
+			if closingEquity, err = closingEquity.Add(domain.NewMoney(latest[bucket.ID], currency)); err != nil {
+				return nil, fmt.Errorf("failed to sum equity market values: %w", err)
+			}
+		}
+	}
+
+	// 3. Net worth at both ends, which the listed changes must reconcile
+	openingNetWorth, err := openingLiquidity.Add(openingEquity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum opening net worth: %w", err)
+	}
+	closingNetWorth, err := closingLiquidity.Add(closingEquity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum closing net worth: %w", err)
+	}
+	if !openingNetWorth.Amount.Add(changes).Equal(closingNetWorth.Amount) {
+		return nil, fmt.Errorf("statement does not reconcile: opening %s + changes %s != closing %s",
+			openingNetWorth.Amount, changes, closingNetWorth.Amount)
+	}
+
+	statement.OpeningNetWorth = openingNetWorth.Amount
+	statement.ClosingNetWorth = closingNetWorth.Amount
+	return statement, nil
+}
+
+// GetUpcoming returns a cash-flow forecast of the scheduled transactions due within the next horizonDays days
+// Logic:
+//   - Items: every pending scheduled transaction with an effective date up to now + horizonDays, oldest first
+//     (overdue ones that were not activated yet are included, since their money has not moved either),
+//     with its net effect on the PHYSICAL bucket balances; moves between physical buckets are left out
+//   - Each item carries the running projected liquidity, starting from the current PHYSICAL balances
+//
+// Scheduled transactions are the only forward-looking records and only LogExpense can schedule one (RecordInflow
+// has no effective date), so the forecast covers outgoing obligations only: expected inflows are not projected
+func (s *DashboardService) GetUpcoming(ctx context.Context, horizonDays int) (*UpcomingForecast, error) {
+	if horizonDays <= 0 {
+		return nil, domain.NewValidationError("horizon days must be positive")
+	}
+
+	physicalBuckets, err := s.BucketRepo.List(ctx, domain.BucketTypePhysical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list physical buckets: %w", err)
+	}
+	liquidity, err := sumBalances(physicalBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum liquidity: %w", err)
+	}
+	physicalByID := make(map[uuid.UUID]*domain.Bucket, len(physicalBuckets))
+	for _, bucket := range physicalBuckets {
+		physicalByID[bucket.ID] = bucket
+	}
+
+	until := time.Now().AddDate(0, 0, horizonDays)
+	transactions, err := s.TransactionRepo.ListScheduledDue(ctx, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+
+	forecast := &UpcomingForecast{
+		Until:            until,
+		CurrentLiquidity: liquidity.Amount,
+		Items:            make([]UpcomingItem, 0, len(transactions)),
+	}
+	projected := liquidity.Amount
+	for _, tx := range transactions {
+		liquidityChange := liquidityChangeOf(tx, physicalByID)
+		if liquidityChange.IsZero() {
+			continue
+		}
+		projected = projected.Add(liquidityChange)
+		forecast.Items = append(forecast.Items, UpcomingItem{
+			Transaction:      tx,
+			LiquidityChange:  liquidityChange,
+			ProjectedBalance: projected,
+		})
+	}
+	forecast.ProjectedLiquidity = projected
+
+	return forecast, nil
+}
+
+// liquidityChangeOf returns the net effect of a transaction's entries on the given PHYSICAL buckets
+// (negative for money leaving); entries on other buckets are ignored
+func liquidityChangeOf(tx *domain.Transaction, physicalByID map[uuid.UUID]*domain.Bucket) decimal.Decimal {
+	change := decimal.Zero
+	for _, entry := range tx.Entries {
+		if _, ok := physicalByID[entry.BucketID]; ok {
+			effect := decimal.NewFromInt(int64(domain.BalanceEffect(entry.Type)))
+			change = change.Add(entry.Amount.Mul(effect))
+		}
+	}
+	return change
+}
+
+// liquidityBefore returns the summed balances of physicalBuckets rebuilt from the entries of transactions dated before the given time
+// Every bucket must be in domain.DefaultCurrency (see sumBalances)
+func (s *DashboardService) liquidityBefore(ctx context.Context, physicalBuckets []*domain.Bucket, before time.Time) (domain.Money, error) {
+	liquidity := domain.ZeroMoney(domain.DefaultCurrency)
+	if len(physicalBuckets) == 0 {
+		return liquidity, nil
+	}
+
+	bucketIDs := make([]uuid.UUID, 0, len(physicalBuckets))
+	for _, bucket := range physicalBuckets {
+		bucketIDs = append(bucketIDs, bucket.ID)
+	}
+	sums, err := s.TransactionRepo.SumEntriesByBucketBefore(ctx, bucketIDs, before)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("failed to sum physical bucket entries: %w", err)
+	}
+
+	for _, bucket := range physicalBuckets {
+		balance := domain.NewMoney(sums[bucket.ID].BalanceDelta(), bucket.Balance().Currency)
+		if liquidity, err = liquidity.Add(balance); err != nil {
+			return domain.Money{}, fmt.Errorf("bucket %s: %w", bucket.ID, err)
+		}
+	}
+	return liquidity, nil
+}
@@ -0,0 +1,1425 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBucketRepository is a mock implementation of BucketRepository for testing
+type MockBucketRepository struct {
+	mock.Mock
+}
+
+func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
+	args := m.Called(ctx, bucket)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) GetSystemBucket(ctx context.Context, bucketType domain.BucketType) (*domain.Bucket, error) {
+	args := m.Called(ctx, bucketType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.BucketType) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, typeFilter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+// MockTransactionRepository is a mock implementation of TransactionRepository for testing
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+// MockHoldingRepository is a mock implementation of HoldingRepository for testing
+type MockHoldingRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldingRepository) GetByBucketID(ctx context.Context, bucketID uuid.UUID) (*domain.Holding, error) {
+	args := m.Called(ctx, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Holding), args.Error(1)
+}
+
+func (m *MockHoldingRepository) GetForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.Holding, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Holding), args.Error(1)
+}
+
+func (m *MockHoldingRepository) Save(ctx context.Context, holding *domain.Holding) error {
+	args := m.Called(ctx, holding)
+	return args.Error(0)
+}
+
+// MockMarketValueRepository is a mock implementation of MarketValueRepository for testing
+type MockMarketValueRepository struct {
+	mock.Mock
+}
+
+func (m *MockMarketValueRepository) Add(ctx context.Context, entry *domain.MarketValueHistory) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockMarketValueRepository) GetLatest(ctx context.Context, bucketID uuid.UUID) (*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) GetLatestForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListByBucket(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListForBuckets(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) List(ctx context.Context, bucketID uuid.UUID, limit, offset int, ascending bool) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, limit, offset, ascending)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) Count(ctx context.Context, bucketID uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetNetWorth_EquityProfit(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), mockMarketValueRepo, new(MockHoldingRepository))
+
+	physicalBuckets := []*domain.Bucket{
+		{ID: uuid.New(), Name: "Main Bank", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(2000)},
+	}
+
+	stocksID := uuid.New()
+	cryptoID := uuid.New()
+	unvaluedID := uuid.New()
+	equityBuckets := []*domain.Bucket{
+		{ID: stocksID, Name: "Tesla Stock", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(1000)},
+		{ID: cryptoID, Name: "Crypto", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(500)},
+		{ID: unvaluedID, Name: "New ETF", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(200)},
+	}
+
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return(physicalBuckets, nil)
+	mockBucketRepo.On("List", ctx, domain.BucketTypeEquity).Return(equityBuckets, nil)
+	mockMarketValueRepo.On("GetLatestForBuckets", ctx, []uuid.UUID{stocksID, cryptoID, unvaluedID}).Return(map[uuid.UUID]*domain.MarketValueHistory{
+		stocksID: {BucketID: stocksID, MarketValue: decimal.NewFromInt(1300)},
+		cryptoID: {BucketID: cryptoID, MarketValue: decimal.NewFromInt(400)},
+	}, nil)
+
+	result, err := service.GetNetWorth(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Equity.Equal(decimal.NewFromInt(1700)), "expected equity 1700, got %s", result.Equity)
+	// The unvalued bucket is left out of all three equity totals, so Equity - EquityBookValue = EquityProfit
+	assert.True(t, result.EquityBookValue.Equal(decimal.NewFromInt(1500)), "expected book value 1500, got %s", result.EquityBookValue)
+	// (1300 - 1000) + (400 - 500) = 200
+	assert.True(t, result.EquityProfit.Equal(decimal.NewFromInt(200)), "expected profit 200, got %s", result.EquityProfit)
+	assert.True(t, result.Equity.Sub(result.EquityBookValue).Equal(result.EquityProfit))
+	assert.True(t, result.Total.Equal(decimal.NewFromInt(3700)), "expected total 3700, got %s", result.Total)
+	mockBucketRepo.AssertExpectations(t)
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestGetNetWorth_UnitPricedHolding(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), mockMarketValueRepo, mockHoldingRepo)
+
+	// 2 units priced at 120 (market value 240), then 0.5 more units bought at 150 (book value 275)
+	etfID := uuid.New()
+	unitPrice := decimal.NewFromInt(120)
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return([]*domain.Bucket{}, nil)
+	mockBucketRepo.On("List", ctx, domain.BucketTypeEquity).Return([]*domain.Bucket{
+		{ID: etfID, Name: "VWCE", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(275)},
+	}, nil)
+	mockMarketValueRepo.On("GetLatestForBuckets", ctx, []uuid.UUID{etfID}).Return(map[uuid.UUID]*domain.MarketValueHistory{
+		etfID: {BucketID: etfID, MarketValue: decimal.NewFromInt(240), UnitPrice: &unitPrice},
+	}, nil)
+	mockHoldingRepo.On("GetForBuckets", ctx, []uuid.UUID{etfID}).Return(map[uuid.UUID]*domain.Holding{
+		etfID: {BucketID: etfID, Quantity: decimal.RequireFromString("2.5"), AvgCost: decimal.NewFromInt(110)},
+	}, nil)
+
+	result, err := service.GetNetWorth(ctx)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	// Valued like InvestmentService.CalculateProfitDetail: 2.5 * 120 = 300, not the stored 240
+	assert.True(t, result.Equity.Equal(decimal.NewFromInt(300)), "expected equity 300, got %s", result.Equity)
+	assert.True(t, result.EquityProfit.Equal(decimal.NewFromInt(25)), "expected profit 25, got %s", result.EquityProfit)
+	mockHoldingRepo.AssertExpectations(t)
+}
+
+func TestGetNetWorth_CurrencyMismatch(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), mockMarketValueRepo, new(MockHoldingRepository))
+
+	physicalBuckets := []*domain.Bucket{
+		{ID: uuid.New(), Name: "Main Bank", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(2000)},
+		{ID: uuid.New(), Name: "US Account", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(500), Currency: "USD"},
+	}
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return(physicalBuckets, nil)
+
+	result, err := service.GetNetWorth(ctx)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch, "Balances in different currencies must not be added up")
+	mockMarketValueRepo.AssertNotCalled(t, "GetLatestForBuckets", mock.Anything, mock.Anything)
+}
+
+func TestGetBucketTree(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, mockMarketValueRepo, new(MockHoldingRepository))
+
+	// Setup: Two physical buckets
+	mainBankID := uuid.New()
+	mainBank := &domain.Bucket{
+		ID:             mainBankID,
+		Name:           "Main Bank",
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}
+
+	savingsBankID := uuid.New()
+	savingsBank := &domain.Bucket{
+		ID:             savingsBankID,
+		Name:           "Savings Bank",
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.NewFromInt(500),
+	}
+
+	// Setup: Main Bank holds two virtual buckets that don't cover its full balance
+	freeCash := &domain.Bucket{
+		ID:                     uuid.New(),
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &mainBankID,
+		CurrentBalance:         decimal.NewFromInt(600),
+	}
+	fixedCosts := &domain.Bucket{
+		ID:                     uuid.New(),
+		Name:                   "Fixed Costs",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &mainBankID,
+		CurrentBalance:         decimal.NewFromInt(300),
+	}
+
+	// Setup: Savings Bank holds one virtual bucket covering its full balance
+	emergency := &domain.Bucket{
+		ID:                     uuid.New(),
+		Name:                   "Emergency Fund",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &savingsBankID,
+		CurrentBalance:         decimal.NewFromInt(500),
+	}
+
+	mockBucketRepo.On("ListPhysicalWithChildren", ctx).Return([]domain.BucketFamily{
+		{Physical: mainBank, Children: []*domain.Bucket{freeCash, fixedCosts}},
+		{Physical: savingsBank, Children: []*domain.Bucket{emergency}},
+	}, nil)
+
+	// Execute
+	nodes, err := service.GetBucketTree(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 2)
+
+	assert.Equal(t, mainBankID, nodes[0].Physical.ID)
+	assert.Len(t, nodes[0].Children, 2)
+	assert.True(t, nodes[0].VirtualBalance.Equal(decimal.NewFromInt(900)))
+	assert.True(t, nodes[0].Unbucketed.Equal(decimal.NewFromInt(100))) // 1000 - 900
+
+	assert.Equal(t, savingsBankID, nodes[1].Physical.ID)
+	assert.Len(t, nodes[1].Children, 1)
+	assert.True(t, nodes[1].VirtualBalance.Equal(decimal.NewFromInt(500)))
+	assert.True(t, nodes[1].Unbucketed.IsZero())
+
+	mockBucketRepo.AssertExpectations(t)
+}
+
+func TestGetUnbucketedAmount(t *testing.T) {
+	ctx := context.Background()
+
+	physicalBucketID := uuid.New()
+	otherPhysicalID := uuid.New()
+
+	tests := []struct {
+		name               string
+		physicalBalance    decimal.Decimal
+		virtualBuckets     []*domain.Bucket
+		expectedUnbucketed decimal.Decimal
+		expectedDrift      bool
+	}{
+		{
+			name:            "Fully allocated physical bucket has no drift",
+			physicalBalance: decimal.NewFromInt(1000),
+			virtualBuckets: []*domain.Bucket{
+				{ID: uuid.New(), Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID, CurrentBalance: decimal.NewFromInt(700)},
+				{ID: uuid.New(), Name: "Fixed Costs", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID, CurrentBalance: decimal.NewFromInt(300)},
+			},
+			expectedUnbucketed: decimal.Zero,
+			expectedDrift:      false,
+		},
+		{
+			name:            "Unassigned money is flagged as drift",
+			physicalBalance: decimal.NewFromInt(1000),
+			virtualBuckets: []*domain.Bucket{
+				{ID: uuid.New(), Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID, CurrentBalance: decimal.NewFromInt(750)},
+				// Belongs to a different physical bucket - must be ignored
+				{ID: uuid.New(), Name: "Other", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &otherPhysicalID, CurrentBalance: decimal.NewFromInt(250)},
+			},
+			expectedUnbucketed: decimal.NewFromInt(250),
+			expectedDrift:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+			physicalBucket := &domain.Bucket{
+				ID:             physicalBucketID,
+				Name:           "Main Bank",
+				BucketType:     domain.BucketTypePhysical,
+				CurrentBalance: tt.physicalBalance,
+			}
+
+			mockBucketRepo.On("GetByID", ctx, physicalBucketID).Return(physicalBucket, nil)
+			mockBucketRepo.On("List", ctx, domain.BucketTypeVirtual).Return(tt.virtualBuckets, nil)
+
+			result, err := service.GetUnbucketedAmount(ctx, physicalBucketID)
+
+			assert.NoError(t, err)
+			assert.True(t, result.Unbucketed.Equal(tt.expectedUnbucketed), "expected %s, got %s", tt.expectedUnbucketed, result.Unbucketed)
+			assert.Equal(t, tt.expectedDrift, result.HasDrift)
+			mockBucketRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUnbucketedAmount_NotPhysical(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	parentID := uuid.New()
+	virtualBucketID := uuid.New()
+	virtualBucket := &domain.Bucket{
+		ID:                     virtualBucketID,
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &parentID,
+		CurrentBalance:         decimal.NewFromInt(100),
+	}
+
+	mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(virtualBucket, nil)
+
+	result, err := service.GetUnbucketedAmount(ctx, virtualBucketID)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "must reference a physical bucket")
+}
+
+func TestLatestMarketValues(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(new(MockBucketRepository), new(MockTransactionRepository), mockMarketValueRepo, new(MockHoldingRepository))
+
+	stocksID := uuid.New()
+	unvaluedID := uuid.New()
+	buckets := []*domain.Bucket{
+		{ID: uuid.New(), Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+		{ID: stocksID, Name: "Stocks", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(1000)},
+		{ID: unvaluedID, Name: "Crypto", BucketType: domain.BucketTypeEquity},
+	}
+
+	// Only the equity buckets are looked up, in one batch
+	mockMarketValueRepo.On("GetLatestForBuckets", ctx, []uuid.UUID{stocksID, unvaluedID}).Return(map[uuid.UUID]*domain.MarketValueHistory{
+		stocksID: {BucketID: stocksID, MarketValue: decimal.NewFromInt(1300)},
+	}, nil)
+
+	result, err := service.LatestMarketValues(ctx, buckets)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.True(t, result[stocksID].Equal(decimal.NewFromInt(1300)), "expected 1300, got %s", result[stocksID])
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestLatestMarketValues_NoEquityBuckets(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(new(MockBucketRepository), new(MockTransactionRepository), mockMarketValueRepo, new(MockHoldingRepository))
+
+	buckets := []*domain.Bucket{
+		{ID: uuid.New(), Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+	}
+
+	result, err := service.LatestMarketValues(ctx, buckets)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockMarketValueRepo.AssertNotCalled(t, "GetLatestForBuckets", mock.Anything, mock.Anything)
+}
+
+func TestListBucketsByParent(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mainBankID := uuid.New()
+	mainBank := &domain.Bucket{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}
+	children := []*domain.Bucket{
+		{ID: uuid.New(), Name: "Fixed Costs", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		{ID: uuid.New(), Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	}
+
+	mockBucketRepo.On("GetByID", ctx, mainBankID).Return(mainBank, nil)
+	mockBucketRepo.On("ListByParent", ctx, mainBankID).Return(children, nil)
+
+	result, err := service.ListBucketsByParent(ctx, mainBankID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, children, result)
+	mockBucketRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionsBetweenBuckets(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mainBankID, savingsBankID, unallocatedID := uuid.New(), uuid.New(), uuid.New()
+	mockBucketRepo.On("GetByID", ctx, mainBankID).Return(&domain.Bucket{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, savingsBankID).Return(&domain.Bucket{ID: savingsBankID, Name: "Savings Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, unallocatedID).Return(&domain.Bucket{ID: unallocatedID, Name: "Unallocated", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+	transfers := []*domain.Transaction{{ID: uuid.New(), Description: "Complete transfer", IsInternalTransfer: true}}
+	mockTxRepo.On("ListBetweenBuckets", ctx, mainBankID, savingsBankID).Return(transfers, nil)
+
+	result, err := service.GetTransactionsBetweenBuckets(ctx, mainBankID, savingsBankID)
+	assert.NoError(t, err)
+	assert.Equal(t, transfers, result)
+
+	// Only two different physical buckets can be compared
+	_, err = service.GetTransactionsBetweenBuckets(ctx, mainBankID, mainBankID)
+	assert.Contains(t, err.Error(), "buckets must differ")
+	_, err = service.GetTransactionsBetweenBuckets(ctx, mainBankID, unallocatedID)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "must be a physical bucket")
+	mockTxRepo.AssertNumberOfCalls(t, "ListBetweenBuckets", 1)
+}
+
+func TestGetFundingBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mainBankID, groceriesID, salaryID, missingID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mainBank := &domain.Bucket{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}
+	mockBucketRepo.On("GetByID", ctx, mainBankID).Return(mainBank, nil)
+	mockBucketRepo.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+	mockBucketRepo.On("GetByID", ctx, salaryID).Return(&domain.Bucket{ID: salaryID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, missingID).Return(nil, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, missingID))
+
+	result, err := service.GetFundingBucket(ctx, groceriesID)
+	assert.NoError(t, err)
+	assert.Equal(t, mainBank, result)
+
+	// Only virtual buckets have a funding bucket
+	_, err = service.GetFundingBucket(ctx, salaryID)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "must be a virtual bucket")
+
+	_, err = service.GetFundingBucket(ctx, missingID)
+	assert.ErrorIs(t, err, domain.ErrBucketNotFound)
+}
+
+func TestListBucketsByParent_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	incomeID := uuid.New()
+	missingID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeID).Return(&domain.Bucket{ID: incomeID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, missingID).Return(nil, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, missingID))
+
+	_, err := service.ListBucketsByParent(ctx, incomeID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must reference a physical bucket")
+
+	_, err = service.ListBucketsByParent(ctx, missingID)
+	assert.ErrorIs(t, err, domain.ErrBucketNotFound)
+
+	mockBucketRepo.AssertNotCalled(t, "ListByParent", mock.Anything, mock.Anything)
+}
+
+func TestGetProfitHistory(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, mockMarketValueRepo, new(MockHoldingRepository))
+
+	stockID := uuid.New()
+	day := func(d int) time.Time { return time.Date(2025, time.January, d, 12, 0, 0, 0, time.UTC) }
+	start, end := day(1), day(31)
+
+	mockBucketRepo.On("GetByID", ctx, stockID).Return(&domain.Bucket{ID: stockID, Name: "ETF", BucketType: domain.BucketTypeEquity}, nil)
+	mockMarketValueRepo.On("ListByBucket", ctx, stockID, start, end).Return([]*domain.MarketValueHistory{
+		{ID: uuid.New(), BucketID: stockID, Date: day(5), MarketValue: decimal.NewFromInt(1100)},
+		{ID: uuid.New(), BucketID: stockID, Date: day(10), MarketValue: decimal.NewFromInt(1400)},
+		{ID: uuid.New(), BucketID: stockID, Date: day(20), MarketValue: decimal.NewFromInt(1300)},
+	}, nil)
+	// Bought 1000 before the first valuation, 500 more on the second valuation date, sold 200 after the last
+	mockTxRepo.On("ListBalanceChanges", ctx, stockID, end).Return([]domain.BalanceChange{
+		{Date: day(2), Totals: domain.EntryTotals{Debits: decimal.NewFromInt(1000), Credits: decimal.Zero}},
+		{Date: day(10), Totals: domain.EntryTotals{Debits: decimal.NewFromInt(500), Credits: decimal.Zero}},
+		{Date: day(25), Totals: domain.EntryTotals{Debits: decimal.Zero, Credits: decimal.NewFromInt(200)}},
+	}, nil)
+
+	points, err := service.GetProfitHistory(ctx, stockID, start, end)
+
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+
+	expected := []struct {
+		bookValue int64
+		profit    int64
+	}{
+		{1000, 100},  // 1100 - 1000
+		{1500, -100}, // Same-date purchase is included: 1400 - 1500
+		{1500, -200}, // Later sale not yet applied: 1300 - 1500
+	}
+	for i, exp := range expected {
+		assert.True(t, points[i].BookValue.Equal(decimal.NewFromInt(exp.bookValue)), "point %d book value: %s", i, points[i].BookValue)
+		assert.True(t, points[i].Profit.Equal(decimal.NewFromInt(exp.profit)), "point %d profit: %s", i, points[i].Profit)
+	}
+	assert.Equal(t, day(10), points[1].Date)
+
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestGetProfitHistory_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), mockMarketValueRepo, new(MockHoldingRepository))
+
+	bankID := uuid.New()
+	now := time.Now()
+	mockBucketRepo.On("GetByID", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+
+	_, err := service.GetProfitHistory(ctx, bankID, now, now.Add(-time.Hour))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "end date must not be before start date")
+
+	_, err = service.GetProfitHistory(ctx, bankID, now.Add(-time.Hour), now)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must reference an equity bucket")
+
+	mockMarketValueRepo.AssertNotCalled(t, "ListByBucket", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetBucketsSummary(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mainBankID := uuid.New()
+	buckets := []*domain.Bucket{
+		{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(1500)},
+		{ID: uuid.New(), Name: "Credit Card", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(-300)},
+		{ID: uuid.New(), Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID, CurrentBalance: decimal.NewFromInt(900)},
+		{ID: uuid.New(), Name: "Vault", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID, CurrentBalance: decimal.NewFromInt(600)},
+		{ID: uuid.New(), Name: "Tesla Stock", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(2000)},
+	}
+	mockBucketRepo.On("List", ctx, domain.BucketType("")).Return(buckets, nil)
+
+	summary, err := service.GetBucketsSummary(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[domain.BucketType]int{
+		domain.BucketTypePhysical: 2,
+		domain.BucketTypeVirtual:  2,
+		domain.BucketTypeEquity:   1,
+	}, summary.CountsByType)
+	// Only physical balances count towards liquidity: 1500 - 300
+	assert.True(t, summary.TotalLiquidity.Equal(decimal.NewFromInt(1200)), "expected liquidity 1200, got %s", summary.TotalLiquidity)
+	mockBucketRepo.AssertNumberOfCalls(t, "List", 1)
+}
+
+func TestRecalculateAllBalances(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	// Vault drifted by 100, Empty has no entries but a stored balance of 25
+	mockBucketRepo.On("RecalculateBalances", ctx, []domain.BucketType{domain.BucketTypePhysical, domain.BucketTypeVirtual}).Return([]domain.BalanceCorrection{
+		{BucketID: uuid.New(), Previous: decimal.NewFromInt(600), Corrected: decimal.NewFromInt(500)},
+		{BucketID: uuid.New(), Previous: decimal.NewFromInt(25), Corrected: decimal.Zero},
+	}, nil)
+
+	result, err := service.RecalculateAllBalances(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.BucketsCorrected)
+	assert.True(t, result.TotalDrift.Equal(decimal.NewFromInt(125)), "expected drift 125, got %s", result.TotalDrift)
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertNotCalled(t, "SumEntriesByBucket", mock.Anything, mock.Anything)
+}
+
+func TestRecalculateAllBalances_NothingToCorrect(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mockBucketRepo.On("RecalculateBalances", ctx, mock.Anything).Return([]domain.BalanceCorrection{}, nil)
+
+	result, err := service.RecalculateAllBalances(ctx)
+
+	assert.NoError(t, err)
+	assert.Zero(t, result.BucketsCorrected)
+	assert.True(t, result.TotalDrift.IsZero())
+}
+
+func TestListBucketGoals(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	goal := func(amount int64) *decimal.Decimal {
+		d := decimal.NewFromInt(amount)
+		return &d
+	}
+	emergency := &domain.Bucket{ID: uuid.New(), Name: "Emergency Fund", BucketType: domain.BucketTypeVirtual, CurrentBalance: decimal.NewFromInt(800), GoalAmount: goal(1000)}
+	holiday := &domain.Bucket{ID: uuid.New(), Name: "Holiday", BucketType: domain.BucketTypeVirtual, CurrentBalance: decimal.NewFromInt(100), GoalAmount: goal(2000)}
+	laptop := &domain.Bucket{ID: uuid.New(), Name: "Laptop", BucketType: domain.BucketTypeVirtual, CurrentBalance: decimal.NewFromInt(1500), GoalAmount: goal(1200)}
+	mockBucketRepo.On("ListWithGoals", ctx).Return([]*domain.Bucket{emergency, holiday, laptop}, nil)
+
+	goals, err := service.ListBucketGoals(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, goals, 3) {
+		assert.Equal(t, "Laptop", goals[0].Bucket.Name)
+		assert.True(t, goals[0].Met, "Exceeded goal should be met")
+		assert.True(t, goals[0].Progress.Equal(decimal.NewFromInt(125)))
+
+		assert.Equal(t, "Emergency Fund", goals[1].Bucket.Name)
+		assert.False(t, goals[1].Met)
+		assert.True(t, goals[1].Progress.Equal(decimal.NewFromInt(80)))
+
+		assert.Equal(t, "Holiday", goals[2].Bucket.Name)
+		assert.True(t, goals[2].Progress.Equal(decimal.NewFromInt(5)))
+	}
+}
+
+func TestListBucketGoals_NoGoals(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mockBucketRepo.On("ListWithGoals", ctx).Return([]*domain.Bucket{}, nil)
+
+	goals, err := service.ListBucketGoals(ctx)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, goals)
+	assert.Empty(t, goals)
+}
+
+func TestGetCategoryTrend(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	groceriesID := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	totals := []domain.PeriodTotal{
+		{Period: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Total: decimal.NewFromInt(320)},
+		{Period: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Total: decimal.NewFromInt(275)},
+	}
+	mockBucketRepo.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+	mockTxRepo.On("SumNetDebitsByPeriod", ctx, groceriesID, domain.LayerVirtual, start, end, domain.TrendGranularityMonth).Return(totals, nil)
+
+	result, err := service.GetCategoryTrend(ctx, groceriesID, start, end, domain.TrendGranularityMonth)
+
+	assert.NoError(t, err)
+	assert.Equal(t, totals, result)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestGetCategoryTrend_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	bankID := uuid.New()
+	now := time.Now()
+	mockBucketRepo.On("GetByID", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+
+	_, err := service.GetCategoryTrend(ctx, bankID, now, now.Add(-time.Hour), domain.TrendGranularityMonth)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "end date must not be before start date")
+
+	_, err = service.GetCategoryTrend(ctx, bankID, now.Add(-time.Hour), now, domain.TrendGranularity("fortnight"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid granularity")
+
+	_, err = service.GetCategoryTrend(ctx, bankID, now.Add(-time.Hour), now, domain.TrendGranularityMonth)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "category bucket ID must reference an expense bucket")
+
+	mockTxRepo.AssertNotCalled(t, "SumNetDebitsByPeriod", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTransactionHistogram(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	counts := []domain.DateCount{
+		{Date: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), Count: 2},
+		{Date: time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), Count: 5},
+	}
+	mockTxRepo.On("CountByDay", ctx, start, end, (*uuid.UUID)(nil)).Return(counts, nil)
+
+	result, err := service.GetTransactionHistogram(ctx, start, end, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, counts, result)
+	mockBucketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestGetTransactionHistogram_BucketFilter(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	bankID := uuid.New()
+	unknownID := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockBucketRepo.On("GetByID", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, unknownID).Return(nil, domain.ErrBucketNotFound)
+	mockTxRepo.On("CountByDay", ctx, start, end, &bankID).Return([]domain.DateCount{}, nil)
+
+	result, err := service.GetTransactionHistogram(ctx, start, end, &bankID)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = service.GetTransactionHistogram(ctx, start, end, &unknownID)
+	assert.ErrorIs(t, err, domain.ErrBucketNotFound)
+
+	_, err = service.GetTransactionHistogram(ctx, end, start, nil)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	mockTxRepo.AssertNumberOfCalls(t, "CountByDay", 1)
+}
+
+func TestGetExpenseBreakdownByPhysicalBucket_Override(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	// Groceries is a virtual bucket under Main Bank, but one expense was paid with the
+	// Credit Card by mistake (physical override): the card, not the bank, was credited
+	bankID := uuid.New()
+	cardID := uuid.New()
+	buckets := map[uuid.UUID]*domain.Bucket{
+		bankID: {ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+		cardID: {ID: cardID, Name: "Credit Card", BucketType: domain.BucketTypePhysical},
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockTxRepo.On("SumCreditsByBucketType", ctx, domain.BucketTypePhysical, domain.LayerPhysical, start, end).Return(map[uuid.UUID]decimal.Decimal{
+		bankID: decimal.NewFromInt(40),
+		cardID: decimal.NewFromInt(65),
+	}, nil)
+	mockBucketRepo.On("GetByIDs", ctx, mock.MatchedBy(func(ids []uuid.UUID) bool {
+		return assert.ElementsMatch(t, []uuid.UUID{bankID, cardID}, ids)
+	})).Return(buckets, nil)
+
+	result, err := service.GetExpenseBreakdownByPhysicalBucket(ctx, start, end)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Credit Card", result[0].Bucket.Name)
+	assert.True(t, decimal.NewFromInt(65).Equal(result[0].TotalSpent))
+	assert.Equal(t, "Main Bank", result[1].Bucket.Name)
+	assert.True(t, decimal.NewFromInt(40).Equal(result[1].TotalSpent))
+}
+
+func TestGetExpenseBreakdownByPhysicalBucket_NoSpending(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockTxRepo.On("SumCreditsByBucketType", ctx, domain.BucketTypePhysical, domain.LayerPhysical, start, end).Return(map[uuid.UUID]decimal.Decimal{}, nil)
+
+	result, err := service.GetExpenseBreakdownByPhysicalBucket(ctx, start, end)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockBucketRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+
+	_, err = service.GetExpenseBreakdownByPhysicalBucket(ctx, end, start)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestGetInflowAllocationShares(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	// Two inflows in the period:
+	//   Salary 2000: Vault 500, Free Cash 1500
+	//   Bonus  1000: Vault 500, Free Cash 500
+	// Vault received 1000 of 3000 (33.33%), Free Cash 2000 of 3000 (66.67%)
+	vaultID := uuid.New()
+	freeCashID := uuid.New()
+	buckets := map[uuid.UUID]*domain.Bucket{
+		vaultID:    {ID: vaultID, Name: "Vault", BucketType: domain.BucketTypeVirtual},
+		freeCashID: {ID: freeCashID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual},
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockTxRepo.On("SumExternalInflowDebitsByBucket", ctx, domain.LayerVirtual, start, end).Return(map[uuid.UUID]decimal.Decimal{
+		vaultID:    decimal.NewFromInt(500).Add(decimal.NewFromInt(500)),
+		freeCashID: decimal.NewFromInt(1500).Add(decimal.NewFromInt(500)),
+	}, nil)
+	mockBucketRepo.On("GetByIDs", ctx, mock.Anything).Return(buckets, nil)
+
+	result, err := service.GetInflowAllocationShares(ctx, start, end)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Free Cash", result[0].Bucket.Name)
+	assert.True(t, decimal.NewFromInt(2000).Equal(result[0].Total))
+	assert.Equal(t, "66.67", result[0].SharePercent.StringFixed(2))
+	assert.Equal(t, "Vault", result[1].Bucket.Name)
+	assert.True(t, decimal.NewFromInt(1000).Equal(result[1].Total))
+	assert.Equal(t, "33.33", result[1].SharePercent.StringFixed(2))
+}
+
+func TestGetInflowAllocationShares_NoInflows(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockTxRepo.On("SumExternalInflowDebitsByBucket", ctx, domain.LayerVirtual, start, end).Return(map[uuid.UUID]decimal.Decimal{}, nil)
+
+	result, err := service.GetInflowAllocationShares(ctx, start, end)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	mockBucketRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+
+	_, err = service.GetInflowAllocationShares(ctx, end, start)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestVerifyLedgerIntegrity(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(new(MockBucketRepository), mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	balanced := func() *domain.Transaction {
+		txID := uuid.New()
+		bankID := uuid.New()
+		incomeID := uuid.New()
+		return &domain.Transaction{
+			ID:          txID,
+			Description: "Salary",
+			Entries: []domain.TransactionEntry{
+				{ID: uuid.New(), TransactionID: txID, BucketID: bankID, Amount: decimal.NewFromInt(100), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+				{ID: uuid.New(), TransactionID: txID, BucketID: incomeID, Amount: decimal.NewFromInt(100), Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			},
+		}
+	}
+
+	// A full first page forces a second page; the corrupted transaction sits on the second one
+	firstPage := make([]*domain.Transaction, ledgerIntegrityPageSize)
+	for i := range firstPage {
+		firstPage[i] = balanced()
+	}
+	unbalanced := balanced()
+	unbalanced.Entries[1].Amount = decimal.NewFromInt(90) // e.g. edited directly in SQL
+	empty := &domain.Transaction{ID: uuid.New(), Description: "Orphaned header", Entries: []domain.TransactionEntry{}}
+	secondPage := []*domain.Transaction{balanced(), unbalanced, empty}
+
+	mockTxRepo.On("List", ctx, ledgerIntegrityPageSize, 0, (*uuid.UUID)(nil)).Return(firstPage, nil)
+	mockTxRepo.On("List", ctx, ledgerIntegrityPageSize, ledgerIntegrityPageSize, (*uuid.UUID)(nil)).Return(secondPage, nil)
+
+	report, err := service.VerifyLedgerIntegrity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerIntegrityPageSize+3, report.TransactionsChecked)
+	assert.Len(t, report.Violations, 2)
+	assert.Equal(t, unbalanced.ID, report.Violations[0].TransactionID)
+	assert.Contains(t, report.Violations[0].Reason, "PHYSICAL layer")
+	assert.Equal(t, empty.ID, report.Violations[1].TransactionID)
+	assert.Contains(t, report.Violations[1].Reason, "at least one entry")
+	mockTxRepo.AssertNumberOfCalls(t, "List", 2)
+}
+
+func TestVerifyLedgerIntegrity_ListError(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(new(MockBucketRepository), mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mockTxRepo.On("List", ctx, ledgerIntegrityPageSize, 0, (*uuid.UUID)(nil)).Return(nil, fmt.Errorf("connection refused"))
+
+	report, err := service.VerifyLedgerIntegrity(ctx)
+
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestGetLiquidity(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mockBucketRepo.On("SumBalances", ctx, domain.BucketTypePhysical).Return(decimal.RequireFromString("1234.56"), nil)
+
+	liquidity, err := service.GetLiquidity(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1234.56", liquidity.StringFixed(2))
+	mockBucketRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestGetLiquidity_CurrencyMismatch(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mismatch := fmt.Errorf("bucket %s: %w: EUR and USD", uuid.New(), domain.ErrCurrencyMismatch)
+	mockBucketRepo.On("SumBalances", ctx, domain.BucketTypePhysical).Return(decimal.Zero, mismatch)
+
+	_, err := service.GetLiquidity(ctx)
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+}
+
+func TestGetStatement(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, mockMarketValueRepo, new(MockHoldingRepository))
+
+	start := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, time.October, d, 12, 0, 0, 0, time.UTC) }
+
+	mainBankID, savingsID := uuid.New(), uuid.New()
+	stocksID, cryptoID := uuid.New(), uuid.New()
+	employerID, groceriesID := uuid.New(), uuid.New()
+	physicalIDs := []uuid.UUID{mainBankID, savingsID}
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return([]*domain.Bucket{
+		{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+		{ID: savingsID, Name: "Savings", BucketType: domain.BucketTypePhysical},
+	}, nil)
+	stocks := &domain.Bucket{ID: stocksID, Name: "Stocks", BucketType: domain.BucketTypeEquity}
+	crypto := &domain.Bucket{ID: cryptoID, Name: "Crypto", BucketType: domain.BucketTypeEquity}
+	mockBucketRepo.On("List", ctx, domain.BucketTypeEquity).Return([]*domain.Bucket{stocks, crypto}, nil)
+
+	// Liquidity: 800 at the start, 1000 at the end
+	mockTxRepo.On("SumEntriesByBucketBefore", ctx, physicalIDs, start).Return(map[uuid.UUID]domain.EntryTotals{
+		mainBankID: {Debits: decimal.NewFromInt(1000), Credits: decimal.NewFromInt(200)},
+	}, nil)
+	mockTxRepo.On("SumEntriesByBucketBefore", ctx, physicalIDs, end).Return(map[uuid.UUID]domain.EntryTotals{
+		mainBankID: {Debits: decimal.NewFromInt(1500), Credits: decimal.NewFromInt(600)},
+		savingsID:  {Debits: decimal.NewFromInt(100), Credits: decimal.Zero},
+	}, nil)
+
+	entry := func(bucketID uuid.UUID, amount int64, entryType domain.EntryType) domain.TransactionEntry {
+		return domain.TransactionEntry{BucketID: bucketID, Amount: decimal.NewFromInt(amount), Type: entryType, Layer: domain.LayerPhysical}
+	}
+	salary := &domain.Transaction{ID: uuid.New(), Description: "Salary", Date: day(2), Entries: []domain.TransactionEntry{
+		entry(mainBankID, 500, domain.EntryTypeDebit), entry(employerID, 500, domain.EntryTypeCredit),
+	}}
+	groceries := &domain.Transaction{ID: uuid.New(), Description: "Groceries", Date: day(3), Entries: []domain.TransactionEntry{
+		entry(groceriesID, 300, domain.EntryTypeDebit), entry(mainBankID, 300, domain.EntryTypeCredit),
+	}}
+	toSavings := &domain.Transaction{ID: uuid.New(), Description: "To savings", Date: day(4), Entries: []domain.TransactionEntry{
+		entry(savingsID, 100, domain.EntryTypeDebit), entry(mainBankID, 100, domain.EntryTypeCredit),
+	}}
+	mockTxRepo.On("ListInPeriod", ctx, physicalIDs, start, end).Return([]*domain.Transaction{salary, groceries, toSavings}, nil)
+
+	// Equity: Stocks 2000 at the start, then 2100 and 1900; Crypto valued for the first time in the period
+	mockMarketValueRepo.On("ListForBuckets", ctx, []uuid.UUID{stocksID, cryptoID}, end).Return([]*domain.MarketValueHistory{
+		{BucketID: stocksID, Date: time.Date(2024, time.September, 15, 0, 0, 0, 0, time.UTC), MarketValue: decimal.NewFromInt(2000)},
+		{BucketID: cryptoID, Date: day(5), MarketValue: decimal.NewFromInt(300)},
+		{BucketID: stocksID, Date: day(10), MarketValue: decimal.NewFromInt(2100)},
+		{BucketID: stocksID, Date: day(20), MarketValue: decimal.NewFromInt(1900)},
+	}, nil)
+
+	statement, err := service.GetStatement(ctx, start, end)
+
+	assert.NoError(t, err)
+	assert.True(t, statement.OpeningNetWorth.Equal(decimal.NewFromInt(2800)), "expected opening 2800, got %s", statement.OpeningNetWorth)
+	assert.True(t, statement.ClosingNetWorth.Equal(decimal.NewFromInt(3200)), "expected closing 3200, got %s", statement.ClosingNetWorth)
+
+	// The move between physical buckets does not change net worth and is left out
+	assert.Len(t, statement.Transactions, 2)
+	assert.Equal(t, salary, statement.Transactions[0].Transaction)
+	assert.True(t, statement.Transactions[0].LiquidityChange.Equal(decimal.NewFromInt(500)))
+	assert.Equal(t, groceries, statement.Transactions[1].Transaction)
+	assert.True(t, statement.Transactions[1].LiquidityChange.Equal(decimal.NewFromInt(-300)))
+
+	assert.Len(t, statement.MarketValueChanges, 3)
+	expected := []struct {
+		bucket   *domain.Bucket
+		previous int64
+		change   int64
+	}{
+		{crypto, 0, 300},
+		{stocks, 2000, 100},
+		{stocks, 2100, -200},
+	}
+	for i, want := range expected {
+		got := statement.MarketValueChanges[i]
+		assert.Equal(t, want.bucket, got.Bucket)
+		assert.True(t, got.PreviousValue.Equal(decimal.NewFromInt(want.previous)), "change %d: previous %s", i, got.PreviousValue)
+		assert.True(t, got.Change.Equal(decimal.NewFromInt(want.change)), "change %d: change %s", i, got.Change)
+	}
+}
+
+func TestGetStatement_DoesNotReconcile(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	start := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	mainBankID := uuid.New()
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return([]*domain.Bucket{
+		{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+	}, nil)
+	mockBucketRepo.On("List", ctx, domain.BucketTypeEquity).Return([]*domain.Bucket{}, nil)
+
+	// The balance grew by 50 but no transaction in the period explains it
+	mockTxRepo.On("SumEntriesByBucketBefore", ctx, []uuid.UUID{mainBankID}, start).Return(map[uuid.UUID]domain.EntryTotals{}, nil)
+	mockTxRepo.On("SumEntriesByBucketBefore", ctx, []uuid.UUID{mainBankID}, end).Return(map[uuid.UUID]domain.EntryTotals{
+		mainBankID: {Debits: decimal.NewFromInt(50), Credits: decimal.Zero},
+	}, nil)
+	mockTxRepo.On("ListInPeriod", ctx, []uuid.UUID{mainBankID}, start, end).Return([]*domain.Transaction{}, nil)
+
+	statement, err := service.GetStatement(ctx, start, end)
+
+	assert.Error(t, err)
+	assert.Nil(t, statement)
+	assert.Contains(t, err.Error(), "does not reconcile")
+}
+
+func TestGetStatement_InvalidPeriod(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	start := time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.GetStatement(ctx, start, start)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockBucketRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestGetUpcoming(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mainBankID, savingsID := uuid.New(), uuid.New()
+	employerID, rentID, insuranceID := uuid.New(), uuid.New(), uuid.New()
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return([]*domain.Bucket{
+		{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(1000)},
+		{ID: savingsID, Name: "Savings", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(500)},
+	}, nil)
+
+	entry := func(bucketID uuid.UUID, amount int64, entryType domain.EntryType) domain.TransactionEntry {
+		return domain.TransactionEntry{BucketID: bucketID, Amount: decimal.NewFromInt(amount), Type: entryType, Layer: domain.LayerPhysical}
+	}
+	scheduled := func(description string, days int, entries ...domain.TransactionEntry) *domain.Transaction {
+		effective := time.Now().AddDate(0, 0, days)
+		return &domain.Transaction{ID: uuid.New(), Description: description, Date: effective, Scheduled: true, EffectiveDate: &effective, Entries: entries}
+	}
+	// An overdue rent payment that was not activated yet, a monthly insurance payment,
+	// a move between physical buckets and a scheduled salary
+	rent := scheduled("Rent", -1, entry(rentID, 700, domain.EntryTypeDebit), entry(mainBankID, 700, domain.EntryTypeCredit))
+	insurance := scheduled("Insurance", 3, entry(insuranceID, 50, domain.EntryTypeDebit), entry(mainBankID, 50, domain.EntryTypeCredit))
+	toSavings := scheduled("To savings", 5, entry(savingsID, 100, domain.EntryTypeDebit), entry(mainBankID, 100, domain.EntryTypeCredit))
+	salary := scheduled("Salary", 10, entry(mainBankID, 2000, domain.EntryTypeDebit), entry(employerID, 2000, domain.EntryTypeCredit))
+	mockTxRepo.On("ListScheduledDue", ctx, mock.AnythingOfType("time.Time")).Return([]*domain.Transaction{rent, insurance, toSavings, salary}, nil)
+
+	before := time.Now()
+	forecast, err := service.GetUpcoming(ctx, 30)
+
+	assert.NoError(t, err)
+	assert.False(t, forecast.Until.Before(before.AddDate(0, 0, 30)), "forecast should reach the horizon")
+	assert.True(t, forecast.CurrentLiquidity.Equal(decimal.NewFromInt(1500)), "got %s", forecast.CurrentLiquidity)
+
+	// The move between physical buckets does not change liquidity and is left out
+	expected := []struct {
+		tx        *domain.Transaction
+		change    int64
+		projected int64
+	}{
+		{rent, -700, 800},
+		{insurance, -50, 750},
+		{salary, 2000, 2750},
+	}
+	if assert.Len(t, forecast.Items, len(expected)) {
+		for i, want := range expected {
+			got := forecast.Items[i]
+			assert.Equal(t, want.tx, got.Transaction)
+			assert.True(t, got.LiquidityChange.Equal(decimal.NewFromInt(want.change)), "item %d: change %s", i, got.LiquidityChange)
+			assert.True(t, got.ProjectedBalance.Equal(decimal.NewFromInt(want.projected)), "item %d: projected %s", i, got.ProjectedBalance)
+		}
+	}
+	assert.True(t, forecast.ProjectedLiquidity.Equal(decimal.NewFromInt(2750)), "got %s", forecast.ProjectedLiquidity)
+}
+
+func TestGetUpcoming_NothingScheduled(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewDashboardService(mockBucketRepo, mockTxRepo, new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	mockBucketRepo.On("List", ctx, domain.BucketTypePhysical).Return([]*domain.Bucket{
+		{ID: uuid.New(), Name: "Main Bank", BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.NewFromInt(250)},
+	}, nil)
+	mockTxRepo.On("ListScheduledDue", ctx, mock.AnythingOfType("time.Time")).Return([]*domain.Transaction{}, nil)
+
+	forecast, err := service.GetUpcoming(ctx, 7)
+
+	assert.NoError(t, err)
+	assert.Empty(t, forecast.Items)
+	assert.True(t, forecast.ProjectedLiquidity.Equal(forecast.CurrentLiquidity))
+	assert.True(t, forecast.CurrentLiquidity.Equal(decimal.NewFromInt(250)))
+}
+
+func TestGetUpcoming_InvalidHorizon(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewDashboardService(mockBucketRepo, new(MockTransactionRepository), new(MockMarketValueRepository), new(MockHoldingRepository))
+
+	_, err := service.GetUpcoming(ctx, 0)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockBucketRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
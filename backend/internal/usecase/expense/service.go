@@ -2,7 +2,7 @@ package expense
 
 import (
 	"context"
-	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,15 +14,41 @@ import (
 type LogExpenseInput struct {
 	Amount             decimal.Decimal
 	Description        string
+	Memo               string // Optional long-form note (e.g. receipt details)
 	VirtualBucketID    uuid.UUID
 	CategoryBucketID   uuid.UUID
 	PhysicalOverrideID *uuid.UUID // Optional: Override the physical bucket source
+	IsRefund           bool       // Money coming back (e.g. a returned item): entry directions are swapped
+
+	// Optional: Schedule the expense (e.g. an upcoming bill). It is stored but only counted in balances
+	// once ProcessScheduled activates it on or after this date, which must be in the future
+	EffectiveDate *time.Time
+}
+
+// ExpenseLine represents the part of a split expense booked against one category
+type ExpenseLine struct {
+	CategoryBucketID uuid.UUID
+	Amount           decimal.Decimal
+}
+
+// LogSplitExpenseInput represents the input for logging one expense split across several categories
+// (e.g. a supermarket trip that is partly Groceries, partly Household)
+type LogSplitExpenseInput struct {
+	Amount             decimal.Decimal // Total paid; must equal the sum of the line amounts
+	Description        string
+	Memo               string // Optional long-form note (e.g. receipt details)
+	VirtualBucketID    uuid.UUID
+	PhysicalOverrideID *uuid.UUID // Optional: Override the physical bucket source
+	Lines              []ExpenseLine
 }
 
 // ExpenseService handles expense logging operations
 type ExpenseService struct {
 	BucketRepo      domain.BucketRepository
 	TransactionRepo domain.TransactionRepository
+
+	// AllowBlankDescription skips the non-blank description check (descriptions are required by default)
+	AllowBlankDescription bool
 }
 
 // NewExpenseService creates a new ExpenseService instance
@@ -40,12 +66,23 @@ func NewExpenseService(bucketRepo domain.BucketRepository, transactionRepo domai
 //  3. Create Transaction with 4 entries:
 //     - Physical Layer: Credit Source Physical, Debit Category
 //     - Virtual Layer: Credit Virtual Bucket, Debit Category
+//     For a refund the directions are swapped (Debit Physical/Virtual, Credit Category); the amount stays positive
+//     If EffectiveDate is set the transaction is stored as scheduled and dated on EffectiveDate
 //  4. Validate transaction
 //  5. Save using TransactionRepo.Create
 func (s *ExpenseService) LogExpense(ctx context.Context, input LogExpenseInput) (*domain.Transaction, error) {
 	// Validate input
 	if input.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, errors.New("expense amount must be positive")
+		return nil, domain.NewValidationError("expense amount must be positive")
+	}
+	if !s.AllowBlankDescription {
+		if err := domain.ValidateDescription(input.Description); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if input.EffectiveDate != nil && !input.EffectiveDate.After(now) {
+		return nil, domain.NewValidationError("effective date of a scheduled expense must be in the future")
 	}
 
 	// 1. Fetch Virtual Bucket and Category Bucket
@@ -55,96 +92,89 @@ func (s *ExpenseService) LogExpense(ctx context.Context, input LogExpenseInput)
 	}
 
 	// Validate virtual bucket type
-	if virtualBucket.BucketType != domain.BucketTypeVirtual {
-		return nil, errors.New("virtual bucket ID must reference a virtual bucket")
-	}
-
-	categoryBucket, err := s.BucketRepo.GetByID(ctx, input.CategoryBucketID)
-	if err != nil {
+	if err := virtualBucket.ValidateRole(domain.BucketRoleExpenseSource); err != nil {
 		return nil, err
 	}
 
-	// Validate category bucket type
-	if categoryBucket.BucketType != domain.BucketTypeExpense {
-		return nil, errors.New("category bucket ID must reference an expense bucket")
+	if err := s.validateCategory(ctx, input.CategoryBucketID); err != nil {
+		return nil, err
 	}
 
 	// 2. Determine Source Physical Bucket
-	var sourcePhysicalBucketID uuid.UUID
-	if input.PhysicalOverrideID != nil {
-		// Use override if provided
-		sourcePhysicalBucketID = *input.PhysicalOverrideID
-		// Validate that the override bucket exists and is physical
-		overrideBucket, err := s.BucketRepo.GetByID(ctx, sourcePhysicalBucketID)
-		if err != nil {
-			return nil, err
-		}
-		if overrideBucket.BucketType != domain.BucketTypePhysical {
-			return nil, errors.New("physical override bucket must be a physical bucket")
-		}
-	} else {
-		// Use virtual bucket's parent physical bucket
-		if virtualBucket.ParentPhysicalBucketID == nil {
-			return nil, errors.New("virtual bucket must have a parent physical bucket ID")
-		}
-		sourcePhysicalBucketID = *virtualBucket.ParentPhysicalBucketID
+	sourcePhysicalBucketID, err := s.resolveSourcePhysical(ctx, virtualBucket, input.PhysicalOverrideID)
+	if err != nil {
+		return nil, err
 	}
 
 	// 3. Create Transaction with 4 entries
 	txID := uuid.New()
-	now := time.Now()
+
+	// A refund flows back from the category into the source buckets
+	sourceType, categoryType := domain.EntryTypeCredit, domain.EntryTypeDebit
+	if input.IsRefund {
+		sourceType, categoryType = domain.EntryTypeDebit, domain.EntryTypeCredit
+	}
 
 	// Physical Layer: Credit Source Physical (decrease asset), Debit Category (increase expense)
-	physicalCreditEntry := domain.TransactionEntry{
+	// Reversed for a refund
+	physicalSourceEntry := domain.TransactionEntry{
 		ID:            uuid.New(),
 		TransactionID: txID,
 		BucketID:      sourcePhysicalBucketID,
 		Amount:        input.Amount,
-		Type:          domain.EntryTypeCredit,
+		Type:          sourceType,
 		Layer:         domain.LayerPhysical,
 	}
 
-	physicalDebitEntry := domain.TransactionEntry{
+	physicalCategoryEntry := domain.TransactionEntry{
 		ID:            uuid.New(),
 		TransactionID: txID,
 		BucketID:      input.CategoryBucketID,
 		Amount:        input.Amount,
-		Type:          domain.EntryTypeDebit,
+		Type:          categoryType,
 		Layer:         domain.LayerPhysical,
 	}
 
 	// Virtual Layer: Credit Virtual Bucket (decrease available funds), Debit Category (increase expense)
-	virtualCreditEntry := domain.TransactionEntry{
+	// Reversed for a refund
+	virtualSourceEntry := domain.TransactionEntry{
 		ID:            uuid.New(),
 		TransactionID: txID,
 		BucketID:      input.VirtualBucketID,
 		Amount:        input.Amount,
-		Type:          domain.EntryTypeCredit,
+		Type:          sourceType,
 		Layer:         domain.LayerVirtual,
 	}
 
-	virtualDebitEntry := domain.TransactionEntry{
+	virtualCategoryEntry := domain.TransactionEntry{
 		ID:            uuid.New(),
 		TransactionID: txID,
 		BucketID:      input.CategoryBucketID,
 		Amount:        input.Amount,
-		Type:          domain.EntryTypeDebit,
+		Type:          categoryType,
 		Layer:         domain.LayerVirtual,
 	}
 
 	tx := &domain.Transaction{
 		ID:                 txID,
-		Description:        input.Description,
+		Description:        strings.TrimSpace(input.Description),
+		Memo:               input.Memo,
 		Date:               now,
 		IsInternalTransfer: false,
 		IsExternalInflow:   false,
+		IsRefund:           input.IsRefund,
 		Entries: []domain.TransactionEntry{
-			physicalCreditEntry,
-			physicalDebitEntry,
-			virtualCreditEntry,
-			virtualDebitEntry,
+			physicalSourceEntry,
+			physicalCategoryEntry,
+			virtualSourceEntry,
+			virtualCategoryEntry,
 		},
 	}
+	if input.EffectiveDate != nil {
+		tx.Scheduled = true
+		tx.EffectiveDate = input.EffectiveDate
+		tx.Date = *input.EffectiveDate
+	}
 
 	// 4. Validate transaction
 	if err := tx.Validate(); err != nil {
@@ -158,3 +188,180 @@ func (s *ExpenseService) LogExpense(ctx context.Context, input LogExpenseInput)
 
 	return tx, nil
 }
+
+// LogSplitExpense creates a single transaction for an expense split across several categories
+// Logic:
+//  1. Validate the lines: positive amounts, distinct categories, summing to the total
+//  2. Fetch the Virtual Bucket and every Category Bucket
+//  3. Determine Source Physical Bucket (override or parent)
+//  4. Create Transaction:
+//     - Physical Layer: Credit Source Physical (total), Debit each Category (line amount)
+//     - Virtual Layer: Credit Virtual Bucket (total), Debit each Category (line amount)
+//  5. Validate and save using TransactionRepo.Create
+func (s *ExpenseService) LogSplitExpense(ctx context.Context, input LogSplitExpenseInput) (*domain.Transaction, error) {
+	// Validate input
+	if input.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, domain.NewValidationError("expense amount must be positive")
+	}
+	if !s.AllowBlankDescription {
+		if err := domain.ValidateDescription(input.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. Validate the lines
+	if len(input.Lines) == 0 {
+		return nil, domain.NewValidationError("split expense must have at least one line")
+	}
+	linesTotal := decimal.Zero
+	seen := make(map[uuid.UUID]bool, len(input.Lines))
+	for _, line := range input.Lines {
+		if line.Amount.LessThanOrEqual(decimal.Zero) {
+			return nil, domain.NewValidationErrorf("line amount for category %s must be positive", line.CategoryBucketID)
+		}
+		if seen[line.CategoryBucketID] {
+			return nil, domain.NewValidationErrorf("category %s appears in more than one line", line.CategoryBucketID)
+		}
+		seen[line.CategoryBucketID] = true
+		linesTotal = linesTotal.Add(line.Amount)
+	}
+	if !linesTotal.Equal(input.Amount) {
+		return nil, domain.NewValidationErrorf("line amounts sum to %s but the expense total is %s", linesTotal, input.Amount)
+	}
+
+	// 2. Fetch Virtual Bucket and Category Buckets
+	virtualBucket, err := s.BucketRepo.GetByID(ctx, input.VirtualBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := virtualBucket.ValidateRole(domain.BucketRoleExpenseSource); err != nil {
+		return nil, err
+	}
+	for _, line := range input.Lines {
+		if err := s.validateCategory(ctx, line.CategoryBucketID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Determine Source Physical Bucket
+	sourcePhysicalBucketID, err := s.resolveSourcePhysical(ctx, virtualBucket, input.PhysicalOverrideID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Create Transaction: one Credit of the total per layer, one Debit per line per layer
+	txID := uuid.New()
+	entries := make([]domain.TransactionEntry, 0, 2+2*len(input.Lines))
+	for _, layer := range []domain.Layer{domain.LayerPhysical, domain.LayerVirtual} {
+		sourceBucketID := sourcePhysicalBucketID
+		if layer == domain.LayerVirtual {
+			sourceBucketID = input.VirtualBucketID
+		}
+		entries = append(entries, domain.TransactionEntry{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      sourceBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeCredit,
+			Layer:         layer,
+		})
+		for _, line := range input.Lines {
+			entries = append(entries, domain.TransactionEntry{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      line.CategoryBucketID,
+				Amount:        line.Amount,
+				Type:          domain.EntryTypeDebit,
+				Layer:         layer,
+			})
+		}
+	}
+
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        strings.TrimSpace(input.Description),
+		Memo:               input.Memo,
+		Date:               time.Now(),
+		IsInternalTransfer: false,
+		IsExternalInflow:   false,
+		Entries:            entries,
+	}
+
+	// 5. Validate and save
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.TransactionRepo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// RecategorizeTransactions moves past expenses from one category to another (e.g. "Dining" into "Groceries")
+// Logic:
+//  1. Validate: source and target must be distinct EXPENSE buckets, the target not archived
+//  2. Atomically re-point the source's entries (both layers, including refund CREDITs) to the target, only for transactions
+//     whose description contains descriptionFilter (case-insensitive) if it is set, and move their
+//     amounts between the two category balances
+//
+// Unlike MergeCategoryBuckets, the source category stays active. Returns the number of re-pointed entries.
+func (s *ExpenseService) RecategorizeTransactions(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	if sourceCategoryID == targetCategoryID {
+		return 0, domain.NewValidationError("source and target category must be different")
+	}
+
+	// 1. Validate both categories (an archived source may still hold expenses worth moving)
+	sourceBucket, err := s.BucketRepo.GetByID(ctx, sourceCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	if err := sourceBucket.ValidateRole(domain.BucketRoleExpenseCategory); err != nil {
+		return 0, err
+	}
+	if err := s.validateCategory(ctx, targetCategoryID); err != nil {
+		return 0, err
+	}
+
+	// 2. Re-point the entries
+	return s.TransactionRepo.Recategorize(ctx, sourceCategoryID, targetCategoryID, strings.TrimSpace(descriptionFilter))
+}
+
+// validateCategory ensures the category bucket exists, is an expense bucket and is not archived
+func (s *ExpenseService) validateCategory(ctx context.Context, categoryBucketID uuid.UUID) error {
+	categoryBucket, err := s.BucketRepo.GetByID(ctx, categoryBucketID)
+	if err != nil {
+		return err
+	}
+
+	// Validate category bucket type
+	if err := categoryBucket.ValidateRole(domain.BucketRoleExpenseCategory); err != nil {
+		return err
+	}
+	if categoryBucket.IsArchived {
+		return domain.NewValidationErrorf("category bucket %s is archived", categoryBucket.ID)
+	}
+	return nil
+}
+
+// resolveSourcePhysical returns the physical bucket paying for an expense: the override if provided,
+// otherwise the virtual bucket's parent physical bucket
+func (s *ExpenseService) resolveSourcePhysical(ctx context.Context, virtualBucket *domain.Bucket, overrideID *uuid.UUID) (uuid.UUID, error) {
+	if overrideID != nil {
+		// Validate that the override bucket exists and is physical
+		overrideBucket, err := s.BucketRepo.GetByID(ctx, *overrideID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if err := overrideBucket.ValidateRole(domain.BucketRolePhysicalOverride); err != nil {
+			return uuid.Nil, err
+		}
+		return *overrideID, nil
+	}
+
+	// Use virtual bucket's parent physical bucket
+	if virtualBucket.ParentPhysicalBucketID == nil {
+		return uuid.Nil, domain.NewValidationError("virtual bucket must have a parent physical bucket ID")
+	}
+	return *virtualBucket.ParentPhysicalBucketID, nil
+}
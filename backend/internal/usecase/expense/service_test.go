@@ -3,6 +3,7 @@ package expense
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -24,6 +25,22 @@ func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return args.Get(0).(*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
 func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
 	args := m.Called(ctx, bucket)
 	return args.Error(0)
@@ -45,6 +62,58 @@ func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.Bucke
 	return args.Get(0).([]*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 // MockTransactionRepository is a mock implementation of TransactionRepository for testing
 type MockTransactionRepository struct {
 	mock.Mock
@@ -55,6 +124,19 @@ func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Trans
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
 	args := m.Called(ctx, limit, offset, bucketID)
 	if args.Get(0) == nil {
@@ -68,6 +150,127 @@ func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UU
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
 func TestLogExpense_StandardFlow(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
@@ -106,6 +309,7 @@ func TestLogExpense_StandardFlow(t *testing.T) {
 	input := LogExpenseInput{
 		Amount:             decimal.NewFromInt(50),
 		Description:        "Weekly groceries",
+		Memo:               "Includes snacks for the party",
 		VirtualBucketID:    virtualBucketID,
 		CategoryBucketID:   categoryBucketID,
 		PhysicalOverrideID: nil, // Use virtual bucket's parent
@@ -157,6 +361,7 @@ func TestLogExpense_StandardFlow(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "Weekly groceries", result.Description)
+	assert.Equal(t, "Includes snacks for the party", result.Memo)
 	assert.Equal(t, 4, len(result.Entries))
 	assert.False(t, result.IsInternalTransfer)
 	assert.False(t, result.IsExternalInflow)
@@ -166,6 +371,43 @@ func TestLogExpense_StandardFlow(t *testing.T) {
 	mockTxRepo.AssertExpectations(t)
 }
 
+func TestLogExpense_TrimsDescription(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	physicalBucketID := uuid.New()
+	virtualBucketID := uuid.New()
+	categoryBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(&domain.Bucket{
+		ID:                     virtualBucketID,
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalBucketID,
+		CurrentBalance:         decimal.NewFromInt(500),
+	}, nil)
+	mockBucketRepo.On("GetByID", ctx, categoryBucketID).Return(&domain.Bucket{
+		ID:         categoryBucketID,
+		Name:       "Groceries",
+		BucketType: domain.BucketTypeExpense,
+	}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(50),
+		Description:      "  Weekly groceries ",
+		VirtualBucketID:  virtualBucketID,
+		CategoryBucketID: categoryBucketID,
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Weekly groceries", result.Description)
+}
+
 func TestLogExpense_WrongCardOverride(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
@@ -461,3 +703,552 @@ func TestLogExpense_InvalidPhysicalOverrideType(t *testing.T) {
 	// Verify transaction repo was not called
 	mockTxRepo.AssertNotCalled(t, "Create")
 }
+
+func TestLogExpense_EquityBucketRejected(t *testing.T) {
+	ctx := context.Background()
+
+	physicalBucketID := uuid.New()
+	virtualBucketID := uuid.New()
+	virtualBucket := &domain.Bucket{
+		ID:                     virtualBucketID,
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalBucketID,
+		CurrentBalance:         decimal.NewFromInt(500),
+	}
+
+	categoryBucketID := uuid.New()
+	categoryBucket := &domain.Bucket{
+		ID:             categoryBucketID,
+		Name:           "Groceries",
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}
+
+	equityBucketID := uuid.New()
+	equityBucket := &domain.Bucket{
+		ID:             equityBucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}
+
+	tests := []struct {
+		name   string
+		input  LogExpenseInput
+		errMsg string
+	}{
+		{
+			name: "Equity bucket as virtual bucket",
+			input: LogExpenseInput{
+				Amount:           decimal.NewFromInt(50),
+				Description:      "Equity as virtual",
+				VirtualBucketID:  equityBucketID,
+				CategoryBucketID: categoryBucketID,
+			},
+			errMsg: "virtual bucket ID must reference a virtual bucket",
+		},
+		{
+			name: "Equity bucket as category",
+			input: LogExpenseInput{
+				Amount:           decimal.NewFromInt(50),
+				Description:      "Equity as category",
+				VirtualBucketID:  virtualBucketID,
+				CategoryBucketID: equityBucketID,
+			},
+			errMsg: "category bucket ID must reference an expense bucket",
+		},
+		{
+			name: "Equity bucket as physical override",
+			input: LogExpenseInput{
+				Amount:             decimal.NewFromInt(50),
+				Description:        "Equity as override",
+				VirtualBucketID:    virtualBucketID,
+				CategoryBucketID:   categoryBucketID,
+				PhysicalOverrideID: &equityBucketID,
+			},
+			errMsg: "physical override bucket must be a physical bucket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+			mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(virtualBucket, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, categoryBucketID).Return(categoryBucket, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, equityBucketID).Return(equityBucket, nil)
+
+			result, err := service.LogExpense(ctx, tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Contains(t, err.Error(), string(domain.BucketTypeEquity))
+
+			mockTxRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestLogExpense_Refund(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	physicalBucketID := uuid.New()
+	virtualBucketID := uuid.New()
+	categoryBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(&domain.Bucket{
+		ID:                     virtualBucketID,
+		Name:                   "Groceries Budget",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalBucketID,
+	}, nil)
+	mockBucketRepo.On("GetByID", ctx, categoryBucketID).Return(&domain.Bucket{
+		ID:         categoryBucketID,
+		Name:       "Groceries",
+		BucketType: domain.BucketTypeExpense,
+	}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(20),
+		Description:      "Returned item",
+		VirtualBucketID:  virtualBucketID,
+		CategoryBucketID: categoryBucketID,
+		IsRefund:         true,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, result.Validate(), "Refund must keep positive amounts and balanced layers")
+	assert.True(t, result.IsRefund, "Refund flag should be persisted with the transaction")
+
+	// Balance effect per bucket and layer, as applied by the balance trigger (DEBIT adds, CREDIT subtracts)
+	effect := make(map[domain.Layer]map[uuid.UUID]decimal.Decimal)
+	for _, entry := range result.Entries {
+		assert.True(t, entry.Amount.IsPositive())
+		if effect[entry.Layer] == nil {
+			effect[entry.Layer] = make(map[uuid.UUID]decimal.Decimal)
+		}
+		delta := entry.Amount
+		if entry.Type == domain.EntryTypeCredit {
+			delta = delta.Neg()
+		}
+		effect[entry.Layer][entry.BucketID] = effect[entry.Layer][entry.BucketID].Add(delta)
+	}
+
+	// Category spending goes down, the virtual and physical buckets get the money back
+	assert.True(t, effect[domain.LayerVirtual][categoryBucketID].Equal(decimal.NewFromInt(-20)))
+	assert.True(t, effect[domain.LayerVirtual][virtualBucketID].Equal(decimal.NewFromInt(20)))
+	assert.True(t, effect[domain.LayerPhysical][categoryBucketID].Equal(decimal.NewFromInt(-20)))
+	assert.True(t, effect[domain.LayerPhysical][physicalBucketID].Equal(decimal.NewFromInt(20)))
+}
+
+func TestLogExpense_RefundRequiresPositiveAmount(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(new(MockBucketRepository), mockTxRepo)
+
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(-20),
+		Description:      "Returned item",
+		VirtualBucketID:  uuid.New(),
+		CategoryBucketID: uuid.New(),
+		IsRefund:         true,
+	})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expense amount must be positive")
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLogExpense_BlankDescription(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	for _, description := range []string{"", "  \t"} {
+		result, err := service.LogExpense(ctx, LogExpenseInput{
+			Amount:           decimal.NewFromInt(20),
+			Description:      description,
+			VirtualBucketID:  uuid.New(),
+			CategoryBucketID: uuid.New(),
+		})
+
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr, "description %q", description)
+		assert.Contains(t, err.Error(), "description must not be blank")
+		assert.Nil(t, result)
+	}
+	mockBucketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLogExpense_BlankDescriptionAllowed(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+	service.AllowBlankDescription = true
+
+	physicalBucketID := uuid.New()
+	virtualBucketID := uuid.New()
+	categoryBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(&domain.Bucket{ID: virtualBucketID, Name: "Free Cash", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID}, nil)
+	mockBucketRepo.On("GetByID", ctx, categoryBucketID).Return(&domain.Bucket{ID: categoryBucketID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(20),
+		Description:      "",
+		VirtualBucketID:  virtualBucketID,
+		CategoryBucketID: categoryBucketID,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Description)
+}
+
+// setupSplitExpense registers a virtual bucket under a physical bucket and the given expense categories
+func setupSplitExpense(ctx context.Context, mockBucketRepo *MockBucketRepository, categoryNames ...string) (physicalID, virtualID uuid.UUID, categoryIDs []uuid.UUID) {
+	physicalID = uuid.New()
+	virtualID = uuid.New()
+	mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Free Cash",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalID,
+		CurrentBalance:         decimal.NewFromInt(500),
+	}, nil)
+	for _, name := range categoryNames {
+		categoryID := uuid.New()
+		mockBucketRepo.On("GetByID", ctx, categoryID).Return(&domain.Bucket{
+			ID:         categoryID,
+			Name:       name,
+			BucketType: domain.BucketTypeExpense,
+		}, nil)
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	return physicalID, virtualID, categoryIDs
+}
+
+// assertSplitEntries checks a split expense credits the sources for the total and debits each category for its line
+func assertSplitEntries(t *testing.T, tx *domain.Transaction, physicalID, virtualID uuid.UUID, total decimal.Decimal, lines []ExpenseLine) {
+	t.Helper()
+	assert.Len(t, tx.Entries, 2+2*len(lines))
+
+	sources := map[domain.Layer]uuid.UUID{domain.LayerPhysical: physicalID, domain.LayerVirtual: virtualID}
+	for _, layer := range []domain.Layer{domain.LayerPhysical, domain.LayerVirtual} {
+		debits := make(map[uuid.UUID]decimal.Decimal)
+		for _, entry := range tx.Entries {
+			if entry.Layer != layer {
+				continue
+			}
+			if entry.Type == domain.EntryTypeCredit {
+				assert.Equal(t, sources[layer], entry.BucketID, "%s credit should hit the source", layer)
+				assert.True(t, total.Equal(entry.Amount), "%s credit should be the total", layer)
+				continue
+			}
+			debits[entry.BucketID] = entry.Amount
+		}
+		assert.Len(t, debits, len(lines))
+		for _, line := range lines {
+			assert.True(t, line.Amount.Equal(debits[line.CategoryBucketID]), "%s debit for category %s", layer, line.CategoryBucketID)
+		}
+	}
+}
+
+func TestLogSplitExpense_TwoCategories(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	physicalID, virtualID, categoryIDs := setupSplitExpense(ctx, mockBucketRepo, "Groceries", "Household")
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	lines := []ExpenseLine{
+		{CategoryBucketID: categoryIDs[0], Amount: decimal.RequireFromString("62.40")},
+		{CategoryBucketID: categoryIDs[1], Amount: decimal.RequireFromString("17.60")},
+	}
+	total := decimal.NewFromInt(80)
+
+	result, err := service.LogSplitExpense(ctx, LogSplitExpenseInput{
+		Amount:          total,
+		Description:     "Supermarket",
+		VirtualBucketID: virtualID,
+		Lines:           lines,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Supermarket", result.Description)
+	assertSplitEntries(t, result, physicalID, virtualID, total, lines)
+	mockTxRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestLogSplitExpense_ThreeCategoriesWithOverride(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	_, virtualID, categoryIDs := setupSplitExpense(ctx, mockBucketRepo, "Groceries", "Household", "Pharmacy")
+	cardID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, cardID).Return(&domain.Bucket{ID: cardID, Name: "Credit Card", BucketType: domain.BucketTypePhysical}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	lines := []ExpenseLine{
+		{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(50)},
+		{CategoryBucketID: categoryIDs[1], Amount: decimal.NewFromInt(30)},
+		{CategoryBucketID: categoryIDs[2], Amount: decimal.RequireFromString("12.99")},
+	}
+	total := decimal.RequireFromString("92.99")
+
+	result, err := service.LogSplitExpense(ctx, LogSplitExpenseInput{
+		Amount:             total,
+		Description:        "Hypermarket",
+		VirtualBucketID:    virtualID,
+		PhysicalOverrideID: &cardID,
+		Lines:              lines,
+	})
+
+	assert.NoError(t, err)
+	assertSplitEntries(t, result, cardID, virtualID, total, lines)
+}
+
+func TestLogSplitExpense_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	_, virtualID, categoryIDs := setupSplitExpense(ctx, mockBucketRepo, "Groceries", "Household")
+	incomeID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeID).Return(&domain.Bucket{ID: incomeID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+
+	tests := []struct {
+		name          string
+		total         string
+		lines         []ExpenseLine
+		expectedError string
+	}{
+		{
+			name:          "no lines",
+			total:         "10",
+			expectedError: "at least one line",
+		},
+		{
+			name:  "lines do not sum to total",
+			total: "100",
+			lines: []ExpenseLine{
+				{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(60)},
+				{CategoryBucketID: categoryIDs[1], Amount: decimal.NewFromInt(30)},
+			},
+			expectedError: "line amounts sum to 90 but the expense total is 100",
+		},
+		{
+			name:  "non-positive line",
+			total: "60",
+			lines: []ExpenseLine{
+				{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(60)},
+				{CategoryBucketID: categoryIDs[1], Amount: decimal.Zero},
+			},
+			expectedError: "must be positive",
+		},
+		{
+			name:  "duplicate category",
+			total: "60",
+			lines: []ExpenseLine{
+				{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(30)},
+				{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(30)},
+			},
+			expectedError: "appears in more than one line",
+		},
+		{
+			name:  "category is not an expense bucket",
+			total: "60",
+			lines: []ExpenseLine{
+				{CategoryBucketID: categoryIDs[0], Amount: decimal.NewFromInt(30)},
+				{CategoryBucketID: incomeID, Amount: decimal.NewFromInt(30)},
+			},
+			expectedError: "category bucket ID must reference an expense bucket",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.LogSplitExpense(ctx, LogSplitExpenseInput{
+				Amount:          decimal.RequireFromString(tt.total),
+				Description:     "Supermarket",
+				VirtualBucketID: virtualID,
+				Lines:           tt.lines,
+			})
+
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Nil(t, result)
+		})
+	}
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestLogExpense_Scheduled(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	_, virtualID, categoryIDs := setupSplitExpense(ctx, mockBucketRepo, "Utilities")
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	dueDate := time.Now().AddDate(0, 0, 10)
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(120),
+		Description:      "Electricity bill",
+		VirtualBucketID:  virtualID,
+		CategoryBucketID: categoryIDs[0],
+		EffectiveDate:    &dueDate,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Scheduled)
+	assert.Equal(t, &dueDate, result.EffectiveDate)
+	assert.True(t, dueDate.Equal(result.Date), "Scheduled expense is dated on its effective date")
+	assert.Len(t, result.Entries, 4)
+}
+
+func TestLogExpense_ScheduledInThePast(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	result, err := service.LogExpense(ctx, LogExpenseInput{
+		Amount:           decimal.NewFromInt(120),
+		Description:      "Electricity bill",
+		VirtualBucketID:  uuid.New(),
+		CategoryBucketID: uuid.New(),
+		EffectiveDate:    &yesterday,
+	})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "must be in the future")
+	assert.Nil(t, result)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecategorizeTransactions_Success(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	diningID := uuid.New()
+	groceriesID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, diningID).Return(&domain.Bucket{ID: diningID, Name: "Dining", BucketType: domain.BucketTypeExpense}, nil)
+	mockBucketRepo.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+	mockTxRepo.On("Recategorize", ctx, diningID, groceriesID, "supermarket").Return(4, nil)
+
+	updated, err := service.RecategorizeTransactions(ctx, diningID, groceriesID, "  supermarket ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, updated)
+	mockBucketRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecategorizeTransactions_ArchivedSource(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+	oldID := uuid.New()
+	newID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, oldID).Return(&domain.Bucket{ID: oldID, BucketType: domain.BucketTypeExpense, IsArchived: true}, nil)
+	mockBucketRepo.On("GetByID", ctx, newID).Return(&domain.Bucket{ID: newID, BucketType: domain.BucketTypeExpense}, nil)
+	mockTxRepo.On("Recategorize", ctx, oldID, newID, "").Return(0, nil)
+
+	updated, err := service.RecategorizeTransactions(ctx, oldID, newID, "")
+
+	assert.NoError(t, err, "Expenses can still be moved out of an archived category")
+	assert.Zero(t, updated)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecategorizeTransactions_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	expenseBucket := func(id uuid.UUID) *domain.Bucket {
+		return &domain.Bucket{ID: id, BucketType: domain.BucketTypeExpense}
+	}
+
+	tests := []struct {
+		name          string
+		targetID      uuid.UUID
+		setup         func(*MockBucketRepository)
+		expectedError string
+	}{
+		{
+			name:          "Same category",
+			targetID:      sourceID,
+			setup:         func(*MockBucketRepository) {},
+			expectedError: "source and target category must be different",
+		},
+		{
+			name:     "Source is not an expense bucket",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByID", ctx, sourceID).Return(&domain.Bucket{ID: sourceID, BucketType: domain.BucketTypeVirtual}, nil)
+			},
+			expectedError: "category bucket ID must reference an expense bucket",
+		},
+		{
+			name:     "Target is not an expense bucket",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				b.On("GetByID", ctx, sourceID).Return(expenseBucket(sourceID), nil)
+				b.On("GetByID", ctx, targetID).Return(&domain.Bucket{ID: targetID, BucketType: domain.BucketTypePhysical}, nil)
+			},
+			expectedError: "category bucket ID must reference an expense bucket",
+		},
+		{
+			name:     "Target is archived",
+			targetID: targetID,
+			setup: func(b *MockBucketRepository) {
+				archived := expenseBucket(targetID)
+				archived.IsArchived = true
+				b.On("GetByID", ctx, sourceID).Return(expenseBucket(sourceID), nil)
+				b.On("GetByID", ctx, targetID).Return(archived, nil)
+			},
+			expectedError: "is archived",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			tt.setup(mockBucketRepo)
+			service := NewExpenseService(mockBucketRepo, mockTxRepo)
+
+			updated, err := service.RecategorizeTransactions(ctx, sourceID, tt.targetID, "")
+
+			assert.Zero(t, updated)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockTxRepo.AssertNotCalled(t, "Recategorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
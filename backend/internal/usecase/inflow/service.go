@@ -3,20 +3,95 @@ package inflow
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
 	"github.com/simaogato/wealthflow-backend/internal/usecase/allocator"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 )
 
 // RecordInflowInput represents the input for recording an inflow
 type RecordInflowInput struct {
 	Amount         decimal.Decimal
 	Description    string
+	Memo           string // Optional long-form note
 	SourceBucketID uuid.UUID
 	IsExternal     bool
+	AllocationMode allocator.AllocationMode // How FIXED items exceeding the amount are handled (empty = STRICT)
+
+	// Optional: Allocate the whole amount to this virtual bucket instead of applying a split rule
+	// (e.g. a gift or reimbursement). Mutually exclusive with IsExternal and AllocationMode
+	TargetVirtualBucketID *uuid.UUID
+
+	// Optional: Run every validation and the allocation, returning the would-be transaction without saving it
+	DryRun bool
+
+	// Optional: Physical bucket the money arrived in. When set, income that neither a split rule nor a target
+	// applies to is parked in SYS_EXTRA_INCOME (virtual layer) for later allocation instead of failing.
+	// There is no default: without it such an inflow fails, since only the caller knows which bank received it
+	ParkInPhysicalBucketID *uuid.UUID
+}
+
+// CreateSplitRuleInput represents the input for creating a split rule
+type CreateSplitRuleInput struct {
+	Name           string
+	SourceBucketID uuid.UUID
+	// DestinationPhysicalBucketID is the physical bucket inflows land in (optional: inferred from the first target if nil)
+	DestinationPhysicalBucketID *uuid.UUID
+	Items                       []domain.SplitRuleItem // IDs and SplitRuleID are assigned by the service
+}
+
+// CloneSplitRuleInput represents the input for copying a split rule to another income bucket
+type CloneSplitRuleInput struct {
+	FromSourceBucketID uuid.UUID // Income bucket whose split rule is copied
+	ToSourceBucketID   uuid.UUID // Income bucket receiving the copy (must not have a split rule yet)
+	Name               string    // Optional: name of the copy (defaults to the copied rule's name)
+}
+
+// AllocationPreview represents how an inflow would be split by a source bucket's split rule
+type AllocationPreview struct {
+	Items      []domain.SplitRuleItem        // Split rule items in priority order
+	Allocation map[uuid.UUID]decimal.Decimal // Target bucket ID -> allocated amount
+	Steps      []allocator.AllocationStep    // Derivation steps (only populated when explain is requested)
+	Targets    []AllocationTarget            // Allocated amount per target with its bucket name, in priority order
+}
+
+// AllocationTarget represents the amount allocated to a split rule target bucket
+type AllocationTarget struct {
+	BucketID uuid.UUID
+	Name     string
+	Amount   decimal.Decimal
+}
+
+// IncomeSource represents an income bucket together with a summary of its split rule
+type IncomeSource struct {
+	Bucket    *domain.Bucket
+	HasRule   bool // False means external inflows from this bucket fail until a split rule is created
+	ItemCount int  // Number of split rule items (0 without a rule)
+}
+
+// UnallocatedIncome represents the income parked in SYS_EXTRA_INCOME that is waiting to be allocated
+type UnallocatedIncome struct {
+	Bucket        *domain.Bucket       // The SYS_EXTRA_INCOME system bucket
+	Amount        decimal.Decimal      // Parked income minus what was already allocated out of it
+	Contributions []ParkedIncomeChange // Parked inflows and allocations, ordered by date ascending
+}
+
+// ParkedIncomeChange represents a transaction that changed the parked income
+type ParkedIncomeChange struct {
+	Transaction *domain.Transaction
+	Amount      decimal.Decimal // Positive for a parked inflow, negative for an allocation out of it
+}
+
+// ParkedIncomeAllocation represents one line of an allocation of parked income
+type ParkedIncomeAllocation struct {
+	VirtualBucketID uuid.UUID
+	Amount          decimal.Decimal
 }
 
 // InflowService handles inflow recording operations
@@ -24,6 +99,10 @@ type InflowService struct {
 	BucketRepo      domain.BucketRepository
 	TransactionRepo domain.TransactionRepository
 	SplitRuleRepo   domain.SplitRuleRepository
+	UnitOfWork      domain.UnitOfWork
+
+	// AllowBlankDescription skips the non-blank description check (descriptions are required by default)
+	AllowBlankDescription bool
 }
 
 // NewInflowService creates a new InflowService instance
@@ -31,11 +110,13 @@ func NewInflowService(
 	bucketRepo domain.BucketRepository,
 	transactionRepo domain.TransactionRepository,
 	splitRuleRepo domain.SplitRuleRepository,
+	unitOfWork domain.UnitOfWork,
 ) *InflowService {
 	return &InflowService{
 		BucketRepo:      bucketRepo,
 		TransactionRepo: transactionRepo,
 		SplitRuleRepo:   splitRuleRepo,
+		UnitOfWork:      unitOfWork,
 	}
 }
 
@@ -44,16 +125,29 @@ func NewInflowService(
 //  1. Fetch Source Bucket
 //  2. If IsExternal is true:
 //     - Fetch the Split Rule for this source bucket
-//     - Call allocator.CalculateAllocation to get target buckets
+//     - Call allocator.CalculateMoneyAllocation to get target buckets (in the source bucket's currency)
 //     - Create Transaction:
 //     - Physical Layer: Debit Source's Parent Physical (Bank), Credit Source (Income Bucket)
 //     - Virtual Layer: Debit Target Buckets (from allocation), Credit Source (Income Bucket)
-//  3. If IsExternal is false (Internal Transfer):
+//  3. If TargetVirtualBucketID is set (ad-hoc income without a split rule):
+//     - Physical Layer: Debit the target's Parent Physical, Credit Source (Income Bucket)
+//     - Virtual Layer: Debit the target for the full amount, Credit Source (Income Bucket)
+//  4. If IsExternal is false (Internal Transfer):
 //     - For this task, focus on External logic as priority
+//
+// If ParkInPhysicalBucketID is set and no active split rule (external) or target (otherwise) applies,
+// the income is parked in SYS_EXTRA_INCOME instead of failing (see recordParkedInflow)
+//
+// With DryRun set, the transaction is built and validated but not saved
 func (s *InflowService) RecordInflow(ctx context.Context, input RecordInflowInput) (*domain.Transaction, error) {
 	// Validate input
 	if input.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, errors.New("inflow amount must be positive")
+		return nil, domain.NewValidationError("inflow amount must be positive")
+	}
+	if !s.AllowBlankDescription {
+		if err := domain.ValidateDescription(input.Description); err != nil {
+			return nil, err
+		}
 	}
 
 	// 1. Fetch Source Bucket
@@ -63,20 +157,474 @@ func (s *InflowService) RecordInflow(ctx context.Context, input RecordInflowInpu
 	}
 
 	// Validate source bucket type
-	if sourceBucket.BucketType != domain.BucketTypeIncome {
-		return nil, errors.New("source bucket must be an income bucket")
+	if err := sourceBucket.ValidateRole(domain.BucketRoleInflowSource); err != nil {
+		return nil, err
 	}
 
 	// 2. Handle External Inflow
+	if input.TargetVirtualBucketID != nil && input.IsExternal {
+		return nil, domain.NewValidationError("target virtual bucket cannot be combined with is_external: it bypasses the split rule")
+	}
 	if input.IsExternal {
-		return s.recordExternalInflow(ctx, input, sourceBucket)
+		tx, err := s.recordExternalInflow(ctx, input, sourceBucket)
+		if input.ParkInPhysicalBucketID != nil &&
+			(errors.Is(err, domain.ErrSplitRuleNotFound) || errors.Is(err, domain.ErrSplitRuleHasNoItems) ||
+				errors.Is(err, domain.ErrSplitRuleInactive)) {
+			return s.recordParkedInflow(ctx, input)
+		}
+		return tx, err
+	}
+
+	// 3. Handle Targeted Inflow
+	if input.TargetVirtualBucketID != nil {
+		// STRICT is the default, so only an explicit SCALE_FIXED is a conflicting request
+		if input.AllocationMode == allocator.AllocationModeScaleFixed {
+			return nil, domain.NewValidationError("allocation mode only applies to split rule inflows")
+		}
+		return s.recordTargetedInflow(ctx, input)
 	}
 
-	// 3. Internal Transfer (simplified for now - focus on external as priority)
+	// Nothing says where the money goes: park it if the caller said where it arrived
+	if input.ParkInPhysicalBucketID != nil {
+		return s.recordParkedInflow(ctx, input)
+	}
+
+	// 4. Internal Transfer (simplified for now - focus on external as priority)
 	// TODO: Implement internal transfer logic if needed
 	return nil, errors.New("internal transfer inflow not yet implemented")
 }
 
+// PreviewAllocation computes the split of an inflow without creating a transaction
+// Logic:
+//  1. Fetch the Split Rule for the source bucket; like RecordInflow, a rule without items or a disabled rule
+//     is rejected (ErrSplitRuleHasNoItems / ErrSplitRuleInactive)
+//  2. Call allocator.CalculateAllocation (and allocator.ExplainAllocation if explain is true)
+//  3. Resolve the target bucket names (single batch query)
+func (s *InflowService) PreviewAllocation(
+	ctx context.Context,
+	sourceBucketID uuid.UUID,
+	amount decimal.Decimal,
+	mode allocator.AllocationMode,
+	explain bool,
+) (*AllocationPreview, error) {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, domain.NewValidationError("inflow amount must be positive")
+	}
+
+	// 1. Fetch Split Rule for this source bucket
+	splitRule, err := s.SplitRuleRepo.GetBySourceBucketID(ctx, sourceBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSplitRuleItems(splitRule); err != nil {
+		return nil, err
+	}
+	// A disabled rule would not apply to the inflow, so there is nothing to preview
+	if !splitRule.Active {
+		return nil, fmt.Errorf("%w: rule %s", domain.ErrSplitRuleInactive, splitRule.ID)
+	}
+
+	// 2. Calculate allocation
+	if err := checkFixedCommitment(splitRule, amount, mode); err != nil {
+		return nil, err
+	}
+	allocation, err := allocator.CalculateAllocation(amount, splitRule.Items, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Resolve target bucket names
+	targets, err := s.resolveAllocationTargets(ctx, splitRule.Items, allocation)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &AllocationPreview{
+		Items:      splitRule.Items,
+		Allocation: allocation,
+		Targets:    targets,
+	}
+
+	if explain {
+		steps, err := allocator.ExplainAllocation(amount, splitRule.Items, mode)
+		if err != nil {
+			return nil, err
+		}
+		preview.Steps = steps
+	}
+
+	return preview, nil
+}
+
+// resolveAllocationTargets pairs each split rule item's allocated amount with its target bucket name
+// Names are batch-loaded in a single query; targets are returned in priority order so they read like the rule
+func (s *InflowService) resolveAllocationTargets(
+	ctx context.Context,
+	items []domain.SplitRuleItem,
+	allocation map[uuid.UUID]decimal.Decimal,
+) ([]AllocationTarget, error) {
+	ordered := make([]domain.SplitRuleItem, len(items))
+	copy(ordered, items)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	ids := make([]uuid.UUID, 0, len(ordered))
+	for _, item := range ordered {
+		ids = append(ids, item.TargetBucketID)
+	}
+	buckets, err := s.BucketRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]AllocationTarget, 0, len(ordered))
+	for _, item := range ordered {
+		target := AllocationTarget{
+			BucketID: item.TargetBucketID,
+			Amount:   allocation[item.TargetBucketID],
+		}
+		if bucket, ok := buckets[item.TargetBucketID]; ok {
+			target.Name = bucket.Name
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// AllocationsFromTransaction returns where an inflow transaction's money went: one target per bucket
+// debited in the virtual layer (amounts of repeated buckets summed), in order of first entry,
+// with the bucket names resolved in a single batch query
+func (s *InflowService) AllocationsFromTransaction(ctx context.Context, tx *domain.Transaction) ([]AllocationTarget, error) {
+	targets := make([]AllocationTarget, 0, len(tx.Entries))
+	ids := make([]uuid.UUID, 0, len(tx.Entries))
+	indexByBucket := make(map[uuid.UUID]int, len(tx.Entries))
+	for _, entry := range tx.Entries {
+		if entry.Layer != domain.LayerVirtual || entry.Type != domain.EntryTypeDebit {
+			continue
+		}
+		if i, ok := indexByBucket[entry.BucketID]; ok {
+			targets[i].Amount = targets[i].Amount.Add(entry.Amount)
+			continue
+		}
+		indexByBucket[entry.BucketID] = len(targets)
+		targets = append(targets, AllocationTarget{BucketID: entry.BucketID, Amount: entry.Amount})
+		ids = append(ids, entry.BucketID)
+	}
+	if len(targets) == 0 {
+		return targets, nil
+	}
+
+	buckets, err := s.BucketRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range targets {
+		if bucket, ok := buckets[targets[i].BucketID]; ok {
+			targets[i].Name = bucket.Name
+		}
+	}
+
+	return targets, nil
+}
+
+// GetInflowAllocation reconstructs how a past external inflow was split, from its virtual DEBIT entries
+// Unlike PreviewAllocation this reflects what actually happened, even if the split rule changed since
+// Returns the transaction alongside the targets; a transaction that is not an external inflow is reported
+// as domain.ErrTransactionNotFound
+func (s *InflowService) GetInflowAllocation(ctx context.Context, transactionID uuid.UUID) (*domain.Transaction, []AllocationTarget, error) {
+	tx, err := s.TransactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !tx.IsExternalInflow {
+		return nil, nil, fmt.Errorf("%w: %s is not an external inflow", domain.ErrTransactionNotFound, transactionID)
+	}
+
+	targets, err := s.AllocationsFromTransaction(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, targets, nil
+}
+
+// CreateSplitRule creates the split rule for an income bucket
+// Returns the created rule and advisory warnings (see domain.SplitRule.Lint); warnings do not block saving
+// Logic:
+//  1. Validate the rule (hard invariants, e.g. exactly one REMAINDER item)
+//  2. Verify the source bucket is an income bucket without an existing split rule
+//  3. Verify every target bucket is a virtual bucket
+//  4. Save using SplitRuleRepo.Create
+func (s *InflowService) CreateSplitRule(ctx context.Context, input CreateSplitRuleInput) (*domain.SplitRule, []string, error) {
+	rule := &domain.SplitRule{
+		ID:                          uuid.New(),
+		Name:                        input.Name,
+		SourceBucketID:              input.SourceBucketID,
+		DestinationPhysicalBucketID: input.DestinationPhysicalBucketID,
+		Active:                      true,
+		Items:                       make([]domain.SplitRuleItem, len(input.Items)),
+	}
+	for i, item := range input.Items {
+		item.ID = uuid.New()
+		item.SplitRuleID = rule.ID
+		rule.Items[i] = item
+	}
+
+	// 1. Validate the rule
+	if err := rule.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Verify source bucket
+	sourceBucket, err := s.BucketRepo.GetByID(ctx, input.SourceBucketID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := sourceBucket.ValidateRole(domain.BucketRoleInflowSource); err != nil {
+		return nil, nil, err
+	}
+	if err := ensureNoSplitRule(ctx, s.SplitRuleRepo, input.SourceBucketID); err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Verify the destination physical bucket, if configured
+	if rule.DestinationPhysicalBucketID != nil {
+		destination, err := s.BucketRepo.GetByID(ctx, *rule.DestinationPhysicalBucketID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := destination.ValidateRole(domain.BucketRoleInflowDestination); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 4. Verify target buckets (and that they belong to the configured destination)
+	for _, item := range rule.Items {
+		targetBucket, err := s.BucketRepo.GetByID(ctx, item.TargetBucketID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := targetBucket.ValidateRole(domain.BucketRoleSplitTarget); err != nil {
+			return nil, nil, err
+		}
+		if err := checkDestinationParent(rule, targetBucket); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 5. Save
+	if err := s.SplitRuleRepo.Create(ctx, rule); err != nil {
+		return nil, nil, err
+	}
+
+	return rule, rule.Lint(), nil
+}
+
+// CloneSplitRule copies the split rule of one income bucket to another income bucket (atomically)
+// The copy gets fresh rule and item IDs but the same items and destination physical bucket. The targets
+// are not re-verified: they were checked when the copied rule was created.
+func (s *InflowService) CloneSplitRule(ctx context.Context, input CloneSplitRuleInput) (*domain.SplitRule, error) {
+	if input.FromSourceBucketID == input.ToSourceBucketID {
+		return nil, domain.NewValidationError("cannot clone a split rule onto its own source bucket")
+	}
+
+	var clone *domain.SplitRule
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 1. Load the rule to copy
+		original, err := repos.SplitRules.GetBySourceBucketID(ctx, input.FromSourceBucketID)
+		if err != nil {
+			return err
+		}
+		if err := requireSplitRuleItems(original); err != nil {
+			return err
+		}
+
+		// 2. Verify the new source bucket
+		sourceBucket, err := repos.Buckets.GetByID(ctx, input.ToSourceBucketID)
+		if err != nil {
+			return err
+		}
+		if err := sourceBucket.ValidateRole(domain.BucketRoleInflowSource); err != nil {
+			return err
+		}
+		if err := ensureNoSplitRule(ctx, repos.SplitRules, input.ToSourceBucketID); err != nil {
+			return err
+		}
+
+		// 3. Copy with fresh IDs
+		name := input.Name
+		if strings.TrimSpace(name) == "" {
+			name = original.Name
+		}
+		clone = &domain.SplitRule{
+			ID:                          uuid.New(),
+			Name:                        name,
+			SourceBucketID:              input.ToSourceBucketID,
+			DestinationPhysicalBucketID: original.DestinationPhysicalBucketID,
+			Active:                      original.Active,
+			Items:                       make([]domain.SplitRuleItem, len(original.Items)),
+		}
+		for i, item := range original.Items {
+			item.ID = uuid.New()
+			item.SplitRuleID = clone.ID
+			clone.Items[i] = item
+		}
+
+		// 4. Save
+		return repos.SplitRules.Create(ctx, clone)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// ensureNoSplitRule rejects a source bucket that already has a split rule
+// A rule header left without items still occupies the source bucket; any other lookup failure is returned as is
+func ensureNoSplitRule(ctx context.Context, splitRuleRepo domain.SplitRuleRepository, sourceBucketID uuid.UUID) error {
+	_, err := splitRuleRepo.GetBySourceBucketID(ctx, sourceBucketID)
+	if err == nil {
+		return domain.NewValidationError("source bucket already has a split rule")
+	}
+	if !errors.Is(err, domain.ErrSplitRuleNotFound) {
+		return err
+	}
+	return nil
+}
+
+// requireSplitRuleItems rejects a rule without items (e.g. a header left behind by a failed write),
+// which cannot allocate anything
+func requireSplitRuleItems(splitRule *domain.SplitRule) error {
+	if len(splitRule.Items) == 0 {
+		return fmt.Errorf("%w: rule %s", domain.ErrSplitRuleHasNoItems, splitRule.ID)
+	}
+	return nil
+}
+
+// GetSplitRule returns the split rule applied to external inflows of a source bucket
+func (s *InflowService) GetSplitRule(ctx context.Context, sourceBucketID uuid.UUID) (*domain.SplitRule, error) {
+	return s.SplitRuleRepo.GetBySourceBucketID(ctx, sourceBucketID)
+}
+
+// SetSplitRuleActive enables or disables the split rule of a source bucket and returns the updated rule
+// The items are kept, so a disabled rule (e.g. for a bonus month allocated by hand) can be enabled again as is;
+// a header without items is returned without them rather than failing, since the update has been applied
+func (s *InflowService) SetSplitRuleActive(ctx context.Context, sourceBucketID uuid.UUID, active bool) (*domain.SplitRule, error) {
+	if err := s.SplitRuleRepo.SetActive(ctx, sourceBucketID, active); err != nil {
+		return nil, err
+	}
+	return s.SplitRuleRepo.GetBySourceBucketID(ctx, sourceBucketID)
+}
+
+// ListIncomeSources returns every income bucket with whether it has a split rule and how many items it has
+// Buckets are returned in the order of BucketRepo.List; rule items are counted in a single query, not loaded
+func (s *InflowService) ListIncomeSources(ctx context.Context) ([]IncomeSource, error) {
+	buckets, err := s.BucketRepo.List(ctx, domain.BucketTypeIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	itemCounts, err := s.SplitRuleRepo.CountItemsBySourceBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]IncomeSource, 0, len(buckets))
+	for _, bucket := range buckets {
+		count, hasRule := itemCounts[bucket.ID]
+		sources = append(sources, IncomeSource{
+			Bucket:    bucket,
+			HasRule:   hasRule,
+			ItemCount: count,
+		})
+	}
+
+	return sources, nil
+}
+
+// splitRuleTargets loads every split rule target in a single batch query, in item order, skipping repeated targets
+// Returns domain.ErrBucketNotFound if a target does not exist. The rule must have at least one item.
+func (s *InflowService) splitRuleTargets(ctx context.Context, splitRule *domain.SplitRule) ([]*domain.Bucket, error) {
+	ids := make([]uuid.UUID, 0, len(splitRule.Items))
+	seen := make(map[uuid.UUID]bool, len(splitRule.Items))
+	for _, item := range splitRule.Items {
+		if seen[item.TargetBucketID] {
+			continue
+		}
+		seen[item.TargetBucketID] = true
+		ids = append(ids, item.TargetBucketID)
+	}
+
+	buckets, err := s.BucketRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]*domain.Bucket, 0, len(ids))
+	for _, id := range ids {
+		target, ok := buckets[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrBucketNotFound, id)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// splitRuleDestination returns the physical bucket a split rule's inflows land in
+// Rules with a configured DestinationPhysicalBucketID use it; older rules without one fall back to
+// inferring it from the parent of the first target bucket. Either way, recordExternalInflow then
+// verifies that every target belongs to the returned bucket.
+func (s *InflowService) splitRuleDestination(ctx context.Context, splitRule *domain.SplitRule, firstTarget *domain.Bucket) (uuid.UUID, error) {
+	if splitRule.DestinationPhysicalBucketID != nil {
+		destination, err := s.BucketRepo.GetByID(ctx, *splitRule.DestinationPhysicalBucketID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if err := destination.ValidateRole(domain.BucketRoleInflowDestination); err != nil {
+			return uuid.Nil, err
+		}
+		return destination.ID, nil
+	}
+
+	return firstTarget.SplitTargetParent()
+}
+
+// checkDestinationParent rejects a split target that is not a child of the rule's configured destination
+// Rules without a configured destination accept any target (the destination is inferred at inflow time)
+func checkDestinationParent(rule *domain.SplitRule, target *domain.Bucket) error {
+	if rule.DestinationPhysicalBucketID == nil {
+		return nil
+	}
+	if target.ParentPhysicalBucketID == nil || *target.ParentPhysicalBucketID != *rule.DestinationPhysicalBucketID {
+		return domain.NewValidationErrorf(
+			"split rule target bucket %s does not belong to destination physical bucket %s",
+			target.ID, *rule.DestinationPhysicalBucketID,
+		)
+	}
+	return nil
+}
+
+// checkFixedCommitment rejects an inflow that cannot cover the split rule's FIXED items
+// This reports the shortfall up front instead of the allocator's generic per-item error
+// In SCALE_FIXED mode the allocator scales FIXED items down instead, so there is nothing to check
+func checkFixedCommitment(splitRule *domain.SplitRule, amount decimal.Decimal, mode allocator.AllocationMode) error {
+	if mode == allocator.AllocationModeScaleFixed {
+		return nil
+	}
+
+	totalFixed := splitRule.TotalFixed()
+	if amount.LessThan(totalFixed) {
+		return domain.NewValidationErrorf(
+			"inflow amount %s is less than the split rule's fixed commitments of %s",
+			amount, totalFixed,
+		)
+	}
+	return nil
+}
+
 // recordExternalInflow handles external inflow with split rule allocation
 func (s *InflowService) recordExternalInflow(
 	ctx context.Context,
@@ -88,54 +636,46 @@ func (s *InflowService) recordExternalInflow(
 	if err != nil {
 		return nil, err
 	}
-
-	// Calculate allocation using the allocator
-	allocation, err := allocator.CalculateAllocation(input.Amount, splitRule.Items)
-	if err != nil {
+	if err := requireSplitRuleItems(splitRule); err != nil {
 		return nil, err
 	}
-
-	// Determine the parent physical bucket for the source income bucket.
-	// Logic: We infer the physical destination bucket from the first virtual target in the split rule.
-	// Assumption: All split targets in a single rule belong to the same physical bucket (e.g., Bank Account).
-
-	// Get the first target bucket to determine parent physical
-	if len(splitRule.Items) == 0 {
-		return nil, errors.New("split rule must have at least one item")
+	// A disabled rule is kept for later but does not apply, as if the source bucket had none
+	if !splitRule.Active {
+		return nil, fmt.Errorf("%w: rule %s", domain.ErrSplitRuleInactive, splitRule.ID)
 	}
 
-	firstTargetBucketID := splitRule.Items[0].TargetBucketID
-	firstTargetBucket, err := s.BucketRepo.GetByID(ctx, firstTargetBucketID)
+	// Calculate allocation using the allocator
+	if err := checkFixedCommitment(splitRule, input.Amount, input.AllocationMode); err != nil {
+		return nil, err
+	}
+	// The inflow is in the income bucket's currency, and so is every allocated amount
+	inflow := domain.NewMoney(input.Amount, sourceBucket.Currency)
+	allocation, err := allocator.CalculateMoneyAllocation(inflow, splitRule.Items, input.AllocationMode)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate target bucket is virtual
-	if firstTargetBucket.BucketType != domain.BucketTypeVirtual {
-		return nil, errors.New("split rule target buckets must be virtual buckets")
+	// Load every target in one query, including items that received nothing from this inflow
+	targets, err := s.splitRuleTargets(ctx, splitRule)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get parent physical bucket from the first target virtual bucket
-	if firstTargetBucket.ParentPhysicalBucketID == nil {
-		return nil, errors.New("virtual bucket must have a parent physical bucket")
+	// Determine the physical bucket the inflow lands in (configured on the rule, or inferred)
+	parentPhysicalBucketID, err := s.splitRuleDestination(ctx, splitRule, targets[0])
+	if err != nil {
+		return nil, err
 	}
-	parentPhysicalBucketID := *firstTargetBucket.ParentPhysicalBucketID
 
-	// Verify all target buckets belong to the same parent physical bucket
+	// Verify every target is a virtual bucket of that physical bucket, in the inflow's currency
 	// (This is a business rule: all split targets should be in the same physical bucket)
-	for bucketID := range allocation {
-		targetBucket, err := s.BucketRepo.GetByID(ctx, bucketID)
-		if err != nil {
+	for _, targetBucket := range targets {
+		if err := targetBucket.ValidateSplitTarget(parentPhysicalBucketID); err != nil {
 			return nil, err
 		}
-		if targetBucket.BucketType != domain.BucketTypeVirtual {
-			return nil, errors.New("all split rule target buckets must be virtual buckets")
-		}
-		if targetBucket.ParentPhysicalBucketID == nil {
-			return nil, errors.New("virtual bucket must have a parent physical bucket")
-		}
-		if *targetBucket.ParentPhysicalBucketID != parentPhysicalBucketID {
-			return nil, errors.New("all split rule target buckets must belong to the same parent physical bucket")
+		if currency := targetBucket.Balance().Currency; currency != inflow.Currency {
+			return nil, fmt.Errorf("%w: split rule target bucket %s holds %s but the inflow is in %s",
+				domain.ErrCurrencyMismatch, targetBucket.ID, currency, inflow.Currency)
 		}
 	}
 
@@ -165,19 +705,36 @@ func (s *InflowService) recordExternalInflow(
 
 	entries = append(entries, physicalDebitEntry, physicalCreditEntry)
 
-	// Virtual Layer: Debit Target Virtual Buckets (from allocation), Credit Income Source
-	for targetBucketID, allocatedAmount := range allocation {
+	// Virtual Layer: Debit Target Virtual Buckets (from allocation, in split rule order), Credit Income Source
+	// Zero allocations are skipped (Transaction.Validate rejects zero-amount entries), so for a tiny
+	// inflow only the items that actually received money are debited
+	debited := make(map[uuid.UUID]bool, len(allocation))
+	virtualDebitTotal := decimal.Zero
+	for _, item := range splitRule.Items {
+		targetBucketID := item.TargetBucketID
+		allocatedAmount := allocation[targetBucketID]
+		// Items that received nothing (e.g. a 0% item, or everything after scaled FIXED items) get no entry
+		if allocatedAmount.IsZero() || debited[targetBucketID] {
+			continue
+		}
+		debited[targetBucketID] = true
+		virtualDebitTotal = virtualDebitTotal.Add(allocatedAmount.Amount)
 		virtualDebitEntry := domain.TransactionEntry{
 			ID:            uuid.New(),
 			TransactionID: txID,
 			BucketID:      targetBucketID,
-			Amount:        allocatedAmount,
+			Amount:        allocatedAmount.Amount,
 			Type:          domain.EntryTypeDebit,
 			Layer:         domain.LayerVirtual,
 		}
 		entries = append(entries, virtualDebitEntry)
 	}
 
+	// The remaining debits must still balance the income credit below
+	if !virtualDebitTotal.Equal(input.Amount) {
+		return nil, fmt.Errorf("allocated %s does not match the inflow amount %s", virtualDebitTotal, input.Amount)
+	}
+
 	// Credit Income Source in Virtual Layer
 	virtualCreditEntry := domain.TransactionEntry{
 		ID:            uuid.New(),
@@ -191,7 +748,8 @@ func (s *InflowService) recordExternalInflow(
 
 	tx := &domain.Transaction{
 		ID:                 txID,
-		Description:        input.Description,
+		Description:        strings.TrimSpace(input.Description),
+		Memo:               input.Memo,
 		Date:               now,
 		IsInternalTransfer: false,
 		IsExternalInflow:   true,
@@ -203,6 +761,11 @@ func (s *InflowService) recordExternalInflow(
 		return nil, err
 	}
 
+	// A dry run stops before anything is written
+	if input.DryRun {
+		return tx, nil
+	}
+
 	// Save using TransactionRepo.Create
 	if err := s.TransactionRepo.Create(ctx, tx); err != nil {
 		return nil, err
@@ -210,3 +773,332 @@ func (s *InflowService) recordExternalInflow(
 
 	return tx, nil
 }
+
+// recordTargetedInflow handles an inflow allocated entirely to a single virtual bucket, without a split rule
+func (s *InflowService) recordTargetedInflow(ctx context.Context, input RecordInflowInput) (*domain.Transaction, error) {
+	targetBucket, err := s.BucketRepo.GetByID(ctx, *input.TargetVirtualBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := targetBucket.ValidateRole(domain.BucketRoleInflowTarget); err != nil {
+		return nil, err
+	}
+	if targetBucket.ParentPhysicalBucketID == nil {
+		return nil, domain.NewValidationError("virtual bucket must have a parent physical bucket")
+	}
+
+	txID := uuid.New()
+	entries := []domain.TransactionEntry{
+		// Physical Layer: Debit Parent Physical Bucket (Bank - increase asset), Credit Income Source
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      *targetBucket.ParentPhysicalBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeDebit,
+			Layer:         domain.LayerPhysical,
+		},
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      input.SourceBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeCredit,
+			Layer:         domain.LayerPhysical,
+		},
+		// Virtual Layer: Debit Target Virtual Bucket (full amount), Credit Income Source
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      targetBucket.ID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeDebit,
+			Layer:         domain.LayerVirtual,
+		},
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      input.SourceBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeCredit,
+			Layer:         domain.LayerVirtual,
+		},
+	}
+
+	// Still income from outside the system, so it is flagged like a split rule inflow
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        strings.TrimSpace(input.Description),
+		Memo:               input.Memo,
+		Date:               time.Now(),
+		IsInternalTransfer: false,
+		IsExternalInflow:   true,
+		Entries:            entries,
+	}
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+	if input.DryRun {
+		return tx, nil
+	}
+
+	if err := s.TransactionRepo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// recordParkedInflow handles income without a split rule or target by parking it in SYS_EXTRA_INCOME
+// The money is in the bank (physical layer) but not yet in any envelope (virtual layer):
+//   - Physical Layer: Debit ParkInPhysicalBucketID, Credit Source (Income Bucket)
+//   - Virtual Layer: Debit SYS_EXTRA_INCOME, Credit Source (Income Bucket)
+func (s *InflowService) recordParkedInflow(ctx context.Context, input RecordInflowInput) (*domain.Transaction, error) {
+	physicalBucket, err := s.BucketRepo.GetByID(ctx, *input.ParkInPhysicalBucketID)
+	if err != nil {
+		return nil, err
+	}
+	if err := physicalBucket.ValidateRole(domain.BucketRoleInflowDestination); err != nil {
+		return nil, err
+	}
+	// Fails if the system buckets were not seeded
+	extraIncome, err := s.BucketRepo.GetByID(ctx, seeder.SYS_EXTRA_INCOME)
+	if err != nil {
+		return nil, err
+	}
+
+	txID := uuid.New()
+	entries := []domain.TransactionEntry{
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      physicalBucket.ID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeDebit,
+			Layer:         domain.LayerPhysical,
+		},
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      input.SourceBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeCredit,
+			Layer:         domain.LayerPhysical,
+		},
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      extraIncome.ID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeDebit,
+			Layer:         domain.LayerVirtual,
+		},
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      input.SourceBucketID,
+			Amount:        input.Amount,
+			Type:          domain.EntryTypeCredit,
+			Layer:         domain.LayerVirtual,
+		},
+	}
+
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        strings.TrimSpace(input.Description),
+		Memo:               input.Memo,
+		Date:               time.Now(),
+		IsInternalTransfer: false,
+		IsExternalInflow:   true,
+		Entries:            entries,
+	}
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+	if input.DryRun {
+		return tx, nil
+	}
+
+	if err := s.TransactionRepo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// IsParked reports whether an inflow was parked in SYS_EXTRA_INCOME and still needs to be allocated
+func IsParked(tx *domain.Transaction) bool {
+	return parkedIncomeChange(tx).IsPositive()
+}
+
+// parkedIncomeChange returns how much a transaction added to (parked inflows) or took from (internal transfers
+// out of SYS_EXTRA_INCOME) the parked income, looking at the virtual layer only
+// SYS_EXTRA_INCOME also balances opening balances and initial equity values; those transactions are neither
+// external inflows nor internal transfers, so they do not count as parked income
+func parkedIncomeChange(tx *domain.Transaction) decimal.Decimal {
+	change := decimal.Zero
+	for _, entry := range tx.Entries {
+		if entry.Layer != domain.LayerVirtual || entry.BucketID != seeder.SYS_EXTRA_INCOME {
+			continue
+		}
+		switch {
+		case tx.IsExternalInflow && entry.Type == domain.EntryTypeDebit:
+			change = change.Add(entry.Amount)
+		case tx.IsInternalTransfer && entry.Type == domain.EntryTypeCredit:
+			change = change.Sub(entry.Amount)
+		}
+	}
+	return change
+}
+
+// GetUnallocatedIncome returns the income parked in SYS_EXTRA_INCOME and the transactions that parked or
+// allocated it, so the user can find and distribute money that no split rule applied to
+func (s *InflowService) GetUnallocatedIncome(ctx context.Context) (*UnallocatedIncome, error) {
+	extraIncome, err := s.BucketRepo.GetByID(ctx, seeder.SYS_EXTRA_INCOME)
+	if err != nil {
+		return nil, err
+	}
+
+	return unallocatedIncome(ctx, s.TransactionRepo, extraIncome)
+}
+
+// unallocatedIncome sums the parked income of the SYS_EXTRA_INCOME bucket extraIncome from its transactions
+func unallocatedIncome(ctx context.Context, transactionRepo domain.TransactionRepository, extraIncome *domain.Bucket) (*UnallocatedIncome, error) {
+	transactions, err := transactionRepo.ListInPeriod(ctx, []uuid.UUID{extraIncome.ID}, time.Time{}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UnallocatedIncome{
+		Bucket:        extraIncome,
+		Amount:        decimal.Zero,
+		Contributions: make([]ParkedIncomeChange, 0),
+	}
+	for _, tx := range transactions {
+		change := parkedIncomeChange(tx)
+		if change.IsZero() {
+			continue
+		}
+		result.Amount = result.Amount.Add(change)
+		result.Contributions = append(result.Contributions, ParkedIncomeChange{Transaction: tx, Amount: change})
+	}
+
+	return result, nil
+}
+
+// AllocateUnallocatedIncome distributes parked income from SYS_EXTRA_INCOME into virtual buckets
+// The money already sits in a bank, so only the virtual layer changes (like MoveBetweenVirtualBuckets);
+// the targets must be envelopes of that bank, otherwise its virtual buckets would no longer add up to its balance
+// Logic:
+//  1. Validate the lines: positive amounts, distinct virtual target buckets, all held in the same physical bucket
+//  2. Build Transaction (IsInternalTransfer):
+//     - Virtual Layer: Credit SYS_EXTRA_INCOME (total), Debit each target (its amount)
+//  3. In one unit of work with SYS_EXTRA_INCOME locked, so concurrent allocations cannot both spend
+//     the same parked income: check the total does not exceed the income parked in the targets' physical bucket
+//     (domain.ErrInsufficientFunds otherwise), then create the transaction
+func (s *InflowService) AllocateUnallocatedIncome(
+	ctx context.Context,
+	lines []ParkedIncomeAllocation,
+	description string,
+) (*domain.Transaction, error) {
+	// 1. Validate the lines
+	if len(lines) == 0 {
+		return nil, domain.NewValidationError("at least one allocation is required")
+	}
+	total := decimal.Zero
+	seen := make(map[uuid.UUID]bool, len(lines))
+	var physicalBucketID uuid.UUID
+	for _, line := range lines {
+		if !line.Amount.IsPositive() {
+			return nil, domain.NewValidationErrorf("allocation amount for bucket %s must be positive", line.VirtualBucketID)
+		}
+		if seen[line.VirtualBucketID] {
+			return nil, domain.NewValidationErrorf("bucket %s is allocated more than once", line.VirtualBucketID)
+		}
+		seen[line.VirtualBucketID] = true
+
+		target, err := s.BucketRepo.GetByID(ctx, line.VirtualBucketID)
+		if err != nil {
+			return nil, err
+		}
+		if err := target.ValidateRole(domain.BucketRoleInflowTarget); err != nil {
+			return nil, err
+		}
+		if target.ParentPhysicalBucketID == nil {
+			return nil, domain.NewValidationErrorf("allocation target %s has no parent physical bucket", target.ID)
+		}
+		if physicalBucketID == uuid.Nil {
+			physicalBucketID = *target.ParentPhysicalBucketID
+		} else if *target.ParentPhysicalBucketID != physicalBucketID {
+			return nil, domain.NewValidationErrorf(
+				"allocation target %s is held in physical bucket %s, but all targets must belong to the same physical bucket %s",
+				target.ID, *target.ParentPhysicalBucketID, physicalBucketID,
+			)
+		}
+		total = total.Add(line.Amount)
+	}
+
+	// 2. Virtual-layer transaction out of the system bucket
+	description = strings.TrimSpace(description)
+	if description == "" {
+		description = "Allocate parked income"
+	}
+	txID := uuid.New()
+	entries := []domain.TransactionEntry{
+		{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      seeder.SYS_EXTRA_INCOME,
+			Amount:        total,
+			Type:          domain.EntryTypeCredit,
+			Layer:         domain.LayerVirtual,
+		},
+	}
+	for _, line := range lines {
+		entries = append(entries, domain.TransactionEntry{
+			ID:            uuid.New(),
+			TransactionID: txID,
+			BucketID:      line.VirtualBucketID,
+			Amount:        line.Amount,
+			Type:          domain.EntryTypeDebit,
+			Layer:         domain.LayerVirtual,
+		})
+	}
+
+	tx := &domain.Transaction{
+		ID:                 txID,
+		Description:        description,
+		Date:               time.Now(),
+		IsInternalTransfer: true,
+		IsExternalInflow:   false,
+		Entries:            entries,
+	}
+
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 3. Never allocate more than was parked in the targets' bank
+		if _, err := repos.Buckets.GetByIDForUpdate(ctx, seeder.SYS_EXTRA_INCOME); err != nil {
+			return err
+		}
+		parked, err := repos.Transactions.SumParkedIncome(ctx, seeder.SYS_EXTRA_INCOME, physicalBucketID)
+		if err != nil {
+			return err
+		}
+		if total.GreaterThan(parked) {
+			return fmt.Errorf("%w: %s of income parked in physical bucket %s, cannot allocate %s",
+				domain.ErrInsufficientFunds, parked, physicalBucketID, total)
+		}
+
+		return repos.Transactions.Create(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
@@ -2,11 +2,16 @@ package inflow
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/allocator"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,6 +29,22 @@ func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return args.Get(0).(*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
 func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
 	args := m.Called(ctx, bucket)
 	return args.Error(0)
@@ -45,6 +66,58 @@ func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.Bucke
 	return args.Get(0).([]*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 // MockTransactionRepository is a mock implementation of TransactionRepository for testing
 type MockTransactionRepository struct {
 	mock.Mock
@@ -55,6 +128,19 @@ func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Trans
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
 	args := m.Called(ctx, limit, offset, bucketID)
 	if args.Get(0) == nil {
@@ -68,6 +154,127 @@ func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UU
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
 // MockSplitRuleRepository is a mock implementation of SplitRuleRepository for testing
 type MockSplitRuleRepository struct {
 	mock.Mock
@@ -81,13 +288,40 @@ func (m *MockSplitRuleRepository) GetBySourceBucketID(ctx context.Context, bucke
 	return args.Get(0).(*domain.SplitRule), args.Error(1)
 }
 
+func (m *MockSplitRuleRepository) Create(ctx context.Context, rule *domain.SplitRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockSplitRuleRepository) CountItemsBySourceBucket(ctx context.Context) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+func (m *MockSplitRuleRepository) SetActive(ctx context.Context, sourceBucketID uuid.UUID, active bool) error {
+	args := m.Called(ctx, sourceBucketID, active)
+	return args.Error(0)
+}
+
+// MockUnitOfWork runs the function directly against the given (mock) repositories
+type MockUnitOfWork struct {
+	Repos domain.Repositories
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(repos domain.Repositories) error) error {
+	return fn(m.Repos)
+}
+
 func TestRecordInflow_SalaryInflowWithSplitRule(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
 	mockTxRepo := new(MockTransactionRepository)
 	mockSplitRuleRepo := new(MockSplitRuleRepository)
 
-	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
 
 	// Setup: Physical Bucket (Bank Account)
 	physicalBucketID := uuid.New()
@@ -137,6 +371,7 @@ func TestRecordInflow_SalaryInflowWithSplitRule(t *testing.T) {
 		ID:             splitRuleID,
 		Name:           "Salary Split",
 		SourceBucketID: incomeBucketID,
+		Active:         true,
 		Items: []domain.SplitRuleItem{
 			{
 				ID:             uuid.New(),
@@ -186,12 +421,12 @@ func TestRecordInflow_SalaryInflowWithSplitRule(t *testing.T) {
 	// Mock repository calls
 	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(incomeBucket, nil)
 	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(splitRule, nil)
-	// GetByID for vaultBucketID: once for first item check, once in validation loop
-	mockBucketRepo.On("GetByID", ctx, vaultBucketID).Return(vaultBucket, nil).Times(2)
-	// GetByID for freeCashBucketID: once in validation loop
-	mockBucketRepo.On("GetByID", ctx, freeCashBucketID).Return(freeCashBucket, nil).Once()
-	// GetByID for emergencyBucketID: once in validation loop
-	mockBucketRepo.On("GetByID", ctx, emergencyBucketID).Return(emergencyBucket, nil).Once()
+	// All targets are fetched together in a single batched lookup, in split rule order
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{vaultBucketID, freeCashBucketID, emergencyBucketID}).Return(map[uuid.UUID]*domain.Bucket{
+		vaultBucketID:     vaultBucket,
+		freeCashBucketID:  freeCashBucket,
+		emergencyBucketID: emergencyBucket,
+	}, nil).Once()
 
 	// Mock transaction creation
 	mockTxRepo.On("Create", ctx, mock.MatchedBy(func(tx *domain.Transaction) bool {
@@ -321,7 +556,7 @@ func TestRecordInflow_InvalidAmount(t *testing.T) {
 	mockTxRepo := new(MockTransactionRepository)
 	mockSplitRuleRepo := new(MockSplitRuleRepository)
 
-	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
 
 	input := RecordInflowInput{
 		Amount:         decimal.Zero,
@@ -337,13 +572,234 @@ func TestRecordInflow_InvalidAmount(t *testing.T) {
 	assert.Contains(t, err.Error(), "amount must be positive")
 }
 
+func TestRecordInflow_AmountBelowFixedCommitment(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{
+		ID:         incomeBucketID,
+		Name:       "Employer",
+		BucketType: domain.BucketTypeIncome,
+	}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(800), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(400), Priority: 2},
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeRemainder, Priority: 99},
+		},
+	}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(1000),
+		Description:    "Small payday",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	assert.Nil(t, result)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "inflow amount 1000 is less than the split rule's fixed commitments of 1200")
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecordInflow_ScaleFixedMode(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	rentID, savingsID, catchAllID := uuid.New(), uuid.New(), uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	targetIDs := []uuid.UUID{rentID, savingsID, catchAllID}
+	targets := make(map[uuid.UUID]*domain.Bucket, len(targetIDs))
+	for _, id := range targetIDs {
+		targets[id] = &domain.Bucket{ID: id, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID}
+	}
+	mockBucketRepo.On("GetByIDs", ctx, targetIDs).Return(targets, nil).Once()
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: rentID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(800), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: savingsID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(400), Priority: 2},
+			{ID: uuid.New(), TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Priority: 99},
+		},
+	}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(1000),
+		Description:    "Small payday",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+		AllocationMode: allocator.AllocationModeScaleFixed,
+	})
+
+	assert.NoError(t, err)
+	// Virtual Layer: the scaled FIXED items are debited, the empty REMAINDER gets no entry
+	virtualDebits := make(map[uuid.UUID]decimal.Decimal)
+	for _, entry := range result.Entries {
+		if entry.Layer == domain.LayerVirtual && entry.Type == domain.EntryTypeDebit {
+			virtualDebits[entry.BucketID] = entry.Amount
+		}
+	}
+	assert.Len(t, virtualDebits, 2)
+	assert.True(t, virtualDebits[rentID].Equal(decimal.RequireFromString("666.66")), "got %s", virtualDebits[rentID])
+	assert.True(t, virtualDebits[savingsID].Equal(decimal.RequireFromString("333.34")), "got %s", virtualDebits[savingsID])
+	assert.NotContains(t, virtualDebits, catchAllID)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecordInflow_DryRun(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	rentID, catchAllID := uuid.New(), uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	targetIDs := []uuid.UUID{rentID, catchAllID}
+	targets := make(map[uuid.UUID]*domain.Bucket, len(targetIDs))
+	for _, id := range targetIDs {
+		targets[id] = &domain.Bucket{ID: id, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID}
+	}
+	mockBucketRepo.On("GetByIDs", ctx, targetIDs).Return(targets, nil).Once()
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: rentID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(800), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Priority: 99},
+		},
+	}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(3000),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+		DryRun:         true,
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The would-be transaction carries the full allocation, but nothing is written
+	virtualDebits := make(map[uuid.UUID]decimal.Decimal)
+	for _, entry := range result.Entries {
+		if entry.Layer == domain.LayerVirtual && entry.Type == domain.EntryTypeDebit {
+			virtualDebits[entry.BucketID] = entry.Amount
+		}
+	}
+	assert.True(t, virtualDebits[rentID].Equal(decimal.NewFromInt(800)), "got %s", virtualDebits[rentID])
+	assert.True(t, virtualDebits[catchAllID].Equal(decimal.NewFromInt(2200)), "got %s", virtualDebits[catchAllID])
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// Validation still runs in a dry run
+	_, err = service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(500),
+		Description:    "Small payday",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+		DryRun:         true,
+	})
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecordInflow_TinyInflowSkipsZeroAllocations(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	rentID, missionsID, unusedID, catchAllID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	targetIDs := []uuid.UUID{rentID, missionsID, unusedID, catchAllID}
+	targets := make(map[uuid.UUID]*domain.Bucket, len(targetIDs))
+	for _, id := range targetIDs {
+		targets[id] = &domain.Bucket{ID: id, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID}
+	}
+	mockBucketRepo.On("GetByIDs", ctx, targetIDs).Return(targets, nil).Once()
+	// Scaled FIXED rent takes the whole cent, 10% and 0% items round to nothing, REMAINDER is empty
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: rentID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(800), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: missionsID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2},
+			{ID: uuid.New(), TargetBucketID: unusedID, Type: domain.SplitRuleItemTypePercent, Value: decimal.Zero, Priority: 3},
+			{ID: uuid.New(), TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Priority: 99},
+		},
+	}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	amount := decimal.RequireFromString("0.01")
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         amount,
+		Description:    "Interest",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+		AllocationMode: allocator.AllocationModeScaleFixed,
+	})
+
+	assert.NoError(t, err)
+	// Virtual Layer: one debit for the only non-zero allocation, balancing the income credit
+	var virtualDebits, virtualCredits []domain.TransactionEntry
+	for _, entry := range result.Entries {
+		assert.True(t, entry.Amount.IsPositive(), "no zero-amount entries should be created")
+		if entry.Layer != domain.LayerVirtual {
+			continue
+		}
+		if entry.Type == domain.EntryTypeDebit {
+			virtualDebits = append(virtualDebits, entry)
+		} else {
+			virtualCredits = append(virtualCredits, entry)
+		}
+	}
+	assert.Len(t, virtualDebits, 1)
+	assert.Equal(t, rentID, virtualDebits[0].BucketID)
+	assert.True(t, virtualDebits[0].Amount.Equal(amount))
+	assert.Len(t, virtualCredits, 1)
+	assert.True(t, virtualCredits[0].Amount.Equal(amount))
+	mockTxRepo.AssertExpectations(t)
+}
+
 func TestRecordInflow_InvalidSourceBucketType(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
 	mockTxRepo := new(MockTransactionRepository)
 	mockSplitRuleRepo := new(MockSplitRuleRepository)
 
-	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
 
 	// Setup: Physical Bucket (wrong type)
 	physicalBucketID := uuid.New()
@@ -371,29 +827,379 @@ func TestRecordInflow_InvalidSourceBucketType(t *testing.T) {
 	assert.Contains(t, err.Error(), "source bucket must be an income bucket")
 }
 
-func TestRecordInflow_InternalTransferNotImplemented(t *testing.T) {
+func TestRecordInflow_ParksIncomeWithoutSplitRule(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
 	mockTxRepo := new(MockTransactionRepository)
 	mockSplitRuleRepo := new(MockSplitRuleRepository)
 
-	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
 
 	incomeBucketID := uuid.New()
-	incomeBucket := &domain.Bucket{
-		ID:                     incomeBucketID,
-		Name:                   "Employer",
-		BucketType:             domain.BucketTypeIncome,
-		ParentPhysicalBucketID: nil,
-		CurrentBalance:         decimal.Zero,
-	}
+	bankID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Freelance", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByID", ctx, seeder.SYS_EXTRA_INCOME).Return(&domain.Bucket{ID: seeder.SYS_EXTRA_INCOME, Name: "System Extra Income", BucketType: domain.BucketTypeSystem}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, incomeBucketID))
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
 
-	input := RecordInflowInput{
-		Amount:         decimal.NewFromInt(1000),
-		Description:    "Test",
-		SourceBucketID: incomeBucketID,
-		IsExternal:     false,
-	}
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:                 decimal.NewFromInt(450),
+		Description:            "Invoice 42",
+		SourceBucketID:         incomeBucketID,
+		IsExternal:             true,
+		ParkInPhysicalBucketID: &bankID,
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, result.Validate(), "Parked inflow must be a balanced transaction")
+	assert.True(t, result.IsExternalInflow)
+	assert.True(t, IsParked(result))
+	assert.Len(t, result.Entries, 4)
+	for _, entry := range result.Entries {
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(450)))
+		switch {
+		case entry.Type == domain.EntryTypeCredit:
+			assert.Equal(t, incomeBucketID, entry.BucketID, "The income source is credited on both layers")
+		case entry.Layer == domain.LayerPhysical:
+			assert.Equal(t, bankID, entry.BucketID, "The money lands in the given bank")
+		default:
+			assert.Equal(t, seeder.SYS_EXTRA_INCOME, entry.BucketID, "The money waits in System Extra Income")
+		}
+	}
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecordInflow_InactiveSplitRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		park   bool
+		parked bool
+	}{
+		{name: "parked when a bank is given", park: true, parked: true},
+		{name: "fails without a bank to park in", park: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			mockSplitRuleRepo := new(MockSplitRuleRepository)
+			service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+			incomeBucketID, bankID, holidaysID := uuid.New(), uuid.New(), uuid.New()
+			mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Bonus", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByID", ctx, bankID).Return(&domain.Bucket{ID: bankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+			mockBucketRepo.On("GetByID", ctx, seeder.SYS_EXTRA_INCOME).Return(&domain.Bucket{ID: seeder.SYS_EXTRA_INCOME, Name: "System Extra Income", BucketType: domain.BucketTypeSystem}, nil)
+			// The disabled rule keeps its items, but none of them may be allocated to
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+				ID:             uuid.New(),
+				SourceBucketID: incomeBucketID,
+				Active:         false,
+				Items: []domain.SplitRuleItem{
+					{ID: uuid.New(), TargetBucketID: holidaysID, Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+				},
+			}, nil)
+			mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+			input := RecordInflowInput{
+				Amount:         decimal.NewFromInt(1000),
+				Description:    "Bonus",
+				SourceBucketID: incomeBucketID,
+				IsExternal:     true,
+			}
+			if tt.park {
+				input.ParkInPhysicalBucketID = &bankID
+			}
+
+			result, err := service.RecordInflow(ctx, input)
+
+			mockBucketRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+			if !tt.parked {
+				assert.Nil(t, result)
+				assert.ErrorIs(t, err, domain.ErrSplitRuleInactive)
+				mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.True(t, IsParked(result))
+			for _, entry := range result.Entries {
+				assert.NotEqual(t, holidaysID, entry.BucketID, "The disabled rule's targets receive nothing")
+			}
+			mockTxRepo.AssertNumberOfCalls(t, "Create", 1)
+		})
+	}
+}
+
+func TestSetSplitRuleActive(t *testing.T) {
+	ctx := context.Background()
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID, missingID := uuid.New(), uuid.New()
+	rule := &domain.SplitRule{ID: uuid.New(), SourceBucketID: incomeBucketID, Active: false}
+	mockSplitRuleRepo.On("SetActive", ctx, incomeBucketID, false).Return(nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(rule, nil)
+	mockSplitRuleRepo.On("SetActive", ctx, missingID, true).Return(fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, missingID))
+
+	result, err := service.SetSplitRuleActive(ctx, incomeBucketID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, rule, result)
+
+	_, err = service.SetSplitRuleActive(ctx, missingID, true)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleNotFound)
+	mockSplitRuleRepo.AssertNotCalled(t, "GetBySourceBucketID", ctx, missingID)
+}
+
+func TestSetSplitRuleActive_RuleWithoutItems(t *testing.T) {
+	ctx := context.Background()
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	// The update is applied, so the item-less header is returned instead of an error
+	incomeBucketID := uuid.New()
+	header := &domain.SplitRule{ID: uuid.New(), SourceBucketID: incomeBucketID, Active: true}
+	mockSplitRuleRepo.On("SetActive", ctx, incomeBucketID, true).Return(nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(header, nil)
+
+	result, err := service.SetSplitRuleActive(ctx, incomeBucketID, true)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, result.Active)
+	assert.Empty(t, result.Items)
+}
+
+func TestPreviewAllocation_InactiveSplitRule(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         false,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 1},
+		},
+	}, nil)
+
+	preview, err := service.PreviewAllocation(ctx, incomeBucketID, decimal.NewFromInt(100), allocator.AllocationModeStrict, false)
+
+	assert.Nil(t, preview)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleInactive)
+	mockBucketRepo.AssertNotCalled(t, "GetByIDs", mock.Anything, mock.Anything)
+}
+
+func TestRecordInflow_NoSplitRuleWithoutParkingFails(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Freelance", BucketType: domain.BucketTypeIncome}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, incomeBucketID))
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(450),
+		Description:    "Invoice 42",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleNotFound)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestGetUnallocatedIncome(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	bankID, freelanceID, holidaysID := uuid.New(), uuid.New(), uuid.New()
+	entry := func(bucketID uuid.UUID, amount int64, entryType domain.EntryType, layer domain.Layer) domain.TransactionEntry {
+		return domain.TransactionEntry{ID: uuid.New(), BucketID: bucketID, Amount: decimal.NewFromInt(amount), Type: entryType, Layer: layer}
+	}
+	parked := &domain.Transaction{ID: uuid.New(), Description: "Invoice 42", IsExternalInflow: true, Entries: []domain.TransactionEntry{
+		entry(bankID, 450, domain.EntryTypeDebit, domain.LayerPhysical),
+		entry(freelanceID, 450, domain.EntryTypeCredit, domain.LayerPhysical),
+		entry(seeder.SYS_EXTRA_INCOME, 450, domain.EntryTypeDebit, domain.LayerVirtual),
+		entry(freelanceID, 450, domain.EntryTypeCredit, domain.LayerVirtual),
+	}}
+	// An opening balance also touches SYS_EXTRA_INCOME but is not parked income
+	openingBalance := &domain.Transaction{ID: uuid.New(), Description: "Opening balance", Entries: []domain.TransactionEntry{
+		entry(bankID, 1000, domain.EntryTypeDebit, domain.LayerPhysical),
+		entry(seeder.SYS_EXTRA_INCOME, 1000, domain.EntryTypeCredit, domain.LayerPhysical),
+	}}
+	allocated := &domain.Transaction{ID: uuid.New(), Description: "Allocate parked income", IsInternalTransfer: true, Entries: []domain.TransactionEntry{
+		entry(seeder.SYS_EXTRA_INCOME, 150, domain.EntryTypeCredit, domain.LayerVirtual),
+		entry(holidaysID, 150, domain.EntryTypeDebit, domain.LayerVirtual),
+	}}
+
+	mockBucketRepo.On("GetByID", ctx, seeder.SYS_EXTRA_INCOME).Return(&domain.Bucket{ID: seeder.SYS_EXTRA_INCOME, Name: "System Extra Income", BucketType: domain.BucketTypeSystem}, nil)
+	mockTxRepo.On("ListInPeriod", ctx, []uuid.UUID{seeder.SYS_EXTRA_INCOME}, time.Time{}, mock.AnythingOfType("time.Time")).
+		Return([]*domain.Transaction{parked, openingBalance, allocated}, nil)
+
+	result, err := service.GetUnallocatedIncome(ctx)
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, seeder.SYS_EXTRA_INCOME, result.Bucket.ID)
+	assert.True(t, result.Amount.Equal(decimal.NewFromInt(300)), "got %s", result.Amount)
+	if assert.Len(t, result.Contributions, 2) {
+		assert.Equal(t, parked.ID, result.Contributions[0].Transaction.ID)
+		assert.True(t, result.Contributions[0].Amount.Equal(decimal.NewFromInt(450)))
+		assert.Equal(t, allocated.ID, result.Contributions[1].Transaction.ID)
+		assert.True(t, result.Contributions[1].Amount.Equal(decimal.NewFromInt(-150)))
+	}
+}
+
+func TestAllocateUnallocatedIncome(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), uow)
+
+	bankID, holidaysID, savingsID := uuid.New(), uuid.New(), uuid.New()
+	mockBucketRepo.On("GetByID", ctx, holidaysID).Return(&domain.Bucket{ID: holidaysID, Name: "Holidays", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockBucketRepo.On("GetByID", ctx, savingsID).Return(&domain.Bucket{ID: savingsID, Name: "Savings", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockBucketRepo.On("GetByIDForUpdate", ctx, seeder.SYS_EXTRA_INCOME).Return(&domain.Bucket{ID: seeder.SYS_EXTRA_INCOME, BucketType: domain.BucketTypeSystem}, nil)
+	mockTxRepo.On("SumParkedIncome", ctx, seeder.SYS_EXTRA_INCOME, bankID).Return(decimal.NewFromInt(500), nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	tx, err := service.AllocateUnallocatedIncome(ctx, []ParkedIncomeAllocation{
+		{VirtualBucketID: holidaysID, Amount: decimal.NewFromInt(200)},
+		{VirtualBucketID: savingsID, Amount: decimal.NewFromInt(250)},
+	}, "")
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, tx.IsInternalTransfer)
+	assert.Equal(t, "Allocate parked income", tx.Description)
+	if assert.Len(t, tx.Entries, 3) {
+		for _, entry := range tx.Entries {
+			assert.Equal(t, domain.LayerVirtual, entry.Layer, "Only the virtual layer changes")
+		}
+		assert.Equal(t, seeder.SYS_EXTRA_INCOME, tx.Entries[0].BucketID)
+		assert.Equal(t, domain.EntryTypeCredit, tx.Entries[0].Type)
+		assert.True(t, tx.Entries[0].Amount.Equal(decimal.NewFromInt(450)))
+		assert.Equal(t, holidaysID, tx.Entries[1].BucketID)
+		assert.Equal(t, savingsID, tx.Entries[2].BucketID)
+	}
+	// The allocation reduces the parked income
+	assert.True(t, parkedIncomeChange(tx).Equal(decimal.NewFromInt(-450)))
+	// The parked income is read with SYS_EXTRA_INCOME locked, in the same unit of work as the Create
+	mockBucketRepo.AssertCalled(t, "GetByIDForUpdate", ctx, seeder.SYS_EXTRA_INCOME)
+	mockBucketRepo.AssertNotCalled(t, "GetByID", ctx, seeder.SYS_EXTRA_INCOME)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestAllocateUnallocatedIncome_ExceedsParkedIncome(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo}}
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), uow)
+
+	bankID, holidaysID := uuid.New(), uuid.New()
+	mockBucketRepo.On("GetByID", ctx, holidaysID).Return(&domain.Bucket{ID: holidaysID, Name: "Holidays", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil)
+	mockBucketRepo.On("GetByIDForUpdate", ctx, seeder.SYS_EXTRA_INCOME).Return(&domain.Bucket{ID: seeder.SYS_EXTRA_INCOME, BucketType: domain.BucketTypeSystem}, nil)
+	mockTxRepo.On("SumParkedIncome", ctx, seeder.SYS_EXTRA_INCOME, bankID).Return(decimal.NewFromInt(100), nil)
+
+	tx, err := service.AllocateUnallocatedIncome(ctx, []ParkedIncomeAllocation{
+		{VirtualBucketID: holidaysID, Amount: decimal.NewFromInt(150)},
+	}, "Holiday money")
+
+	assert.Nil(t, tx)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAllocateUnallocatedIncome_Invalid(t *testing.T) {
+	bankID, otherBankID, virtualID, otherVirtualID, expenseID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		lines  []ParkedIncomeAllocation
+		errMsg string
+	}{
+		{"no lines", nil, "at least one allocation is required"},
+		{"zero amount", []ParkedIncomeAllocation{{VirtualBucketID: virtualID, Amount: decimal.Zero}}, "must be positive"},
+		{"duplicate target", []ParkedIncomeAllocation{
+			{VirtualBucketID: virtualID, Amount: decimal.NewFromInt(10)},
+			{VirtualBucketID: virtualID, Amount: decimal.NewFromInt(20)},
+		}, "allocated more than once"},
+		{"non-virtual target", []ParkedIncomeAllocation{{VirtualBucketID: expenseID, Amount: decimal.NewFromInt(10)}}, "must reference a virtual bucket"},
+		{"targets in different banks", []ParkedIncomeAllocation{
+			{VirtualBucketID: virtualID, Amount: decimal.NewFromInt(10)},
+			{VirtualBucketID: otherVirtualID, Amount: decimal.NewFromInt(20)},
+		}, "all targets must belong to the same physical bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+			mockBucketRepo.On("GetByID", ctx, virtualID).Return(&domain.Bucket{ID: virtualID, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID}, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, otherVirtualID).Return(&domain.Bucket{ID: otherVirtualID, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &otherBankID}, nil).Maybe()
+			mockBucketRepo.On("GetByID", ctx, expenseID).Return(&domain.Bucket{ID: expenseID, BucketType: domain.BucketTypeExpense}, nil).Maybe()
+
+			tx, err := service.AllocateUnallocatedIncome(ctx, tt.lines, "")
+
+			assert.Nil(t, tx)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecordInflow_InternalTransferNotImplemented(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	incomeBucket := &domain.Bucket{
+		ID:                     incomeBucketID,
+		Name:                   "Employer",
+		BucketType:             domain.BucketTypeIncome,
+		ParentPhysicalBucketID: nil,
+		CurrentBalance:         decimal.Zero,
+	}
+
+	input := RecordInflowInput{
+		Amount:         decimal.NewFromInt(1000),
+		Description:    "Test",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     false,
+	}
 
 	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(incomeBucket, nil)
 
@@ -403,3 +1209,1084 @@ func TestRecordInflow_InternalTransferNotImplemented(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "internal transfer inflow not yet implemented")
 }
+
+func TestRecordInflow_EquitySourceBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	equityBucketID := uuid.New()
+	equityBucket := &domain.Bucket{
+		ID:             equityBucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}
+
+	input := RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Equity as source",
+		SourceBucketID: equityBucketID,
+		IsExternal:     true,
+	}
+
+	mockBucketRepo.On("GetByID", ctx, equityBucketID).Return(equityBucket, nil)
+
+	result, err := service.RecordInflow(ctx, input)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "source bucket must be an income bucket")
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_EquitySplitTarget(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	incomeBucket := &domain.Bucket{
+		ID:             incomeBucketID,
+		Name:           "Employer",
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}
+
+	// Setup: Split rule whose only target is an equity bucket
+	equityBucketID := uuid.New()
+	equityBucket := &domain.Bucket{
+		ID:             equityBucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}
+
+	splitRuleID := uuid.New()
+	splitRule := &domain.SplitRule{
+		ID:             splitRuleID,
+		Name:           "Invest Everything",
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{
+				ID:             uuid.New(),
+				SplitRuleID:    splitRuleID,
+				TargetBucketID: equityBucketID,
+				Type:           domain.SplitRuleItemTypeRemainder,
+				Value:          decimal.Zero,
+				Priority:       1,
+			},
+		},
+	}
+
+	input := RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	}
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(incomeBucket, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(splitRule, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{equityBucketID}).Return(map[uuid.UUID]*domain.Bucket{equityBucketID: equityBucket}, nil)
+
+	result, err := service.RecordInflow(ctx, input)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "split rule target buckets must be virtual buckets")
+	assert.Contains(t, err.Error(), string(domain.BucketTypeEquity))
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_SplitTargetInOtherCurrency(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	// Setup: EUR income (default currency) split into a USD virtual bucket
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	incomeBucket := &domain.Bucket{
+		ID:             incomeBucketID,
+		Name:           "Employer",
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}
+	dollarBucketID := uuid.New()
+	dollarBucket := &domain.Bucket{
+		ID:                     dollarBucketID,
+		Name:                   "Dollar Savings",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalBucketID,
+		CurrentBalance:         decimal.Zero,
+		Currency:               "USD",
+	}
+
+	splitRuleID := uuid.New()
+	splitRule := &domain.SplitRule{
+		ID:             splitRuleID,
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{
+				ID:             uuid.New(),
+				SplitRuleID:    splitRuleID,
+				TargetBucketID: dollarBucketID,
+				Type:           domain.SplitRuleItemTypeRemainder,
+				Value:          decimal.Zero,
+				Priority:       1,
+			},
+		},
+	}
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(incomeBucket, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(splitRule, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{dollarBucketID}).Return(map[uuid.UUID]*domain.Bucket{dollarBucketID: dollarBucket}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+	assert.Nil(t, result)
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_InvalidLaterSplitTarget(t *testing.T) {
+	mainBankID, savingsBankID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name          string
+		target        domain.Bucket
+		expectedError string
+	}{
+		{"Income bucket", domain.Bucket{Name: "Gifts", BucketType: domain.BucketTypeIncome}, "split rule target buckets must be virtual buckets"},
+		{"Expense bucket", domain.Bucket{Name: "Rent", BucketType: domain.BucketTypeExpense}, "split rule target buckets must be virtual buckets"},
+		{"Virtual bucket without parent", domain.Bucket{Name: "Orphan", BucketType: domain.BucketTypeVirtual}, "has no parent physical bucket"},
+		{"Virtual bucket in another physical bucket", domain.Bucket{Name: "Savings", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &savingsBankID}, "must belong to the same parent physical bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			mockSplitRuleRepo := new(MockSplitRuleRepository)
+			service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+			incomeBucketID, groceriesID := uuid.New(), uuid.New()
+			target := tt.target
+			target.ID = uuid.New()
+
+			// The FIXED item takes the whole inflow, so the invalid REMAINDER target receives nothing
+			mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{groceriesID, target.ID}).Return(map[uuid.UUID]*domain.Bucket{
+				groceriesID: {ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+				target.ID:   &target,
+			}, nil).Once()
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+				ID:             uuid.New(),
+				SourceBucketID: incomeBucketID,
+				Active:         true,
+				Items: []domain.SplitRuleItem{
+					{ID: uuid.New(), TargetBucketID: groceriesID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(100), Priority: 1},
+					{ID: uuid.New(), TargetBucketID: target.ID, Type: domain.SplitRuleItemTypeRemainder, Priority: 2},
+				},
+			}, nil)
+
+			result, err := service.RecordInflow(ctx, RecordInflowInput{
+				Amount:         decimal.NewFromInt(100),
+				Description:    "Salary",
+				SourceBucketID: incomeBucketID,
+				IsExternal:     true,
+			})
+
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockBucketRepo.AssertExpectations(t)
+			mockTxRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestRecordInflow_MissingSplitTarget(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID, deletedID := uuid.New(), uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{deletedID}).Return(map[uuid.UUID]*domain.Bucket{}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:             uuid.New(),
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: deletedID, Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+		},
+	}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrBucketNotFound)
+	assert.Nil(t, result)
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_DestinationPhysicalBucket(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured bool // Whether the split rule names its destination or leaves it to be inferred
+	}{
+		{"Configured", true},
+		{"Inferred", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+			service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+			savingsBankID := uuid.New()
+			incomeBucketID := uuid.New()
+			emergencyID, catchAllID := uuid.New(), uuid.New()
+
+			mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByID", ctx, savingsBankID).Return(&domain.Bucket{ID: savingsBankID, Name: "Savings Bank", BucketType: domain.BucketTypePhysical}, nil)
+			targetIDs := []uuid.UUID{emergencyID, catchAllID}
+			targets := make(map[uuid.UUID]*domain.Bucket, len(targetIDs))
+			for _, id := range targetIDs {
+				targets[id] = &domain.Bucket{ID: id, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &savingsBankID}
+			}
+			mockBucketRepo.On("GetByIDs", ctx, targetIDs).Return(targets, nil).Once()
+			rule := &domain.SplitRule{
+				ID:             uuid.New(),
+				SourceBucketID: incomeBucketID,
+				Active:         true,
+				Items: []domain.SplitRuleItem{
+					{ID: uuid.New(), TargetBucketID: emergencyID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(50), Priority: 1},
+					{ID: uuid.New(), TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Priority: 99},
+				},
+			}
+			if tt.configured {
+				rule.DestinationPhysicalBucketID = &savingsBankID
+			}
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(rule, nil)
+			mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+			result, err := service.RecordInflow(ctx, RecordInflowInput{
+				Amount:         decimal.NewFromInt(100),
+				Description:    "Salary",
+				SourceBucketID: incomeBucketID,
+				IsExternal:     true,
+			})
+
+			assert.NoError(t, err)
+			var physicalDebits []domain.TransactionEntry
+			for _, entry := range result.Entries {
+				if entry.Layer == domain.LayerPhysical && entry.Type == domain.EntryTypeDebit {
+					physicalDebits = append(physicalDebits, entry)
+				}
+			}
+			assert.Len(t, physicalDebits, 1)
+			assert.Equal(t, savingsBankID, physicalDebits[0].BucketID)
+			if tt.configured {
+				mockBucketRepo.AssertCalled(t, "GetByID", ctx, savingsBankID)
+			} else {
+				mockBucketRepo.AssertNotCalled(t, "GetByID", ctx, savingsBankID)
+			}
+		})
+	}
+}
+
+func TestRecordInflow_TargetOutsideConfiguredDestination(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	savingsBankID, mainBankID := uuid.New(), uuid.New()
+	incomeBucketID := uuid.New()
+	groceriesID := uuid.New()
+
+	// The rule lands in Savings Bank but its only target lives in Main Bank
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, savingsBankID).Return(&domain.Bucket{ID: savingsBankID, Name: "Savings Bank", BucketType: domain.BucketTypePhysical}, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{groceriesID}).Return(map[uuid.UUID]*domain.Bucket{
+		groceriesID: {ID: groceriesID, BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{
+		ID:                          uuid.New(),
+		SourceBucketID:              incomeBucketID,
+		Active:                      true,
+		DestinationPhysicalBucketID: &savingsBankID,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: groceriesID, Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+		},
+	}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "must belong to the same parent physical bucket")
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_SplitRuleWithoutItems(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	// The repository returns a header without items as is; the rule is rejected before any target lookup
+	incomeBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Employer", BucketType: domain.BucketTypeIncome}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{ID: uuid.New(), SourceBucketID: incomeBucketID, Active: true}, nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "Salary",
+		SourceBucketID: incomeBucketID,
+		IsExternal:     true,
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleHasNoItems)
+	assert.Contains(t, err.Error(), "split rule has no items")
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestPreviewAllocation_SplitRuleWithoutItems(t *testing.T) {
+	ctx := context.Background()
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{ID: uuid.New(), SourceBucketID: incomeBucketID, Active: true}, nil)
+
+	preview, err := service.PreviewAllocation(ctx, incomeBucketID, decimal.NewFromInt(100), allocator.AllocationModeStrict, false)
+
+	assert.Nil(t, preview)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleHasNoItems)
+}
+
+func TestPreviewAllocation_Explain(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	coffeeBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	splitRule := &domain.SplitRule{
+		ID:             uuid.New(),
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: coffeeBucketID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: catchAllBucketID, Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 2},
+		},
+	}
+
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(splitRule, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{coffeeBucketID, catchAllBucketID}).Return(map[uuid.UUID]*domain.Bucket{}, nil)
+
+	preview, err := service.PreviewAllocation(ctx, incomeBucketID, decimal.NewFromInt(1000), allocator.AllocationModeStrict, true)
+
+	assert.NoError(t, err)
+	assert.True(t, preview.Allocation[coffeeBucketID].Equal(decimal.NewFromInt(50)))
+	assert.True(t, preview.Allocation[catchAllBucketID].Equal(decimal.NewFromInt(950)))
+	assert.Len(t, preview.Steps, 2)
+
+	// Without explain, no steps are returned and nothing is persisted
+	preview, err = service.PreviewAllocation(ctx, incomeBucketID, decimal.NewFromInt(1000), allocator.AllocationModeStrict, false)
+
+	assert.NoError(t, err)
+	assert.Empty(t, preview.Steps)
+	mockTxRepo.AssertNotCalled(t, "Create")
+}
+
+func TestPreviewAllocation_ChurchFootballScenarioTargetNames(t *testing.T) {
+	// Same rule as allocator.TestCalculateAllocation_ChurchFootballScenario, stored out of priority order
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	mainBankID := uuid.New()
+	incomeBucketID := uuid.New()
+	coffeeBucketID := uuid.New()
+	missionsBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	splitRule := &domain.SplitRule{
+		ID:             uuid.New(),
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Active:         true,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), TargetBucketID: catchAllBucketID, Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 3},
+			{ID: uuid.New(), TargetBucketID: coffeeBucketID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50), Priority: 1},
+			{ID: uuid.New(), TargetBucketID: missionsBucketID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2},
+		},
+	}
+
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(splitRule, nil)
+	// Names are loaded in a single batch query
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{coffeeBucketID, missionsBucketID, catchAllBucketID}).Return(map[uuid.UUID]*domain.Bucket{
+		coffeeBucketID:   {ID: coffeeBucketID, Name: "Coffee", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		missionsBucketID: {ID: missionsBucketID, Name: "Missions", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		catchAllBucketID: {ID: catchAllBucketID, Name: "Catch-All", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	}, nil).Once()
+
+	preview, err := service.PreviewAllocation(ctx, incomeBucketID, decimal.NewFromInt(1000), allocator.AllocationModeStrict, false)
+
+	assert.NoError(t, err)
+	expected := []struct {
+		bucketID uuid.UUID
+		name     string
+		amount   int64
+	}{
+		{coffeeBucketID, "Coffee", 50},
+		{missionsBucketID, "Missions", 95},
+		{catchAllBucketID, "Catch-All", 855},
+	}
+	assert.Len(t, preview.Targets, len(expected))
+	for i, want := range expected {
+		assert.Equal(t, want.bucketID, preview.Targets[i].BucketID)
+		assert.Equal(t, want.name, preview.Targets[i].Name)
+		assert.True(t, preview.Targets[i].Amount.Equal(decimal.NewFromInt(want.amount)), "%s should be %d, got %s", want.name, want.amount, preview.Targets[i].Amount)
+	}
+	mockBucketRepo.AssertExpectations(t)
+	mockBucketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestAllocationsFromTransaction(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	mainBankID := uuid.New()
+	incomeBucketID := uuid.New()
+	coffeeBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	tx := &domain.Transaction{
+		ID: uuid.New(),
+		Entries: []domain.TransactionEntry{
+			{BucketID: mainBankID, Amount: decimal.NewFromInt(1000), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+			{BucketID: incomeBucketID, Amount: decimal.NewFromInt(1000), Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			{BucketID: coffeeBucketID, Amount: decimal.NewFromInt(50), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: catchAllBucketID, Amount: decimal.NewFromInt(950), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: incomeBucketID, Amount: decimal.NewFromInt(1000), Type: domain.EntryTypeCredit, Layer: domain.LayerVirtual},
+		},
+	}
+
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{coffeeBucketID, catchAllBucketID}).Return(map[uuid.UUID]*domain.Bucket{
+		coffeeBucketID:   {ID: coffeeBucketID, Name: "Coffee", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		catchAllBucketID: {ID: catchAllBucketID, Name: "Catch-All", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	}, nil)
+
+	targets, err := service.AllocationsFromTransaction(ctx, tx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AllocationTarget{
+		{BucketID: coffeeBucketID, Name: "Coffee", Amount: decimal.NewFromInt(50)},
+		{BucketID: catchAllBucketID, Name: "Catch-All", Amount: decimal.NewFromInt(950)},
+	}, targets)
+	mockBucketRepo.AssertExpectations(t)
+}
+
+func TestCreateSplitRule_SavesWithWarnings(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	mainBankID := uuid.New()
+	incomeBucketID := uuid.New()
+	missionsBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, missionsBucketID).Return(&domain.Bucket{ID: missionsBucketID, Name: "Missions", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+	mockBucketRepo.On("GetByID", ctx, catchAllBucketID).Return(&domain.Bucket{ID: catchAllBucketID, Name: "Catch-All", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, incomeBucketID))
+	mockSplitRuleRepo.On("Create", ctx, mock.AnythingOfType("*domain.SplitRule")).Return(nil)
+
+	input := CreateSplitRuleInput{
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Items: []domain.SplitRuleItem{
+			{TargetBucketID: missionsBucketID, Type: domain.SplitRuleItemTypePercent, Value: decimal.Zero, Priority: 1},
+			{TargetBucketID: catchAllBucketID, Type: domain.SplitRuleItemTypeRemainder, Value: decimal.Zero, Priority: 2},
+		},
+	}
+
+	rule, warnings, err := service.CreateSplitRule(ctx, input)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, rule)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "0%")
+	assert.True(t, rule.Active, "New rules are active")
+	for _, item := range rule.Items {
+		assert.NotEqual(t, uuid.Nil, item.ID)
+		assert.Equal(t, rule.ID, item.SplitRuleID)
+	}
+	mockSplitRuleRepo.AssertCalled(t, "Create", ctx, rule)
+}
+
+func TestCreateSplitRule_InvalidRule(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	// No REMAINDER item - a hard validation error, not a warning
+	input := CreateSplitRuleInput{
+		Name:           "Broken Split",
+		SourceBucketID: uuid.New(),
+		Items: []domain.SplitRuleItem{
+			{TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(50), Priority: 1},
+		},
+	}
+
+	rule, warnings, err := service.CreateSplitRule(ctx, input)
+
+	assert.Error(t, err)
+	assert.Nil(t, rule)
+	assert.Nil(t, warnings)
+	assert.Contains(t, err.Error(), "exactly one REMAINDER item")
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockSplitRuleRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateSplitRule_ExistingRuleWithoutItems(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+	// A header left behind without items still counts as the source bucket's rule
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(&domain.SplitRule{ID: uuid.New(), SourceBucketID: incomeBucketID}, nil)
+
+	rule, _, err := service.CreateSplitRule(ctx, CreateSplitRuleInput{
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Items: []domain.SplitRuleItem{
+			{TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+		},
+	})
+
+	assert.Nil(t, rule)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "already has a split rule")
+	mockSplitRuleRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateSplitRule_LookupFailure(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+	// A failed lookup says nothing about whether a rule exists, so nothing is created
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(nil, errors.New("connection refused"))
+
+	rule, _, err := service.CreateSplitRule(ctx, CreateSplitRuleInput{
+		Name:           "Salary Split",
+		SourceBucketID: incomeBucketID,
+		Items: []domain.SplitRuleItem{
+			{TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+		},
+	})
+
+	assert.Nil(t, rule)
+	assert.EqualError(t, err, "connection refused")
+	mockSplitRuleRepo.AssertNotCalled(t, "Create")
+}
+
+func TestCreateSplitRule_DestinationPhysicalBucket(t *testing.T) {
+	mainBankID, savingsBankID := uuid.New(), uuid.New()
+	incomeBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+	virtualBucketID := uuid.New()
+
+	tests := []struct {
+		name          string
+		destinationID uuid.UUID
+		expectedError string
+	}{
+		{"Parent of every target", mainBankID, ""},
+		{"Target in another physical bucket", savingsBankID, "does not belong to destination physical bucket"},
+		{"Not a physical bucket", virtualBucketID, "destination physical bucket must be a physical bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+			service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), mockSplitRuleRepo, new(MockUnitOfWork))
+
+			mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Salary", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByID", ctx, mainBankID).Return(&domain.Bucket{ID: mainBankID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+			mockBucketRepo.On("GetByID", ctx, savingsBankID).Return(&domain.Bucket{ID: savingsBankID, Name: "Savings Bank", BucketType: domain.BucketTypePhysical}, nil)
+			mockBucketRepo.On("GetByID", ctx, virtualBucketID).Return(&domain.Bucket{ID: virtualBucketID, Name: "Fixed Costs", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+			mockBucketRepo.On("GetByID", ctx, catchAllBucketID).Return(&domain.Bucket{ID: catchAllBucketID, Name: "Catch-All", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID}, nil)
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, incomeBucketID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, incomeBucketID))
+			mockSplitRuleRepo.On("Create", ctx, mock.AnythingOfType("*domain.SplitRule")).Return(nil)
+
+			destinationID := tt.destinationID
+			rule, _, err := service.CreateSplitRule(ctx, CreateSplitRuleInput{
+				Name:                        "Salary Split",
+				SourceBucketID:              incomeBucketID,
+				DestinationPhysicalBucketID: &destinationID,
+				Items: []domain.SplitRuleItem{
+					{TargetBucketID: catchAllBucketID, Type: domain.SplitRuleItemTypeRemainder, Priority: 1},
+				},
+			})
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, &destinationID, rule.DestinationPhysicalBucketID)
+				mockSplitRuleRepo.AssertCalled(t, "Create", ctx, rule)
+				return
+			}
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockSplitRuleRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestCloneSplitRule_CopiesItemsWithFreshIDs(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, SplitRules: mockSplitRuleRepo}}
+
+	service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), new(MockSplitRuleRepository), uow)
+
+	salaryID, freelanceID := uuid.New(), uuid.New()
+	mainBankID := uuid.New()
+	netBucketID, titheBucketID, catchAllID := uuid.New(), uuid.New(), uuid.New()
+	originalID := uuid.New()
+	original := &domain.SplitRule{
+		ID:                          originalID,
+		Name:                        "Salary Split",
+		SourceBucketID:              salaryID,
+		Active:                      true,
+		DestinationPhysicalBucketID: &mainBankID,
+		Items: []domain.SplitRuleItem{
+			{ID: uuid.New(), SplitRuleID: originalID, TargetBucketID: netBucketID, Type: domain.SplitRuleItemTypeFixed, Value: decimal.NewFromInt(500), Priority: 1},
+			{ID: uuid.New(), SplitRuleID: originalID, TargetBucketID: titheBucketID, Type: domain.SplitRuleItemTypePercent, Value: decimal.NewFromInt(10), Priority: 2, RelativeToBucketID: &netBucketID},
+			{ID: uuid.New(), SplitRuleID: originalID, TargetBucketID: catchAllID, Type: domain.SplitRuleItemTypeRemainder, Priority: 3},
+		},
+	}
+
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, salaryID).Return(original, nil)
+	mockBucketRepo.On("GetByID", ctx, freelanceID).Return(&domain.Bucket{ID: freelanceID, Name: "Freelance", BucketType: domain.BucketTypeIncome}, nil)
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, freelanceID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, freelanceID))
+	mockSplitRuleRepo.On("Create", ctx, mock.AnythingOfType("*domain.SplitRule")).Return(nil)
+
+	clone, err := service.CloneSplitRule(ctx, CloneSplitRuleInput{FromSourceBucketID: salaryID, ToSourceBucketID: freelanceID})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEqual(t, originalID, clone.ID)
+	assert.Equal(t, "Salary Split", clone.Name, "An empty name keeps the copied rule's name")
+	assert.Equal(t, freelanceID, clone.SourceBucketID)
+	assert.Equal(t, &mainBankID, clone.DestinationPhysicalBucketID)
+	if !assert.Len(t, clone.Items, len(original.Items)) {
+		return
+	}
+	for i, item := range clone.Items {
+		assert.NotEqual(t, original.Items[i].ID, item.ID)
+		assert.Equal(t, clone.ID, item.SplitRuleID)
+		assert.Equal(t, original.Items[i].TargetBucketID, item.TargetBucketID)
+		assert.Equal(t, original.Items[i].Type, item.Type)
+		assert.True(t, original.Items[i].Value.Equal(item.Value))
+		assert.Equal(t, original.Items[i].Priority, item.Priority)
+		assert.Equal(t, original.Items[i].RelativeToBucketID, item.RelativeToBucketID)
+	}
+	mockSplitRuleRepo.AssertCalled(t, "Create", ctx, clone)
+}
+
+func TestCloneSplitRule_InvalidTarget(t *testing.T) {
+	salaryID, freelanceID, groceriesID := uuid.New(), uuid.New(), uuid.New()
+	original := &domain.SplitRule{
+		ID:             uuid.New(),
+		Name:           "Salary Split",
+		SourceBucketID: salaryID,
+		Active:         true,
+		Items:          []domain.SplitRuleItem{{ID: uuid.New(), TargetBucketID: uuid.New(), Type: domain.SplitRuleItemTypeRemainder, Priority: 1}},
+	}
+
+	tests := []struct {
+		name          string
+		toID          uuid.UUID
+		expectedError string
+	}{
+		{"Same source bucket", salaryID, "cannot clone a split rule onto its own source bucket"},
+		{"Target already has a rule", freelanceID, "source bucket already has a split rule"},
+		{"Target is not an income bucket", groceriesID, "source bucket must be an income bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockSplitRuleRepo := new(MockSplitRuleRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, SplitRules: mockSplitRuleRepo}}
+
+			service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), new(MockSplitRuleRepository), uow)
+
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, salaryID).Return(original, nil)
+			mockSplitRuleRepo.On("GetBySourceBucketID", ctx, freelanceID).Return(&domain.SplitRule{ID: uuid.New(), SourceBucketID: freelanceID}, nil)
+			mockBucketRepo.On("GetByID", ctx, freelanceID).Return(&domain.Bucket{ID: freelanceID, Name: "Freelance", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByID", ctx, groceriesID).Return(&domain.Bucket{ID: groceriesID, Name: "Groceries", BucketType: domain.BucketTypeExpense}, nil)
+
+			clone, err := service.CloneSplitRule(ctx, CloneSplitRuleInput{FromSourceBucketID: salaryID, ToSourceBucketID: tt.toID, Name: "Copy"})
+
+			assert.Nil(t, clone)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockSplitRuleRepo.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestCloneSplitRule_SourceWithoutRule(t *testing.T) {
+	ctx := context.Background()
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: new(MockBucketRepository), SplitRules: mockSplitRuleRepo}}
+
+	service := NewInflowService(new(MockBucketRepository), new(MockTransactionRepository), new(MockSplitRuleRepository), uow)
+
+	salaryID := uuid.New()
+	mockSplitRuleRepo.On("GetBySourceBucketID", ctx, salaryID).Return(nil, fmt.Errorf("%w for source bucket ID %s", domain.ErrSplitRuleNotFound, salaryID))
+
+	clone, err := service.CloneSplitRule(ctx, CloneSplitRuleInput{FromSourceBucketID: salaryID, ToSourceBucketID: uuid.New()})
+
+	assert.Nil(t, clone)
+	assert.ErrorIs(t, err, domain.ErrSplitRuleNotFound)
+	mockSplitRuleRepo.AssertNotCalled(t, "Create")
+}
+
+func TestRecordInflow_TargetVirtualBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	incomeBucket := &domain.Bucket{
+		ID:             incomeBucketID,
+		Name:           "Gifts",
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}
+	travelBucketID := uuid.New()
+	travelBucket := &domain.Bucket{
+		ID:                     travelBucketID,
+		Name:                   "Travel",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &physicalBucketID,
+		CurrentBalance:         decimal.NewFromInt(50),
+	}
+
+	input := RecordInflowInput{
+		Amount:                decimal.NewFromInt(150),
+		Description:           "Birthday gift",
+		SourceBucketID:        incomeBucketID,
+		TargetVirtualBucketID: &travelBucketID,
+	}
+
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(incomeBucket, nil)
+	mockBucketRepo.On("GetByID", ctx, travelBucketID).Return(travelBucket, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.RecordInflow(ctx, input)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.True(t, result.IsExternalInflow)
+	assert.Len(t, result.Entries, 4)
+
+	amount := decimal.NewFromInt(150)
+	expected := map[domain.Layer]map[domain.EntryType]uuid.UUID{
+		domain.LayerPhysical: {domain.EntryTypeDebit: physicalBucketID, domain.EntryTypeCredit: incomeBucketID},
+		domain.LayerVirtual:  {domain.EntryTypeDebit: travelBucketID, domain.EntryTypeCredit: incomeBucketID},
+	}
+	for _, entry := range result.Entries {
+		assert.Equal(t, expected[entry.Layer][entry.Type], entry.BucketID, "%s %s entry", entry.Layer, entry.Type)
+		assert.True(t, amount.Equal(entry.Amount))
+	}
+	mockSplitRuleRepo.AssertNotCalled(t, "GetBySourceBucketID", mock.Anything, mock.Anything)
+}
+
+func TestRecordInflow_TargetVirtualBucketValidation(t *testing.T) {
+	incomeBucketID := uuid.New()
+	physicalBucketID := uuid.New()
+	orphanBucketID := uuid.New()
+
+	tests := []struct {
+		name           string
+		targetID       uuid.UUID
+		isExternal     bool
+		allocationMode allocator.AllocationMode
+		expectedError  string
+	}{
+		{name: "combined with split rule", targetID: uuid.New(), isExternal: true, expectedError: "cannot be combined with is_external"},
+		{name: "allocation mode set", targetID: uuid.New(), allocationMode: allocator.AllocationModeScaleFixed, expectedError: "allocation mode only applies to split rule inflows"},
+		{name: "target is not virtual", targetID: physicalBucketID, expectedError: "target virtual bucket ID must reference a virtual bucket"},
+		{name: "target without parent physical", targetID: orphanBucketID, expectedError: "virtual bucket must have a parent physical bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+			mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Gifts", BucketType: domain.BucketTypeIncome}, nil)
+			mockBucketRepo.On("GetByID", ctx, physicalBucketID).Return(&domain.Bucket{ID: physicalBucketID, Name: "Main Bank", BucketType: domain.BucketTypePhysical}, nil)
+			mockBucketRepo.On("GetByID", ctx, orphanBucketID).Return(&domain.Bucket{ID: orphanBucketID, Name: "Orphan", BucketType: domain.BucketTypeVirtual}, nil)
+
+			result, err := service.RecordInflow(ctx, RecordInflowInput{
+				Amount:                decimal.NewFromInt(100),
+				Description:           "Reimbursement",
+				SourceBucketID:        incomeBucketID,
+				IsExternal:            tt.isExternal,
+				AllocationMode:        tt.allocationMode,
+				TargetVirtualBucketID: &tt.targetID,
+			})
+
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecordInflow_BlankDescription(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:         decimal.NewFromInt(100),
+		Description:    "   ",
+		SourceBucketID: uuid.New(),
+		IsExternal:     true,
+	})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "description must not be blank")
+	assert.Nil(t, result)
+	mockBucketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestRecordInflow_BlankDescriptionAllowed(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+	service.AllowBlankDescription = true
+
+	physicalBucketID := uuid.New()
+	incomeBucketID := uuid.New()
+	targetBucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, incomeBucketID).Return(&domain.Bucket{ID: incomeBucketID, Name: "Gifts", BucketType: domain.BucketTypeIncome}, nil)
+	mockBucketRepo.On("GetByID", ctx, targetBucketID).Return(&domain.Bucket{ID: targetBucketID, Name: "Travel", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalBucketID}, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.RecordInflow(ctx, RecordInflowInput{
+		Amount:                decimal.NewFromInt(100),
+		Description:           "",
+		SourceBucketID:        incomeBucketID,
+		TargetVirtualBucketID: &targetBucketID,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Description)
+}
+
+func TestListIncomeSources(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockSplitRuleRepo := new(MockSplitRuleRepository)
+
+	service := NewInflowService(mockBucketRepo, mockTxRepo, mockSplitRuleRepo, new(MockUnitOfWork))
+
+	salary := &domain.Bucket{ID: uuid.New(), Name: "Salary", BucketType: domain.BucketTypeIncome}
+	freelance := &domain.Bucket{ID: uuid.New(), Name: "Freelance", BucketType: domain.BucketTypeIncome}
+	mockBucketRepo.On("List", ctx, domain.BucketTypeIncome).Return([]*domain.Bucket{freelance, salary}, nil)
+	mockSplitRuleRepo.On("CountItemsBySourceBucket", ctx).Return(map[uuid.UUID]int{salary.ID: 3}, nil)
+
+	sources, err := service.ListIncomeSources(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []IncomeSource{
+		{Bucket: freelance, HasRule: false, ItemCount: 0},
+		{Bucket: salary, HasRule: true, ItemCount: 3},
+	}, sources)
+	mockSplitRuleRepo.AssertNotCalled(t, "GetBySourceBucketID", mock.Anything, mock.Anything)
+}
+
+func TestAllocationsFromTransaction_GroupsRepeatedBuckets(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	service := NewInflowService(mockBucketRepo, new(MockTransactionRepository), new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	incomeBucketID := uuid.New()
+	savingsBucketID := uuid.New()
+	tx := &domain.Transaction{
+		ID: uuid.New(),
+		Entries: []domain.TransactionEntry{
+			{BucketID: savingsBucketID, Amount: decimal.NewFromInt(300), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: savingsBucketID, Amount: decimal.NewFromInt(200), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: incomeBucketID, Amount: decimal.NewFromInt(500), Type: domain.EntryTypeCredit, Layer: domain.LayerVirtual},
+		},
+	}
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{savingsBucketID}).Return(map[uuid.UUID]*domain.Bucket{
+		savingsBucketID: {ID: savingsBucketID, Name: "Savings", BucketType: domain.BucketTypeVirtual},
+	}, nil)
+
+	targets, err := service.AllocationsFromTransaction(ctx, tx)
+
+	assert.NoError(t, err)
+	assert.Len(t, targets, 1)
+	assert.Equal(t, "Savings", targets[0].Name)
+	assert.True(t, targets[0].Amount.Equal(decimal.NewFromInt(500)))
+}
+
+func TestGetInflowAllocation(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewInflowService(mockBucketRepo, mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	mainBankID := uuid.New()
+	incomeBucketID := uuid.New()
+	rentBucketID := uuid.New()
+	catchAllBucketID := uuid.New()
+	txID := uuid.New()
+
+	mockTxRepo.On("GetByID", ctx, txID).Return(&domain.Transaction{
+		ID:               txID,
+		Description:      "March salary",
+		IsExternalInflow: true,
+		Entries: []domain.TransactionEntry{
+			{BucketID: mainBankID, Amount: decimal.NewFromInt(2000), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+			{BucketID: incomeBucketID, Amount: decimal.NewFromInt(2000), Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			{BucketID: rentBucketID, Amount: decimal.NewFromInt(800), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: catchAllBucketID, Amount: decimal.NewFromInt(1200), Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{BucketID: incomeBucketID, Amount: decimal.NewFromInt(2000), Type: domain.EntryTypeCredit, Layer: domain.LayerVirtual},
+		},
+	}, nil)
+	mockBucketRepo.On("GetByIDs", ctx, []uuid.UUID{rentBucketID, catchAllBucketID}).Return(map[uuid.UUID]*domain.Bucket{
+		rentBucketID:     {ID: rentBucketID, Name: "Rent", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		catchAllBucketID: {ID: catchAllBucketID, Name: "Catch-All", BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	}, nil)
+
+	tx, targets, err := service.GetInflowAllocation(ctx, txID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "March salary", tx.Description)
+	assert.Equal(t, []AllocationTarget{
+		{BucketID: rentBucketID, Name: "Rent", Amount: decimal.NewFromInt(800)},
+		{BucketID: catchAllBucketID, Name: "Catch-All", Amount: decimal.NewFromInt(1200)},
+	}, targets)
+	mockSplitRuleRepo := service.SplitRuleRepo.(*MockSplitRuleRepository)
+	mockSplitRuleRepo.AssertNotCalled(t, "GetBySourceBucketID", mock.Anything, mock.Anything)
+}
+
+func TestGetInflowAllocation_NotAnExternalInflow(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	service := NewInflowService(new(MockBucketRepository), mockTxRepo, new(MockSplitRuleRepository), new(MockUnitOfWork))
+
+	txID := uuid.New()
+	mockTxRepo.On("GetByID", ctx, txID).Return(&domain.Transaction{ID: txID, Description: "Groceries"}, nil)
+
+	tx, targets, err := service.GetInflowAllocation(ctx, txID)
+
+	assert.Nil(t, tx)
+	assert.Nil(t, targets)
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+}
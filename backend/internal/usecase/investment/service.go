@@ -3,41 +3,70 @@ package investment
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 )
 
+// AddEquityPurchaseInput represents a purchase of (possibly fractional) units of an equity bucket
+type AddEquityPurchaseInput struct {
+	BucketID  uuid.UUID
+	Quantity  decimal.Decimal // Units bought, may be fractional
+	UnitPrice decimal.Decimal // Price paid per unit
+	// Optional: virtual bucket the purchase is paid from; required unless this is the bucket's first purchase
+	SourceVirtualBucketID *uuid.UUID
+}
+
+// EquityPurchaseResult represents the outcome of recording an equity purchase
+type EquityPurchaseResult struct {
+	Holding     *domain.Holding     // Holding after the purchase (updated weighted-average cost)
+	Transaction *domain.Transaction // Transaction raising the book value by the purchase cost
+}
+
 // InvestmentService handles investment-related operations
 type InvestmentService struct {
 	BucketRepo      domain.BucketRepository
 	MarketValueRepo domain.MarketValueRepository
+	HoldingRepo     domain.HoldingRepository
+	UnitOfWork      domain.UnitOfWork
 }
 
 // NewInvestmentService creates a new InvestmentService instance
-func NewInvestmentService(bucketRepo domain.BucketRepository, marketValueRepo domain.MarketValueRepository) *InvestmentService {
+func NewInvestmentService(
+	bucketRepo domain.BucketRepository,
+	marketValueRepo domain.MarketValueRepository,
+	holdingRepo domain.HoldingRepository,
+	unitOfWork domain.UnitOfWork,
+) *InvestmentService {
 	return &InvestmentService{
 		BucketRepo:      bucketRepo,
 		MarketValueRepo: marketValueRepo,
+		HoldingRepo:     holdingRepo,
+		UnitOfWork:      unitOfWork,
 	}
 }
 
-// UpdateMarketValue records a new market value point for a bucket
+// UpdateMarketValue records a new market value point for an equity bucket
 // Logic: Insert a new row into market_value_history (does NOT create a transaction entry)
 // Returns the created market value history entry
 func (s *InvestmentService) UpdateMarketValue(ctx context.Context, bucketID uuid.UUID, amount decimal.Decimal) (*domain.MarketValueHistory, error) {
 	// Validate amount is positive
 	if amount.LessThanOrEqual(decimal.Zero) {
-		return nil, errors.New("market value must be positive")
+		return nil, domain.NewValidationError("market value must be positive")
 	}
 
-	// Verify bucket exists (we don't need to use the bucket, just verify it exists)
-	_, err := s.BucketRepo.GetByID(ctx, bucketID)
+	// Verify bucket exists and is an equity bucket (market values are meaningless elsewhere)
+	bucket, err := s.BucketRepo.GetByID(ctx, bucketID)
 	if err != nil {
 		return nil, err
 	}
+	if bucket.BucketType != domain.BucketTypeEquity {
+		return nil, domain.NewValidationError("bucket ID must reference an equity bucket")
+	}
 
 	// Create market value history entry
 	entry := &domain.MarketValueHistory{
@@ -55,29 +84,294 @@ func (s *InvestmentService) UpdateMarketValue(ctx context.Context, bucketID uuid
 	return entry, nil
 }
 
+// UpdateMarketPrice records a new per-unit market price for a quantity-tracked equity bucket
+// Logic: Insert a new row into market_value_history with MarketValue = Quantity * unitPrice (2 decimals)
+// and the unit price itself, so later purchases are valued at the latest price
+// Returns the created market value history entry
+func (s *InvestmentService) UpdateMarketPrice(ctx context.Context, bucketID uuid.UUID, unitPrice decimal.Decimal) (*domain.MarketValueHistory, error) {
+	if !unitPrice.IsPositive() {
+		return nil, domain.NewValidationError("unit price must be positive")
+	}
+
+	// A per-unit price is only meaningful for buckets with a quantity
+	holding, err := s.HoldingRepo.GetByBucketID(ctx, bucketID)
+	if err != nil {
+		if errors.Is(err, domain.ErrHoldingNotFound) {
+			return nil, domain.NewValidationError("unit price requires a bucket with purchases recorded (use market value instead)")
+		}
+		return nil, err
+	}
+
+	entry := &domain.MarketValueHistory{
+		ID:          uuid.New(),
+		BucketID:    bucketID,
+		Date:        time.Now(),
+		MarketValue: holding.MarketValueAt(unitPrice).Round(2),
+		UnitPrice:   &unitPrice,
+	}
+	if err := s.MarketValueRepo.Add(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// AddEquityPurchase records a purchase of units of an equity bucket (atomically)
+// Logic:
+//  1. Validate: the bucket must be EQUITY, quantity and unit price must be positive
+//  2. Update the holding's quantity and weighted-average cost
+//  3. Raise the book value by the purchase cost (Quantity * UnitPrice, 2 decimals):
+//     - Physical Layer: Debit Equity Bucket, Credit the source's parent physical bucket
+//     - Virtual Layer: Debit SYS_VIRTUAL_CLEARING, Credit the source virtual bucket
+//
+// Both layers leave the bank, like LogExpense, so its virtual buckets keep adding up to its balance;
+// the virtual side is balanced by SYS_VIRTUAL_CLEARING since the equity bucket's balance is its book value
+// Without a source bucket only the first purchase (an initial holding bought before tracking started) is accepted,
+// balanced by SYS_EXTRA_INCOME like an opening balance (physical layer only); later purchases must name the envelope that paid
+// A bucket holding a book value without a quantity (e.g. created with a lump book value) is rejected,
+// since its profit could no longer be derived from Quantity * price
+func (s *InvestmentService) AddEquityPurchase(ctx context.Context, input AddEquityPurchaseInput) (*EquityPurchaseResult, error) {
+	var result *EquityPurchaseResult
+
+	err := s.UnitOfWork.Do(ctx, func(repos domain.Repositories) error {
+		// 1. Validate
+		bucket, err := repos.Buckets.GetByID(ctx, input.BucketID)
+		if err != nil {
+			return err
+		}
+		if bucket.BucketType != domain.BucketTypeEquity {
+			return domain.NewValidationError("bucket ID must reference an equity bucket")
+		}
+
+		holding, err := repos.Holdings.GetByBucketID(ctx, input.BucketID)
+		initialHolding := errors.Is(err, domain.ErrHoldingNotFound)
+		if initialHolding {
+			if !bucket.CurrentBalance.IsZero() {
+				return domain.NewValidationError("bucket has a book value without a quantity; quantity tracking must start from an empty bucket")
+			}
+			holding = &domain.Holding{BucketID: input.BucketID, Quantity: decimal.Zero, AvgCost: decimal.Zero}
+		} else if err != nil {
+			return err
+		}
+
+		// The money comes out of the source bucket; only an initial holding may be balanced by SYS_EXTRA_INCOME
+		var source *domain.Bucket
+		if input.SourceVirtualBucketID != nil {
+			source, err = repos.Buckets.GetByID(ctx, *input.SourceVirtualBucketID)
+			if err != nil {
+				return err
+			}
+			if err := source.ValidateRole(domain.BucketRolePurchaseSource); err != nil {
+				return err
+			}
+			if source.ParentPhysicalBucketID == nil {
+				return domain.NewValidationError("source virtual bucket must have a parent physical bucket")
+			}
+			if source.Balance().Currency != bucket.Balance().Currency {
+				return fmt.Errorf("%w: source bucket %s holds %s but equity bucket %s holds %s",
+					domain.ErrCurrencyMismatch, source.ID, source.Balance().Currency, bucket.ID, bucket.Balance().Currency)
+			}
+		} else if !initialHolding {
+			return domain.NewValidationError("source virtual bucket is required for a purchase into an existing holding")
+		}
+
+		// 2. Weighted-average cost
+		if err := holding.AddPurchase(input.Quantity, input.UnitPrice); err != nil {
+			return err
+		}
+		if err := repos.Holdings.Save(ctx, holding); err != nil {
+			return err
+		}
+
+		// 3. Book value
+		cost := input.Quantity.Mul(input.UnitPrice).Round(2)
+		txID := uuid.New()
+		tx := &domain.Transaction{
+			ID:                 txID,
+			Description:        "Purchase of " + input.Quantity.String() + " units of " + bucket.Name,
+			Date:               time.Now(),
+			IsInternalTransfer: false,
+			IsExternalInflow:   false,
+			Entries: []domain.TransactionEntry{
+				{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      input.BucketID,
+					Amount:        cost,
+					Type:          domain.EntryTypeDebit,
+					Layer:         domain.LayerPhysical,
+				},
+			},
+		}
+		if source == nil {
+			tx.Entries = append(tx.Entries, domain.TransactionEntry{
+				ID:            uuid.New(),
+				TransactionID: txID,
+				BucketID:      seeder.SYS_EXTRA_INCOME,
+				Amount:        cost,
+				Type:          domain.EntryTypeCredit,
+				Layer:         domain.LayerPhysical,
+			})
+		} else {
+			tx.Entries = append(tx.Entries,
+				domain.TransactionEntry{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      *source.ParentPhysicalBucketID,
+					Amount:        cost,
+					Type:          domain.EntryTypeCredit,
+					Layer:         domain.LayerPhysical,
+				},
+				domain.TransactionEntry{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      seeder.SYS_VIRTUAL_CLEARING,
+					Amount:        cost,
+					Type:          domain.EntryTypeDebit,
+					Layer:         domain.LayerVirtual,
+				},
+				domain.TransactionEntry{
+					ID:            uuid.New(),
+					TransactionID: txID,
+					BucketID:      source.ID,
+					Amount:        cost,
+					Type:          domain.EntryTypeCredit,
+					Layer:         domain.LayerVirtual,
+				},
+			)
+		}
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		if err := repos.Transactions.Create(ctx, tx); err != nil {
+			return err
+		}
+
+		result = &EquityPurchaseResult{Holding: holding, Transaction: tx}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListMarketValueHistory returns a page of a bucket's market value history and the total number of entries
+// Ordered newest-first unless ascending is true (e.g. for charting)
+func (s *InvestmentService) ListMarketValueHistory(
+	ctx context.Context,
+	bucketID uuid.UUID,
+	limit, offset int,
+	ascending bool,
+) ([]*domain.MarketValueHistory, int, error) {
+	if limit <= 0 {
+		return nil, 0, domain.NewValidationError("limit must be positive")
+	}
+	if offset < 0 {
+		return nil, 0, domain.NewValidationError("offset must be non-negative")
+	}
+
+	// Verify bucket exists so an unknown ID is reported instead of an empty page
+	if _, err := s.BucketRepo.GetByID(ctx, bucketID); err != nil {
+		return nil, 0, err
+	}
+
+	totalCount, err := s.MarketValueRepo.Count(ctx, bucketID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := s.MarketValueRepo.List(ctx, bucketID, limit, offset, ascending)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, totalCount, nil
+}
+
+// RecentMarketValues returns the last limit market values of a bucket, oldest first (e.g. for a sparkline)
+// Only equity buckets have market values; other bucket types get an empty list without a query
+func (s *InvestmentService) RecentMarketValues(ctx context.Context, bucket *domain.Bucket, limit int) ([]*domain.MarketValueHistory, error) {
+	if limit <= 0 {
+		return nil, domain.NewValidationError("limit must be positive")
+	}
+	if bucket.BucketType != domain.BucketTypeEquity {
+		return []*domain.MarketValueHistory{}, nil
+	}
+
+	// Fetch the newest entries, then flip them into chronological order
+	entries, err := s.MarketValueRepo.List(ctx, bucket.ID, limit, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	return entries, nil
+}
+
+// ProfitDetail represents the profit/loss of a bucket in absolute and relative terms
+type ProfitDetail struct {
+	BookValue      decimal.Decimal  // bucket.current_balance
+	MarketValue    decimal.Decimal  // Latest market value (equals BookValue if no history exists)
+	AbsoluteProfit decimal.Decimal  // MarketValue - BookValue
+	PercentProfit  *decimal.Decimal // AbsoluteProfit / BookValue * 100 (2 decimals); nil if BookValue is 0
+}
+
 // CalculateProfit calculates the profit/loss for a bucket
 // Logic: Profit = MarketValue - BookValue
 // BookValue = bucket.current_balance
-// MarketValue = latest entry in market_value_history
+// MarketValue = latest entry in market_value_history, or Quantity * latest unit price for quantity-tracked buckets
 func (s *InvestmentService) CalculateProfit(ctx context.Context, bucketID uuid.UUID) (decimal.Decimal, error) {
+	detail, err := s.CalculateProfitDetail(ctx, bucketID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return detail.AbsoluteProfit, nil
+}
+
+// CalculateProfitDetail calculates the profit/loss for a bucket along with the values it is based on
+// and the percentage return relative to the book value
+// If no market value history exists, the market value is assumed to equal the book value (profit 0)
+func (s *InvestmentService) CalculateProfitDetail(ctx context.Context, bucketID uuid.UUID) (*ProfitDetail, error) {
 	// Fetch bucket to get Book Value (current_balance)
 	bucket, err := s.BucketRepo.GetByID(ctx, bucketID)
 	if err != nil {
-		return decimal.Zero, err
+		return nil, err
 	}
 
-	bookValue := bucket.CurrentBalance
+	detail := &ProfitDetail{
+		BookValue:      bucket.CurrentBalance,
+		MarketValue:    bucket.CurrentBalance,
+		AbsoluteProfit: decimal.Zero,
+	}
 
 	// Fetch latest market value
+	// If no history exists, keep MarketValue = BookValue and profit 0 (safe default)
 	marketValueEntry, err := s.MarketValueRepo.GetLatest(ctx, bucketID)
-	if err != nil {
-		// If no history exists, return 0 (safe default)
-		// This handles the "No History" scenario gracefully
-		return decimal.Zero, nil
+	if err == nil {
+		// Value quantity-tracked buckets at the latest unit price, so purchases made since count
+		var holding *domain.Holding
+		if marketValueEntry.UnitPrice != nil {
+			holding, err = s.HoldingRepo.GetByBucketID(ctx, bucketID)
+			if err != nil && !errors.Is(err, domain.ErrHoldingNotFound) {
+				return nil, err
+			}
+		}
+		detail.MarketValue = domain.CurrentMarketValue(marketValueEntry, holding)
+
+		// Calculate profit: MarketValue - BookValue
+		detail.AbsoluteProfit = domain.CalculateProfit(detail.BookValue, detail.MarketValue)
 	}
 
-	// Calculate profit: MarketValue - BookValue
-	profit := marketValueEntry.MarketValue.Sub(bookValue)
+	// Percentage return is undefined without a book value (e.g. a position fully withdrawn)
+	if !detail.BookValue.IsZero() {
+		percent := detail.AbsoluteProfit.Div(detail.BookValue).Mul(decimal.NewFromInt(100)).Round(2)
+		detail.PercentProfit = &percent
+	}
 
-	return profit, nil
+	return detail, nil
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/simaogato/wealthflow-backend/internal/usecase/seeder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -25,6 +27,22 @@ func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return args.Get(0).(*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
 func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
 	args := m.Called(ctx, bucket)
 	return args.Error(0)
@@ -46,6 +64,58 @@ func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.Bucke
 	return args.Get(0).([]*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 // MockMarketValueRepository is a mock implementation of MarketValueRepository for testing
 type MockMarketValueRepository struct {
 	mock.Mock
@@ -64,12 +134,241 @@ func (m *MockMarketValueRepository) GetLatest(ctx context.Context, bucketID uuid
 	return args.Get(0).(*domain.MarketValueHistory), args.Error(1)
 }
 
+func (m *MockMarketValueRepository) GetLatestForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListByBucket(ctx context.Context, bucketID uuid.UUID, start, end time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) ListForBuckets(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) List(ctx context.Context, bucketID uuid.UUID, limit, offset int, ascending bool) ([]*domain.MarketValueHistory, error) {
+	args := m.Called(ctx, bucketID, limit, offset, ascending)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MarketValueHistory), args.Error(1)
+}
+
+func (m *MockMarketValueRepository) Count(ctx context.Context, bucketID uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockTransactionRepository is a mock implementation of TransactionRepository for testing
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+// MockHoldingRepository is a mock implementation of HoldingRepository for testing
+type MockHoldingRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldingRepository) GetByBucketID(ctx context.Context, bucketID uuid.UUID) (*domain.Holding, error) {
+	args := m.Called(ctx, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Holding), args.Error(1)
+}
+
+func (m *MockHoldingRepository) Save(ctx context.Context, holding *domain.Holding) error {
+	args := m.Called(ctx, holding)
+	return args.Error(0)
+}
+
+func (m *MockHoldingRepository) GetForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]*domain.Holding, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Holding), args.Error(1)
+}
+
+// MockUnitOfWork runs the function directly against the given (mock) repositories
+type MockUnitOfWork struct {
+	Repos domain.Repositories
+}
+
+func (m *MockUnitOfWork) Do(ctx context.Context, fn func(repos domain.Repositories) error) error {
+	return fn(m.Repos)
+}
+
 func TestCalculateProfit_ProfitScenario(t *testing.T) {
 	ctx := context.Background()
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket with Book Value = 1000
 	bucketID := uuid.New()
@@ -108,7 +407,7 @@ func TestCalculateProfit_LossScenario(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket with Book Value = 1000
 	bucketID := uuid.New()
@@ -147,7 +446,7 @@ func TestCalculateProfit_NoHistory(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket with Book Value = 1000
 	bucketID := uuid.New()
@@ -179,7 +478,7 @@ func TestCalculateProfit_BucketNotFound(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket does not exist
 	bucketID := uuid.New()
@@ -202,7 +501,7 @@ func TestUpdateMarketValue_Success(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket exists
 	bucketID := uuid.New()
@@ -239,7 +538,7 @@ func TestUpdateMarketValue_ZeroAmount(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Execute with zero amount
 	bucketID := uuid.New()
@@ -260,7 +559,7 @@ func TestUpdateMarketValue_NegativeAmount(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Execute with negative amount
 	bucketID := uuid.New()
@@ -281,7 +580,7 @@ func TestUpdateMarketValue_BucketNotFound(t *testing.T) {
 	mockBucketRepo := new(MockBucketRepository)
 	mockMarketValueRepo := new(MockMarketValueRepository)
 
-	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
 
 	// Setup: Bucket does not exist
 	bucketID := uuid.New()
@@ -298,3 +597,439 @@ func TestUpdateMarketValue_BucketNotFound(t *testing.T) {
 	// Verify market value repo was not called
 	mockMarketValueRepo.AssertNotCalled(t, "Add")
 }
+
+func TestUpdateMarketValue_NonEquityBucket(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	// Setup: Bucket exists but is a physical bucket
+	bucketID := uuid.New()
+	bucket := &domain.Bucket{
+		ID:             bucketID,
+		Name:           "Main Bank",
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(bucket, nil)
+
+	// Execute
+	entry, err := service.UpdateMarketValue(ctx, bucketID, decimal.NewFromInt(1200))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, entry)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "must reference an equity bucket")
+
+	// Verify market value repo was not called
+	mockMarketValueRepo.AssertNotCalled(t, "Add")
+}
+
+func TestListMarketValueHistory_Success(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	bucketID := uuid.New()
+	page := []*domain.MarketValueHistory{
+		{ID: uuid.New(), BucketID: bucketID, MarketValue: decimal.NewFromInt(1200)},
+		{ID: uuid.New(), BucketID: bucketID, MarketValue: decimal.NewFromInt(1100)},
+	}
+
+	// Mock repository calls
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{ID: bucketID, BucketType: domain.BucketTypeEquity}, nil)
+	mockMarketValueRepo.On("Count", ctx, bucketID).Return(250, nil)
+	mockMarketValueRepo.On("List", ctx, bucketID, 2, 10, true).Return(page, nil)
+
+	// Execute
+	entries, totalCount, err := service.ListMarketValueHistory(ctx, bucketID, 2, 10, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, page, entries)
+	assert.Equal(t, 250, totalCount)
+
+	mockBucketRepo.AssertExpectations(t)
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestRecentMarketValues(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(new(MockBucketRepository), mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	bucket := &domain.Bucket{ID: uuid.New(), Name: "Tesla Stock", BucketType: domain.BucketTypeEquity}
+	now := time.Now()
+	newestFirst := []*domain.MarketValueHistory{
+		{ID: uuid.New(), BucketID: bucket.ID, Date: now, MarketValue: decimal.NewFromInt(1300)},
+		{ID: uuid.New(), BucketID: bucket.ID, Date: now.AddDate(0, 0, -1), MarketValue: decimal.NewFromInt(1200)},
+		{ID: uuid.New(), BucketID: bucket.ID, Date: now.AddDate(0, 0, -2), MarketValue: decimal.NewFromInt(1100)},
+	}
+	mockMarketValueRepo.On("List", ctx, bucket.ID, 3, 0, false).Return(newestFirst, nil)
+
+	entries, err := service.RecentMarketValues(ctx, bucket, 3)
+
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 3) {
+		// Oldest first, so the points plot left to right
+		assert.True(t, entries[0].MarketValue.Equal(decimal.NewFromInt(1100)))
+		assert.True(t, entries[1].MarketValue.Equal(decimal.NewFromInt(1200)))
+		assert.True(t, entries[2].MarketValue.Equal(decimal.NewFromInt(1300)))
+	}
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestRecentMarketValues_NonEquityBucket(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(new(MockBucketRepository), mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	entries, err := service.RecentMarketValues(ctx, &domain.Bucket{ID: uuid.New(), BucketType: domain.BucketTypeVirtual}, 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	mockMarketValueRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListMarketValueHistory_InvalidPagination(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	_, _, err := service.ListMarketValueHistory(ctx, uuid.New(), 0, 0, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "limit must be positive")
+
+	_, _, err = service.ListMarketValueHistory(ctx, uuid.New(), 10, -1, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "offset must be non-negative")
+
+	// Verify no repository calls were made
+	mockBucketRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	mockMarketValueRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCalculateProfitDetail_ProfitScenario(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	// Setup: Book Value = 1000, Latest Market Value = 1150
+	bucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{
+		ID:             bucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}, nil)
+	mockMarketValueRepo.On("GetLatest", ctx, bucketID).Return(&domain.MarketValueHistory{
+		ID:          uuid.New(),
+		BucketID:    bucketID,
+		MarketValue: decimal.NewFromInt(1150),
+	}, nil)
+
+	// Execute
+	detail, err := service.CalculateProfitDetail(ctx, bucketID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, detail.BookValue.Equal(decimal.NewFromInt(1000)))
+	assert.True(t, detail.MarketValue.Equal(decimal.NewFromInt(1150)))
+	assert.True(t, detail.AbsoluteProfit.Equal(decimal.NewFromInt(150)))
+	assert.NotNil(t, detail.PercentProfit)
+	assert.True(t, detail.PercentProfit.Equal(decimal.NewFromInt(15)), "150 / 1000 = 15%%, got %s", detail.PercentProfit)
+
+	mockBucketRepo.AssertExpectations(t)
+	mockMarketValueRepo.AssertExpectations(t)
+}
+
+func TestCalculateProfitDetail_ZeroBookValue(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	// Setup: Book Value = 0 (e.g. fully withdrawn), Latest Market Value = 50
+	bucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{
+		ID:             bucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}, nil)
+	mockMarketValueRepo.On("GetLatest", ctx, bucketID).Return(&domain.MarketValueHistory{
+		ID:          uuid.New(),
+		BucketID:    bucketID,
+		MarketValue: decimal.NewFromInt(50),
+	}, nil)
+
+	// Execute (must not panic on divide-by-zero)
+	detail, err := service.CalculateProfitDetail(ctx, bucketID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, detail.AbsoluteProfit.Equal(decimal.NewFromInt(50)))
+	assert.Nil(t, detail.PercentProfit, "Percentage is undefined without a book value")
+}
+
+func TestCalculateProfitDetail_NoHistory(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, new(MockHoldingRepository), new(MockUnitOfWork))
+
+	// Setup: Book Value = 1000, no market value history
+	bucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{
+		ID:             bucketID,
+		Name:           "XTB Portfolio",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(1000),
+	}, nil)
+	mockMarketValueRepo.On("GetLatest", ctx, bucketID).Return(nil, errors.New("no market value history found"))
+
+	// Execute
+	detail, err := service.CalculateProfitDetail(ctx, bucketID)
+
+	// Assert: market value falls back to book value, so profit and percentage are 0
+	assert.NoError(t, err)
+	assert.True(t, detail.MarketValue.Equal(decimal.NewFromInt(1000)))
+	assert.True(t, detail.AbsoluteProfit.IsZero())
+	assert.NotNil(t, detail.PercentProfit)
+	assert.True(t, detail.PercentProfit.IsZero())
+}
+
+func TestAddEquityPurchase_WeightedAverage(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, Holdings: mockHoldingRepo}}
+	service := NewInvestmentService(new(MockBucketRepository), new(MockMarketValueRepository), new(MockHoldingRepository), uow)
+
+	// Setup: 2 units held at 100 (book value 200), buy 0.5 units at 150
+	bucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{
+		ID:             bucketID,
+		Name:           "VWCE",
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(200),
+	}, nil)
+	mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(&domain.Holding{
+		BucketID: bucketID,
+		Quantity: decimal.NewFromInt(2),
+		AvgCost:  decimal.NewFromInt(100),
+	}, nil)
+	bankID, sourceID := uuid.New(), uuid.New()
+	mockBucketRepo.On("GetByID", ctx, sourceID).Return(&domain.Bucket{
+		ID:                     sourceID,
+		Name:                   "Investing",
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &bankID,
+		CurrentBalance:         decimal.NewFromInt(1000),
+	}, nil)
+	mockHoldingRepo.On("Save", ctx, mock.AnythingOfType("*domain.Holding")).Return(nil)
+	// The purchase is paid from the source envelope and its bank, not balanced by SYS_EXTRA_INCOME
+	mockTxRepo.On("Create", ctx, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return len(tx.Entries) == 4 &&
+			tx.Entries[0].BucketID == bucketID && tx.Entries[0].Type == domain.EntryTypeDebit &&
+			tx.Entries[0].Amount.Equal(decimal.NewFromInt(75)) && tx.Entries[0].Layer == domain.LayerPhysical &&
+			tx.Entries[1].BucketID == bankID && tx.Entries[1].Type == domain.EntryTypeCredit && tx.Entries[1].Layer == domain.LayerPhysical &&
+			tx.Entries[2].BucketID == seeder.SYS_VIRTUAL_CLEARING && tx.Entries[2].Type == domain.EntryTypeDebit && tx.Entries[2].Layer == domain.LayerVirtual &&
+			tx.Entries[3].BucketID == sourceID && tx.Entries[3].Type == domain.EntryTypeCredit && tx.Entries[3].Layer == domain.LayerVirtual
+	})).Return(nil)
+
+	// Execute
+	result, err := service.AddEquityPurchase(ctx, AddEquityPurchaseInput{
+		BucketID:              bucketID,
+		Quantity:              decimal.RequireFromString("0.5"),
+		UnitPrice:             decimal.NewFromInt(150),
+		SourceVirtualBucketID: &sourceID,
+	})
+
+	// Assert: (2 * 100 + 0.5 * 150) / 2.5 = 110
+	assert.NoError(t, err)
+	assert.True(t, result.Holding.Quantity.Equal(decimal.RequireFromString("2.5")))
+	assert.True(t, result.Holding.AvgCost.Equal(decimal.NewFromInt(110)), "got %s", result.Holding.AvgCost)
+	assert.NotNil(t, result.Transaction)
+	mockHoldingRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestAddEquityPurchase_FirstPurchase(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, Holdings: mockHoldingRepo}}
+	service := NewInvestmentService(new(MockBucketRepository), new(MockMarketValueRepository), new(MockHoldingRepository), uow)
+
+	bucketID := uuid.New()
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{ID: bucketID, Name: "VWCE", BucketType: domain.BucketTypeEquity}, nil)
+	mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(nil, domain.ErrHoldingNotFound)
+	mockHoldingRepo.On("Save", ctx, mock.AnythingOfType("*domain.Holding")).Return(nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*domain.Transaction")).Return(nil)
+
+	result, err := service.AddEquityPurchase(ctx, AddEquityPurchaseInput{
+		BucketID:  bucketID,
+		Quantity:  decimal.RequireFromString("0.25"),
+		UnitPrice: decimal.NewFromInt(80),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Holding.Quantity.Equal(decimal.RequireFromString("0.25")))
+	assert.True(t, result.Holding.AvgCost.Equal(decimal.NewFromInt(80)))
+	assert.True(t, result.Transaction.Entries[0].Amount.Equal(decimal.NewFromInt(20)))
+	// Without a source bucket the initial holding is balanced by SYS_EXTRA_INCOME
+	if assert.Len(t, result.Transaction.Entries, 2) {
+		assert.Equal(t, seeder.SYS_EXTRA_INCOME, result.Transaction.Entries[1].BucketID)
+	}
+}
+
+func TestAddEquityPurchase_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		bucket  *domain.Bucket
+		holding *domain.Holding
+		errMsg  string
+	}{
+		{
+			name:   "not an equity bucket",
+			bucket: &domain.Bucket{Name: "Main Bank", BucketType: domain.BucketTypePhysical},
+			errMsg: "bucket ID must reference an equity bucket",
+		},
+		{
+			name:   "book value without quantity",
+			bucket: &domain.Bucket{Name: "Portfolio", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(500)},
+			errMsg: "bucket has a book value without a quantity; quantity tracking must start from an empty bucket",
+		},
+		{
+			name:    "existing holding without source bucket",
+			bucket:  &domain.Bucket{Name: "VWCE", BucketType: domain.BucketTypeEquity, CurrentBalance: decimal.NewFromInt(200)},
+			holding: &domain.Holding{Quantity: decimal.NewFromInt(2), AvgCost: decimal.NewFromInt(100)},
+			errMsg:  "source virtual bucket is required for a purchase into an existing holding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockBucketRepo := new(MockBucketRepository)
+			mockTxRepo := new(MockTransactionRepository)
+			mockHoldingRepo := new(MockHoldingRepository)
+			uow := &MockUnitOfWork{Repos: domain.Repositories{Buckets: mockBucketRepo, Transactions: mockTxRepo, Holdings: mockHoldingRepo}}
+			service := NewInvestmentService(new(MockBucketRepository), new(MockMarketValueRepository), new(MockHoldingRepository), uow)
+
+			bucketID := uuid.New()
+			tt.bucket.ID = bucketID
+			mockBucketRepo.On("GetByID", ctx, bucketID).Return(tt.bucket, nil)
+			if tt.holding != nil {
+				tt.holding.BucketID = bucketID
+				mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(tt.holding, nil)
+			} else {
+				mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(nil, domain.ErrHoldingNotFound)
+			}
+
+			result, err := service.AddEquityPurchase(ctx, AddEquityPurchaseInput{
+				BucketID:  bucketID,
+				Quantity:  decimal.NewFromInt(1),
+				UnitPrice: decimal.NewFromInt(10),
+			})
+
+			assert.Nil(t, result)
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.EqualError(t, err, tt.errMsg)
+			mockHoldingRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateMarketPrice(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	service := NewInvestmentService(new(MockBucketRepository), mockMarketValueRepo, mockHoldingRepo, new(MockUnitOfWork))
+
+	bucketID := uuid.New()
+	mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(&domain.Holding{
+		BucketID: bucketID,
+		Quantity: decimal.RequireFromString("2.5"),
+		AvgCost:  decimal.NewFromInt(110),
+	}, nil)
+	mockMarketValueRepo.On("Add", ctx, mock.AnythingOfType("*domain.MarketValueHistory")).Return(nil)
+
+	entry, err := service.UpdateMarketPrice(ctx, bucketID, decimal.NewFromInt(120))
+
+	assert.NoError(t, err)
+	assert.True(t, entry.MarketValue.Equal(decimal.NewFromInt(300)), "2.5 * 120 = 300, got %s", entry.MarketValue)
+	if assert.NotNil(t, entry.UnitPrice) {
+		assert.True(t, entry.UnitPrice.Equal(decimal.NewFromInt(120)))
+	}
+}
+
+func TestUpdateMarketPrice_NoHolding(t *testing.T) {
+	ctx := context.Background()
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	service := NewInvestmentService(new(MockBucketRepository), mockMarketValueRepo, mockHoldingRepo, new(MockUnitOfWork))
+
+	bucketID := uuid.New()
+	mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(nil, domain.ErrHoldingNotFound)
+
+	entry, err := service.UpdateMarketPrice(ctx, bucketID, decimal.NewFromInt(120))
+
+	assert.Nil(t, entry)
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	mockMarketValueRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestCalculateProfitDetail_QuantityTimesLatestPrice(t *testing.T) {
+	ctx := context.Background()
+	mockBucketRepo := new(MockBucketRepository)
+	mockMarketValueRepo := new(MockMarketValueRepository)
+	mockHoldingRepo := new(MockHoldingRepository)
+	service := NewInvestmentService(mockBucketRepo, mockMarketValueRepo, mockHoldingRepo, new(MockUnitOfWork))
+
+	// Setup: price 120 recorded while holding 2 units; 0.5 units bought since (book value 275)
+	bucketID := uuid.New()
+	unitPrice := decimal.NewFromInt(120)
+	mockBucketRepo.On("GetByID", ctx, bucketID).Return(&domain.Bucket{
+		ID:             bucketID,
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.NewFromInt(275),
+	}, nil)
+	mockMarketValueRepo.On("GetLatest", ctx, bucketID).Return(&domain.MarketValueHistory{
+		BucketID:    bucketID,
+		MarketValue: decimal.NewFromInt(240),
+		UnitPrice:   &unitPrice,
+	}, nil)
+	mockHoldingRepo.On("GetByBucketID", ctx, bucketID).Return(&domain.Holding{
+		BucketID: bucketID,
+		Quantity: decimal.RequireFromString("2.5"),
+		AvgCost:  decimal.NewFromInt(110),
+	}, nil)
+
+	detail, err := service.CalculateProfitDetail(ctx, bucketID)
+
+	// Assert: 2.5 * 120 - 275 = 25
+	assert.NoError(t, err)
+	assert.True(t, detail.MarketValue.Equal(decimal.NewFromInt(300)), "got %s", detail.MarketValue)
+	assert.True(t, detail.AbsoluteProfit.Equal(decimal.NewFromInt(25)), "got %s", detail.AbsoluteProfit)
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+)
+
+// ScheduledProcessor activates scheduled (future-dated) transactions once they are due
+type ScheduledProcessor struct {
+	TransactionRepo domain.TransactionRepository
+}
+
+// NewScheduledProcessor creates a new ScheduledProcessor instance
+func NewScheduledProcessor(transactionRepo domain.TransactionRepository) *ScheduledProcessor {
+	return &ScheduledProcessor{
+		TransactionRepo: transactionRepo,
+	}
+}
+
+// ProcessScheduled activates every scheduled transaction whose effective date is at or before asOf,
+// so its entries start counting in the bucket balances (and net worth)
+// Returns the IDs of the activated transactions, oldest effective date first
+// Each activation is atomic on its own: if one fails, the ones before it stay activated
+// and the rest are picked up by the next run
+func (p *ScheduledProcessor) ProcessScheduled(ctx context.Context, asOf time.Time) ([]uuid.UUID, error) {
+	due, err := p.TransactionRepo.ListScheduledDue(ctx, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due scheduled transactions: %w", err)
+	}
+
+	activated := make([]uuid.UUID, 0, len(due))
+	for _, tx := range due {
+		if err := p.TransactionRepo.Activate(ctx, tx.ID); err != nil {
+			return activated, fmt.Errorf("failed to activate scheduled transaction %s: %w", tx.ID, err)
+		}
+		activated = append(activated, tx.ID)
+	}
+
+	return activated, nil
+}
@@ -0,0 +1,230 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/simaogato/wealthflow-backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactionRepository is a mock implementation of TransactionRepository for testing
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) List(ctx context.Context, limit, offset int, bucketID *uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Count(ctx context.Context, bucketID *uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListForBuckets(ctx context.Context, limit, offset int, bucketIDs []uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountForBuckets(ctx context.Context, bucketIDs []uuid.UUID) (int, error) {
+	args := m.Called(ctx, bucketIDs)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListFiltered(ctx context.Context, limit, offset int, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, limit, offset, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountFiltered(ctx context.Context, filter domain.TransactionFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBalanceChanges(ctx context.Context, bucketID uuid.UUID, until time.Time) ([]domain.BalanceChange, error) {
+	args := m.Called(ctx, bucketID, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceChange), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumParkedIncome(ctx context.Context, parkingBucketID, physicalBucketID uuid.UUID) (decimal.Decimal, error) {
+	args := m.Called(ctx, parkingBucketID, physicalBucketID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucket(ctx context.Context, bucketIDs []uuid.UUID) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumNetDebitsByPeriod(ctx context.Context, bucketID uuid.UUID, layer domain.Layer, start, end time.Time, granularity domain.TrendGranularity) ([]domain.PeriodTotal, error) {
+	args := m.Called(ctx, bucketID, layer, start, end, granularity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PeriodTotal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountByDay(ctx context.Context, start, end time.Time, bucketID *uuid.UUID) ([]domain.DateCount, error) {
+	args := m.Called(ctx, start, end, bucketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DateCount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumCreditsByBucketType(ctx context.Context, bucketType domain.BucketType, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumExternalInflowDebitsByBucket(ctx context.Context, layer domain.Layer, start, end time.Time) (map[uuid.UUID]decimal.Decimal, error) {
+	args := m.Called(ctx, layer, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]decimal.Decimal), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListScheduledDue(ctx context.Context, asOf time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) Activate(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) Recategorize(ctx context.Context, sourceCategoryID, targetCategoryID uuid.UUID, descriptionFilter string) (int, error) {
+	args := m.Called(ctx, sourceCategoryID, targetCategoryID, descriptionFilter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) SumEntriesByBucketBefore(ctx context.Context, bucketIDs []uuid.UUID, before time.Time) (map[uuid.UUID]domain.EntryTotals, error) {
+	args := m.Called(ctx, bucketIDs, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]domain.EntryTotals), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListInPeriod(ctx context.Context, bucketIDs []uuid.UUID, start, end time.Time) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListBetweenBuckets(ctx context.Context, bucketA, bucketB uuid.UUID) ([]*domain.Transaction, error) {
+	args := m.Called(ctx, bucketA, bucketB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
+
+func TestProcessScheduled_ActivatesDueTransactions(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	processor := NewScheduledProcessor(mockTxRepo)
+
+	asOf := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rentDue := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+	billDue := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rent := &domain.Transaction{ID: uuid.New(), Description: "Rent", Scheduled: true, EffectiveDate: &rentDue}
+	bill := &domain.Transaction{ID: uuid.New(), Description: "Electricity bill", Scheduled: true, EffectiveDate: &billDue}
+
+	mockTxRepo.On("ListScheduledDue", ctx, asOf).Return([]*domain.Transaction{rent, bill}, nil)
+	mockTxRepo.On("Activate", ctx, rent.ID).Return(nil)
+	mockTxRepo.On("Activate", ctx, bill.ID).Return(nil)
+
+	activated, err := processor.ProcessScheduled(ctx, asOf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{rent.ID, bill.ID}, activated)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestProcessScheduled_NothingDue(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	processor := NewScheduledProcessor(mockTxRepo)
+
+	asOf := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockTxRepo.On("ListScheduledDue", ctx, asOf).Return([]*domain.Transaction{}, nil)
+
+	activated, err := processor.ProcessScheduled(ctx, asOf)
+
+	assert.NoError(t, err)
+	assert.Empty(t, activated)
+	mockTxRepo.AssertNotCalled(t, "Activate", mock.Anything, mock.Anything)
+}
+
+func TestProcessScheduled_ActivationFailureKeepsEarlierActivations(t *testing.T) {
+	ctx := context.Background()
+	mockTxRepo := new(MockTransactionRepository)
+	processor := NewScheduledProcessor(mockTxRepo)
+
+	asOf := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	first := &domain.Transaction{ID: uuid.New(), Scheduled: true, EffectiveDate: &asOf}
+	second := &domain.Transaction{ID: uuid.New(), Scheduled: true, EffectiveDate: &asOf}
+	third := &domain.Transaction{ID: uuid.New(), Scheduled: true, EffectiveDate: &asOf}
+
+	mockTxRepo.On("ListScheduledDue", ctx, asOf).Return([]*domain.Transaction{first, second, third}, nil)
+	mockTxRepo.On("Activate", ctx, first.ID).Return(nil)
+	mockTxRepo.On("Activate", ctx, second.ID).Return(errors.New("connection reset"))
+
+	activated, err := processor.ProcessScheduled(ctx, asOf)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), second.ID.String())
+	assert.Equal(t, []uuid.UUID{first.ID}, activated)
+	mockTxRepo.AssertNotCalled(t, "Activate", ctx, third.ID)
+}
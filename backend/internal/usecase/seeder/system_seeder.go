@@ -82,3 +82,15 @@ func (s *SystemSeeder) Seed(ctx context.Context) error {
 
 	return nil
 }
+
+// IsSeeded reports whether all system buckets exist, looking them up by their fixed UUIDs
+func (s *SystemSeeder) IsSeeded(ctx context.Context) (bool, error) {
+	ids := []uuid.UUID{SYS_VIRTUAL_CLEARING, SYS_LOST_MISC, SYS_EXTRA_INCOME}
+
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return false, err
+	}
+
+	return len(found) == len(ids), nil
+}
@@ -25,6 +25,22 @@ func (m *MockBucketRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return args.Get(0).(*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Bucket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Bucket, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*domain.Bucket), args.Error(1)
+}
+
 func (m *MockBucketRepository) Create(ctx context.Context, bucket *domain.Bucket) error {
 	args := m.Called(ctx, bucket)
 	return args.Error(0)
@@ -46,6 +62,58 @@ func (m *MockBucketRepository) List(ctx context.Context, typeFilter domain.Bucke
 	return args.Get(0).([]*domain.Bucket), args.Error(1)
 }
 
+func (m *MockBucketRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*domain.Bucket, error) {
+	args := m.Called(ctx, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListWithGoals(ctx context.Context) ([]*domain.Bucket, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Bucket), args.Error(1)
+}
+
+func (m *MockBucketRepository) ListPhysicalWithChildren(ctx context.Context) ([]domain.BucketFamily, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BucketFamily), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateParent(ctx context.Context, bucketID, parentPhysicalBucketID uuid.UUID) error {
+	args := m.Called(ctx, bucketID, parentPhysicalBucketID)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) RecalculateBalances(ctx context.Context, bucketTypes []domain.BucketType) ([]domain.BalanceCorrection, error) {
+	args := m.Called(ctx, bucketTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.BalanceCorrection), args.Error(1)
+}
+
+func (m *MockBucketRepository) UpdateGoal(ctx context.Context, bucketID uuid.UUID, goal *decimal.Decimal) error {
+	args := m.Called(ctx, bucketID, goal)
+	return args.Error(0)
+}
+
+func (m *MockBucketRepository) MergeInto(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBucketRepository) SumBalances(ctx context.Context, bucketType domain.BucketType) (decimal.Decimal, error) {
+	args := m.Called(ctx, bucketType)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
 func TestSystemSeeder_Seed_BucketsMissing(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockBucketRepository)
@@ -159,3 +227,34 @@ func TestSystemSeeder_Seed_PartialBucketsExist(t *testing.T) {
 	// Verify Create was called 2 times (for the 2 missing buckets)
 	mockRepo.AssertNumberOfCalls(t, "Create", 2)
 }
+
+func TestSystemSeeder_IsSeeded(t *testing.T) {
+	ctx := context.Background()
+	ids := []uuid.UUID{SYS_VIRTUAL_CLEARING, SYS_LOST_MISC, SYS_EXTRA_INCOME}
+
+	t.Run("All system buckets exist", func(t *testing.T) {
+		mockRepo := new(MockBucketRepository)
+		mockRepo.On("GetByIDs", ctx, ids).Return(map[uuid.UUID]*domain.Bucket{
+			SYS_VIRTUAL_CLEARING: {ID: SYS_VIRTUAL_CLEARING},
+			SYS_LOST_MISC:        {ID: SYS_LOST_MISC},
+			SYS_EXTRA_INCOME:     {ID: SYS_EXTRA_INCOME},
+		}, nil)
+
+		seeded, err := NewSystemSeeder(mockRepo).IsSeeded(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, seeded)
+	})
+
+	t.Run("A system bucket is missing", func(t *testing.T) {
+		mockRepo := new(MockBucketRepository)
+		mockRepo.On("GetByIDs", ctx, ids).Return(map[uuid.UUID]*domain.Bucket{
+			SYS_VIRTUAL_CLEARING: {ID: SYS_VIRTUAL_CLEARING},
+		}, nil)
+
+		seeded, err := NewSystemSeeder(mockRepo).IsSeeded(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, seeded)
+	})
+}
@@ -2,7 +2,7 @@ package task_generator
 
 import (
 	"context"
-	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -17,6 +17,7 @@ import (
 //   - Group credits and debits by their Physical Parent Bucket
 //   - If money moves from Physical Bucket A to Physical Bucket B -> Create a Task
 //   - If money moves within Bucket A (Virtual A1 -> Virtual A2) -> DO NOT create a Task
+//   - Tasks carry the transaction's description so the pending move can be recognized
 //
 // Returns an error if bucket lookup fails or if entries reference invalid buckets.
 func GenerateTasks(ctx context.Context, tx domain.Transaction, bucketRepo domain.BucketRepository) ([]domain.TransferTask, error) {
@@ -54,7 +55,7 @@ func GenerateTasks(ctx context.Context, tx domain.Transaction, bucketRepo domain
 		case domain.BucketTypeVirtual:
 			// Virtual buckets must have a parent physical bucket
 			if bucket.ParentPhysicalBucketID == nil {
-				return nil, errors.New("virtual bucket must have a parent physical bucket")
+				return nil, domain.NewValidationError("virtual bucket must have a parent physical bucket")
 			}
 			physicalBucketID = *bucket.ParentPhysicalBucketID
 		case domain.BucketTypeIncome, domain.BucketTypeExpense:
@@ -66,19 +67,14 @@ func GenerateTasks(ctx context.Context, tx domain.Transaction, bucketRepo domain
 			continue
 		}
 
-		// Calculate net flow: DEBIT increases (money coming in), CREDIT decreases (money going out)
-		currentFlow := physicalBucketFlows[physicalBucketID]
-		if entry.Type == domain.EntryTypeDebit {
-			// Money coming into this physical bucket
-			physicalBucketFlows[physicalBucketID] = currentFlow.Add(entry.Amount)
-		} else if entry.Type == domain.EntryTypeCredit {
-			// Money going out of this physical bucket
-			physicalBucketFlows[physicalBucketID] = currentFlow.Sub(entry.Amount)
-		}
+		// Calculate net flow: positive is money coming into the physical bucket, negative money going out
+		effect := decimal.NewFromInt(int64(domain.BalanceEffect(entry.Type)))
+		physicalBucketFlows[physicalBucketID] = physicalBucketFlows[physicalBucketID].Add(entry.Amount.Mul(effect))
 	}
 
 	// Generate tasks for net flows between different physical buckets
 	tasks := make([]domain.TransferTask, 0)
+	createdAt := time.Now()
 
 	// Find buckets with positive flow (receiving money) and negative flow (sending money)
 	receivingBuckets := make([]uuid.UUID, 0)
@@ -115,6 +111,8 @@ func GenerateTasks(ctx context.Context, tx domain.Transaction, bucketRepo domain
 					ToPhysicalBucketID:     toBucketID,
 					Amount:                 transferAmount,
 					IsCompleted:            false,
+					Description:            tx.Description,
+					CreatedAt:              createdAt,
 				}
 				tasks = append(tasks, task)
 
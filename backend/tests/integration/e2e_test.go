@@ -5,19 +5,26 @@ package integration
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	wealthflowv1 "github.com/simaogato/wealthflow-backend/internal/adapter/grpc/wealthflow/v1"
 	"github.com/simaogato/wealthflow-backend/internal/adapter/repository/postgres"
@@ -364,6 +371,18 @@ func TestEndToEndFlow(t *testing.T) {
 	require.NoError(t, err, "RecordInflow should succeed")
 	assert.NotEmpty(t, inflowResp.TransactionId, "Transaction ID should be returned")
 
+	// The returned allocation breakdown should account for the whole inflow
+	require.NotEmpty(t, inflowResp.Allocations, "Allocations should be returned")
+	allocatedTotal := decimal.Zero
+	for _, allocation := range inflowResp.Allocations {
+		assert.NotEmpty(t, allocation.Name, "Allocation should include the bucket name")
+		allocationAmount, err := decimal.NewFromString(allocation.Amount)
+		require.NoError(t, err)
+		allocatedTotal = allocatedTotal.Add(allocationAmount)
+	}
+	assert.True(t, allocatedTotal.Equal(decimal.RequireFromString(inflowAmount)),
+		"Allocations should sum to the inflow amount, got %s", allocatedTotal)
+
 	// Step B: Verify money landed in "Main Bank" (via the Virtual Unallocated parent)
 	// Verify transaction entries were created correctly
 	// Check physical layer entry: Main Bank should be debited
@@ -518,6 +537,8 @@ func TestEndToEndFlow(t *testing.T) {
 	netWorthResp, err := grpcClient.GetNetWorth(ctx, netWorthReq)
 	require.NoError(t, err, "GetNetWorth should succeed")
 	require.NotNil(t, netWorthResp, "GetNetWorth response should not be nil")
+	require.NotNil(t, netWorthResp.CalculatedAt, "GetNetWorth should report when it was calculated")
+	assert.WithinDuration(t, time.Now(), netWorthResp.CalculatedAt.AsTime(), time.Minute)
 
 	// Verify Liquidity matches the current Main Bank balance
 	liquidityDecimal, err := decimal.NewFromString(netWorthResp.Liquidity)
@@ -795,6 +816,18 @@ func TestNegativeScenarios(t *testing.T) {
 		require.Error(t, err, "UpdateInvestment with malformed UUID should return an error")
 		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
 	})
+
+	// 4. Wrong bucket type: UpdateInvestment on a physical bucket
+	t.Run("NonEquityBucket", func(t *testing.T) {
+		investmentReq := &wealthflowv1.UpdateInvestmentRequest{
+			BucketId:    testBuckets["Main Bank"].String(),
+			MarketValue: "100.00",
+		}
+
+		_, err := grpcClient.UpdateInvestment(ctx, investmentReq)
+		require.Error(t, err, "UpdateInvestment on a physical bucket should return an error")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+	})
 }
 
 // TestReadFlow tests the Read APIs: ListBuckets, ListTransactions, and GetNetWorth
@@ -1110,6 +1143,7 @@ func TestGetBucket(t *testing.T) {
 		assert.Equal(t, mainBankID.String(), getBucketResp.Bucket.Id, "Bucket ID should match")
 		assert.Equal(t, "Main Bank", getBucketResp.Bucket.Name, "Bucket name should match")
 		assert.Equal(t, wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL, getBucketResp.Bucket.Type, "Bucket type should be PHYSICAL")
+		assert.Empty(t, getBucketResp.ParentName, "Physical bucket should have no parent name")
 
 		// Verify balance is a valid decimal string
 		balance, err := decimal.NewFromString(getBucketResp.Bucket.CurrentBalance)
@@ -1135,6 +1169,7 @@ func TestGetBucket(t *testing.T) {
 		assert.Equal(t, "Unallocated", getBucketResp.Bucket.Name, "Bucket name should match")
 		assert.Equal(t, wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL, getBucketResp.Bucket.Type, "Bucket type should be VIRTUAL")
 		assert.NotEmpty(t, getBucketResp.Bucket.ParentId, "Virtual bucket should have a parent ID")
+		assert.Equal(t, "Main Bank", getBucketResp.ParentName, "Virtual bucket should report its parent's name")
 	})
 
 	t.Run("GetNonExistentBucket", func(t *testing.T) {
@@ -1161,3 +1196,2919 @@ func TestGetBucket(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
 	})
 }
+
+// TestImportTransactions tests the ImportTransactions client-streaming RPC
+func TestImportTransactions(t *testing.T) {
+	ctx := getAuthContext()
+	employerID := testBuckets["Employer"]
+	unallocatedID := testBuckets["Unallocated"]
+	groceriesID := testBuckets["Groceries"]
+
+	stream, err := grpcClient.ImportTransactions(ctx)
+	require.NoError(t, err, "ImportTransactions stream should open")
+
+	rows := []*wealthflowv1.ImportTransactionsRequest{
+		{
+			Row: &wealthflowv1.ImportTransactionsRequest_Inflow{
+				Inflow: &wealthflowv1.RecordInflowRequest{
+					Amount:         "500.00",
+					Description:    "Imported Salary",
+					SourceBucketId: employerID.String(),
+					IsExternal:     true,
+				},
+			},
+		},
+		{
+			// Malformed row: invalid amount should be reported, not abort the batch
+			Row: &wealthflowv1.ImportTransactionsRequest_Expense{
+				Expense: &wealthflowv1.LogExpenseRequest{
+					Amount:           "not-a-number",
+					Description:      "Broken Row",
+					VirtualBucketId:  unallocatedID.String(),
+					CategoryBucketId: groceriesID.String(),
+				},
+			},
+		},
+		{
+			Row: &wealthflowv1.ImportTransactionsRequest_Expense{
+				Expense: &wealthflowv1.LogExpenseRequest{
+					Amount:           "20.00",
+					Description:      "Imported Groceries",
+					VirtualBucketId:  unallocatedID.String(),
+					CategoryBucketId: groceriesID.String(),
+				},
+			},
+		},
+	}
+
+	for _, row := range rows {
+		require.NoError(t, stream.Send(row), "Sending import row should succeed")
+	}
+
+	summary, err := stream.CloseAndRecv()
+	require.NoError(t, err, "ImportTransactions should return a summary")
+
+	assert.Equal(t, int32(2), summary.SuccessCount, "Two rows should be imported")
+	assert.Equal(t, int32(1), summary.FailureCount, "One row should fail")
+	assert.Len(t, summary.TransactionIds, 2, "Each successful row should return a transaction ID")
+	require.Len(t, summary.Errors, 1, "The malformed row should be reported")
+	assert.Equal(t, int32(2), summary.Errors[0].Row, "The error should reference the second row")
+	assert.Contains(t, summary.Errors[0].Error, "invalid amount format")
+}
+
+// TestGetBucketTransactions tests fetching the recent activity of a single bucket
+func TestGetBucketTransactions(t *testing.T) {
+	ctx := getAuthContext()
+
+	t.Run("RecentActivityForBucket", func(t *testing.T) {
+		employerID := testBuckets["Employer"]
+
+		inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+			Amount:         "42.00",
+			Description:    "Bucket Activity Inflow",
+			SourceBucketId: employerID.String(),
+			IsExternal:     true,
+		})
+		require.NoError(t, err, "RecordInflow should succeed")
+
+		resp, err := grpcClient.GetBucketTransactions(ctx, &wealthflowv1.GetBucketTransactionsRequest{
+			BucketId: employerID.String(),
+		})
+		require.NoError(t, err, "GetBucketTransactions should succeed")
+		require.NotEmpty(t, resp.Transactions, "Bucket should have at least one transaction")
+		assert.LessOrEqual(t, len(resp.Transactions), 10, "Default limit should be 10")
+		assert.GreaterOrEqual(t, resp.TotalCount, int32(len(resp.Transactions)))
+
+		// Newest first: the inflow we just recorded should lead the list
+		assert.Equal(t, inflowResp.TransactionId, resp.Transactions[0].Id,
+			"Most recent transaction should be returned first")
+		assert.Equal(t, "Employer", resp.BucketNames[employerID.String()])
+	})
+
+	t.Run("NonExistentBucket", func(t *testing.T) {
+		_, err := grpcClient.GetBucketTransactions(ctx, &wealthflowv1.GetBucketTransactionsRequest{
+			BucketId: uuid.New().String(),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err), "Error code should be NotFound")
+	})
+
+	t.Run("InvalidUUID", func(t *testing.T) {
+		_, err := grpcClient.GetBucketTransactions(ctx, &wealthflowv1.GetBucketTransactionsRequest{
+			BucketId: "not-a-uuid",
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+	})
+}
+
+// TestListTransactionsForBuckets tests filtering transactions by a physical bucket and its virtual child
+func TestListTransactionsForBuckets(t *testing.T) {
+	ctx := getAuthContext()
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	// The inflow touches both Main Bank (physical layer) and Unallocated (virtual layer)
+	inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "15.00",
+		Description:    "Multi-Bucket Inflow",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+
+	resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+		Limit:     100,
+		BucketIds: []string{mainBankID.String(), unallocatedID.String()},
+	})
+	require.NoError(t, err, "ListTransactions with bucket_ids should succeed")
+
+	// Each transaction must appear once even though it touches both buckets
+	seen := make(map[string]int)
+	for _, tx := range resp.Transactions {
+		seen[tx.Id]++
+	}
+	for id, count := range seen {
+		assert.Equal(t, 1, count, "Transaction %s should appear exactly once", id)
+	}
+	assert.Equal(t, 1, seen[inflowResp.TransactionId], "Inflow should be listed for the bucket pair")
+
+	// The combined count covers at least everything each single-bucket filter returns
+	for _, bucketID := range []uuid.UUID{mainBankID, unallocatedID} {
+		single, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+			Limit:    1,
+			BucketId: bucketID.String(),
+		})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, resp.TotalCount, single.TotalCount)
+	}
+
+	t.Run("InvalidBucketIDs", func(t *testing.T) {
+		_, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+			Limit:     10,
+			BucketIds: []string{"not-a-uuid"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+	})
+}
+
+// TestRepositoryNullDecimal tests that a NULL decimal column yields an actionable error instead of a scan failure
+func TestRepositoryNullDecimal(t *testing.T) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	// current_balance is the only nullable DECIMAL column in the schema
+	bucketID := uuid.New()
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO buckets (id, name, bucket_type, current_balance)
+		VALUES ($1, $2, $3, NULL)
+	`, bucketID, "Null Balance Bucket "+bucketID.String(), string(domain.BucketTypePhysical))
+	require.NoError(t, err, "Inserting a bucket with NULL balance should succeed")
+	defer db.ExecContext(ctx, `DELETE FROM buckets WHERE id = $1`, bucketID)
+
+	_, err = bucketRepo.GetByID(ctx, bucketID)
+	require.Error(t, err, "GetByID should fail for a NULL balance")
+	assert.Contains(t, err.Error(), fmt.Sprintf("bucket %s has null balance", bucketID))
+
+	_, err = bucketRepo.List(ctx, domain.BucketTypePhysical)
+	require.Error(t, err, "List should fail for a NULL balance")
+	assert.Contains(t, err.Error(), "has null balance")
+}
+
+// TestListBucketsByParent tests listing the virtual children of a physical bucket
+func TestListBucketsByParent(t *testing.T) {
+	ctx := getAuthContext()
+
+	t.Run("PhysicalParent", func(t *testing.T) {
+		mainBankID := testBuckets["Main Bank"]
+
+		resp, err := grpcClient.ListBucketsByParent(ctx, &wealthflowv1.ListBucketsByParentRequest{
+			ParentId: mainBankID.String(),
+		})
+		require.NoError(t, err, "ListBucketsByParent should succeed")
+
+		var names []string
+		for _, bucket := range resp.Buckets {
+			assert.Equal(t, mainBankID.String(), bucket.ParentId, "Every child should belong to Main Bank")
+			names = append(names, bucket.Name)
+		}
+		assert.Contains(t, names, "Unallocated")
+		assert.IsNonDecreasing(t, names, "Children should be ordered by name")
+	})
+
+	t.Run("NonExistentParent", func(t *testing.T) {
+		_, err := grpcClient.ListBucketsByParent(ctx, &wealthflowv1.ListBucketsByParentRequest{
+			ParentId: uuid.New().String(),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err), "Error code should be NotFound")
+	})
+
+	t.Run("NonPhysicalParent", func(t *testing.T) {
+		_, err := grpcClient.ListBucketsByParent(ctx, &wealthflowv1.ListBucketsByParentRequest{
+			ParentId: testBuckets["Unallocated"].String(),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+	})
+}
+
+// TestTransactionMemo tests that a memo recorded with an inflow is returned by GetTransaction
+func TestTransactionMemo(t *testing.T) {
+	ctx := getAuthContext()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		memo := "Invoice #42\nPaid late, includes interest"
+		inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+			Amount:         "12.50",
+			Description:    "Memo Inflow",
+			Memo:           memo,
+			SourceBucketId: testBuckets["Employer"].String(),
+			IsExternal:     true,
+		})
+		require.NoError(t, err, "RecordInflow with memo should succeed")
+
+		resp, err := grpcClient.GetTransaction(ctx, &wealthflowv1.GetTransactionRequest{
+			TransactionId: inflowResp.TransactionId,
+		})
+		require.NoError(t, err, "GetTransaction should succeed")
+		assert.Equal(t, inflowResp.TransactionId, resp.Transaction.Id)
+		assert.Equal(t, "Memo Inflow", resp.Transaction.Description)
+		assert.Equal(t, memo, resp.Memo, "Memo should round-trip unchanged")
+		assert.NotEmpty(t, resp.Entries, "Entries should be returned")
+		for _, entry := range resp.Entries {
+			assert.Contains(t, resp.BucketNames, entry.BucketId, "Every entry bucket should have a resolved name")
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := grpcClient.GetTransaction(ctx, &wealthflowv1.GetTransactionRequest{
+			TransactionId: uuid.New().String(),
+		})
+		require.Error(t, err, "GetTransaction should fail for an unknown ID")
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Error should be a gRPC status")
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+}
+
+func TestTransactionRepositoryListBetweenBuckets(t *testing.T) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+	txRepo := postgres.NewTransactionRepository(db)
+
+	bankAID, bankBID, incomeID := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: bankAID, Name: "Between Bank A " + bankAID.String(), BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.Zero}))
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: bankBID, Name: "Between Bank B " + bankBID.String(), BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.Zero}))
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: incomeID, Name: "Between Income " + incomeID.String(), BucketType: domain.BucketTypeIncome, CurrentBalance: decimal.Zero}))
+
+	// A transfer between the two banks
+	transferID := uuid.New()
+	require.NoError(t, txRepo.Create(ctx, &domain.Transaction{
+		ID:                 transferID,
+		Description:        "Transfer A to B",
+		Date:               time.Now(),
+		IsInternalTransfer: true,
+		Entries: []domain.TransactionEntry{
+			{ID: uuid.New(), TransactionID: transferID, BucketID: bankAID, Amount: decimal.NewFromInt(40), Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			{ID: uuid.New(), TransactionID: transferID, BucketID: bankBID, Amount: decimal.NewFromInt(40), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+		},
+	}))
+
+	// An unrelated transaction touching only bank A
+	unrelatedID := uuid.New()
+	require.NoError(t, txRepo.Create(ctx, &domain.Transaction{
+		ID:          unrelatedID,
+		Description: "Income into A",
+		Date:        time.Now(),
+		Entries: []domain.TransactionEntry{
+			{ID: uuid.New(), TransactionID: unrelatedID, BucketID: bankAID, Amount: decimal.NewFromInt(90), Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+			{ID: uuid.New(), TransactionID: unrelatedID, BucketID: incomeID, Amount: decimal.NewFromInt(90), Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+		},
+	}))
+
+	transactions, err := txRepo.ListBetweenBuckets(ctx, bankAID, bankBID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1, "Only the transfer has entries for both banks")
+	assert.Equal(t, transferID, transactions[0].ID)
+	assert.Len(t, transactions[0].Entries, 2, "Entries should be loaded")
+
+	// The order of the buckets does not matter
+	transactions, err = txRepo.ListBetweenBuckets(ctx, bankBID, bankAID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+
+	// Same result through the RPC
+	resp, err := grpcClient.GetTransactionsBetweenBuckets(getAuthContext(), &wealthflowv1.GetTransactionsBetweenBucketsRequest{
+		BucketAId: bankAID.String(),
+		BucketBId: bankBID.String(),
+	})
+	require.NoError(t, err, "GetTransactionsBetweenBuckets should succeed")
+	require.Len(t, resp.Transactions, 1)
+	assert.Equal(t, transferID.String(), resp.Transactions[0].Id)
+}
+
+// TestTransactionEntryOrder tests that entries are read back in the order they were created
+// (random entry IDs must not reorder them)
+func TestTransactionEntryOrder(t *testing.T) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+	txRepo := postgres.NewTransactionRepository(db)
+
+	bankID, incomeID, virtualID := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: bankID, Name: "Entry Order Bank " + bankID.String(), BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.Zero}))
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: incomeID, Name: "Entry Order Income " + incomeID.String(), BucketType: domain.BucketTypeIncome, CurrentBalance: decimal.Zero}))
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: virtualID, Name: "Entry Order Virtual " + virtualID.String(), BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID, CurrentBalance: decimal.Zero}))
+
+	txID := uuid.New()
+	amount := decimal.NewFromInt(25)
+	tx := &domain.Transaction{
+		ID:          txID,
+		Description: "Entry Order Inflow",
+		Date:        time.Now(),
+		Entries: []domain.TransactionEntry{
+			{ID: uuid.New(), TransactionID: txID, BucketID: bankID, Amount: amount, Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+			{ID: uuid.New(), TransactionID: txID, BucketID: incomeID, Amount: amount, Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			{ID: uuid.New(), TransactionID: txID, BucketID: virtualID, Amount: amount, Type: domain.EntryTypeDebit, Layer: domain.LayerVirtual},
+			{ID: uuid.New(), TransactionID: txID, BucketID: incomeID, Amount: amount, Type: domain.EntryTypeCredit, Layer: domain.LayerVirtual},
+		},
+	}
+	require.NoError(t, txRepo.Create(ctx, tx))
+
+	stored, err := txRepo.GetByID(ctx, txID)
+	require.NoError(t, err)
+	require.Len(t, stored.Entries, len(tx.Entries))
+	for i, entry := range stored.Entries {
+		assert.Equal(t, tx.Entries[i].ID, entry.ID, "Entry %d should keep its position", i)
+	}
+}
+
+// TestTransactionEntryIndexes checks that the bucket-filtered listing and the entry loading can use the
+// transaction_entries indexes instead of scanning the whole table
+// Sequential scans are disabled for the check: on a small test database the planner would pick them anyway
+func TestTransactionEntryIndexes(t *testing.T) {
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `SET LOCAL enable_seqscan = off`)
+	require.NoError(t, err)
+
+	explain := func(query string, args ...interface{}) string {
+		rows, err := tx.QueryContext(ctx, "EXPLAIN "+query, args...)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var plan strings.Builder
+		for rows.Next() {
+			var line string
+			require.NoError(t, rows.Scan(&line))
+			plan.WriteString(line + "\n")
+		}
+		require.NoError(t, rows.Err())
+		return plan.String()
+	}
+
+	// The join used by List/Count with a bucket filter
+	plan := explain(`
+		SELECT DISTINCT t.id, t.description, t.date
+		FROM transactions t
+		INNER JOIN transaction_entries te ON t.id = te.transaction_id
+		WHERE te.bucket_id = $1
+		ORDER BY t.date DESC, t.id
+		LIMIT 50`, testBuckets["Main Bank"])
+	assert.Contains(t, plan, "idx_transaction_entries_bucket_id", "Bucket filter should use the bucket_id index:\n%s", plan)
+
+	// The entry lookup used when loading transactions
+	plan = explain(`
+		SELECT id, transaction_id, bucket_id, amount, type, layer
+		FROM transaction_entries
+		WHERE transaction_id = ANY($1)
+		ORDER BY transaction_id, sequence, id`, pq.Array([]uuid.UUID{uuid.New()}))
+	assert.Contains(t, plan, "idx_transaction_entries_transaction_id", "Entry loading should use the transaction_id index:\n%s", plan)
+}
+
+// TestSetOpeningBalance tests initializing a fresh physical bucket and its virtual child
+func TestSetOpeningBalance(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	// Fresh buckets so the "no transactions yet" guard passes
+	bankID := uuid.New()
+	virtualID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             bankID,
+		Name:           "Opening Balance Bank " + bankID.String(),
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}))
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:                     virtualID,
+		Name:                   "Opening Balance Cash " + virtualID.String(),
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &bankID,
+		CurrentBalance:         decimal.Zero,
+	}))
+
+	resp, err := grpcClient.SetOpeningBalance(ctx, &wealthflowv1.SetOpeningBalanceRequest{
+		BucketId:        bankID.String(),
+		Amount:          "1234.56",
+		VirtualBucketId: virtualID.String(),
+	})
+	require.NoError(t, err, "SetOpeningBalance should succeed")
+	assert.NotEmpty(t, resp.TransactionId)
+
+	for _, id := range []uuid.UUID{bankID, virtualID} {
+		bucketResp, err := grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{BucketId: id.String()})
+		require.NoError(t, err, "GetBucket should succeed")
+		assert.Equal(t, "1234.56", bucketResp.Bucket.CurrentBalance, "Bucket should hold the opening balance")
+	}
+
+	// Second call must be rejected
+	_, err = grpcClient.SetOpeningBalance(ctx, &wealthflowv1.SetOpeningBalanceRequest{
+		BucketId: bankID.String(),
+		Amount:   "10",
+	})
+	require.Error(t, err, "Opening balance can only be set once")
+	st, ok := status.FromError(err)
+	require.True(t, ok, "Error should be a gRPC status")
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+// TestGetProfitHistory tests that book value is reconstructed as of each market value date
+func TestGetProfitHistory(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	txRepo := postgres.NewTransactionRepository(db)
+	marketValueRepo := postgres.NewMarketValueRepository(db)
+
+	// Fresh equity bucket so other tests don't affect its history
+	stockID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             stockID,
+		Name:           "Profit History ETF " + stockID.String(),
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 12, 0, 0, 0, time.UTC) }
+
+	// Purchases: 1000 on day 1, 500 on day 15
+	for _, purchase := range []struct {
+		date   time.Time
+		amount int64
+	}{{day(1), 1000}, {day(15), 500}} {
+		txID := uuid.New()
+		amount := decimal.NewFromInt(purchase.amount)
+		require.NoError(t, txRepo.Create(context.Background(), &domain.Transaction{
+			ID:          txID,
+			Description: "Buy ETF",
+			Date:        purchase.date,
+			Entries: []domain.TransactionEntry{
+				{ID: uuid.New(), TransactionID: txID, BucketID: stockID, Amount: amount, Type: domain.EntryTypeDebit, Layer: domain.LayerPhysical},
+				{ID: uuid.New(), TransactionID: txID, BucketID: testBuckets["Main Bank"], Amount: amount, Type: domain.EntryTypeCredit, Layer: domain.LayerPhysical},
+			},
+		}))
+	}
+
+	// Valuations on day 10 and day 20
+	for d, value := range map[int]int64{10: 1200, 20: 1400} {
+		require.NoError(t, marketValueRepo.Add(context.Background(), &domain.MarketValueHistory{
+			ID:          uuid.New(),
+			BucketID:    stockID,
+			Date:        day(d),
+			MarketValue: decimal.NewFromInt(value),
+		}))
+	}
+
+	resp, err := grpcClient.GetProfitHistory(ctx, &wealthflowv1.GetProfitHistoryRequest{
+		BucketId:  stockID.String(),
+		StartDate: timestamppb.New(day(1)),
+		EndDate:   timestamppb.New(day(31)),
+	})
+	require.NoError(t, err, "GetProfitHistory should succeed")
+	require.Len(t, resp.Points, 2)
+
+	assert.Equal(t, "1000.00", resp.Points[0].BookValue)
+	assert.Equal(t, "1200.00", resp.Points[0].MarketValue)
+	assert.Equal(t, "200.00", resp.Points[0].Profit)
+
+	assert.Equal(t, "1500.00", resp.Points[1].BookValue)
+	assert.Equal(t, "1400.00", resp.Points[1].MarketValue)
+	assert.Equal(t, "-100.00", resp.Points[1].Profit)
+}
+
+// TestMarketValueGetLatestForBuckets tests that the batch query returns only the newest entry per bucket
+func TestMarketValueGetLatestForBuckets(t *testing.T) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+	marketValueRepo := postgres.NewMarketValueRepository(db)
+
+	// Two fresh equity buckets, each with several history rows
+	firstID, secondID, unvaluedID := uuid.New(), uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{firstID, secondID, unvaluedID} {
+		require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{
+			ID:             id,
+			Name:           "Latest Market Value " + id.String(),
+			BucketType:     domain.BucketTypeEquity,
+			CurrentBalance: decimal.Zero,
+		}))
+	}
+
+	day := func(d int) time.Time { return time.Date(2024, time.June, d, 12, 0, 0, 0, time.UTC) }
+	history := []struct {
+		bucketID uuid.UUID
+		date     time.Time
+		value    int64
+	}{
+		{firstID, day(1), 100},
+		{firstID, day(3), 130}, // latest for first
+		{firstID, day(2), 120},
+		{secondID, day(5), 900}, // latest for second
+		{secondID, day(4), 950},
+	}
+	for _, h := range history {
+		require.NoError(t, marketValueRepo.Add(ctx, &domain.MarketValueHistory{
+			ID:          uuid.New(),
+			BucketID:    h.bucketID,
+			Date:        h.date,
+			MarketValue: decimal.NewFromInt(h.value),
+		}))
+	}
+
+	latest, err := marketValueRepo.GetLatestForBuckets(ctx, []uuid.UUID{firstID, secondID, unvaluedID})
+	require.NoError(t, err, "GetLatestForBuckets should succeed")
+	require.Len(t, latest, 2, "Buckets without history should be absent")
+
+	assert.True(t, latest[firstID].MarketValue.Equal(decimal.NewFromInt(130)), "got %s", latest[firstID].MarketValue)
+	assert.True(t, latest[secondID].MarketValue.Equal(decimal.NewFromInt(900)), "got %s", latest[secondID].MarketValue)
+	assert.NotContains(t, latest, unvaluedID)
+}
+
+// TestListBucketsIncludeMarketValue tests that ListBuckets reports equity market values only when asked
+func TestListBucketsIncludeMarketValue(t *testing.T) {
+	ctx := context.Background()
+	authCtx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	marketValueRepo := postgres.NewMarketValueRepository(db)
+
+	valuedID, unvaluedID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{valuedID, unvaluedID} {
+		require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{
+			ID:             id,
+			Name:           "Listed Market Value " + id.String(),
+			BucketType:     domain.BucketTypeEquity,
+			CurrentBalance: decimal.Zero,
+		}))
+	}
+	for d, value := range []int64{500, 650} {
+		require.NoError(t, marketValueRepo.Add(ctx, &domain.MarketValueHistory{
+			ID:          uuid.New(),
+			BucketID:    valuedID,
+			Date:        time.Date(2024, time.July, d+1, 12, 0, 0, 0, time.UTC),
+			MarketValue: decimal.NewFromInt(value),
+		}))
+	}
+
+	bucketsByID := func(resp *wealthflowv1.ListBucketsResponse) map[string]*wealthflowv1.Bucket {
+		byID := make(map[string]*wealthflowv1.Bucket, len(resp.Buckets))
+		for _, bucket := range resp.Buckets {
+			byID[bucket.Id] = bucket
+		}
+		return byID
+	}
+
+	t.Run("Requested", func(t *testing.T) {
+		resp, err := grpcClient.ListBuckets(authCtx, &wealthflowv1.ListBucketsRequest{IncludeMarketValue: true})
+		require.NoError(t, err, "ListBuckets should succeed")
+
+		byID := bucketsByID(resp)
+		require.Contains(t, byID, valuedID.String())
+		assert.Equal(t, "650.00", byID[valuedID.String()].MarketValue, "Latest market value should be reported")
+		require.Contains(t, byID, unvaluedID.String())
+		assert.Empty(t, byID[unvaluedID.String()].MarketValue, "Equity bucket without history should have no market value")
+		assert.Empty(t, byID[testBuckets["Main Bank"].String()].MarketValue, "Non-equity buckets should have no market value")
+	})
+
+	t.Run("NotRequested", func(t *testing.T) {
+		resp, err := grpcClient.ListBuckets(authCtx, &wealthflowv1.ListBucketsRequest{
+			BucketType: wealthflowv1.BucketType_BUCKET_TYPE_EQUITY,
+		})
+		require.NoError(t, err, "ListBuckets should succeed")
+
+		byID := bucketsByID(resp)
+		require.Contains(t, byID, valuedID.String())
+		assert.Empty(t, byID[valuedID.String()].MarketValue, "Market value should only be set when requested")
+	})
+}
+
+// TestListMarketValueHistory tests paging through a bucket's market value history in both orders
+func TestListMarketValueHistory(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	marketValueRepo := postgres.NewMarketValueRepository(db)
+
+	stockID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             stockID,
+		Name:           "Paged History ETF " + stockID.String(),
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	// Five daily prices: 101..105
+	for d := 1; d <= 5; d++ {
+		require.NoError(t, marketValueRepo.Add(context.Background(), &domain.MarketValueHistory{
+			ID:          uuid.New(),
+			BucketID:    stockID,
+			Date:        time.Date(2024, time.July, d, 12, 0, 0, 0, time.UTC),
+			MarketValue: decimal.NewFromInt(int64(100 + d)),
+		}))
+	}
+
+	t.Run("NewestFirst", func(t *testing.T) {
+		resp, err := grpcClient.ListMarketValueHistory(ctx, &wealthflowv1.ListMarketValueHistoryRequest{
+			BucketId: stockID.String(),
+			Limit:    2,
+			Offset:   1,
+		})
+		require.NoError(t, err, "ListMarketValueHistory should succeed")
+		assert.Equal(t, int32(5), resp.TotalCount)
+		require.Len(t, resp.Entries, 2)
+		assert.Equal(t, "104.00", resp.Entries[0].MarketValue)
+		assert.Equal(t, "103.00", resp.Entries[1].MarketValue)
+	})
+
+	t.Run("Ascending", func(t *testing.T) {
+		resp, err := grpcClient.ListMarketValueHistory(ctx, &wealthflowv1.ListMarketValueHistoryRequest{
+			BucketId:  stockID.String(),
+			Ascending: true,
+		})
+		require.NoError(t, err, "ListMarketValueHistory should succeed")
+		require.Len(t, resp.Entries, 5, "Default page size should cover the whole history")
+		assert.Equal(t, "101.00", resp.Entries[0].MarketValue)
+		assert.Equal(t, "105.00", resp.Entries[4].MarketValue)
+	})
+
+	t.Run("GetBucketRecentMarketValues", func(t *testing.T) {
+		resp, err := grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{
+			BucketId:                  stockID.String(),
+			IncludeRecentMarketValues: true,
+			RecentMarketValuesLimit:   3,
+		})
+		require.NoError(t, err, "GetBucket should succeed")
+		require.Len(t, resp.RecentMarketValues, 3)
+		assert.Equal(t, "103.00", resp.RecentMarketValues[0].MarketValue, "Recent values should be oldest first")
+		assert.Equal(t, "104.00", resp.RecentMarketValues[1].MarketValue)
+		assert.Equal(t, "105.00", resp.RecentMarketValues[2].MarketValue)
+
+		// Without the flag nothing extra is loaded
+		resp, err = grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{BucketId: stockID.String()})
+		require.NoError(t, err, "GetBucket should succeed")
+		assert.Empty(t, resp.RecentMarketValues)
+	})
+
+	t.Run("GetBucketIgnoresFlagForNonEquity", func(t *testing.T) {
+		resp, err := grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{
+			BucketId:                  testBuckets["Main Bank"].String(),
+			IncludeRecentMarketValues: true,
+		})
+		require.NoError(t, err, "GetBucket should succeed")
+		assert.Empty(t, resp.RecentMarketValues)
+	})
+}
+
+// TestDeleteTransaction tests that deleting a transaction reverts balances and is blocked by completed transfers
+func TestDeleteTransaction(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	transferTaskRepo := postgres.NewTransferTaskRepository(db)
+
+	getBalance := func(id uuid.UUID) decimal.Decimal {
+		bucket, err := bucketRepo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return bucket.CurrentBalance
+	}
+
+	t.Run("RevertsBalances", func(t *testing.T) {
+		mainBankBefore := getBalance(testBuckets["Main Bank"])
+		unallocatedBefore := getBalance(testBuckets["Unallocated"])
+
+		inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+			Amount:         "77.00",
+			Description:    "Accidental Inflow",
+			SourceBucketId: testBuckets["Employer"].String(),
+			IsExternal:     true,
+		})
+		require.NoError(t, err, "RecordInflow should succeed")
+
+		_, err = grpcClient.DeleteTransaction(ctx, &wealthflowv1.DeleteTransactionRequest{
+			TransactionId: inflowResp.TransactionId,
+		})
+		require.NoError(t, err, "DeleteTransaction should succeed")
+
+		assert.True(t, getBalance(testBuckets["Main Bank"]).Equal(mainBankBefore), "Main Bank balance should be restored")
+		assert.True(t, getBalance(testBuckets["Unallocated"]).Equal(unallocatedBefore), "Unallocated balance should be restored")
+
+		_, err = grpcClient.GetTransaction(ctx, &wealthflowv1.GetTransactionRequest{TransactionId: inflowResp.TransactionId})
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Error should be a gRPC status")
+		assert.Equal(t, codes.NotFound, st.Code(), "Deleted transaction should be gone")
+	})
+
+	t.Run("CompletedTransferTask", func(t *testing.T) {
+		inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+			Amount:         "5.00",
+			Description:    "Transferred Inflow",
+			SourceBucketId: testBuckets["Employer"].String(),
+			IsExternal:     true,
+		})
+		require.NoError(t, err, "RecordInflow should succeed")
+
+		txID, err := uuid.Parse(inflowResp.TransactionId)
+		require.NoError(t, err)
+		require.NoError(t, transferTaskRepo.Create(context.Background(), &domain.TransferTask{
+			ID:                   uuid.New(),
+			RelatedTransactionID: txID,
+			FromPhysicalBucketID: testBuckets["Main Bank"],
+			ToPhysicalBucketID:   testBuckets["Main Bank"],
+			Amount:               decimal.NewFromInt(5),
+			IsCompleted:          true,
+		}))
+
+		_, err = grpcClient.DeleteTransaction(ctx, &wealthflowv1.DeleteTransactionRequest{TransactionId: inflowResp.TransactionId})
+		require.Error(t, err, "Deleting a transaction with a completed transfer should fail")
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Error should be a gRPC status")
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := grpcClient.DeleteTransaction(ctx, &wealthflowv1.DeleteTransactionRequest{TransactionId: uuid.New().String()})
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Error should be a gRPC status")
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+}
+
+// TestGetTransactionCount tests that the count matches the ListTransactions total
+func TestGetTransactionCount(t *testing.T) {
+	ctx := getAuthContext()
+	mainBankID := testBuckets["Main Bank"].String()
+
+	countResp, err := grpcClient.GetTransactionCount(ctx, &wealthflowv1.GetTransactionCountRequest{
+		BucketId: mainBankID,
+	})
+	require.NoError(t, err, "GetTransactionCount should succeed")
+
+	listResp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+		Limit:    1,
+		BucketId: mainBankID,
+	})
+	require.NoError(t, err, "ListTransactions should succeed")
+	assert.Equal(t, int64(listResp.TotalCount), countResp.Count, "Count should match the pagination total")
+
+	_, err = grpcClient.GetTransactionCount(ctx, &wealthflowv1.GetTransactionCountRequest{BucketId: "not-a-uuid"})
+	st, ok := status.FromError(err)
+	require.True(t, ok, "Error should be a gRPC status")
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+// TestGetBucketsSummary tests that the summary agrees with ListBuckets and GetNetWorth
+func TestGetBucketsSummary(t *testing.T) {
+	ctx := getAuthContext()
+
+	summary, err := grpcClient.GetBucketsSummary(ctx, &wealthflowv1.GetBucketsSummaryRequest{})
+	require.NoError(t, err, "GetBucketsSummary should succeed")
+
+	listResp, err := grpcClient.ListBuckets(ctx, &wealthflowv1.ListBucketsRequest{
+		BucketType: wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL,
+	})
+	require.NoError(t, err, "ListBuckets should succeed")
+	assert.Equal(t, int32(len(listResp.Buckets)), summary.CountsByType["PHYSICAL"])
+
+	netWorth, err := grpcClient.GetNetWorth(ctx, &wealthflowv1.GetNetWorthRequest{})
+	require.NoError(t, err, "GetNetWorth should succeed")
+	assert.Equal(t, netWorth.Liquidity, summary.TotalLiquidity)
+}
+
+// TestGetSplitRule tests fetching the Employer split rule created in TestMain
+func TestGetSplitRule(t *testing.T) {
+	ctx := getAuthContext()
+
+	resp, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{
+		SourceBucketId: testBuckets["Employer"].String(),
+	})
+	require.NoError(t, err, "GetSplitRule should succeed")
+	assert.Equal(t, testBuckets["Employer"].String(), resp.SourceBucketId)
+	require.NotEmpty(t, resp.Items)
+	assert.Equal(t, testBuckets["Unallocated"].String(), resp.Items[len(resp.Items)-1].TargetBucketId)
+	assert.Equal(t, "0.00", resp.TotalFixed, "The Employer rule has no FIXED items")
+	assert.Equal(t, "0", resp.TotalPercent, "The Employer rule has no PERCENT items")
+	assert.Equal(t, "100", resp.RemainderPercent, "Everything reaches the REMAINDER item")
+	assert.True(t, resp.HasMeaningfulRemainder)
+	assert.Empty(t, resp.DestinationPhysicalBucketId, "The Employer rule infers its destination from its targets")
+
+	_, err = grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{
+		SourceBucketId: testBuckets["Groceries"].String(),
+	})
+	st, ok := status.FromError(err)
+	require.True(t, ok, "Error should be a gRPC status")
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+// TestSplitRuleDestinationPhysicalBucket tests creating a split rule with a configured destination
+// and that its inflows land in that physical bucket
+func TestSplitRuleDestinationPhysicalBucket(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	bonusID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             bonusID,
+		Name:           "Bonus " + bonusID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+	items := []*wealthflowv1.SplitRuleItem{
+		{TargetBucketId: unallocatedID.String(), Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER, Priority: 1},
+	}
+
+	// A destination that is not a physical bucket is rejected
+	_, err := grpcClient.CreateSplitRule(ctx, &wealthflowv1.CreateSplitRuleRequest{
+		Name:                        "Bonus Split",
+		SourceBucketId:              bonusID.String(),
+		DestinationPhysicalBucketId: testBuckets["Tesla Stock"].String(),
+		Items:                       items,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+
+	_, err = grpcClient.CreateSplitRule(ctx, &wealthflowv1.CreateSplitRuleRequest{
+		Name:                        "Bonus Split",
+		SourceBucketId:              bonusID.String(),
+		DestinationPhysicalBucketId: mainBankID.String(),
+		Items:                       items,
+	})
+	require.NoError(t, err, "CreateSplitRule with a destination should succeed")
+
+	rule, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{SourceBucketId: bonusID.String()})
+	require.NoError(t, err, "GetSplitRule should succeed")
+	assert.Equal(t, mainBankID.String(), rule.DestinationPhysicalBucketId)
+
+	bankBefore, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+
+	_, err = grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "40.00",
+		Description:    "Quarterly bonus",
+		SourceBucketId: bonusID.String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+
+	bankAfter, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+	assert.True(t, bankBefore.CurrentBalance.Add(decimal.NewFromInt(40)).Equal(bankAfter.CurrentBalance), "Destination physical bucket receives the money")
+}
+
+// TestCloneSplitRule tests copying the Employer split rule to a new income bucket
+func TestCloneSplitRule(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	freelanceID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             freelanceID,
+		Name:           "Freelance " + freelanceID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	original, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{SourceBucketId: testBuckets["Employer"].String()})
+	require.NoError(t, err, "GetSplitRule should succeed")
+
+	clone, err := grpcClient.CloneSplitRule(ctx, &wealthflowv1.CloneSplitRuleRequest{
+		FromSourceBucketId: testBuckets["Employer"].String(),
+		ToSourceBucketId:   freelanceID.String(),
+		Name:               "Freelance Split",
+	})
+	require.NoError(t, err, "CloneSplitRule should succeed")
+	assert.NotEqual(t, original.SplitRuleId, clone.SplitRuleId)
+	assert.Equal(t, "Freelance Split", clone.Name)
+	require.Len(t, clone.Items, len(original.Items))
+	for i, item := range clone.Items {
+		assert.NotEqual(t, original.Items[i].Id, item.Id, "Items get fresh IDs")
+		assert.Equal(t, original.Items[i].TargetBucketId, item.TargetBucketId)
+	}
+
+	stored, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{SourceBucketId: freelanceID.String()})
+	require.NoError(t, err, "GetSplitRule of the copy should succeed")
+	assert.Equal(t, clone.SplitRuleId, stored.SplitRuleId)
+
+	// The new source bucket now has a rule, so cloning onto it again is rejected
+	_, err = grpcClient.CloneSplitRule(ctx, &wealthflowv1.CloneSplitRuleRequest{
+		FromSourceBucketId: testBuckets["Employer"].String(),
+		ToSourceBucketId:   freelanceID.String(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+
+	// Cloning from an income bucket without a rule is NotFound
+	_, err = grpcClient.CloneSplitRule(ctx, &wealthflowv1.CloneSplitRuleRequest{
+		FromSourceBucketId: testBuckets["Groceries"].String(),
+		ToSourceBucketId:   uuid.New().String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Error code should be NotFound")
+}
+
+// TestListTransferTasks tests that transfer tasks are listed with their description and creation time
+func TestListTransferTasks(t *testing.T) {
+	ctx := getAuthContext()
+	transferTaskRepo := postgres.NewTransferTaskRepository(db)
+
+	pending := &domain.TransferTask{
+		ID:                   uuid.New(),
+		RelatedTransactionID: uuid.New(),
+		FromPhysicalBucketID: testBuckets["Main Bank"],
+		ToPhysicalBucketID:   testBuckets["Main Bank"],
+		Amount:               decimal.NewFromInt(42),
+		Description:          "Transfer to Investment",
+	}
+	require.NoError(t, transferTaskRepo.Create(context.Background(), pending))
+
+	completedID := uuid.New()
+	completed := &domain.TransferTask{
+		ID:                     uuid.New(),
+		RelatedTransactionID:   uuid.New(),
+		CompletedTransactionID: &completedID,
+		FromPhysicalBucketID:   testBuckets["Main Bank"],
+		ToPhysicalBucketID:     testBuckets["Main Bank"],
+		Amount:                 decimal.NewFromInt(7),
+		IsCompleted:            true,
+		Description:            "Already moved",
+	}
+	require.NoError(t, transferTaskRepo.Create(context.Background(), completed))
+
+	findTask := func(tasks []*wealthflowv1.TransferTask, id uuid.UUID) *wealthflowv1.TransferTask {
+		for _, task := range tasks {
+			if task.Id == id.String() {
+				return task
+			}
+		}
+		return nil
+	}
+
+	t.Run("PendingOnly", func(t *testing.T) {
+		resp, err := grpcClient.ListTransferTasks(ctx, &wealthflowv1.ListTransferTasksRequest{})
+		require.NoError(t, err, "ListTransferTasks should succeed")
+
+		task := findTask(resp.Tasks, pending.ID)
+		require.NotNil(t, task, "Pending task should be listed")
+		assert.Equal(t, "Transfer to Investment", task.Description)
+		assert.Equal(t, "42.00", task.Amount)
+		assert.False(t, task.IsCompleted)
+		assert.WithinDuration(t, pending.CreatedAt, task.CreatedAt.AsTime(), time.Second)
+		assert.Nil(t, findTask(resp.Tasks, completed.ID), "Completed task should not be listed by default")
+	})
+
+	t.Run("IncludeCompleted", func(t *testing.T) {
+		resp, err := grpcClient.ListTransferTasks(ctx, &wealthflowv1.ListTransferTasksRequest{IncludeCompleted: true})
+		require.NoError(t, err, "ListTransferTasks should succeed")
+
+		task := findTask(resp.Tasks, completed.ID)
+		require.NotNil(t, task, "Completed task should be listed")
+		assert.True(t, task.IsCompleted)
+		assert.Equal(t, completedID.String(), task.CompletedTransactionId)
+		assert.Equal(t, "Already moved", task.Description)
+	})
+}
+
+// TestMergeCategoryBuckets tests merging a redundant expense category into another
+func TestMergeCategoryBuckets(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	createCategory := func(name string) uuid.UUID {
+		id := uuid.New()
+		require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+			ID:             id,
+			Name:           name + " " + id.String(),
+			BucketType:     domain.BucketTypeExpense,
+			CurrentBalance: decimal.Zero,
+		}))
+		return id
+	}
+	foodID := createCategory("Food")
+	groceriesID := createCategory("Groceries")
+
+	for _, amount := range []string{"12.50", "7.50"} {
+		_, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+			Amount:           amount,
+			Description:      "Lunch",
+			VirtualBucketId:  testBuckets["Unallocated"].String(),
+			CategoryBucketId: foodID.String(),
+		})
+		require.NoError(t, err, "LogExpense should succeed")
+	}
+
+	resp, err := grpcClient.MergeCategoryBuckets(ctx, &wealthflowv1.MergeCategoryBucketsRequest{
+		SourceBucketId: foodID.String(),
+		TargetBucketId: groceriesID.String(),
+	})
+	require.NoError(t, err, "MergeCategoryBuckets should succeed")
+	assert.Equal(t, int32(2), resp.RepointedEntries)
+
+	food, err := bucketRepo.GetByID(context.Background(), foodID)
+	require.NoError(t, err)
+	assert.True(t, food.IsArchived, "Source bucket should be archived")
+	assert.True(t, food.CurrentBalance.IsZero(), "Source balance should be moved to the target")
+
+	groceries, err := bucketRepo.GetByID(context.Background(), groceriesID)
+	require.NoError(t, err)
+	assert.True(t, groceries.CurrentBalance.Equal(decimal.NewFromInt(20)), "Target should hold the merged balance, got %s", groceries.CurrentBalance)
+
+	var remaining int
+	require.NoError(t, db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM transaction_entries WHERE bucket_id = $1`, foodID).Scan(&remaining))
+	assert.Zero(t, remaining, "No entries should reference the source bucket")
+
+	t.Run("ArchivedSourceRejected", func(t *testing.T) {
+		_, err := grpcClient.MergeCategoryBuckets(ctx, &wealthflowv1.MergeCategoryBucketsRequest{
+			SourceBucketId: foodID.String(),
+			TargetBucketId: groceriesID.String(),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("NonExpenseRejected", func(t *testing.T) {
+		_, err := grpcClient.MergeCategoryBuckets(ctx, &wealthflowv1.MergeCategoryBucketsRequest{
+			SourceBucketId: testBuckets["Unallocated"].String(),
+			TargetBucketId: groceriesID.String(),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestRecategorizeTransactions tests moving past expenses matching a description to another category
+func TestRecategorizeTransactions(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	createCategory := func(name string) uuid.UUID {
+		id := uuid.New()
+		require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+			ID:             id,
+			Name:           name + " " + id.String(),
+			BucketType:     domain.BucketTypeExpense,
+			CurrentBalance: decimal.Zero,
+		}))
+		return id
+	}
+	diningID := createCategory("Dining")
+	groceriesID := createCategory("Groceries")
+
+	for _, expense := range []struct {
+		amount, description string
+		isRefund            bool
+	}{
+		{"30.00", "Supermarket run", false},
+		{"5.00", "Supermarket run (returned item)", true},
+		{"20.00", "Restaurant", false},
+	} {
+		_, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+			Amount:           expense.amount,
+			Description:      expense.description,
+			VirtualBucketId:  testBuckets["Unallocated"].String(),
+			CategoryBucketId: diningID.String(),
+			IsRefund:         expense.isRefund,
+		})
+		require.NoError(t, err, "LogExpense should succeed")
+	}
+	dining, err := bucketRepo.GetByID(context.Background(), diningID)
+	require.NoError(t, err)
+	diningBefore := dining.CurrentBalance
+
+	resp, err := grpcClient.RecategorizeTransactions(ctx, &wealthflowv1.RecategorizeTransactionsRequest{
+		SourceCategoryId:  diningID.String(),
+		TargetCategoryId:  groceriesID.String(),
+		DescriptionFilter: "SUPERMARKET",
+	})
+	require.NoError(t, err, "RecategorizeTransactions should succeed")
+	assert.Equal(t, int32(4), resp.UpdatedEntries, "Both layers' category entries of the matching expense and refund should move")
+
+	// Each category's balance should still equal the sum of its entries
+	for _, id := range []uuid.UUID{diningID, groceriesID} {
+		bucket, err := bucketRepo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		var entriesStr string
+		require.NoError(t, db.QueryRowContext(context.Background(), `
+			SELECT COALESCE(SUM(CASE WHEN type = 'DEBIT' THEN amount ELSE -amount END), 0)
+			FROM transaction_entries WHERE bucket_id = $1
+		`, id).Scan(&entriesStr))
+		entries, err := decimal.NewFromString(entriesStr)
+		require.NoError(t, err)
+		assert.True(t, bucket.CurrentBalance.Equal(entries), "Balance of %s should match its entries: %s vs %s", bucket.Name, bucket.CurrentBalance, entries)
+	}
+	groceries, err := bucketRepo.GetByID(context.Background(), groceriesID)
+	require.NoError(t, err)
+	dining, err = bucketRepo.GetByID(context.Background(), diningID)
+	require.NoError(t, err)
+	assert.True(t, dining.CurrentBalance.Add(groceries.CurrentBalance).Equal(diningBefore), "Recategorizing should only move balance between the categories")
+	assert.True(t, groceries.CurrentBalance.Equal(decimal.NewFromInt(25)), "Groceries should hold the expense net of its refund, got %s", groceries.CurrentBalance)
+	assert.False(t, dining.IsArchived, "Source category should stay active")
+
+	t.Run("NonExpenseRejected", func(t *testing.T) {
+		_, err := grpcClient.RecategorizeTransactions(ctx, &wealthflowv1.RecategorizeTransactionsRequest{
+			SourceCategoryId: diningID.String(),
+			TargetCategoryId: testBuckets["Unallocated"].String(),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := grpcClient.RecategorizeTransactions(ctx, &wealthflowv1.RecategorizeTransactionsRequest{
+			SourceCategoryId: "not-a-uuid",
+			TargetCategoryId: groceriesID.String(),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestGetInvestmentProfit tests the absolute and percentage profit of an equity bucket
+func TestGetInvestmentProfit(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	createEquity := func(bookValue int64) uuid.UUID {
+		id := uuid.New()
+		require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+			ID:             id,
+			Name:           "Profit Portfolio " + id.String(),
+			BucketType:     domain.BucketTypeEquity,
+			CurrentBalance: decimal.NewFromInt(bookValue),
+		}))
+		return id
+	}
+
+	t.Run("WithBookValue", func(t *testing.T) {
+		bucketID := createEquity(200)
+		_, err := grpcClient.UpdateInvestment(ctx, &wealthflowv1.UpdateInvestmentRequest{
+			BucketId:    bucketID.String(),
+			MarketValue: "250",
+		})
+		require.NoError(t, err, "UpdateInvestment should succeed")
+
+		resp, err := grpcClient.GetInvestmentProfit(ctx, &wealthflowv1.GetInvestmentProfitRequest{BucketId: bucketID.String()})
+		require.NoError(t, err, "GetInvestmentProfit should succeed")
+		assert.Equal(t, "200.00", resp.BookValue)
+		assert.Equal(t, "250.00", resp.MarketValue)
+		assert.Equal(t, "50.00", resp.AbsoluteProfit)
+		assert.Equal(t, "25", resp.PercentProfit)
+	})
+
+	t.Run("ZeroBookValue", func(t *testing.T) {
+		bucketID := createEquity(0)
+		_, err := grpcClient.UpdateInvestment(ctx, &wealthflowv1.UpdateInvestmentRequest{
+			BucketId:    bucketID.String(),
+			MarketValue: "10",
+		})
+		require.NoError(t, err, "UpdateInvestment should succeed")
+
+		resp, err := grpcClient.GetInvestmentProfit(ctx, &wealthflowv1.GetInvestmentProfitRequest{BucketId: bucketID.String()})
+		require.NoError(t, err, "GetInvestmentProfit should succeed")
+		assert.Equal(t, "10.00", resp.AbsoluteProfit)
+		assert.Empty(t, resp.PercentProfit, "Percentage should be empty without a book value")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := grpcClient.GetInvestmentProfit(ctx, &wealthflowv1.GetInvestmentProfitRequest{BucketId: uuid.New().String()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+// TestPreviewAllocationTargetNames tests that the preview resolves target bucket names
+func TestPreviewAllocationTargetNames(t *testing.T) {
+	ctx := getAuthContext()
+
+	resp, err := grpcClient.PreviewAllocation(ctx, &wealthflowv1.PreviewAllocationRequest{
+		SourceBucketId: testBuckets["Employer"].String(),
+		Amount:         "100.00",
+	})
+	require.NoError(t, err, "PreviewAllocation should succeed")
+	require.NotEmpty(t, resp.Allocations)
+
+	// The Employer rule ends with the REMAINDER item targeting Unallocated
+	last := resp.Allocations[len(resp.Allocations)-1]
+	assert.Equal(t, testBuckets["Unallocated"].String(), last.BucketId)
+	assert.Equal(t, "Unallocated", last.Name)
+	for _, allocation := range resp.Allocations {
+		assert.NotEmpty(t, allocation.Name, "Every target should have a name")
+	}
+}
+
+// TestListTransactionsIncludeEntries tests that entries are only inlined when requested
+func TestListTransactionsIncludeEntries(t *testing.T) {
+	ctx := getAuthContext()
+
+	_, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "10.00",
+		Description:    "Inline Entries Inflow",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+
+	t.Run("Omitted", func(t *testing.T) {
+		resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 10})
+		require.NoError(t, err, "ListTransactions should succeed")
+		require.NotEmpty(t, resp.Transactions)
+		for _, tx := range resp.Transactions {
+			assert.Empty(t, tx.Entries, "Entries should be omitted by default")
+		}
+	})
+
+	t.Run("Included", func(t *testing.T) {
+		resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 10, IncludeEntries: true})
+		require.NoError(t, err, "ListTransactions should succeed")
+		require.NotEmpty(t, resp.Transactions)
+		for _, tx := range resp.Transactions {
+			assert.NotEmpty(t, tx.Entries, "Entries should be included for transaction %s", tx.Id)
+		}
+	})
+}
+
+// TestRecalculateBalances tests that drifted balances are recomputed from transaction entries
+func TestRecalculateBalances(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	bankID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             bankID,
+		Name:           "Drift Bank " + bankID.String(),
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}))
+	_, err := grpcClient.SetOpeningBalance(ctx, &wealthflowv1.SetOpeningBalanceRequest{
+		BucketId: bankID.String(),
+		Amount:   "300.00",
+	})
+	require.NoError(t, err, "SetOpeningBalance should succeed")
+
+	// Simulate drift (e.g. a missing trigger) by corrupting the stored balance
+	_, err = db.ExecContext(context.Background(), `UPDATE buckets SET current_balance = 250 WHERE id = $1`, bankID)
+	require.NoError(t, err)
+
+	resp, err := grpcClient.RecalculateBalances(ctx, &wealthflowv1.RecalculateBalancesRequest{})
+	require.NoError(t, err, "RecalculateBalances should succeed")
+	assert.GreaterOrEqual(t, resp.BucketsCorrected, int32(1))
+	drift, err := decimal.NewFromString(resp.TotalDrift)
+	require.NoError(t, err)
+	assert.True(t, drift.GreaterThanOrEqual(decimal.NewFromInt(50)), "Drift should include the 50 correction, got %s", drift)
+
+	bank, err := bucketRepo.GetByID(context.Background(), bankID)
+	require.NoError(t, err)
+	assert.True(t, bank.CurrentBalance.Equal(decimal.NewFromInt(300)), "Balance should be restored, got %s", bank.CurrentBalance)
+
+	// A second run finds nothing left to correct
+	resp, err = grpcClient.RecalculateBalances(ctx, &wealthflowv1.RecalculateBalancesRequest{})
+	require.NoError(t, err, "RecalculateBalances should succeed")
+	assert.Zero(t, resp.BucketsCorrected)
+}
+
+func TestSetBucketGoal(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	savingsID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             savingsID,
+		Name:           "Goal Savings " + savingsID.String(),
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}))
+	_, err := grpcClient.SetOpeningBalance(ctx, &wealthflowv1.SetOpeningBalanceRequest{
+		BucketId: savingsID.String(),
+		Amount:   "500.00",
+	})
+	require.NoError(t, err, "SetOpeningBalance should succeed")
+
+	resp, err := grpcClient.SetBucketGoal(ctx, &wealthflowv1.SetBucketGoalRequest{
+		BucketId:   savingsID.String(),
+		GoalAmount: "2000",
+	})
+	require.NoError(t, err, "SetBucketGoal should succeed")
+	assert.Equal(t, "2000.00", resp.Bucket.GoalAmount)
+
+	getResp, err := grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{BucketId: savingsID.String()})
+	require.NoError(t, err, "GetBucket should succeed")
+	assert.Equal(t, "2000.00", getResp.Bucket.GoalAmount)
+	assert.Equal(t, "25", getResp.Bucket.GoalProgress)
+
+	// Non-positive goals are rejected
+	_, err = grpcClient.SetBucketGoal(ctx, &wealthflowv1.SetBucketGoalRequest{
+		BucketId:   savingsID.String(),
+		GoalAmount: "-5",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// An empty goal clears it
+	resp, err = grpcClient.SetBucketGoal(ctx, &wealthflowv1.SetBucketGoalRequest{BucketId: savingsID.String()})
+	require.NoError(t, err, "Clearing the goal should succeed")
+	assert.Empty(t, resp.Bucket.GoalAmount)
+	assert.Empty(t, resp.Bucket.GoalProgress)
+}
+
+func TestListBucketGoals(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+
+	// Goals belong to virtual buckets; balances are set directly as no money movement is needed here
+	fundID := uuid.New()
+	fundGoal := decimal.NewFromInt(1000)
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:                     fundID,
+		Name:                   "Emergency Fund " + fundID.String(),
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &mainBankID,
+		CurrentBalance:         decimal.NewFromInt(800),
+		GoalAmount:             &fundGoal,
+	}))
+
+	resp, err := grpcClient.ListBucketGoals(ctx, &wealthflowv1.ListBucketGoalsRequest{})
+	require.NoError(t, err, "ListBucketGoals should succeed")
+
+	var found *wealthflowv1.BucketGoal
+	for _, goal := range resp.Goals {
+		if goal.Bucket.Id == fundID.String() {
+			found = goal
+		}
+	}
+	require.NotNil(t, found, "Bucket with a goal should be listed")
+	assert.Equal(t, "80", found.Progress)
+	assert.False(t, found.Met)
+	assert.Equal(t, "1000.00", found.Bucket.GoalAmount)
+
+	// Buckets without a goal are not listed
+	for _, goal := range resp.Goals {
+		assert.NotEqual(t, testBuckets["Groceries"].String(), goal.Bucket.Id)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	// No authorization metadata: GetStatus is exempt from auth
+	resp, err := grpcClient.GetStatus(context.Background(), &wealthflowv1.GetStatusRequest{})
+	require.NoError(t, err, "GetStatus should succeed without a token")
+
+	assert.True(t, resp.Ready)
+	assert.True(t, resp.DatabaseConnected)
+	assert.True(t, resp.SystemBucketsSeeded)
+	assert.Equal(t, "ok", resp.Status)
+	assert.NotEmpty(t, resp.Version, "Version defaults to \"dev\" when not injected")
+}
+
+// TestCompleteTransferTask tests that completing a task books the physical move and flags the task atomically
+func TestCompleteTransferTask(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	transferTaskRepo := postgres.NewTransferTaskRepository(db)
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	for id, name := range map[uuid.UUID]string{fromID: "Task From ", toID: "Task To "} {
+		require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+			ID:             id,
+			Name:           name + id.String(),
+			BucketType:     domain.BucketTypePhysical,
+			CurrentBalance: decimal.Zero,
+		}))
+	}
+
+	task := &domain.TransferTask{
+		ID:                   uuid.New(),
+		RelatedTransactionID: uuid.New(),
+		FromPhysicalBucketID: fromID,
+		ToPhysicalBucketID:   toID,
+		Amount:               decimal.NewFromInt(40),
+		Description:          "Groceries (wrong card)",
+	}
+	require.NoError(t, transferTaskRepo.Create(context.Background(), task))
+
+	resp, err := grpcClient.CompleteTransferTask(ctx, &wealthflowv1.CompleteTransferTaskRequest{TaskId: task.ID.String()})
+	require.NoError(t, err, "CompleteTransferTask should succeed")
+	assert.True(t, resp.Task.IsCompleted)
+	require.NotEmpty(t, resp.Task.CompletedTransactionId)
+
+	from, err := bucketRepo.GetByID(context.Background(), fromID)
+	require.NoError(t, err)
+	assert.True(t, from.CurrentBalance.Equal(decimal.NewFromInt(-40)), "From bucket should be credited, got %s", from.CurrentBalance)
+	to, err := bucketRepo.GetByID(context.Background(), toID)
+	require.NoError(t, err)
+	assert.True(t, to.CurrentBalance.Equal(decimal.NewFromInt(40)), "To bucket should be debited, got %s", to.CurrentBalance)
+
+	// Completing twice is rejected
+	_, err = grpcClient.CompleteTransferTask(ctx, &wealthflowv1.CompleteTransferTaskRequest{TaskId: task.ID.String()})
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Unknown tasks are NotFound
+	_, err = grpcClient.CompleteTransferTask(ctx, &wealthflowv1.CompleteTransferTaskRequest{TaskId: uuid.New().String()})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestUnitOfWorkRollback tests that a failing unit of work leaves no partial writes behind
+func TestUnitOfWorkRollback(t *testing.T) {
+	bucketRepo := postgres.NewBucketRepository(db)
+	uow := postgres.NewUnitOfWork(db)
+
+	bucketID := uuid.New()
+	err := uow.Do(context.Background(), func(repos domain.Repositories) error {
+		if err := repos.Buckets.Create(context.Background(), &domain.Bucket{
+			ID:             bucketID,
+			Name:           "Rolled Back " + bucketID.String(),
+			BucketType:     domain.BucketTypePhysical,
+			CurrentBalance: decimal.Zero,
+		}); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	require.EqualError(t, err, "abort")
+
+	_, err = bucketRepo.GetByID(context.Background(), bucketID)
+	assert.ErrorIs(t, err, domain.ErrBucketNotFound, "Bucket created in the aborted unit of work should not exist")
+}
+
+// TestGetCategorySpendingTrend tests that category spending is summed per month
+func TestGetCategorySpendingTrend(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	categoryID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             categoryID,
+		Name:           "Trend Groceries " + categoryID.String(),
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	expenses := []struct {
+		amount string
+		date   time.Time
+	}{
+		{"12.50", time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC)},
+		{"7.50", time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)},
+		{"5.00", time.Date(2025, 2, 3, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, expense := range expenses {
+		resp, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+			Amount:           expense.amount,
+			Description:      "Groceries",
+			VirtualBucketId:  testBuckets["Unallocated"].String(),
+			CategoryBucketId: categoryID.String(),
+		})
+		require.NoError(t, err, "LogExpense should succeed")
+
+		// LogExpense books at server time, so backdate the transaction directly
+		_, err = db.ExecContext(context.Background(), `UPDATE transactions SET date = $1 WHERE id = $2`, expense.date, resp.TransactionId)
+		require.NoError(t, err)
+	}
+
+	resp, err := grpcClient.GetCategorySpendingTrend(ctx, &wealthflowv1.GetCategorySpendingTrendRequest{
+		CategoryBucketId: categoryID.String(),
+		StartDate:        timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:          timestamppb.New(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err, "GetCategorySpendingTrend should succeed")
+	require.Len(t, resp.Points, 2, "Only months with spending are returned")
+	assert.Equal(t, time.January, resp.Points[0].Period.AsTime().Month())
+	assert.Equal(t, "20.00", resp.Points[0].Total)
+	assert.Equal(t, time.February, resp.Points[1].Period.AsTime().Month())
+	assert.Equal(t, "5.00", resp.Points[1].Total)
+
+	// Non-expense buckets are rejected
+	_, err = grpcClient.GetCategorySpendingTrend(ctx, &wealthflowv1.GetCategorySpendingTrendRequest{
+		CategoryBucketId: testBuckets["Main Bank"].String(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// TestLogExpenseRefund tests that a refund credits the category back and restores the virtual bucket
+func TestLogExpenseRefund(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	categoryID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             categoryID,
+		Name:           "Refund Clothes " + categoryID.String(),
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	virtualBefore, err := bucketRepo.GetByID(context.Background(), testBuckets["Unallocated"])
+	require.NoError(t, err)
+
+	_, err = grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "50.00",
+		Description:      "Jacket",
+		VirtualBucketId:  testBuckets["Unallocated"].String(),
+		CategoryBucketId: categoryID.String(),
+	})
+	require.NoError(t, err, "LogExpense should succeed")
+
+	refundResp, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "20.00",
+		Description:      "Jacket (partial refund)",
+		VirtualBucketId:  testBuckets["Unallocated"].String(),
+		CategoryBucketId: categoryID.String(),
+		IsRefund:         true,
+	})
+	require.NoError(t, err, "Refund should succeed")
+	assert.Equal(t, testBuckets["Main Bank"].String(), refundResp.PhysicalBucketId)
+
+	category, err := bucketRepo.GetByID(context.Background(), categoryID)
+	require.NoError(t, err)
+	assert.True(t, category.CurrentBalance.Equal(decimal.NewFromInt(30)), "Category spending should be 50 - 20, got %s", category.CurrentBalance)
+
+	// The spending trend nets the refund as well
+	trendResp, err := grpcClient.GetCategorySpendingTrend(ctx, &wealthflowv1.GetCategorySpendingTrendRequest{
+		CategoryBucketId: categoryID.String(),
+	})
+	require.NoError(t, err, "GetCategorySpendingTrend should succeed")
+	require.Len(t, trendResp.Points, 1)
+	assert.Equal(t, "30.00", trendResp.Points[0].Total)
+
+	virtualAfter, err := bucketRepo.GetByID(context.Background(), testBuckets["Unallocated"])
+	require.NoError(t, err)
+	assert.True(t, virtualBefore.CurrentBalance.Sub(virtualAfter.CurrentBalance).Equal(decimal.NewFromInt(30)),
+		"Virtual bucket should only be down by the net 30, before %s after %s", virtualBefore.CurrentBalance, virtualAfter.CurrentBalance)
+}
+
+// TestListTransactionsKindFilter tests isolating refunds and external inflows, combined with the bucket filter
+func TestListTransactionsKindFilter(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	categoryID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             categoryID,
+		Name:           "Kind Electronics " + categoryID.String(),
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	for _, isRefund := range []bool{false, true} {
+		_, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+			Amount:           "30.00",
+			Description:      "Headphones",
+			VirtualBucketId:  testBuckets["Unallocated"].String(),
+			CategoryBucketId: categoryID.String(),
+			IsRefund:         isRefund,
+		})
+		require.NoError(t, err, "LogExpense should succeed")
+	}
+
+	inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "10.00",
+		Description:    "Kind Filter Inflow",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+
+	t.Run("AllKinds", func(t *testing.T) {
+		resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 10, BucketId: categoryID.String()})
+		require.NoError(t, err, "ListTransactions should succeed")
+		assert.Equal(t, int32(2), resp.TotalCount)
+	})
+
+	t.Run("Refund", func(t *testing.T) {
+		resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+			Limit:    10,
+			BucketId: categoryID.String(),
+			Kind:     string(domain.TransactionKindRefund),
+		})
+		require.NoError(t, err, "ListTransactions should succeed")
+		assert.Equal(t, int32(1), resp.TotalCount)
+		require.Len(t, resp.Transactions, 1)
+		assert.True(t, resp.Transactions[0].IsRefund)
+	})
+
+	t.Run("ExternalInflow", func(t *testing.T) {
+		resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+			Limit:    100,
+			BucketId: testBuckets["Employer"].String(),
+			Kind:     string(domain.TransactionKindExternalInflow),
+		})
+		require.NoError(t, err, "ListTransactions should succeed")
+		found := false
+		for _, tx := range resp.Transactions {
+			assert.True(t, tx.IsExternal, "Only external inflows should be listed")
+			if tx.Id == inflowResp.TransactionId {
+				found = true
+			}
+		}
+		assert.True(t, found, "The recorded inflow should be listed")
+
+		// The category has no external inflows
+		resp, err = grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{
+			Limit:    10,
+			BucketId: categoryID.String(),
+			Kind:     string(domain.TransactionKindExternalInflow),
+		})
+		require.NoError(t, err, "ListTransactions should succeed")
+		assert.Zero(t, resp.TotalCount)
+	})
+
+	t.Run("InvalidKind", func(t *testing.T) {
+		_, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 10, Kind: "EXPENSE"})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateEquityBucket(t *testing.T) {
+	ctx := getAuthContext()
+
+	t.Run("MarketEqualsBook", func(t *testing.T) {
+		resp, err := grpcClient.CreateEquityBucket(ctx, &wealthflowv1.CreateEquityBucketRequest{
+			Name:        "ETF " + uuid.New().String(),
+			BookValue:   "1500",
+			MarketValue: "1500",
+		})
+		require.NoError(t, err, "CreateEquityBucket should succeed")
+		assert.Equal(t, wealthflowv1.BucketType_BUCKET_TYPE_EQUITY, resp.Bucket.Type)
+		assert.Equal(t, "1500.00", resp.Bucket.CurrentBalance)
+		assert.NotEmpty(t, resp.TransactionId)
+		assert.NotEmpty(t, resp.MarketValueEntryId)
+		assert.Equal(t, "0.00", resp.AbsoluteProfit, "Profit should be 0 when market equals book")
+
+		// The book value is persisted through the balance trigger
+		bucketResp, err := grpcClient.GetBucket(ctx, &wealthflowv1.GetBucketRequest{BucketId: resp.Bucket.Id})
+		require.NoError(t, err, "GetBucket should succeed")
+		assert.Equal(t, "1500.00", bucketResp.Bucket.CurrentBalance)
+	})
+
+	t.Run("WithoutMarketValue", func(t *testing.T) {
+		resp, err := grpcClient.CreateEquityBucket(ctx, &wealthflowv1.CreateEquityBucketRequest{
+			Name:      "ETF " + uuid.New().String(),
+			BookValue: "200",
+		})
+		require.NoError(t, err, "CreateEquityBucket should succeed")
+		assert.Empty(t, resp.MarketValueEntryId)
+		assert.Equal(t, "200.00", resp.MarketValue, "Market value should fall back to the book value")
+		assert.Equal(t, "0.00", resp.AbsoluteProfit)
+	})
+
+	t.Run("InvalidBookValue", func(t *testing.T) {
+		_, err := grpcClient.CreateEquityBucket(ctx, &wealthflowv1.CreateEquityBucketRequest{
+			Name:      "ETF " + uuid.New().String(),
+			BookValue: "0",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateBuckets(t *testing.T) {
+	ctx := getAuthContext()
+	suffix := uuid.New().String()
+
+	t.Run("BankWithEnvelopes", func(t *testing.T) {
+		resp, err := grpcClient.CreateBuckets(ctx, &wealthflowv1.CreateBucketsRequest{
+			Buckets: []*wealthflowv1.NewBucket{
+				{Name: "Rent " + suffix, Type: wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL, ParentKey: "bank"},
+				{Key: "bank", Name: "Onboarding Bank " + suffix, Type: wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL},
+				{Name: "Buffer " + suffix, Type: wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL, ParentId: testBuckets["Main Bank"].String()},
+			},
+		})
+		require.NoError(t, err, "CreateBuckets should succeed")
+		require.Len(t, resp.Buckets, 3)
+		assert.Equal(t, resp.Buckets[1].Id, resp.Buckets[0].ParentId, "Envelope should be resolved to the bank of the batch")
+		assert.Equal(t, testBuckets["Main Bank"].String(), resp.Buckets[2].ParentId)
+
+		children, err := grpcClient.ListBucketsByParent(ctx, &wealthflowv1.ListBucketsByParentRequest{ParentId: resp.Buckets[1].Id})
+		require.NoError(t, err, "ListBucketsByParent should succeed")
+		require.Len(t, children.Buckets, 1)
+		assert.Equal(t, resp.Buckets[0].Id, children.Buckets[0].Id)
+	})
+
+	t.Run("InvalidBatchCreatesNothing", func(t *testing.T) {
+		bankName := "Rejected Bank " + suffix
+		_, err := grpcClient.CreateBuckets(ctx, &wealthflowv1.CreateBucketsRequest{
+			Buckets: []*wealthflowv1.NewBucket{
+				{Key: "bank", Name: bankName, Type: wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL},
+				{Name: "Orphan " + suffix, Type: wealthflowv1.BucketType_BUCKET_TYPE_VIRTUAL, ParentKey: "missing"},
+			},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		var count int
+		require.NoError(t, db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM buckets WHERE name = $1`, bankName).Scan(&count))
+		assert.Zero(t, count, "No bucket of a rejected batch should be written")
+	})
+}
+
+func TestAddEquityPurchase(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	bucketID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             bucketID,
+		Name:           "Fractional ETF " + bucketID.String(),
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	// 2 units at 100, then 0.5 units at 150 -> average cost (200 + 75) / 2.5 = 110
+	_, err := grpcClient.AddEquityPurchase(ctx, &wealthflowv1.AddEquityPurchaseRequest{
+		BucketId:  bucketID.String(),
+		Quantity:  "2",
+		UnitPrice: "100",
+	})
+	require.NoError(t, err, "First AddEquityPurchase should succeed")
+
+	// Later purchases must name the account that paid
+	_, err = grpcClient.AddEquityPurchase(ctx, &wealthflowv1.AddEquityPurchaseRequest{
+		BucketId:  bucketID.String(),
+		Quantity:  "0.5",
+		UnitPrice: "150",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "A purchase into an existing holding without a source should be rejected")
+
+	bankBefore, err := bucketRepo.GetByID(context.Background(), testBuckets["Main Bank"])
+	require.NoError(t, err)
+	envelopeBefore, err := bucketRepo.GetByID(context.Background(), testBuckets["Unallocated"])
+	require.NoError(t, err)
+
+	resp, err := grpcClient.AddEquityPurchase(ctx, &wealthflowv1.AddEquityPurchaseRequest{
+		BucketId:              bucketID.String(),
+		Quantity:              "0.5",
+		UnitPrice:             "150",
+		SourceVirtualBucketId: testBuckets["Unallocated"].String(),
+	})
+	require.NoError(t, err, "Second AddEquityPurchase should succeed")
+	assert.Equal(t, "2.5", resp.Quantity)
+	assert.Equal(t, "110", resp.AvgCost)
+
+	bankAfter, err := bucketRepo.GetByID(context.Background(), testBuckets["Main Bank"])
+	require.NoError(t, err)
+	assert.True(t, bankBefore.CurrentBalance.Sub(bankAfter.CurrentBalance).Equal(decimal.NewFromInt(75)),
+		"Main Bank should pay the 75 purchase cost, before %s after %s", bankBefore.CurrentBalance, bankAfter.CurrentBalance)
+	envelopeAfter, err := bucketRepo.GetByID(context.Background(), testBuckets["Unallocated"])
+	require.NoError(t, err)
+	assert.True(t, envelopeBefore.CurrentBalance.Sub(envelopeAfter.CurrentBalance).Equal(decimal.NewFromInt(75)),
+		"The source virtual bucket should pay the purchase cost too, so Main Bank does not drift")
+
+	// Record a unit price of 120: market value 2.5 * 120 = 300, book value 275
+	_, err = grpcClient.UpdateInvestment(ctx, &wealthflowv1.UpdateInvestmentRequest{
+		BucketId:  bucketID.String(),
+		UnitPrice: "120",
+	})
+	require.NoError(t, err, "UpdateInvestment with unit_price should succeed")
+
+	profit, err := grpcClient.GetInvestmentProfit(ctx, &wealthflowv1.GetInvestmentProfitRequest{BucketId: bucketID.String()})
+	require.NoError(t, err, "GetInvestmentProfit should succeed")
+	assert.Equal(t, "275.00", profit.BookValue)
+	assert.Equal(t, "300.00", profit.MarketValue)
+	assert.Equal(t, "25.00", profit.AbsoluteProfit)
+
+	t.Run("NonEquityBucket", func(t *testing.T) {
+		_, err := grpcClient.AddEquityPurchase(ctx, &wealthflowv1.AddEquityPurchaseRequest{
+			BucketId:  testBuckets["Main Bank"].String(),
+			Quantity:  "1",
+			UnitPrice: "10",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListTransactionsLimitClamping(t *testing.T) {
+	ctx := getAuthContext()
+
+	// An oversized limit is clamped to the server maximum instead of being rejected
+	resp, err := grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 1000000})
+	require.NoError(t, err, "ListTransactions with an oversized limit should succeed")
+	assert.LessOrEqual(t, len(resp.Transactions), 500)
+
+	// A zero limit is rejected with the default configuration
+	_, err = grpcClient.ListTransactions(ctx, &wealthflowv1.ListTransactionsRequest{Limit: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetTransactionsByDateHistogram(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	categoryID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             categoryID,
+		Name:           "Histogram Coffee " + categoryID.String(),
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	dates := []time.Time{
+		time.Date(2024, 6, 10, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 10, 17, 30, 0, 0, time.UTC),
+		time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC),
+	}
+	for _, date := range dates {
+		resp, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+			Amount:           "2.50",
+			Description:      "Coffee",
+			VirtualBucketId:  testBuckets["Unallocated"].String(),
+			CategoryBucketId: categoryID.String(),
+		})
+		require.NoError(t, err, "LogExpense should succeed")
+
+		// LogExpense books at server time, so backdate the transaction directly
+		_, err = db.ExecContext(context.Background(), `UPDATE transactions SET date = $1 WHERE id = $2`, date, resp.TransactionId)
+		require.NoError(t, err)
+	}
+
+	resp, err := grpcClient.GetTransactionsByDateHistogram(ctx, &wealthflowv1.GetTransactionsByDateHistogramRequest{
+		StartDate: timestamppb.New(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   timestamppb.New(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
+		BucketId:  categoryID.String(),
+	})
+	require.NoError(t, err, "GetTransactionsByDateHistogram should succeed")
+	require.Len(t, resp.Days, 2, "Only days with transactions are returned")
+	assert.Equal(t, 10, resp.Days[0].Date.AsTime().Day())
+	assert.Equal(t, int32(2), resp.Days[0].Count)
+	assert.Equal(t, 12, resp.Days[1].Date.AsTime().Day())
+	assert.Equal(t, int32(1), resp.Days[1].Count)
+
+	// A scheduled expense is dated on its effective date but has not happened yet, so it is not counted
+	effectiveDate := time.Now().AddDate(0, 0, 10)
+	_, err = grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "2.50",
+		Description:      "Coffee (scheduled)",
+		VirtualBucketId:  testBuckets["Unallocated"].String(),
+		CategoryBucketId: categoryID.String(),
+		EffectiveDate:    timestamppb.New(effectiveDate),
+	})
+	require.NoError(t, err, "Scheduled LogExpense should succeed")
+	resp, err = grpcClient.GetTransactionsByDateHistogram(ctx, &wealthflowv1.GetTransactionsByDateHistogramRequest{
+		StartDate: timestamppb.New(effectiveDate.AddDate(0, 0, -1)),
+		EndDate:   timestamppb.New(effectiveDate.AddDate(0, 0, 1)),
+		BucketId:  categoryID.String(),
+	})
+	require.NoError(t, err, "GetTransactionsByDateHistogram should succeed")
+	assert.Empty(t, resp.Days, "Scheduled transactions should not be counted")
+
+	// Unknown bucket filter is reported
+	_, err = grpcClient.GetTransactionsByDateHistogram(ctx, &wealthflowv1.GetTransactionsByDateHistogramRequest{
+		BucketId: uuid.New().String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListOverdueTransferTasks(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	transferTaskRepo := postgres.NewTransferTaskRepository(db)
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	for id, name := range map[uuid.UUID]string{fromID: "Overdue From ", toID: "Overdue To "} {
+		require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+			ID:             id,
+			Name:           name + id.String(),
+			BucketType:     domain.BucketTypePhysical,
+			CurrentBalance: decimal.Zero,
+		}))
+	}
+
+	newTask := func(age time.Duration) *domain.TransferTask {
+		task := &domain.TransferTask{
+			ID:                   uuid.New(),
+			RelatedTransactionID: uuid.New(),
+			FromPhysicalBucketID: fromID,
+			ToPhysicalBucketID:   toID,
+			Amount:               decimal.NewFromInt(10),
+			CreatedAt:            time.Now().Add(-age),
+		}
+		require.NoError(t, transferTaskRepo.Create(context.Background(), task))
+		return task
+	}
+	stale := newTask(10 * 24 * time.Hour)
+	older := newTask(20 * 24 * time.Hour)
+	fresh := newTask(time.Hour)
+
+	resp, err := grpcClient.ListOverdueTransferTasks(ctx, &wealthflowv1.ListOverdueTransferTasksRequest{Days: 7})
+	require.NoError(t, err, "ListOverdueTransferTasks should succeed")
+
+	// Other tests may leave pending tasks behind, so only look at the ones created here
+	var ids []string
+	for _, task := range resp.Tasks {
+		if task.FromPhysicalBucketId == fromID.String() {
+			ids = append(ids, task.Id)
+		}
+	}
+	assert.Equal(t, []string{older.ID.String(), stale.ID.String()}, ids, "Overdue tasks should be returned oldest first")
+	assert.NotContains(t, ids, fresh.ID.String())
+
+	// Negative thresholds are rejected
+	_, err = grpcClient.ListOverdueTransferTasks(ctx, &wealthflowv1.ListOverdueTransferTasksRequest{Days: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetExpenseBreakdownByPhysicalBucket(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	bankID := uuid.New()
+	cardID := uuid.New()
+	virtualID := uuid.New()
+	categoryID := uuid.New()
+	for _, bucket := range []*domain.Bucket{
+		{ID: bankID, Name: "Breakdown Bank " + bankID.String(), BucketType: domain.BucketTypePhysical},
+		{ID: cardID, Name: "Breakdown Card " + cardID.String(), BucketType: domain.BucketTypePhysical},
+		{ID: virtualID, Name: "Breakdown Groceries " + virtualID.String(), BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &bankID},
+		{ID: categoryID, Name: "Breakdown Food " + categoryID.String(), BucketType: domain.BucketTypeExpense},
+	} {
+		bucket.CurrentBalance = decimal.Zero
+		require.NoError(t, bucketRepo.Create(context.Background(), bucket))
+	}
+
+	date := time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC)
+	logExpense := func(amount string, override *uuid.UUID) {
+		req := &wealthflowv1.LogExpenseRequest{
+			Amount:           amount,
+			Description:      "Breakdown expense",
+			VirtualBucketId:  virtualID.String(),
+			CategoryBucketId: categoryID.String(),
+		}
+		if override != nil {
+			req.PhysicalBucketOverrideId = override.String()
+		}
+		resp, err := grpcClient.LogExpense(ctx, req)
+		require.NoError(t, err, "LogExpense should succeed")
+
+		// LogExpense books at server time, so backdate the transaction directly
+		_, err = db.ExecContext(context.Background(), `UPDATE transactions SET date = $1 WHERE id = $2`, date, resp.TransactionId)
+		require.NoError(t, err)
+	}
+	logExpense("40.00", nil)
+	logExpense("25.00", &cardID) // Paid with the wrong card
+
+	resp, err := grpcClient.GetExpenseBreakdownByPhysicalBucket(ctx, &wealthflowv1.GetExpenseBreakdownByPhysicalBucketRequest{
+		StartDate: timestamppb.New(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   timestamppb.New(time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err, "GetExpenseBreakdownByPhysicalBucket should succeed")
+
+	spent := make(map[string]string)
+	for _, bucket := range resp.Buckets {
+		spent[bucket.PhysicalBucketId] = bucket.TotalSpent
+	}
+	assert.Equal(t, "40.00", spent[bankID.String()], "Bank is charged for the regular expense")
+	assert.Equal(t, "25.00", spent[cardID.String()], "Card is charged for the overridden expense")
+
+	// Reversed date range is rejected
+	_, err = grpcClient.GetExpenseBreakdownByPhysicalBucket(ctx, &wealthflowv1.GetExpenseBreakdownByPhysicalBucketRequest{
+		StartDate: timestamppb.New(time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)),
+		EndDate:   timestamppb.New(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRecordInflowDryRun(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	unallocatedID := testBuckets["Unallocated"]
+
+	var txCountBefore int
+	require.NoError(t, db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM transactions`).Scan(&txCountBefore))
+	unallocatedBefore, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+
+	resp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "1200.00",
+		Description:    "Salary preview",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+		DryRun:         true,
+	})
+	require.NoError(t, err, "Dry-run RecordInflow should succeed")
+	assert.Empty(t, resp.TransactionId, "A dry run records no transaction")
+	require.NotEmpty(t, resp.Allocations)
+	total := decimal.Zero
+	for _, allocation := range resp.Allocations {
+		total = total.Add(decimal.RequireFromString(allocation.Amount))
+	}
+	assert.True(t, total.Equal(decimal.NewFromInt(1200)), "The would-be allocation covers the amount, got %s", total)
+
+	var txCountAfter int
+	require.NoError(t, db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM transactions`).Scan(&txCountAfter))
+	assert.Equal(t, txCountBefore, txCountAfter, "No transaction should be written in dry-run mode")
+	unallocatedAfter, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+	assert.True(t, unallocatedBefore.CurrentBalance.Equal(unallocatedAfter.CurrentBalance), "Balances should not change in dry-run mode")
+}
+
+func TestRecordInflowParksUnmatchedIncome(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+
+	freelanceID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             freelanceID,
+		Name:           "Freelance " + freelanceID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	// Without a parking bucket the missing split rule is still an error
+	_, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "300.00",
+		Description:    "Invoice without rule",
+		SourceBucketId: freelanceID.String(),
+		IsExternal:     true,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	bankBefore, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+	unallocatedBefore, err := grpcClient.GetUnallocatedIncome(ctx, &wealthflowv1.GetUnallocatedIncomeRequest{})
+	require.NoError(t, err, "GetUnallocatedIncome should succeed")
+
+	resp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:                 "300.00",
+		Description:            "Invoice without rule",
+		SourceBucketId:         freelanceID.String(),
+		IsExternal:             true,
+		ParkInPhysicalBucketId: mainBankID.String(),
+	})
+	require.NoError(t, err, "RecordInflow should park income without a split rule")
+	assert.True(t, resp.Parked)
+	require.Len(t, resp.Allocations, 1)
+	assert.Equal(t, "System Extra Income", resp.Allocations[0].Name)
+
+	bankAfter, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+	assert.True(t, bankBefore.CurrentBalance.Add(decimal.NewFromInt(300)).Equal(bankAfter.CurrentBalance), "The bank receives the parked money")
+
+	// The parked income is listed until it is allocated
+	unallocatedAfter, err := grpcClient.GetUnallocatedIncome(ctx, &wealthflowv1.GetUnallocatedIncomeRequest{})
+	require.NoError(t, err, "GetUnallocatedIncome should succeed")
+	assert.True(t, decimal.RequireFromString(unallocatedBefore.Amount).Add(decimal.NewFromInt(300)).Equal(decimal.RequireFromString(unallocatedAfter.Amount)))
+	found := false
+	for _, contribution := range unallocatedAfter.Contributions {
+		if contribution.Transaction.Id == resp.TransactionId {
+			found = true
+			assert.Equal(t, "300.00", contribution.Amount)
+		}
+	}
+	assert.True(t, found, "The parked inflow should be listed as a contribution")
+
+	// Distribute part of it into a virtual bucket of the bank it landed in
+	unallocatedID := testBuckets["Unallocated"]
+	envelopeBefore, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+
+	allocateResp, err := grpcClient.AllocateUnallocatedIncome(ctx, &wealthflowv1.AllocateUnallocatedIncomeRequest{
+		Allocations: []*wealthflowv1.ParkedIncomeAllocation{{VirtualBucketId: unallocatedID.String(), Amount: "120.00"}},
+	})
+	require.NoError(t, err, "AllocateUnallocatedIncome should succeed")
+	assert.NotEmpty(t, allocateResp.TransactionId)
+
+	envelopeAfter, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+	assert.True(t, envelopeBefore.CurrentBalance.Add(decimal.NewFromInt(120)).Equal(envelopeAfter.CurrentBalance), "The virtual bucket receives the allocation")
+
+	remaining, err := grpcClient.GetUnallocatedIncome(ctx, &wealthflowv1.GetUnallocatedIncomeRequest{})
+	require.NoError(t, err, "GetUnallocatedIncome should succeed")
+	assert.True(t, decimal.RequireFromString(unallocatedAfter.Amount).Sub(decimal.NewFromInt(120)).Equal(decimal.RequireFromString(remaining.Amount)))
+
+	// More than what is parked cannot be allocated
+	tooMuch := decimal.RequireFromString(remaining.Amount).Add(decimal.NewFromInt(1))
+	_, err = grpcClient.AllocateUnallocatedIncome(ctx, &wealthflowv1.AllocateUnallocatedIncomeRequest{
+		Allocations: []*wealthflowv1.ParkedIncomeAllocation{{VirtualBucketId: unallocatedID.String(), Amount: tooMuch.String()}},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The income was parked in Main Bank, so it cannot fund the envelopes of another bank
+	otherBankID, otherEnvelopeID := uuid.New(), uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             otherBankID,
+		Name:           "Other Bank " + otherBankID.String(),
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}))
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:                     otherEnvelopeID,
+		Name:                   "Other Envelope " + otherEnvelopeID.String(),
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &otherBankID,
+		CurrentBalance:         decimal.Zero,
+	}))
+	_, err = grpcClient.AllocateUnallocatedIncome(ctx, &wealthflowv1.AllocateUnallocatedIncomeRequest{
+		Allocations: []*wealthflowv1.ParkedIncomeAllocation{{VirtualBucketId: otherEnvelopeID.String(), Amount: "10.00"}},
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Nothing was parked in the other bank")
+	_, err = grpcClient.AllocateUnallocatedIncome(ctx, &wealthflowv1.AllocateUnallocatedIncomeRequest{
+		Allocations: []*wealthflowv1.ParkedIncomeAllocation{
+			{VirtualBucketId: unallocatedID.String(), Amount: "10.00"},
+			{VirtualBucketId: otherEnvelopeID.String(), Amount: "10.00"},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Targets must all belong to one bank")
+}
+
+func TestRecordInflowToTargetVirtualBucket(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	giftsID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             giftsID,
+		Name:           "Gifts " + giftsID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	bankBefore, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+	unallocatedBefore, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+
+	// Gifts has no split rule: the whole amount lands in Unallocated
+	resp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:                "75.00",
+		Description:           "Birthday gift",
+		SourceBucketId:        giftsID.String(),
+		TargetVirtualBucketId: unallocatedID.String(),
+	})
+	require.NoError(t, err, "RecordInflow with a target virtual bucket should succeed")
+	require.Len(t, resp.Allocations, 1)
+	assert.Equal(t, unallocatedID.String(), resp.Allocations[0].BucketId)
+	assert.Equal(t, "75.00", resp.Allocations[0].Amount)
+
+	bankAfter, err := bucketRepo.GetByID(context.Background(), mainBankID)
+	require.NoError(t, err)
+	unallocatedAfter, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+	assert.True(t, bankBefore.CurrentBalance.Add(decimal.NewFromInt(75)).Equal(bankAfter.CurrentBalance), "Parent physical bucket receives the money")
+	assert.True(t, unallocatedBefore.CurrentBalance.Add(decimal.NewFromInt(75)).Equal(unallocatedAfter.CurrentBalance), "Target virtual bucket receives the full amount")
+
+	// Combining the target with the split rule path is rejected
+	_, err = grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:                "75.00",
+		Description:           "Birthday gift",
+		SourceBucketId:        giftsID.String(),
+		IsExternal:            true,
+		TargetVirtualBucketId: unallocatedID.String(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// A non-virtual target is rejected
+	_, err = grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:                "75.00",
+		Description:           "Birthday gift",
+		SourceBucketId:        giftsID.String(),
+		TargetVirtualBucketId: mainBankID.String(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetInflowAllocationShares(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+
+	sourceID := uuid.New()
+	travelID := uuid.New()
+	giftsID := uuid.New()
+	for _, bucket := range []*domain.Bucket{
+		{ID: sourceID, Name: "Shares Side Job " + sourceID.String(), BucketType: domain.BucketTypeIncome},
+		{ID: travelID, Name: "Shares Travel " + travelID.String(), BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+		{ID: giftsID, Name: "Shares Gifts " + giftsID.String(), BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &mainBankID},
+	} {
+		bucket.CurrentBalance = decimal.Zero
+		require.NoError(t, bucketRepo.Create(context.Background(), bucket))
+	}
+
+	// Two inflows in a period no other test books into: 300 to Travel, 100 to Gifts
+	date := time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC)
+	for targetID, amount := range map[uuid.UUID]string{travelID: "300.00", giftsID: "100.00"} {
+		resp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+			Amount:                amount,
+			Description:           "Side job payment",
+			SourceBucketId:        sourceID.String(),
+			TargetVirtualBucketId: targetID.String(),
+		})
+		require.NoError(t, err, "RecordInflow should succeed")
+
+		// RecordInflow books at server time, so backdate the transaction directly
+		_, err = db.ExecContext(context.Background(), `UPDATE transactions SET date = $1 WHERE id = $2`, date, resp.TransactionId)
+		require.NoError(t, err)
+	}
+
+	resp, err := grpcClient.GetInflowAllocationShares(ctx, &wealthflowv1.GetInflowAllocationSharesRequest{
+		StartDate: timestamppb.New(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:   timestamppb.New(time.Date(2022, 5, 31, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err, "GetInflowAllocationShares should succeed")
+	require.Len(t, resp.Shares, 2)
+	assert.Equal(t, travelID.String(), resp.Shares[0].Bucket.Id)
+	assert.Equal(t, "300.00", resp.Shares[0].Total)
+	assert.Equal(t, "75.00", resp.Shares[0].SharePercent)
+	assert.Equal(t, giftsID.String(), resp.Shares[1].Bucket.Id)
+	assert.Equal(t, "100.00", resp.Shares[1].Total)
+	assert.Equal(t, "25.00", resp.Shares[1].SharePercent)
+}
+
+func TestVerifyLedgerIntegrity(t *testing.T) {
+	ctx := getAuthContext()
+	mainBankID := testBuckets["Main Bank"]
+	employerID := testBuckets["Employer"]
+
+	// Corrupt the ledger the way an external SQL edit would: a physical debit without a matching credit
+	corruptedID := uuid.New()
+	_, err := db.ExecContext(context.Background(),
+		`INSERT INTO transactions (id, description, date, is_internal_transfer, is_external_inflow) VALUES ($1, $2, $3, false, false)`,
+		corruptedID, "Corrupted by hand", time.Now())
+	require.NoError(t, err)
+	_, err = db.ExecContext(context.Background(),
+		`INSERT INTO transaction_entries (id, transaction_id, bucket_id, amount, type, layer) VALUES ($1, $2, $3, 10, 'DEBIT', 'PHYSICAL'), ($4, $2, $5, 7, 'CREDIT', 'PHYSICAL')`,
+		uuid.New(), corruptedID, mainBankID, uuid.New(), employerID)
+	require.NoError(t, err)
+	// Delete through the repository so the balances the insert trigger changed are reverted
+	t.Cleanup(func() {
+		_ = postgres.NewTransactionRepository(db).Delete(context.Background(), corruptedID)
+	})
+
+	resp, err := grpcClient.VerifyLedgerIntegrity(ctx, &wealthflowv1.VerifyLedgerIntegrityRequest{})
+	require.NoError(t, err, "VerifyLedgerIntegrity should succeed")
+	assert.Positive(t, resp.TransactionsChecked)
+
+	var found *wealthflowv1.LedgerViolation
+	for _, violation := range resp.Violations {
+		if violation.TransactionId == corruptedID.String() {
+			found = violation
+		}
+	}
+	require.NotNil(t, found, "Corrupted transaction should be reported")
+	assert.Contains(t, found.Reason, "PHYSICAL layer")
+
+	// The check is not exempt from auth
+	_, err = grpcClient.VerifyLedgerIntegrity(context.Background(), &wealthflowv1.VerifyLedgerIntegrityRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestLogSplitExpense(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	householdID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             householdID,
+		Name:           "Household " + householdID.String(),
+		BucketType:     domain.BucketTypeExpense,
+		CurrentBalance: decimal.Zero,
+	}))
+	groceriesID := testBuckets["Groceries"]
+
+	balance := func(id uuid.UUID) decimal.Decimal {
+		bucket, err := bucketRepo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return bucket.CurrentBalance
+	}
+	bankBefore := balance(mainBankID)
+	unallocatedBefore := balance(unallocatedID)
+	groceriesBefore := balance(groceriesID)
+
+	resp, err := grpcClient.LogSplitExpense(ctx, &wealthflowv1.LogSplitExpenseRequest{
+		Amount:          "80.00",
+		Description:     "Supermarket",
+		VirtualBucketId: unallocatedID.String(),
+		Lines: []*wealthflowv1.ExpenseLine{
+			{CategoryBucketId: groceriesID.String(), Amount: "62.40"},
+			{CategoryBucketId: householdID.String(), Amount: "17.60"},
+		},
+	})
+	require.NoError(t, err, "LogSplitExpense should succeed")
+	assert.Equal(t, mainBankID.String(), resp.PhysicalBucketId)
+
+	assert.True(t, bankBefore.Sub(decimal.NewFromInt(80)).Equal(balance(mainBankID)), "Bank pays the total")
+	assert.True(t, unallocatedBefore.Sub(decimal.NewFromInt(80)).Equal(balance(unallocatedID)), "Virtual bucket pays the total")
+	assert.True(t, groceriesBefore.Add(decimal.RequireFromString("62.40")).Equal(balance(groceriesID)), "Groceries gets its line")
+	assert.True(t, decimal.RequireFromString("17.60").Equal(balance(householdID)), "Household gets its line")
+
+	// Lines that do not add up to the total are rejected
+	_, err = grpcClient.LogSplitExpense(ctx, &wealthflowv1.LogSplitExpenseRequest{
+		Amount:          "80.00",
+		Description:     "Supermarket",
+		VirtualBucketId: unallocatedID.String(),
+		Lines: []*wealthflowv1.ExpenseLine{
+			{CategoryBucketId: groceriesID.String(), Amount: "60.00"},
+			{CategoryBucketId: householdID.String(), Amount: "10.00"},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBucketRepositorySumBalances(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	// An archived physical bucket must be left out, like in List
+	archivedID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             archivedID,
+		Name:           "Closed Account " + archivedID.String(),
+		BucketType:     domain.BucketTypePhysical,
+		CurrentBalance: decimal.Zero,
+	}))
+	_, err := db.ExecContext(context.Background(), `UPDATE buckets SET current_balance = 999, is_archived = TRUE WHERE id = $1`, archivedID)
+	require.NoError(t, err)
+
+	for _, bucketType := range []domain.BucketType{domain.BucketTypePhysical, domain.BucketTypeVirtual, domain.BucketTypeEquity} {
+		buckets, err := bucketRepo.List(context.Background(), bucketType)
+		require.NoError(t, err)
+		goSum := decimal.Zero
+		for _, bucket := range buckets {
+			goSum = goSum.Add(bucket.CurrentBalance)
+		}
+
+		sqlSum, err := bucketRepo.SumBalances(context.Background(), bucketType)
+		require.NoError(t, err)
+		assert.True(t, goSum.Equal(sqlSum), "%s: SQL sum %s should match Go sum %s", bucketType, sqlSum, goSum)
+	}
+
+	// A type without buckets sums to zero rather than NULL
+	emptySum, err := bucketRepo.SumBalances(context.Background(), domain.BucketType("NONE"))
+	require.NoError(t, err)
+	assert.True(t, emptySum.IsZero())
+
+	// GetLiquidity matches GetNetWorth's liquidity
+	liquidityResp, err := grpcClient.GetLiquidity(ctx, &wealthflowv1.GetLiquidityRequest{})
+	require.NoError(t, err, "GetLiquidity should succeed")
+	netWorthResp, err := grpcClient.GetNetWorth(ctx, &wealthflowv1.GetNetWorthRequest{})
+	require.NoError(t, err)
+	liquidity, err := decimal.NewFromString(liquidityResp.Liquidity)
+	require.NoError(t, err)
+	netWorthLiquidity, err := decimal.NewFromString(netWorthResp.Liquidity)
+	require.NoError(t, err)
+	assert.True(t, liquidity.Equal(netWorthLiquidity))
+}
+
+// TestBucketRepositoryListPhysicalWithChildren tests that the single-query tree matches List + ListByParent
+func TestBucketRepositoryListPhysicalWithChildren(t *testing.T) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	// A physical bucket without children still gets a family
+	emptyID := uuid.New()
+	require.NoError(t, bucketRepo.Create(ctx, &domain.Bucket{ID: emptyID, Name: "Empty Account " + emptyID.String(), BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.Zero}))
+
+	families, err := bucketRepo.ListPhysicalWithChildren(ctx)
+	require.NoError(t, err)
+
+	physicalBuckets, err := bucketRepo.List(ctx, domain.BucketTypePhysical)
+	require.NoError(t, err)
+	require.Len(t, families, len(physicalBuckets))
+	for i, family := range families {
+		assert.Equal(t, physicalBuckets[i].ID, family.Physical.ID, "Families should be ordered like List")
+
+		children, err := bucketRepo.ListByParent(ctx, family.Physical.ID)
+		require.NoError(t, err)
+		var expected []uuid.UUID
+		for _, child := range children {
+			if child.BucketType == domain.BucketTypeVirtual && !child.IsArchived {
+				expected = append(expected, child.ID)
+			}
+		}
+		actual := make([]uuid.UUID, 0, len(family.Children))
+		for _, child := range family.Children {
+			actual = append(actual, child.ID)
+		}
+		assert.ElementsMatch(t, expected, actual, "Children of %s", family.Physical.Name)
+
+		if family.Physical.ID == emptyID {
+			assert.Empty(t, family.Children)
+		}
+	}
+}
+
+// BenchmarkBucketTree compares loading the bucket tree in one query against one query per physical bucket
+// Run with: go test -tags integration -bench BucketTree -run '^$' ./tests/integration/
+func BenchmarkBucketTree(b *testing.B) {
+	ctx := context.Background()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	// 50 physical buckets with 3 virtual children each, removed again afterwards
+	var created []uuid.UUID
+	for i := 0; i < 50; i++ {
+		physicalID := uuid.New()
+		require.NoError(b, bucketRepo.Create(ctx, &domain.Bucket{ID: physicalID, Name: "Bench Bank " + physicalID.String(), BucketType: domain.BucketTypePhysical, CurrentBalance: decimal.Zero}))
+		for j := 0; j < 3; j++ {
+			childID := uuid.New()
+			require.NoError(b, bucketRepo.Create(ctx, &domain.Bucket{ID: childID, Name: "Bench Bucket " + childID.String(), BucketType: domain.BucketTypeVirtual, ParentPhysicalBucketID: &physicalID, CurrentBalance: decimal.Zero}))
+			created = append(created, childID)
+		}
+		created = append(created, physicalID)
+	}
+	b.Cleanup(func() {
+		// Children were appended before their parent, so deleting in order respects the foreign key
+		for _, id := range created {
+			_, _ = db.ExecContext(context.Background(), `DELETE FROM buckets WHERE id = $1`, id)
+		}
+	})
+
+	b.Run("SingleQuery", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := bucketRepo.ListPhysicalWithChildren(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PerParent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			physicalBuckets, err := bucketRepo.List(ctx, domain.BucketTypePhysical)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, physical := range physicalBuckets {
+				if _, err := bucketRepo.ListByParent(ctx, physical.ID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+func TestScheduledExpense(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+	groceriesID := testBuckets["Groceries"]
+
+	balance := func(id uuid.UUID) decimal.Decimal {
+		bucket, err := bucketRepo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return bucket.CurrentBalance
+	}
+	liquidity := func() string {
+		resp, err := grpcClient.GetNetWorth(ctx, &wealthflowv1.GetNetWorthRequest{})
+		require.NoError(t, err, "GetNetWorth should succeed")
+		return resp.Liquidity
+	}
+	bankBefore := balance(mainBankID)
+	unallocatedBefore := balance(unallocatedID)
+	liquidityBefore := liquidity()
+
+	effectiveDate := time.Now().Add(48 * time.Hour)
+	resp, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "45.00",
+		Description:      "Upcoming electricity bill",
+		VirtualBucketId:  unallocatedID.String(),
+		CategoryBucketId: groceriesID.String(),
+		EffectiveDate:    timestamppb.New(effectiveDate),
+	})
+	require.NoError(t, err, "Scheduling an expense should succeed")
+	txID := resp.TransactionId
+
+	// Not counted until activated
+	assert.True(t, bankBefore.Equal(balance(mainBankID)), "Scheduled expense must not touch the bank balance")
+	assert.True(t, unallocatedBefore.Equal(balance(unallocatedID)), "Scheduled expense must not touch the virtual balance")
+	assert.Equal(t, liquidityBefore, liquidity(), "Scheduled expense must not change net worth")
+
+	// It shows up in the forecast as an obligation
+	upcoming, err := grpcClient.GetUpcomingObligations(ctx, &wealthflowv1.GetUpcomingObligationsRequest{HorizonDays: 7})
+	require.NoError(t, err, "GetUpcomingObligations should succeed")
+	assert.Equal(t, liquidityBefore, upcoming.CurrentLiquidity)
+	found := false
+	for _, item := range upcoming.Items {
+		if item.Transaction.Id == txID {
+			found = true
+			assert.Equal(t, "-45.00", item.LiquidityChange)
+		}
+	}
+	assert.True(t, found, "The scheduled expense should be listed")
+
+	// Processing before the effective date leaves it scheduled
+	processResp, err := grpcClient.ProcessScheduledTransactions(ctx, &wealthflowv1.ProcessScheduledTransactionsRequest{})
+	require.NoError(t, err, "ProcessScheduledTransactions should succeed")
+	assert.NotContains(t, processResp.ActivatedTransactionIds, txID)
+
+	processResp, err = grpcClient.ProcessScheduledTransactions(ctx, &wealthflowv1.ProcessScheduledTransactionsRequest{
+		AsOf: timestamppb.New(effectiveDate.Add(time.Minute)),
+	})
+	require.NoError(t, err, "ProcessScheduledTransactions should succeed")
+	assert.Contains(t, processResp.ActivatedTransactionIds, txID)
+
+	assert.True(t, bankBefore.Sub(decimal.NewFromInt(45)).Equal(balance(mainBankID)), "Activated expense pays from the bank")
+	assert.True(t, unallocatedBefore.Sub(decimal.NewFromInt(45)).Equal(balance(unallocatedID)), "Activated expense pays from the virtual bucket")
+
+	// A scheduled expense in the past is rejected
+	_, err = grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "45.00",
+		Description:      "Late bill",
+		VirtualBucketId:  unallocatedID.String(),
+		CategoryBucketId: groceriesID.String(),
+		EffectiveDate:    timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListIncomeSources(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	sideGigID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             sideGigID,
+		Name:           "Side Gig " + sideGigID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	employerRule, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{
+		SourceBucketId: testBuckets["Employer"].String(),
+	})
+	require.NoError(t, err, "GetSplitRule should succeed")
+
+	resp, err := grpcClient.ListIncomeSources(ctx, &wealthflowv1.ListIncomeSourcesRequest{})
+	require.NoError(t, err, "ListIncomeSources should succeed")
+
+	sources := make(map[string]*wealthflowv1.IncomeSource, len(resp.Sources))
+	for _, source := range resp.Sources {
+		assert.Equal(t, wealthflowv1.BucketType_BUCKET_TYPE_INCOME, source.Bucket.Type)
+		sources[source.Bucket.Id] = source
+	}
+
+	employer := sources[testBuckets["Employer"].String()]
+	require.NotNil(t, employer, "Employer should be listed")
+	assert.True(t, employer.HasRule)
+	assert.Equal(t, int32(len(employerRule.Items)), employer.ItemCount)
+
+	sideGig := sources[sideGigID.String()]
+	require.NotNil(t, sideGig, "The new income bucket should be listed")
+	assert.False(t, sideGig.HasRule)
+	assert.Equal(t, int32(0), sideGig.ItemCount)
+}
+
+func TestNotFoundResourceType(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	resourceType := func(err error) string {
+		st, ok := status.FromError(err)
+		require.True(t, ok, "Error should be a gRPC status")
+		require.Equal(t, codes.NotFound, st.Code())
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ResourceInfo); ok {
+				return info.ResourceType
+			}
+		}
+		return ""
+	}
+
+	// An income bucket without a split rule: the rule is missing, not the bucket
+	noRuleID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             noRuleID,
+		Name:           "Royalties " + noRuleID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+	_, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "100.00",
+		Description:    "Royalties",
+		SourceBucketId: noRuleID.String(),
+		IsExternal:     true,
+	})
+	assert.Equal(t, "split_rule", resourceType(err))
+
+	// A source bucket that does not exist
+	_, err = grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "100.00",
+		Description:    "Royalties",
+		SourceBucketId: uuid.New().String(),
+		IsExternal:     true,
+	})
+	assert.Equal(t, "bucket", resourceType(err))
+}
+
+func TestMoveBetweenVirtualBuckets(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	vacationID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:                     vacationID,
+		Name:                   "Vacation " + vacationID.String(),
+		BucketType:             domain.BucketTypeVirtual,
+		ParentPhysicalBucketID: &mainBankID,
+		CurrentBalance:         decimal.Zero,
+	}))
+
+	balance := func(id uuid.UUID) decimal.Decimal {
+		bucket, err := bucketRepo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return bucket.CurrentBalance
+	}
+	bankBefore := balance(mainBankID)
+	unallocatedBefore := balance(unallocatedID)
+
+	resp, err := grpcClient.MoveBetweenVirtualBuckets(ctx, &wealthflowv1.MoveBetweenVirtualBucketsRequest{
+		SourceBucketId:      unallocatedID.String(),
+		DestinationBucketId: vacationID.String(),
+		Amount:              "25.00",
+	})
+	require.NoError(t, err, "MoveBetweenVirtualBuckets should succeed")
+	assert.NotEmpty(t, resp.TransactionId)
+
+	assert.True(t, unallocatedBefore.Sub(decimal.NewFromInt(25)).Equal(balance(unallocatedID)))
+	assert.True(t, decimal.NewFromInt(25).Equal(balance(vacationID)))
+	assert.True(t, bankBefore.Equal(balance(mainBankID)), "The bank balance is unchanged")
+
+	// With the sufficient-funds check, moving more than the source holds fails
+	_, err = grpcClient.MoveBetweenVirtualBuckets(ctx, &wealthflowv1.MoveBetweenVirtualBucketsRequest{
+		SourceBucketId:         vacationID.String(),
+		DestinationBucketId:    unallocatedID.String(),
+		Amount:                 "25.01",
+		RequireSufficientFunds: true,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.True(t, decimal.NewFromInt(25).Equal(balance(vacationID)), "A rejected move leaves the balance unchanged")
+
+	// A move to a non-virtual bucket is rejected
+	_, err = grpcClient.MoveBetweenVirtualBuckets(ctx, &wealthflowv1.MoveBetweenVirtualBucketsRequest{
+		SourceBucketId:      unallocatedID.String(),
+		DestinationBucketId: mainBankID.String(),
+		Amount:              "25.00",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTransactionRepositoryClampsPagination(t *testing.T) {
+	transactionRepo := postgres.NewTransactionRepository(db)
+	ctx := context.Background()
+
+	total, err := transactionRepo.Count(ctx, nil)
+	require.NoError(t, err)
+	require.Positive(t, total, "Earlier tests should have created transactions")
+
+	// A negative offset is treated as 0 instead of producing a SQL error
+	firstPage, err := transactionRepo.List(ctx, 1, 0, nil)
+	require.NoError(t, err)
+	negativeOffset, err := transactionRepo.List(ctx, 1, -5, nil)
+	require.NoError(t, err, "Negative offset should be clamped")
+	require.Len(t, negativeOffset, 1)
+	assert.Equal(t, firstPage[0].ID, negativeOffset[0].ID)
+
+	// A non-positive limit returns a single row
+	zeroLimit, err := transactionRepo.List(ctx, 0, 0, nil)
+	require.NoError(t, err, "Zero limit should be clamped")
+	assert.Len(t, zeroLimit, 1)
+	negativeLimit, err := transactionRepo.ListFiltered(ctx, -1, -1, domain.TransactionFilter{})
+	require.NoError(t, err, "Negative limit should be clamped")
+	assert.Len(t, negativeLimit, 1)
+
+	// A huge limit is capped rather than passed through
+	huge, err := transactionRepo.List(ctx, math.MaxInt32, 0, nil)
+	require.NoError(t, err, "Huge limit should be clamped")
+	assert.LessOrEqual(t, len(huge), 10000)
+	assert.Len(t, huge, min(total, 10000))
+
+	bucketPage, err := transactionRepo.ListForBuckets(ctx, math.MaxInt32, -1, []uuid.UUID{testBuckets["Main Bank"]})
+	require.NoError(t, err, "ListForBuckets should clamp too")
+	assert.NotEmpty(t, bucketPage)
+}
+
+func TestGetInflowAllocation(t *testing.T) {
+	ctx := getAuthContext()
+
+	inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "1000.00",
+		Description:    "Bonus",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+
+	resp, err := grpcClient.GetInflowAllocation(ctx, &wealthflowv1.GetInflowAllocationRequest{
+		TransactionId: inflowResp.TransactionId,
+	})
+	require.NoError(t, err, "GetInflowAllocation should succeed")
+	assert.Equal(t, "Bonus", resp.Description)
+
+	// The stored allocation matches the one reported when the inflow was recorded
+	require.Len(t, resp.Allocations, len(inflowResp.Allocations))
+	for i, allocation := range resp.Allocations {
+		assert.Equal(t, inflowResp.Allocations[i].BucketId, allocation.BucketId)
+		assert.Equal(t, inflowResp.Allocations[i].Name, allocation.Name)
+		assert.Equal(t, inflowResp.Allocations[i].Amount, allocation.Amount)
+	}
+
+	// An expense is not an external inflow
+	expenseResp, err := grpcClient.LogExpense(ctx, &wealthflowv1.LogExpenseRequest{
+		Amount:           "5.00",
+		Description:      "Coffee",
+		VirtualBucketId:  testBuckets["Unallocated"].String(),
+		CategoryBucketId: testBuckets["Groceries"].String(),
+	})
+	require.NoError(t, err, "LogExpense should succeed")
+	_, err = grpcClient.GetInflowAllocation(ctx, &wealthflowv1.GetInflowAllocationRequest{
+		TransactionId: expenseResp.TransactionId,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// TestGetStatement tests that a statement lists the period's net worth changes and reconciles them
+func TestGetStatement(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+
+	equityID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             equityID,
+		Name:           "Statement Equity " + equityID.String(),
+		BucketType:     domain.BucketTypeEquity,
+		CurrentBalance: decimal.Zero,
+	}))
+
+	// The margin absorbs clock differences between the test and the server
+	start := time.Now().Add(-time.Minute)
+
+	inflowResp, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "120.00",
+		Description:    "Statement inflow",
+		SourceBucketId: testBuckets["Employer"].String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should succeed")
+	_, err = grpcClient.UpdateInvestment(ctx, &wealthflowv1.UpdateInvestmentRequest{
+		BucketId:    equityID.String(),
+		MarketValue: "75.00",
+	})
+	require.NoError(t, err, "UpdateInvestment should succeed")
+
+	end := time.Now().Add(time.Minute)
+	resp, err := grpcClient.GetStatement(ctx, &wealthflowv1.GetStatementRequest{
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(end),
+	})
+	require.NoError(t, err, "GetStatement should succeed")
+
+	changes := decimal.Zero
+	var inflow *wealthflowv1.StatementTransaction
+	for _, item := range resp.Transactions {
+		changes = changes.Add(decimal.RequireFromString(item.LiquidityChange))
+		if item.Transaction.Id == inflowResp.TransactionId {
+			inflow = item
+		}
+	}
+	require.NotNil(t, inflow, "The inflow should be listed")
+	assert.Equal(t, "120.00", inflow.LiquidityChange)
+
+	var equityChange *wealthflowv1.StatementMarketValueChange
+	for _, change := range resp.MarketValueChanges {
+		changes = changes.Add(decimal.RequireFromString(change.Change))
+		if change.BucketId == equityID.String() {
+			equityChange = change
+		}
+	}
+	require.NotNil(t, equityChange, "The market value update should be listed")
+	assert.Equal(t, "0.00", equityChange.PreviousMarketValue)
+	assert.Equal(t, "75.00", equityChange.Change)
+
+	opening := decimal.RequireFromString(resp.OpeningNetWorth)
+	closing := decimal.RequireFromString(resp.ClosingNetWorth)
+	assert.True(t, opening.Add(changes).Equal(closing), "opening %s + changes %s should equal closing %s", opening, changes, closing)
+
+	// A period ending before it starts is rejected
+	_, err = grpcClient.GetStatement(ctx, &wealthflowv1.GetStatementRequest{
+		StartDate: timestamppb.New(end),
+		EndDate:   timestamppb.New(start),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Error code should be InvalidArgument")
+}
+
+func TestGetFundingBucket(t *testing.T) {
+	ctx := getAuthContext()
+
+	resp, err := grpcClient.GetFundingBucket(ctx, &wealthflowv1.GetFundingBucketRequest{
+		VirtualBucketId: testBuckets["Unallocated"].String(),
+	})
+	require.NoError(t, err, "GetFundingBucket should succeed")
+	assert.Equal(t, testBuckets["Main Bank"].String(), resp.BucketId)
+	assert.Equal(t, "Main Bank", resp.Name)
+	assert.Equal(t, wealthflowv1.BucketType_BUCKET_TYPE_PHYSICAL, resp.Type)
+
+	// Only virtual buckets are funded by a physical bucket
+	_, err = grpcClient.GetFundingBucket(ctx, &wealthflowv1.GetFundingBucketRequest{
+		VirtualBucketId: testBuckets["Groceries"].String(),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = grpcClient.GetFundingBucket(ctx, &wealthflowv1.GetFundingBucketRequest{
+		VirtualBucketId: uuid.New().String(),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = grpcClient.GetFundingBucket(ctx, &wealthflowv1.GetFundingBucketRequest{
+		VirtualBucketId: "not-a-uuid",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDisableSplitRule(t *testing.T) {
+	ctx := getAuthContext()
+	bucketRepo := postgres.NewBucketRepository(db)
+	mainBankID := testBuckets["Main Bank"]
+	unallocatedID := testBuckets["Unallocated"]
+
+	bonusID := uuid.New()
+	require.NoError(t, bucketRepo.Create(context.Background(), &domain.Bucket{
+		ID:             bonusID,
+		Name:           "Bonus " + bonusID.String(),
+		BucketType:     domain.BucketTypeIncome,
+		CurrentBalance: decimal.Zero,
+	}))
+	_, err := grpcClient.CreateSplitRule(ctx, &wealthflowv1.CreateSplitRuleRequest{
+		Name:           "Bonus Split",
+		SourceBucketId: bonusID.String(),
+		Items: []*wealthflowv1.SplitRuleItem{
+			{TargetBucketId: unallocatedID.String(), Type: wealthflowv1.SplitRuleItemType_SPLIT_RULE_ITEM_TYPE_REMAINDER, Priority: 1},
+		},
+	})
+	require.NoError(t, err, "CreateSplitRule should succeed")
+
+	rule, err := grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{SourceBucketId: bonusID.String()})
+	require.NoError(t, err, "GetSplitRule should succeed")
+	assert.True(t, rule.Active, "New rules are active")
+
+	disabled, err := grpcClient.DisableSplitRule(ctx, &wealthflowv1.DisableSplitRuleRequest{SourceBucketId: bonusID.String()})
+	require.NoError(t, err, "DisableSplitRule should succeed")
+	assert.Equal(t, rule.SplitRuleId, disabled.SplitRuleId)
+	assert.False(t, disabled.Active)
+
+	// The disabled rule is kept, but its inflows are handled as if it had none
+	rule, err = grpcClient.GetSplitRule(ctx, &wealthflowv1.GetSplitRuleRequest{SourceBucketId: bonusID.String()})
+	require.NoError(t, err, "GetSplitRule should still return the disabled rule")
+	assert.False(t, rule.Active)
+	assert.Len(t, rule.Items, 1)
+
+	_, err = grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "500.00",
+		Description:    "Year-end bonus",
+		SourceBucketId: bonusID.String(),
+		IsExternal:     true,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	envelopeBefore, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+	parked, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:                 "500.00",
+		Description:            "Year-end bonus",
+		SourceBucketId:         bonusID.String(),
+		IsExternal:             true,
+		ParkInPhysicalBucketId: mainBankID.String(),
+	})
+	require.NoError(t, err, "RecordInflow should park income of a disabled rule")
+	assert.True(t, parked.Parked)
+	envelopeAfter, err := bucketRepo.GetByID(context.Background(), unallocatedID)
+	require.NoError(t, err)
+	assert.True(t, envelopeBefore.CurrentBalance.Equal(envelopeAfter.CurrentBalance), "The disabled rule's target receives nothing")
+
+	// Enabling the rule applies it again
+	enabled, err := grpcClient.EnableSplitRule(ctx, &wealthflowv1.EnableSplitRuleRequest{SourceBucketId: bonusID.String()})
+	require.NoError(t, err, "EnableSplitRule should succeed")
+	assert.True(t, enabled.Active)
+
+	allocated, err := grpcClient.RecordInflow(ctx, &wealthflowv1.RecordInflowRequest{
+		Amount:         "500.00",
+		Description:    "Year-end bonus",
+		SourceBucketId: bonusID.String(),
+		IsExternal:     true,
+	})
+	require.NoError(t, err, "RecordInflow should use the enabled rule")
+	assert.False(t, allocated.Parked)
+
+	_, err = grpcClient.DisableSplitRule(ctx, &wealthflowv1.DisableSplitRuleRequest{SourceBucketId: uuid.New().String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = grpcClient.EnableSplitRule(ctx, &wealthflowv1.EnableSplitRuleRequest{SourceBucketId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}